@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net/http"
 	"path"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/gorilla/rpc/v2"
 
+	"github.com/lasthyphen/dijetsgo/api"
 	"github.com/lasthyphen/dijetsgo/utils/logging"
 	"github.com/lasthyphen/dijetsgo/utils/password"
 	"github.com/lasthyphen/dijetsgo/utils/timer/mockable"
@@ -29,13 +31,22 @@ const (
 	headerKey      = "Authorization"
 	headerValStart = "Bearer "
 
+	// AdminPrincipal is the principal whose password is set in the node's
+	// config. Only the admin principal can add and remove other principals.
+	AdminPrincipal = "admin"
+
 	// number of bytes to use when generating a new random token ID
 	tokenIDByteLen = 20
 
 	// defaultTokenLifespan is how long a token lives before it expires
 	defaultTokenLifespan = time.Hour * 12
+	// maxTokenLifespan is the longest a token can live before it expires
+	maxTokenLifespan = time.Hour * 24 * 30
 
-	maxEndpoints = 128
+	maxEndpoints        = 128
+	maxMethods          = 128
+	maxPrincipals       = 128
+	maxPrincipalNameLen = 64
 )
 
 var (
@@ -48,32 +59,54 @@ var (
 	errInvalidSigningMethod        = errors.New("auth token didn't specify the HS256 signing method correctly")
 	errTokenRevoked                = errors.New("the provided auth token was revoked")
 	errTokenInsufficientPermission = errors.New("the provided auth token does not allow access to this endpoint")
+	errTokenMethodNotAllowed       = errors.New("the provided auth token does not allow calls to this method")
 	errWrongPassword               = errors.New("incorrect password")
 	errSamePassword                = errors.New("new password can't be same as old password")
 	errNoPassword                  = errors.New("no password")
 	errNoEndpoints                 = errors.New("must name at least one endpoint")
+	errInvalidTokenLifespan        = fmt.Errorf("token lifespan must be positive and at most %s", maxTokenLifespan)
 	errTooManyEndpoints            = fmt.Errorf("can only name at most %d endpoints", maxEndpoints)
+	errTooManyMethods              = fmt.Errorf("can only name at most %d methods", maxMethods)
+	errUnknownPrincipal            = errors.New("unknown principal")
+	errDuplicatePrincipal          = errors.New("principal already exists")
+	errTooManyPrincipals           = fmt.Errorf("can have at most %d principals", maxPrincipals)
+	errInvalidPrincipalName        = fmt.Errorf("principal name must be between 1 and %d characters", maxPrincipalNameLen)
+	errAdminPrincipal              = errors.New("the admin principal can't be added or removed")
 
 	_ Auth = &auth{}
 )
 
 type Auth interface {
-	// Create and return a new token that allows access to each API endpoint for
-	// [duration] such that the API's path ends with an element of [endpoints].
-	// If one of the elements of [endpoints] is "*", all APIs are accessible.
+	// Create and return a new token for the admin principal that allows access
+	// to each API endpoint for [duration] such that the API's path ends with an
+	// element of [endpoints]. If one of the elements of [endpoints] is "*", all
+	// APIs are accessible.
 	NewToken(pw string, duration time.Duration, endpoints []string) (string, error)
 
+	// Create and return a new token for [principal], whose password is [pw].
+	// The token is limited to [endpoints] as in NewToken. If [methods] is
+	// non-empty, the token only allows calls to the JSON-RPC methods named in
+	// [methods]. A method ending in "*" allows calls to every method with that
+	// prefix, e.g. "platform.get*".
+	NewPrincipalToken(principal, pw string, duration time.Duration, endpoints, methods []string) (string, error)
+
 	// Revokes [token]; it will not be accepted as authorization for future API
-	// calls. If the token is invalid, this is a no-op.  If a token is revoked
-	// and then the password is changed, and then changed back to the current
-	// password, the token will be un-revoked. Therefore, passwords shouldn't be
-	// re-used before previously revoked tokens have expired.
+	// calls. [pw] must be the password of the principal the token was issued
+	// to, or the admin password. If the token is invalid, this is a no-op. If
+	// a token is revoked and then the password of its principal is changed,
+	// and then changed back to the current password, the token will be
+	// un-revoked. Therefore, passwords shouldn't be re-used before previously
+	// revoked tokens have expired.
 	RevokeToken(pw, token string) error
 
 	// Authenticates [token] for access to [url].
 	AuthenticateToken(token, url string) error
 
-	// Change the password required to create and revoke tokens.
+	// Authenticates [token] for a call to [method] of the API at [url]. The
+	// call is recorded in the audit log.
+	AuthenticateCall(token, url, method string) error
+
+	// Change the admin password.
 	// [oldPW] is the current password.
 	// [newPW] is the new password. It can't be the empty string and it can't be
 	//         unreasonably long.
@@ -81,6 +114,22 @@ type Auth interface {
 	// invalid.
 	ChangePassword(oldPW, newPW string) error
 
+	// Change the password of [principal] as in ChangePassword.
+	ChangePrincipalPassword(principal, oldPW, newPW string) error
+
+	// Add a principal named [name] with the password [pw]. [adminPW] must be
+	// the admin password.
+	AddPrincipal(adminPW, name, pw string) error
+
+	// Remove the principal named [name]. Tokens issued to the principal are no
+	// longer accepted. [adminPW] must be the admin password.
+	RemovePrincipal(adminPW, name string) error
+
+	// Returns the tokens issued to [principal] that haven't expired or been
+	// revoked. [pw] must be the password of [principal]. If [principal] is the
+	// admin principal, the tokens of every principal are returned.
+	ListTokens(principal, pw string) ([]TokenInfo, error)
+
 	// Create the API endpoint for this auth handler.
 	CreateHandler() (http.Handler, error)
 
@@ -89,39 +138,66 @@ type Auth interface {
 	WrapHandler(h http.Handler) http.Handler
 }
 
+// TokenInfo describes an issued token
+type TokenInfo struct {
+	ID        string    `json:"id"`
+	Principal string    `json:"principal"`
+	Endpoints []string  `json:"endpoints"`
+	Methods   []string  `json:"methods,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
 type auth struct {
 	// Used to mock time.
 	clock mockable.Clock
 
 	log      logging.Logger
+	auditLog logging.Logger
 	endpoint string
 
 	lock sync.RWMutex
-	// Can be changed via API call.
+	// Password of the admin principal. Can be changed via API call.
 	password password.Hash
-	// Set of token IDs that have been revoked
-	revoked map[string]struct{}
+	// Principal name --> password. Doesn't contain the admin principal.
+	principals map[string]*password.Hash
+	// Token ID --> principal the token was issued to, for each token that has
+	// been revoked
+	revoked map[string]string
+	// Token ID --> token, for each token that may still be valid
+	tokens map[string]TokenInfo
 }
 
-func New(log logging.Logger, endpoint, pw string) (Auth, error) {
-	a := &auth{
-		log:      log,
-		endpoint: endpoint,
-		revoked:  make(map[string]struct{}),
-	}
+// New returns an Auth whose admin password is [pw]. Authorized API calls are
+// recorded in [auditLog].
+func New(log, auditLog logging.Logger, endpoint, pw string) (Auth, error) {
+	a := newAuth(log, auditLog, endpoint)
 	return a, a.password.Set(pw)
 }
 
+// NewFromHash returns an Auth whose admin password hashes to [pw]. Authorized
+// API calls are recorded in [log].
 func NewFromHash(log logging.Logger, endpoint string, pw password.Hash) Auth {
+	a := newAuth(log, log, endpoint)
+	a.password = pw
+	return a
+}
+
+func newAuth(log, auditLog logging.Logger, endpoint string) *auth {
 	return &auth{
-		log:      log,
-		endpoint: endpoint,
-		password: pw,
-		revoked:  make(map[string]struct{}),
+		log:        log,
+		auditLog:   auditLog,
+		endpoint:   endpoint,
+		principals: make(map[string]*password.Hash),
+		revoked:    make(map[string]string),
+		tokens:     make(map[string]TokenInfo),
 	}
 }
 
 func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (string, error) {
+	return a.NewPrincipalToken(AdminPrincipal, pw, duration, endpoints, nil)
+}
+
+func (a *auth) NewPrincipalToken(principal, pw string, duration time.Duration, endpoints, methods []string) (string, error) {
 	if pw == "" {
 		return "", errNoPassword
 	}
@@ -130,12 +206,19 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 	} else if l > maxEndpoints {
 		return "", errTooManyEndpoints
 	}
+	if len(methods) > maxMethods {
+		return "", errTooManyMethods
+	}
+	if duration <= 0 || duration > maxTokenLifespan {
+		return "", errInvalidTokenLifespan
+	}
 
-	a.lock.RLock()
-	defer a.lock.RUnlock()
+	a.lock.Lock()
+	defer a.lock.Unlock()
 
-	if !a.password.Check(pw) {
-		return "", errWrongPassword
+	hash, err := a.checkPassword(principal, pw)
+	if err != nil {
+		return "", err
 	}
 
 	canAccessAll := false
@@ -145,6 +228,12 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 			break
 		}
 	}
+	for _, method := range methods {
+		if method == "*" {
+			methods = nil
+			break
+		}
+	}
 
 	idBytes := [tokenIDByteLen]byte{}
 	if _, err := rand.Read(idBytes[:]); err != nil {
@@ -152,11 +241,19 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 	}
 	id := base64.URLEncoding.EncodeToString(idBytes[:])
 
+	now := a.clock.Time()
+	expiresAt := now.Add(duration)
 	claims := endpointClaims{
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: a.clock.Time().Add(duration).Unix(),
+			ExpiresAt: expiresAt.Unix(),
 			Id:        id,
 		},
+		Methods: methods,
+	}
+	// Tokens of the admin principal are issued without a subject so that they
+	// are accepted by nodes that don't support principals.
+	if principal != AdminPrincipal {
+		claims.Subject = principal
 	}
 	if canAccessAll {
 		claims.Endpoints = []string{"*"}
@@ -164,7 +261,21 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 		claims.Endpoints = endpoints
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
-	return token.SignedString(a.password.Password[:]) // Sign the token and return its string repr.
+	tokenStr, err := token.SignedString(hash.Password[:]) // Sign the token and return its string repr.
+	if err != nil {
+		return "", err
+	}
+
+	a.pruneTokens(now)
+	a.tokens[id] = TokenInfo{
+		ID:        id,
+		Principal: principal,
+		Endpoints: claims.Endpoints,
+		Methods:   claims.Methods,
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+	}
+	a.auditLog.Info("issued token %s to principal %q", id, principal)
+	return tokenStr, nil
 }
 
 func (a *auth) RevokeToken(tokenStr, pw string) error {
@@ -178,60 +289,109 @@ func (a *auth) RevokeToken(tokenStr, pw string) error {
 	a.lock.Lock()
 	defer a.lock.Unlock()
 
-	if !a.password.Check(pw) {
-		return errWrongPassword
-	}
-
 	// See if token is well-formed and signature is right
 	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, a.getTokenKey)
 	if err != nil {
 		return err
 	}
 
+	claims, ok := token.Claims.(*endpointClaims)
+	if !ok {
+		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
+	}
+	// The admin can revoke any token
+	principal := claims.principal()
+	if !a.password.Check(pw) {
+		if principal == AdminPrincipal {
+			return errWrongPassword
+		}
+		if _, err := a.checkPassword(principal, pw); err != nil {
+			return err
+		}
+	}
+
 	// If the token isn't valid, it has essentially already been revoked.
 	if !token.Valid {
 		return nil
 	}
 
-	claims, ok := token.Claims.(*endpointClaims)
-	if !ok {
-		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
-	}
-	a.revoked[claims.Id] = struct{}{}
+	a.revoked[claims.Id] = principal
+	delete(a.tokens, claims.Id)
+	a.auditLog.Info("revoked token %s of principal %q", claims.Id, principal)
 	return nil
 }
 
 func (a *auth) AuthenticateToken(tokenStr, url string) error {
+	return a.AuthenticateCall(tokenStr, url, "")
+}
+
+func (a *auth) AuthenticateCall(tokenStr, url, method string) error {
 	a.lock.RLock()
 	defer a.lock.RUnlock()
 
+	claims, err := a.parseClaims(tokenStr)
+	if err != nil {
+		return err
+	}
+
+	principal := claims.principal()
+	if err := a.authorize(claims, url, method); err != nil {
+		a.auditLog.Info("principal %q was denied a call to %q on %s: %s", principal, method, url, err)
+		return err
+	}
+	if method != "" {
+		a.auditLog.Info("principal %q called %q on %s", principal, method, url)
+	}
+	return nil
+}
+
+// authenticateEndpoint returns nil if [tokenStr] allows access to the API at
+// [url], regardless of the method called.
+func (a *auth) authenticateEndpoint(tokenStr, url string) error {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	claims, err := a.parseClaims(tokenStr)
+	if err != nil {
+		return err
+	}
+	return a.authorizeEndpoint(claims, url)
+}
+
+// parseClaims returns the claims of [tokenStr] if it is valid.
+// Assumes [a.lock] is held.
+func (a *auth) parseClaims(tokenStr string) (*endpointClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, a.getTokenKey)
 	if err != nil { // Probably because signature wrong
-		return err
+		return nil, err
 	}
 
-	// Make sure this token gives access to the requested endpoint
 	claims, ok := token.Claims.(*endpointClaims)
 	if !ok {
 		// Error is intentionally dropped here as there is nothing left to do
 		// with it.
-		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
+		return nil, fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
 	}
+	return claims, nil
+}
 
-	_, revoked := a.revoked[claims.Id]
-	if revoked {
-		return errTokenRevoked
+// authorize returns nil if [claims] allows a call to [method] of the API at
+// [url]. Assumes [a.lock] is held.
+func (a *auth) authorize(claims *endpointClaims, url, method string) error {
+	if err := a.authorizeEndpoint(claims, url); err != nil {
+		return err
 	}
-
-	for _, endpoint := range claims.Endpoints {
-		if endpoint == "*" || strings.HasSuffix(url, endpoint) {
-			return nil
-		}
+	if !claims.allowsMethod(method) {
+		return errTokenMethodNotAllowed
 	}
-	return errTokenInsufficientPermission
+	return nil
 }
 
 func (a *auth) ChangePassword(oldPW, newPW string) error {
+	return a.ChangePrincipalPassword(AdminPrincipal, oldPW, newPW)
+}
+
+func (a *auth) ChangePrincipalPassword(principal, oldPW, newPW string) error {
 	if oldPW == newPW {
 		return errSamePassword
 	}
@@ -239,22 +399,150 @@ func (a *auth) ChangePassword(oldPW, newPW string) error {
 	a.lock.Lock()
 	defer a.lock.Unlock()
 
-	if !a.password.Check(oldPW) {
-		return errWrongPassword
+	hash, err := a.checkPassword(principal, oldPW)
+	if err != nil {
+		return err
 	}
 	if err := password.IsValid(newPW, password.OK); err != nil {
 		return err
 	}
-	if err := a.password.Set(newPW); err != nil {
+	if err := hash.Set(newPW); err != nil {
 		return err
 	}
 
-	// All the revoked tokens are now invalid; no need to mark specifically as
-	// revoked.
-	a.revoked = make(map[string]struct{})
+	// All the tokens of the principal are now invalid; no need to mark
+	// specifically as revoked.
+	a.forgetTokens(principal)
+	a.auditLog.Info("changed the password of principal %q", principal)
 	return nil
 }
 
+func (a *auth) AddPrincipal(adminPW, name, pw string) error {
+	switch {
+	case name == AdminPrincipal:
+		return errAdminPrincipal
+	case len(name) == 0 || len(name) > maxPrincipalNameLen:
+		return errInvalidPrincipalName
+	}
+	if err := password.IsValid(pw, password.OK); err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.password.Check(adminPW) {
+		return errWrongPassword
+	}
+	if _, exists := a.principals[name]; exists {
+		return fmt.Errorf("%w: %q", errDuplicatePrincipal, name)
+	}
+	if len(a.principals) >= maxPrincipals {
+		return errTooManyPrincipals
+	}
+
+	hash := &password.Hash{}
+	if err := hash.Set(pw); err != nil {
+		return err
+	}
+	a.principals[name] = hash
+	a.auditLog.Info("added principal %q", name)
+	return nil
+}
+
+func (a *auth) RemovePrincipal(adminPW, name string) error {
+	if name == AdminPrincipal {
+		return errAdminPrincipal
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.password.Check(adminPW) {
+		return errWrongPassword
+	}
+	if _, exists := a.principals[name]; !exists {
+		return fmt.Errorf("%w: %q", errUnknownPrincipal, name)
+	}
+
+	delete(a.principals, name)
+	a.forgetTokens(name)
+	a.auditLog.Info("removed principal %q", name)
+	return nil
+}
+
+func (a *auth) ListTokens(principal, pw string) ([]TokenInfo, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if _, err := a.checkPassword(principal, pw); err != nil {
+		return nil, err
+	}
+
+	a.pruneTokens(a.clock.Time())
+	tokens := []TokenInfo{}
+	for _, token := range a.tokens {
+		if principal == AdminPrincipal || token.Principal == principal {
+			tokens = append(tokens, token)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].ExpiresAt.Before(tokens[j].ExpiresAt)
+	})
+	return tokens, nil
+}
+
+// checkPassword returns the password hash of [principal] if [pw] is its
+// password. Assumes [a.lock] is held.
+func (a *auth) checkPassword(principal, pw string) (*password.Hash, error) {
+	hash, err := a.getPassword(principal)
+	if err != nil {
+		return nil, err
+	}
+	if !hash.Check(pw) {
+		return nil, errWrongPassword
+	}
+	return hash, nil
+}
+
+// getPassword returns the password hash of [principal]. Assumes [a.lock] is
+// held.
+func (a *auth) getPassword(principal string) (*password.Hash, error) {
+	if principal == AdminPrincipal {
+		return &a.password, nil
+	}
+	hash, ok := a.principals[principal]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownPrincipal, principal)
+	}
+	return hash, nil
+}
+
+// forgetTokens removes the issued and revoked tokens of [principal]. Assumes
+// [a.lock] is held.
+func (a *auth) forgetTokens(principal string) {
+	for id, token := range a.tokens {
+		if token.Principal == principal {
+			delete(a.tokens, id)
+		}
+	}
+	for id, revokedPrincipal := range a.revoked {
+		if revokedPrincipal == principal {
+			delete(a.revoked, id)
+		}
+	}
+}
+
+// pruneTokens removes the tokens that have expired by [now]. Assumes [a.lock]
+// is held.
+func (a *auth) pruneTokens(now time.Time) {
+	for id, token := range a.tokens {
+		if !now.Before(token.ExpiresAt) {
+			delete(a.tokens, id)
+		}
+	}
+}
+
 func (a *auth) CreateHandler() (http.Handler, error) {
 	server := rpc.NewServer()
 	codec := cjson.NewCodec()
@@ -274,22 +562,27 @@ func (a *auth) WrapHandler(h http.Handler) http.Handler {
 			return
 		}
 
-		// Should be "Bearer AUTH.TOKEN.HERE"
-		rawHeader := r.Header.Get(headerKey)
-		if rawHeader == "" {
-			writeUnauthorizedResponse(w, errNoToken)
+		tokenStr, err := ParseBearerToken(r.Header.Get(headerKey))
+		if err != nil {
+			writeUnauthorizedResponse(w, err)
+			return
+		}
+
+		// Make sure the token allows access to the endpoint before reading the
+		// body of the request, so that unauthenticated clients can't make the
+		// node buffer request bodies.
+		if err := a.authenticateEndpoint(tokenStr, r.URL.Path); err != nil {
+			writeUnauthorizedResponse(w, err)
 			return
 		}
-		if !strings.HasPrefix(rawHeader, headerValStart) {
-			// Error is intentionally dropped here as there is nothing left to
-			// do with it.
-			writeUnauthorizedResponse(w, errAuthHeaderNotParsable)
+
+		call, err := api.ReadCall(w, r)
+		if err != nil {
+			writeUnauthorizedResponse(w, err)
 			return
 		}
-		// Returns actual auth token. Slice guaranteed to not go OOB
-		tokenStr := rawHeader[len(headerValStart):]
 
-		if err := a.AuthenticateToken(tokenStr, r.URL.Path); err != nil {
+		if err := a.AuthenticateCall(tokenStr, r.URL.Path, call.Method); err != nil {
 			writeUnauthorizedResponse(w, err)
 			return
 		}
@@ -298,10 +591,47 @@ func (a *auth) WrapHandler(h http.Handler) http.Handler {
 	})
 }
 
-// getTokenKey returns the key to use when making and parsing tokens
+// ParseBearerToken returns the auth token in [rawHeader], the value of an
+// authorization header. [rawHeader] should be "Bearer AUTH.TOKEN.HERE".
+func ParseBearerToken(rawHeader string) (string, error) {
+	if rawHeader == "" {
+		return "", errNoToken
+	}
+	if !strings.HasPrefix(rawHeader, headerValStart) {
+		return "", errAuthHeaderNotParsable
+	}
+	// Returns actual auth token. Slice guaranteed to not go OOB
+	return rawHeader[len(headerValStart):], nil
+}
+
+// authorizeEndpoint returns nil if [claims] allows access to the API at [url].
+// Assumes [a.lock] is held.
+func (a *auth) authorizeEndpoint(claims *endpointClaims, url string) error {
+	if _, revoked := a.revoked[claims.Id]; revoked {
+		return errTokenRevoked
+	}
+	for _, endpoint := range claims.Endpoints {
+		if endpoint == "*" || strings.HasSuffix(url, endpoint) {
+			return nil
+		}
+	}
+	return errTokenInsufficientPermission
+}
+
+// getTokenKey returns the key to use when parsing tokens, which is the
+// password hash of the principal the token was issued to. Assumes [a.lock] is
+// held.
 func (a *auth) getTokenKey(t *jwt.Token) (interface{}, error) {
 	if t.Method != jwt.SigningMethodHS256 {
 		return nil, errInvalidSigningMethod
 	}
-	return a.password.Password[:], nil
+	claims, ok := t.Claims.(*endpointClaims)
+	if !ok {
+		return nil, fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", t.Claims)
+	}
+	hash, err := a.getPassword(claims.principal())
+	if err != nil {
+		return nil, err
+	}
+	return hash.Password[:], nil
 }
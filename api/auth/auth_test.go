@@ -243,6 +243,68 @@ func TestWrapHandlerUnauthorizedEndpoint(t *testing.T) {
 	}
 }
 
+// failingReader fails the test if the request body is read.
+type failingReader struct{ t *testing.T }
+
+func (r failingReader) Read([]byte) (int, error) {
+	r.t.Fatal("request body shouldn't be read")
+	return 0, errors.New("unexpected read")
+}
+
+func TestWrapHandlerUnauthorizedEndpointSkipsBody(t *testing.T) {
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+
+	tokenStr, err := auth.NewPrincipalToken(AdminPrincipal, testPassword, defaultTokenLifespan, []string{"/ext/info"}, []string{"info.*"})
+	assert.NoError(t, err)
+
+	wrappedHandler := auth.WrapHandler(dummyHandler)
+	req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650/ext/bc/X", failingReader{t: t})
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+	rr := httptest.NewRecorder()
+	wrappedHandler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Contains(t, rr.Body.String(), errTokenInsufficientPermission.Error())
+}
+
+func TestNewTokenDuration(t *testing.T) {
+	assert := assert.New(t)
+
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	now := time.Now()
+	auth.clock.Set(now)
+	service := &Service{auth: auth}
+
+	args := &NewTokenArgs{
+		Password:  Password{Password: testPassword},
+		Endpoints: []string{"*"},
+	}
+	reply := &Token{}
+	assert.NoError(service.NewToken(nil, args, reply))
+	claims, err := auth.parseClaims(reply.Token)
+	assert.NoError(err)
+	assert.Equal(now.Add(defaultTokenLifespan).Unix(), claims.ExpiresAt)
+
+	args.Duration = "1h30m"
+	assert.NoError(service.NewToken(nil, args, reply))
+	claims, err = auth.parseClaims(reply.Token)
+	assert.NoError(err)
+	assert.Equal(now.Add(90*time.Minute).Unix(), claims.ExpiresAt)
+
+	args.Duration = maxTokenLifespan.String()
+	assert.NoError(service.NewToken(nil, args, reply))
+
+	args.Duration = (maxTokenLifespan + time.Second).String()
+	err = service.NewToken(nil, args, reply)
+	assert.ErrorIs(err, errInvalidTokenLifespan)
+
+	args.Duration = "-1h"
+	err = service.NewToken(nil, args, reply)
+	assert.ErrorIs(err, errInvalidTokenLifespan)
+
+	args.Duration = "tomorrow"
+	assert.Error(service.NewToken(nil, args, reply))
+}
+
 func TestWrapHandlerAuthEndpoint(t *testing.T) {
 	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
 
@@ -284,6 +346,18 @@ func TestWriteUnauthorizedResponse(t *testing.T) {
 	assert.Equal(t, "{\"jsonrpc\":\"2.0\",\"error\":{\"code\":-32600,\"message\":\"example err\"},\"id\":1}\n", rr.Body.String())
 }
 
+func TestParseBearerToken(t *testing.T) {
+	_, err := ParseBearerToken("")
+	assert.Equal(t, errNoToken, err)
+
+	_, err = ParseBearerToken("token")
+	assert.Equal(t, errAuthHeaderNotParsable, err)
+
+	token, err := ParseBearerToken("Bearer token")
+	assert.NoError(t, err)
+	assert.Equal(t, "token", token)
+}
+
 func TestWrapHandlerMutatedRevokedToken(t *testing.T) {
 	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
 
@@ -344,3 +418,109 @@ func TestWrapHandlerInvalidSigningMethod(t *testing.T) {
 		assert.Regexp(t, unAuthorizedResponseRegex, rr.Body.String())
 	}
 }
+
+func TestPrincipalToken(t *testing.T) {
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+
+	principalPassword := "jfwehfiuwehfiwuhf" // #nosec G101
+	err := auth.AddPrincipal("notThePassword", "reader", principalPassword)
+	assert.ErrorIs(t, err, errWrongPassword)
+	err = auth.AddPrincipal(testPassword, AdminPrincipal, principalPassword)
+	assert.ErrorIs(t, err, errAdminPrincipal)
+	err = auth.AddPrincipal(testPassword, "reader", principalPassword)
+	assert.NoError(t, err)
+	err = auth.AddPrincipal(testPassword, "reader", principalPassword)
+	assert.ErrorIs(t, err, errDuplicatePrincipal)
+
+	_, err = auth.NewPrincipalToken("reader", testPassword, defaultTokenLifespan, []string{"*"}, nil)
+	assert.ErrorIs(t, err, errWrongPassword, "should have failed because the admin password isn't the principal's password")
+	_, err = auth.NewPrincipalToken("unknown", principalPassword, defaultTokenLifespan, []string{"*"}, nil)
+	assert.ErrorIs(t, err, errUnknownPrincipal)
+
+	tokenStr, err := auth.NewPrincipalToken("reader", principalPassword, defaultTokenLifespan, []string{"*"}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, auth.AuthenticateToken(tokenStr, "/ext/info"))
+
+	// Removing the principal invalidates its tokens
+	err = auth.RemovePrincipal(testPassword, "reader")
+	assert.NoError(t, err)
+	err = auth.AuthenticateToken(tokenStr, "/ext/info")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), errUnknownPrincipal.Error())
+}
+
+func TestWrapHandlerMethods(t *testing.T) {
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+
+	tokenStr, err := auth.NewPrincipalToken(AdminPrincipal, testPassword, defaultTokenLifespan, []string{"/ext/bc/P"}, []string{"platform.get*", "platform.issueTx"})
+	assert.NoError(t, err)
+
+	wrappedHandler := auth.WrapHandler(dummyHandler)
+	call := func(method string) *httptest.ResponseRecorder {
+		body := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":%q,"params":{}}`, method)
+		req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650/ext/bc/P", strings.NewReader(body))
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+		rr := httptest.NewRecorder()
+		wrappedHandler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	for _, method := range []string{"platform.getHeight", "platform.getCurrentValidators", "platform.issueTx"} {
+		assert.Equal(t, http.StatusOK, call(method).Code, method)
+	}
+	for _, method := range []string{"platform.addValidator", "platform.issueTxs", "", "platform"} {
+		rr := call(method)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, method)
+		assert.Contains(t, rr.Body.String(), errTokenMethodNotAllowed.Error())
+	}
+}
+
+func TestListTokens(t *testing.T) {
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+
+	now := time.Now()
+	auth.clock.Set(now)
+
+	principalPassword := "jfwehfiuwehfiwuhf" // #nosec G101
+	assert.NoError(t, auth.AddPrincipal(testPassword, "reader", principalPassword))
+
+	_, err := auth.NewToken(testPassword, time.Hour, []string{"*"})
+	assert.NoError(t, err)
+	readerToken, err := auth.NewPrincipalToken("reader", principalPassword, 2*time.Hour, []string{"/ext/info"}, []string{"info.*"})
+	assert.NoError(t, err)
+
+	tokens, err := auth.ListTokens("reader", principalPassword)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 1)
+	assert.Equal(t, "reader", tokens[0].Principal)
+	assert.Equal(t, []string{"/ext/info"}, tokens[0].Endpoints)
+	assert.Equal(t, []string{"info.*"}, tokens[0].Methods)
+
+	_, err = auth.ListTokens("reader", testPassword)
+	assert.ErrorIs(t, err, errWrongPassword)
+
+	tokens, err = auth.ListTokens(AdminPrincipal, testPassword)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 2)
+
+	// Revoked and expired tokens aren't listed
+	assert.NoError(t, auth.RevokeToken(readerToken, principalPassword))
+	auth.clock.Set(now.Add(time.Hour))
+	tokens, err = auth.ListTokens(AdminPrincipal, testPassword)
+	assert.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestChangePrincipalPasswordKeepsOtherRevocations(t *testing.T) {
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+
+	principalPassword := "jfwehfiuwehfiwuhf" // #nosec G101
+	assert.NoError(t, auth.AddPrincipal(testPassword, "reader", principalPassword))
+
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, []string{"*"})
+	assert.NoError(t, err)
+	assert.NoError(t, auth.RevokeToken(tokenStr, testPassword))
+
+	assert.NoError(t, auth.ChangePrincipalPassword("reader", principalPassword, "ufwhwohwfohawfhwdwd"))
+	assert.ErrorIs(t, auth.AuthenticateToken(tokenStr, "/ext/info"), errTokenRevoked)
+}
@@ -4,6 +4,8 @@
 package auth
 
 import (
+	"strings"
+
 	"github.com/golang-jwt/jwt"
 )
 
@@ -15,4 +17,38 @@ type endpointClaims struct {
 	// If endpoints has an element "*", allows access to all API endpoints
 	// In this case, "*" should be the only element of [endpoints]
 	Endpoints []string `json:"endpoints,omitempty"`
+
+	// Each element is a JSON-RPC method that the token allows calls to, e.g.
+	// "platform.getHeight". An element ending in "*" allows calls to every
+	// method with that prefix, e.g. "platform.get*". If empty, allows calls to
+	// all methods.
+	Methods []string `json:"methods,omitempty"`
+}
+
+// principal returns the name of the principal the token was issued to. Tokens
+// issued before principals were introduced belong to the admin principal.
+func (c *endpointClaims) principal() string {
+	if c.Subject == "" {
+		return AdminPrincipal
+	}
+	return c.Subject
+}
+
+// allowsMethod returns true if the token allows calls to [method]. Calls that
+// aren't JSON-RPC calls have an empty [method], which is only allowed if the
+// token isn't limited to specific methods.
+func (c *endpointClaims) allowsMethod(method string) bool {
+	if len(c.Methods) == 0 {
+		return true
+	}
+	for _, pattern := range c.Methods {
+		if strings.HasSuffix(pattern, "*") {
+			if prefix := pattern[:len(pattern)-1]; method != "" && strings.HasPrefix(method, prefix) {
+				return true
+			}
+		} else if pattern == method {
+			return true
+		}
+	}
+	return false
 }
@@ -4,7 +4,9 @@
 package auth
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/lasthyphen/dijetsgo/api"
 )
@@ -20,23 +22,50 @@ type Password struct {
 
 type NewTokenArgs struct {
 	Password
+	// Principal the token is issued to. [Password] must be the password of
+	// the principal. Defaults to the admin principal.
+	Principal string `json:"principal"`
 	// Endpoints that may be accessed with this token e.g. if endpoints is
 	// ["/ext/bc/X", "/ext/admin"] then the token holder can hit the X-Chain API
 	// and the admin API. If [Endpoints] contains an element "*" then the token
 	// allows access to all API endpoints. [Endpoints] must have between 1 and
 	// [maxEndpoints] elements
 	Endpoints []string `json:"endpoints"`
+	// JSON-RPC methods that may be called with this token e.g. if methods is
+	// ["platform.get*", "info.getNodeID"] then the token holder can call every
+	// platform method starting with "get" and info.getNodeID. If [Methods] is
+	// empty or contains an element "*" then the token allows calls to all
+	// methods. [Methods] must have at most [maxMethods] elements
+	Methods []string `json:"methods"`
+	// Duration is how long the token is valid for, e.g. "1h30m". Defaults to
+	// 12 hours and can be at most [maxTokenLifespan].
+	Duration string `json:"duration"`
 }
 
 type Token struct {
-	Token string `json:"token"` // The new token. Expires in [TokenLifespan].
+	Token string `json:"token"` // The new token. Expires after the requested duration.
 }
 
 func (s *Service) NewToken(_ *http.Request, args *NewTokenArgs, reply *Token) error {
 	s.auth.log.Debug("Auth: NewToken called")
 
+	lifespan := defaultTokenLifespan
+	if args.Duration != "" {
+		var err error
+		lifespan, err = time.ParseDuration(args.Duration)
+		if err != nil {
+			return fmt.Errorf("couldn't parse duration %q: %w", args.Duration, err)
+		}
+	}
+
 	var err error
-	reply.Token, err = s.auth.NewToken(args.Password.Password, defaultTokenLifespan, args.Endpoints)
+	reply.Token, err = s.auth.NewPrincipalToken(
+		principalOrAdmin(args.Principal),
+		args.Password.Password,
+		lifespan,
+		args.Endpoints,
+		args.Methods,
+	)
 	return err
 }
 
@@ -53,6 +82,7 @@ func (s *Service) RevokeToken(_ *http.Request, args *RevokeTokenArgs, reply *api
 }
 
 type ChangePasswordArgs struct {
+	Principal   string `json:"principal"`   // Defaults to the admin principal
 	OldPassword string `json:"oldPassword"` // Current authorization password
 	NewPassword string `json:"newPassword"` // New authorization password
 }
@@ -61,5 +91,55 @@ func (s *Service) ChangePassword(_ *http.Request, args *ChangePasswordArgs, repl
 	s.auth.log.Debug("Auth: ChangePassword called")
 
 	reply.Success = true
-	return s.auth.ChangePassword(args.OldPassword, args.NewPassword)
+	return s.auth.ChangePrincipalPassword(principalOrAdmin(args.Principal), args.OldPassword, args.NewPassword)
+}
+
+type AddPrincipalArgs struct {
+	AdminPassword string `json:"adminPassword"` // The admin's authorization password
+	Principal     string `json:"principal"`     // Name of the new principal
+	Password      string `json:"password"`      // Authorization password of the new principal
+}
+
+func (s *Service) AddPrincipal(_ *http.Request, args *AddPrincipalArgs, reply *api.SuccessResponse) error {
+	s.auth.log.Debug("Auth: AddPrincipal called")
+
+	reply.Success = true
+	return s.auth.AddPrincipal(args.AdminPassword, args.Principal, args.Password)
+}
+
+type RemovePrincipalArgs struct {
+	AdminPassword string `json:"adminPassword"` // The admin's authorization password
+	Principal     string `json:"principal"`     // Name of the principal to remove
+}
+
+func (s *Service) RemovePrincipal(_ *http.Request, args *RemovePrincipalArgs, reply *api.SuccessResponse) error {
+	s.auth.log.Debug("Auth: RemovePrincipal called")
+
+	reply.Success = true
+	return s.auth.RemovePrincipal(args.AdminPassword, args.Principal)
+}
+
+type ListTokensArgs struct {
+	Password
+	Principal string `json:"principal"` // Defaults to the admin principal
+}
+
+type ListTokensReply struct {
+	Tokens []TokenInfo `json:"tokens"`
+}
+
+func (s *Service) ListTokens(_ *http.Request, args *ListTokensArgs, reply *ListTokensReply) error {
+	s.auth.log.Debug("Auth: ListTokens called")
+
+	var err error
+	reply.Tokens, err = s.auth.ListTokens(principalOrAdmin(args.Principal), args.Password.Password)
+	return err
+}
+
+// principalOrAdmin returns [principal], or the admin principal if it's empty
+func principalOrAdmin(principal string) string {
+	if principal == "" {
+		return AdminPrincipal
+	}
+	return principal
 }
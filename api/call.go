@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/lasthyphen/dijetsgo/utils/units"
+)
+
+// MaxCallSize is the largest request body ReadCall reads
+const MaxCallSize = 16 * units.MiB
+
+// Call is the part of a JSON-RPC request that identifies the called method
+type Call struct {
+	Method string          `json:"method"`
+	ID     json.RawMessage `json:"id"`
+}
+
+// ReadCall returns the JSON-RPC call in the body of [r], if there is one. The
+// body of [r] is replaced so that it can be read again by the API handler. If
+// [r] isn't a JSON-RPC call, the returned call is empty. Returns an error if
+// the body is larger than MaxCallSize.
+func ReadCall(w http.ResponseWriter, r *http.Request) (Call, error) {
+	c := Call{}
+	if r.Method != http.MethodPost || r.Body == nil {
+		return c, nil
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, MaxCallSize))
+	if err != nil {
+		return c, err
+	}
+	if err := r.Body.Close(); err != nil {
+		return c, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	// Malformed calls are reported by the API handler.
+	_ = json.Unmarshal(body, &c)
+	return c, nil
+}
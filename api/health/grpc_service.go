@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/lasthyphen/dijetsgo/api/proto/healthproto"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+)
+
+// defaultWatchInterval is how often results are sent to a watcher that didn't
+// specify an interval
+const defaultWatchInterval = time.Second
+
+var _ healthproto.HealthServer = &GRPCService{}
+
+// GRPCService serves the health API over gRPC
+type GRPCService struct {
+	healthproto.UnimplementedHealthServer
+	log    logging.Logger
+	health Reporter
+}
+
+// NewGRPCService returns a gRPC server that reports the results of [reporter]
+func NewGRPCService(log logging.Logger, reporter Reporter) *GRPCService {
+	return &GRPCService{
+		log:    log,
+		health: reporter,
+	}
+}
+
+// Readiness returns if the node has finished initialization
+func (s *GRPCService) Readiness(context.Context, *emptypb.Empty) (*healthproto.ReadinessResponse, error) {
+	s.log.Debug("Health.readiness called")
+	checks, healthy := s.health.Readiness()
+	protoChecks, err := resultsToProto(checks)
+	return &healthproto.ReadinessResponse{
+		Checks:  protoChecks,
+		Healthy: healthy,
+	}, err
+}
+
+// Health returns a summation of the health of the node
+func (s *GRPCService) Health(context.Context, *emptypb.Empty) (*healthproto.HealthResponse, error) {
+	s.log.Debug("Health.health called")
+	checks, healthy := s.health.Health()
+	protoChecks, err := resultsToProto(checks)
+	return &healthproto.HealthResponse{
+		Checks:  protoChecks,
+		Healthy: healthy,
+	}, err
+}
+
+// Liveness returns if the node is in need of a restart
+func (s *GRPCService) Liveness(context.Context, *emptypb.Empty) (*healthproto.LivenessResponse, error) {
+	s.log.Debug("Health.liveness called")
+	checks, healthy := s.health.Liveness()
+	protoChecks, err := resultsToProto(checks)
+	return &healthproto.LivenessResponse{
+		Checks:  protoChecks,
+		Healthy: healthy,
+	}, err
+}
+
+// Watch periodically sends the results of the requested checks until the
+// client cancels the stream.
+func (s *GRPCService) Watch(req *healthproto.WatchRequest, stream healthproto.Health_WatchServer) error {
+	s.log.Debug("Health.watch called")
+
+	var reporter func() (map[string]Result, bool)
+	switch req.Check {
+	case healthproto.Check_CHECK_READINESS:
+		reporter = s.health.Readiness
+	case healthproto.Check_CHECK_LIVENESS:
+		reporter = s.health.Liveness
+	default:
+		reporter = s.health.Health
+	}
+
+	interval := defaultWatchInterval
+	if req.Interval != nil {
+		if requested := req.Interval.AsDuration(); requested > 0 {
+			interval = requested
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx := stream.Context()
+	for {
+		checks, healthy := reporter()
+		protoChecks, err := resultsToProto(checks)
+		if err != nil {
+			return err
+		}
+		err = stream.Send(&healthproto.WatchResponse{
+			Checks:  protoChecks,
+			Healthy: healthy,
+		})
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func resultsToProto(results map[string]Result) (map[string]*healthproto.Result, error) {
+	protoResults := make(map[string]*healthproto.Result, len(results))
+	for name, result := range results {
+		protoResult := &healthproto.Result{
+			Timestamp:          timestamppb.New(result.Timestamp),
+			Duration:           durationpb.New(result.Duration),
+			ContiguousFailures: result.ContiguousFailures,
+		}
+		if result.Details != nil {
+			details, err := json.Marshal(result.Details)
+			if err != nil {
+				return nil, err
+			}
+			protoResult.Details = details
+		}
+		if result.Error != nil {
+			protoResult.Error = *result.Error
+		}
+		if result.TimeOfFirstFailure != nil {
+			protoResult.TimeOfFirstFailure = timestamppb.New(*result.TimeOfFirstFailure)
+		}
+		protoResults[name] = protoResult
+	}
+	return protoResults, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+)
+
+func TestGRPCServiceResponses(t *testing.T) {
+	assert := assert.New(t)
+
+	passingCheck := CheckerFunc(func() (interface{}, error) {
+		return "details", nil
+	})
+	failingCheck := CheckerFunc(func() (interface{}, error) {
+		return nil, errors.New("unhealthy")
+	})
+
+	h, err := New(prometheus.NewRegistry())
+	assert.NoError(err)
+
+	s := NewGRPCService(logging.NoLog{}, h)
+
+	err = h.RegisterReadinessCheck("check", passingCheck)
+	assert.NoError(err)
+	err = h.RegisterHealthCheck("check", passingCheck)
+	assert.NoError(err)
+	err = h.RegisterLivenessCheck("check", failingCheck)
+	assert.NoError(err)
+
+	{
+		reply, err := s.Health(nil, nil)
+		assert.NoError(err)
+
+		assert.Len(reply.Checks, 1)
+		assert.Equal(*notYetRunResult.Error, reply.Checks["check"].Error)
+		assert.False(reply.Healthy)
+	}
+
+	h.Start(checkFreq)
+	defer h.Stop()
+
+	awaitReadiness(h)
+	awaitHealthy(h, true)
+	awaitLiveness(h, false)
+
+	{
+		reply, err := s.Readiness(nil, nil)
+		assert.NoError(err)
+
+		result := reply.Checks["check"]
+		assert.Equal([]byte(`"details"`), result.Details)
+		assert.Empty(result.Error)
+		assert.Zero(result.ContiguousFailures)
+		assert.Nil(result.TimeOfFirstFailure)
+		assert.True(reply.Healthy)
+	}
+
+	{
+		reply, err := s.Health(nil, nil)
+		assert.NoError(err)
+
+		result := reply.Checks["check"]
+		assert.Equal([]byte(`"details"`), result.Details)
+		assert.Empty(result.Error)
+		assert.True(reply.Healthy)
+	}
+
+	{
+		reply, err := s.Liveness(nil, nil)
+		assert.NoError(err)
+
+		result := reply.Checks["check"]
+		assert.Nil(result.Details)
+		assert.Equal("unhealthy", result.Error)
+		assert.NotZero(result.ContiguousFailures)
+		assert.NotNil(result.TimeOfFirstFailure)
+		assert.False(reply.Healthy)
+	}
+}
@@ -26,6 +26,7 @@ type Client interface {
 	IsBootstrapped(context.Context, string) (bool, error)
 	GetTxFee(context.Context) (*GetTxFeeResponse, error)
 	Uptime(context.Context) (*UptimeResponse, error)
+	GetNetworkUpgrades(context.Context) (*GetNetworkUpgradesReply, error)
 }
 
 // Client implementation for an Info API Client
@@ -103,3 +104,9 @@ func (c *client) Uptime(ctx context.Context) (*UptimeResponse, error) {
 	err := c.requester.SendRequest(ctx, "uptime", struct{}{}, res)
 	return res, err
 }
+
+func (c *client) GetNetworkUpgrades(ctx context.Context) (*GetNetworkUpgradesReply, error) {
+	res := &GetNetworkUpgradesReply{}
+	err := c.requester.SendRequest(ctx, "getNetworkUpgrades", struct{}{}, res)
+	return res, err
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/lasthyphen/dijetsgo/api/proto/infoproto"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+)
+
+var _ infoproto.InfoServer = &GRPCService{}
+
+// GRPCService serves the info API over gRPC
+type GRPCService struct {
+	infoproto.UnimplementedInfoServer
+	service *Info
+}
+
+// NewGRPCService returns a gRPC server that serves [service]
+func NewGRPCService(service *Info) *GRPCService {
+	return &GRPCService{service: service}
+}
+
+func (s *GRPCService) GetNodeVersion(context.Context, *emptypb.Empty) (*infoproto.GetNodeVersionResponse, error) {
+	reply := GetNodeVersionReply{}
+	if err := s.service.GetNodeVersion(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infoproto.GetNodeVersionResponse{
+		Version:         reply.Version,
+		DatabaseVersion: reply.DatabaseVersion,
+		GitCommit:       reply.GitCommit,
+		VmVersions:      reply.VMVersions,
+	}, nil
+}
+
+func (s *GRPCService) GetNodeID(context.Context, *emptypb.Empty) (*infoproto.GetNodeIDResponse, error) {
+	s.service.log.Debug("Info: GetNodeID called")
+
+	return &infoproto.GetNodeIDResponse{
+		NodeId: s.service.NodeID.Bytes(),
+	}, nil
+}
+
+func (s *GRPCService) GetNodeIP(context.Context, *emptypb.Empty) (*infoproto.GetNodeIPResponse, error) {
+	reply := GetNodeIPReply{}
+	if err := s.service.GetNodeIP(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infoproto.GetNodeIPResponse{
+		Ip: reply.IP,
+	}, nil
+}
+
+func (s *GRPCService) GetNetworkID(context.Context, *emptypb.Empty) (*infoproto.GetNetworkIDResponse, error) {
+	reply := GetNetworkIDReply{}
+	if err := s.service.GetNetworkID(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infoproto.GetNetworkIDResponse{
+		NetworkId: uint32(reply.NetworkID),
+	}, nil
+}
+
+func (s *GRPCService) GetNetworkName(context.Context, *emptypb.Empty) (*infoproto.GetNetworkNameResponse, error) {
+	reply := GetNetworkNameReply{}
+	if err := s.service.GetNetworkName(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infoproto.GetNetworkNameResponse{
+		NetworkName: reply.NetworkName,
+	}, nil
+}
+
+func (s *GRPCService) GetBlockchainID(_ context.Context, req *infoproto.GetBlockchainIDRequest) (*infoproto.GetBlockchainIDResponse, error) {
+	reply := GetBlockchainIDReply{}
+	if err := s.service.GetBlockchainID(nil, &GetBlockchainIDArgs{Alias: req.Alias}, &reply); err != nil {
+		return nil, err
+	}
+	return &infoproto.GetBlockchainIDResponse{
+		BlockchainId: reply.BlockchainID[:],
+	}, nil
+}
+
+func (s *GRPCService) Peers(_ context.Context, req *infoproto.PeersRequest) (*infoproto.PeersResponse, error) {
+	args := PeersArgs{
+		NodeIDs: make([]string, len(req.NodeIds)),
+	}
+	for i, nodeIDBytes := range req.NodeIds {
+		nodeID, err := ids.ToShortID(nodeIDBytes)
+		if err != nil {
+			return nil, err
+		}
+		args.NodeIDs[i] = nodeID.PrefixedString(constants.NodeIDPrefix)
+	}
+
+	reply := PeersReply{}
+	if err := s.service.Peers(nil, &args, &reply); err != nil {
+		return nil, err
+	}
+
+	peers := make([]*infoproto.Peer, len(reply.Peers))
+	for i, peer := range reply.Peers {
+		nodeID, err := ids.ShortFromPrefixedString(peer.ID, constants.NodeIDPrefix)
+		if err != nil {
+			return nil, err
+		}
+		peers[i] = &infoproto.Peer{
+			Ip:             peer.IP,
+			PublicIp:       peer.PublicIP,
+			NodeId:         nodeID.Bytes(),
+			Version:        peer.Version,
+			LastSent:       timestamppb.New(peer.LastSent),
+			LastReceived:   timestamppb.New(peer.LastReceived),
+			Benched:        idsToBytes(peer.Benched),
+			ObservedUptime: uint32(peer.ObservedUptime),
+			TrackedSubnets: idsToBytes(peer.TrackedSubnets),
+		}
+	}
+	return &infoproto.PeersResponse{
+		Peers: peers,
+	}, nil
+}
+
+func (s *GRPCService) IsBootstrapped(_ context.Context, req *infoproto.IsBootstrappedRequest) (*infoproto.IsBootstrappedResponse, error) {
+	reply := IsBootstrappedResponse{}
+	if err := s.service.IsBootstrapped(nil, &IsBootstrappedArgs{Chain: req.Chain}, &reply); err != nil {
+		return nil, err
+	}
+	return &infoproto.IsBootstrappedResponse{
+		IsBootstrapped: reply.IsBootstrapped,
+	}, nil
+}
+
+func (s *GRPCService) Uptime(context.Context, *emptypb.Empty) (*infoproto.UptimeResponse, error) {
+	reply := UptimeResponse{}
+	if err := s.service.Uptime(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infoproto.UptimeResponse{
+		RewardingStakePercentage:  float64(reply.RewardingStakePercentage),
+		WeightedAveragePercentage: float64(reply.WeightedAveragePercentage),
+	}, nil
+}
+
+func (s *GRPCService) GetTxFee(context.Context, *emptypb.Empty) (*infoproto.GetTxFeeResponse, error) {
+	reply := GetTxFeeResponse{}
+	if err := s.service.GetTxFee(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infoproto.GetTxFeeResponse{
+		TxFee:                 uint64(reply.TxFee),
+		CreateAssetTxFee:      uint64(reply.CreateAssetTxFee),
+		CreateSubnetTxFee:     uint64(reply.CreateSubnetTxFee),
+		CreateBlockchainTxFee: uint64(reply.CreateBlockchainTxFee),
+	}, nil
+}
+
+func idsToBytes(idList []ids.ID) [][]byte {
+	bytes := make([][]byte, len(idList))
+	for i := range idList {
+		bytes[i] = idList[i][:]
+	}
+	return bytes
+}
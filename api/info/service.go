@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
@@ -46,10 +47,11 @@ type Parameters struct {
 	CreateAssetTxFee      uint64
 	CreateSubnetTxFee     uint64
 	CreateBlockchainTxFee uint64
+	NetworkUpgrades       version.NetworkUpgrades
 }
 
-// NewService returns a new admin API service
-func NewService(
+// New returns a new info API service
+func New(
 	parameters Parameters,
 	log logging.Logger,
 	chainManager chains.Manager,
@@ -57,12 +59,8 @@ func NewService(
 	network network.Network,
 	versionParser version.ApplicationParser,
 	validators validators.Set,
-) (*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
-	codec := json.NewCodec()
-	newServer.RegisterCodec(codec, "application/json")
-	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
-	if err := newServer.RegisterService(&Info{
+) *Info {
+	return &Info{
 		Parameters:    parameters,
 		log:           log,
 		chainManager:  chainManager,
@@ -70,7 +68,16 @@ func NewService(
 		networking:    network,
 		versionParser: versionParser,
 		validators:    validators,
-	}, "info"); err != nil {
+	}
+}
+
+// NewHandler returns a JSON-RPC handler that serves [service]
+func NewHandler(service *Info) (*common.HTTPHandler, error) {
+	newServer := rpc.NewServer()
+	codec := json.NewCodec()
+	newServer.RegisterCodec(codec, "application/json")
+	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	if err := newServer.RegisterService(service, "info"); err != nil {
 		return nil, err
 	}
 	return &common.HTTPHandler{Handler: newServer}, nil
@@ -276,3 +283,31 @@ func (service *Info) GetTxFee(_ *http.Request, args *struct{}, reply *GetTxFeeRe
 	reply.CreateBlockchainTxFee = json.Uint64(service.CreateBlockchainTxFee)
 	return nil
 }
+
+// GetNetworkUpgradesReply are the times the network activates its upgrades
+type GetNetworkUpgradesReply struct {
+	ApricotPhase0Time            time.Time   `json:"apricotPhase0Time"`
+	ApricotPhase1Time            time.Time   `json:"apricotPhase1Time"`
+	ApricotPhase2Time            time.Time   `json:"apricotPhase2Time"`
+	ApricotPhase3Time            time.Time   `json:"apricotPhase3Time"`
+	ApricotPhase4Time            time.Time   `json:"apricotPhase4Time"`
+	ApricotPhase4MinPChainHeight json.Uint64 `json:"apricotPhase4MinPChainHeight"`
+	ApricotPhase5Time            time.Time   `json:"apricotPhase5Time"`
+	ApricotPhase6Time            time.Time   `json:"apricotPhase6Time"`
+}
+
+// GetNetworkUpgrades returns the times the network activates its upgrades
+func (service *Info) GetNetworkUpgrades(_ *http.Request, _ *struct{}, reply *GetNetworkUpgradesReply) error {
+	service.log.Debug("Info: GetNetworkUpgrades called")
+
+	upgrades := service.NetworkUpgrades
+	reply.ApricotPhase0Time = upgrades.ApricotPhase0Time
+	reply.ApricotPhase1Time = upgrades.ApricotPhase1Time
+	reply.ApricotPhase2Time = upgrades.ApricotPhase2Time
+	reply.ApricotPhase3Time = upgrades.ApricotPhase3Time
+	reply.ApricotPhase4Time = upgrades.ApricotPhase4Time
+	reply.ApricotPhase4MinPChainHeight = json.Uint64(upgrades.ApricotPhase4MinPChainHeight)
+	reply.ApricotPhase5Time = upgrades.ApricotPhase5Time
+	reply.ApricotPhase6Time = upgrades.ApricotPhase6Time
+	return nil
+}
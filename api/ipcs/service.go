@@ -48,6 +48,12 @@ func NewService(log logging.Logger, chainManager chains.Manager, httpServer *ser
 // PublishBlockchainArgs are the arguments for calling PublishBlockchain
 type PublishBlockchainArgs struct {
 	BlockchainID string `json:"blockchainID"`
+	// One of "unix", "tcp" or "grpc". Defaults to "unix".
+	Transport string `json:"transport"`
+	// TCP addresses the sockets listen on when publishing over TCP or gRPC.
+	// If omitted, the sockets listen on a free port of localhost.
+	ConsensusAddress string `json:"consensusAddress"`
+	DecisionsAddress string `json:"decisionsAddress"`
 }
 
 // PublishBlockchainReply are the results from calling PublishBlockchain
@@ -66,14 +72,18 @@ func (ipc *IPCServer) PublishBlockchain(r *http.Request, args *PublishBlockchain
 		return err
 	}
 
-	ipcs, err := ipc.ipcs.Publish(chainID)
+	es, err := ipc.ipcs.PublishWithConfig(chainID, ipcs.PublishConfig{
+		Transport:        ipcs.Transport(args.Transport),
+		ConsensusAddress: args.ConsensusAddress,
+		DecisionsAddress: args.DecisionsAddress,
+	})
 	if err != nil {
 		ipc.log.Error("couldn't publish blockchainID: %s", err)
 		return err
 	}
 
-	reply.ConsensusURL = ipcs.ConsensusURL()
-	reply.DecisionsURL = ipcs.DecisionsURL()
+	reply.ConsensusURL = es.ConsensusURL()
+	reply.DecisionsURL = es.DecisionsURL()
 
 	return nil
 }
@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// dataKeySize is the size, in bytes, of the keys that encrypt the data of
+// users created with a KeyStorage
+const dataKeySize = 32
+
+var errKeyStorageUnavailable = errors.New("the key storage backend of this user isn't configured")
+
+// KeyStorage protects the keys that encrypt the data of the keystore's users.
+//
+// Without a KeyStorage, a user's data is encrypted with a key derived from
+// their password. With one, each new user gets a random data key that is
+// wrapped by the KeyStorage and persisted next to the user's password hash.
+// The password is still required to access the user's data.
+type KeyStorage interface {
+	// Name identifies this backend in the records of the users it wrapped
+	// the keys of. It must not change between restarts.
+	Name() string
+
+	// WrapKey encrypts the data key of [username]
+	WrapKey(username string, key []byte) ([]byte, error)
+
+	// UnwrapKey decrypts a data key of [username] that was returned by
+	// WrapKey
+	UnwrapKey(username string, wrappedKey []byte) ([]byte, error)
+}
+
+// storedKey is the wrapped data key of a user
+type storedKey struct {
+	// Name of the KeyStorage that wrapped [Key]
+	Backend string `serialize:"true"`
+	Key     []byte `serialize:"true"`
+}
+
+// newDataKey returns a random data key and its wrapped form
+func newDataKey(storage KeyStorage, username string) ([]byte, *storedKey, error) {
+	key := make([]byte, dataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, err
+	}
+	wrappedKey, err := storage.WrapKey(username, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't wrap data key: %w", err)
+	}
+	return key, &storedKey{
+		Backend: storage.Name(),
+		Key:     wrappedKey,
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/database"
 	"github.com/lasthyphen/dijetsgo/database/encdb"
 	"github.com/lasthyphen/dijetsgo/database/manager"
+	"github.com/lasthyphen/dijetsgo/database/memdb"
 	"github.com/lasthyphen/dijetsgo/database/prefixdb"
 	"github.com/lasthyphen/dijetsgo/ids"
 	"github.com/lasthyphen/dijetsgo/utils/logging"
@@ -26,6 +28,9 @@ import (
 const (
 	// maxUserLen is the maximum allowed length of a username
 	maxUserLen = 1024
+
+	// dataKeyTTL is how long an unwrapped data key is kept in memory
+	dataKeyTTL = 10 * time.Minute
 )
 
 var (
@@ -34,6 +39,7 @@ var (
 
 	usersPrefix = []byte("users")
 	bcsPrefix   = []byte("bcs")
+	keysPrefix  = []byte("keys")
 
 	_ Keystore = &keystore{}
 )
@@ -50,9 +56,10 @@ type Keystore interface {
 	// underlying database.
 	GetDatabase(bID ids.ID, username, password string) (*encdb.Database, error)
 
-	// Get the underlying database that is able to read and write encrypted
-	// values. This Database will not perform any encrypting or decrypting of
-	// values and is not recommended to be used when implementing a VM.
+	// Get the underlying database that is able to read and write values
+	// encrypted with the user's password. This Database will not perform any
+	// encrypting or decrypting of values for users without a wrapped data key
+	// and is not recommended to be used when implementing a VM.
 	GetRawDatabase(bID ids.ID, username, password string) (database.Database, error)
 
 	// CreateUser attempts to register this username and password as a new user
@@ -98,9 +105,21 @@ type keystore struct {
 	// Value: The hash of that user's password
 	usernameToPassword map[string]*password.Hash
 
+	// Wraps the data keys of new users. If nil, the data of new users is
+	// encrypted with a key derived from their password.
+	storage KeyStorage
+
+	// Key: username
+	// Value: The unwrapped data key of that user. Evicted [dataKeyTTL] after
+	// it was unwrapped.
+	usernameToKey map[string]*cachedKey
+	dataKeyTTL    time.Duration
+
 	// Used to persist users and their data
 	userDB database.Database
 	bcDB   database.Database
+	// Holds the wrapped data keys of the users created with a KeyStorage
+	keyDB database.Database
 	//           BaseDB
 	//          /      \
 	//    UserDB        BlockchainDB
@@ -111,12 +130,23 @@ type keystore struct {
 }
 
 func New(log logging.Logger, dbManager manager.Manager) Keystore {
+	return NewWithKeyStorage(log, dbManager, nil)
+}
+
+// NewWithKeyStorage returns a keystore that wraps the data keys of new users
+// with [storage]. Users created before [storage] was configured keep using
+// their password-derived keys.
+func NewWithKeyStorage(log logging.Logger, dbManager manager.Manager, storage KeyStorage) Keystore {
 	currentDB := dbManager.Current()
 	return &keystore{
 		log:                log,
 		usernameToPassword: make(map[string]*password.Hash),
+		storage:            storage,
+		usernameToKey:      make(map[string]*cachedKey),
+		dataKeyTTL:         dataKeyTTL,
 		userDB:             prefixdb.New(usersPrefix, currentDB.Database),
 		bcDB:               prefixdb.New(bcsPrefix, currentDB.Database),
+		keyDB:              prefixdb.New(keysPrefix, currentDB.Database),
 	}
 }
 
@@ -139,11 +169,22 @@ func (ks *keystore) NewBlockchainKeyStore(blockchainID ids.ID) BlockchainKeystor
 }
 
 func (ks *keystore) GetDatabase(bID ids.ID, username, password string) (*encdb.Database, error) {
-	bcDB, err := ks.GetRawDatabase(bID, username, password)
+	if username == "" {
+		return nil, errEmptyUsername
+	}
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	bcDB, err := ks.getRawDatabase(bID, username, password)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ks.getDataKey(username, password)
 	if err != nil {
 		return nil, err
 	}
-	return encdb.New([]byte(password), bcDB)
+	return encdb.New(key, bcDB)
 }
 
 func (ks *keystore) GetRawDatabase(bID ids.ID, username, pw string) (database.Database, error) {
@@ -154,6 +195,33 @@ func (ks *keystore) GetRawDatabase(bID ids.ID, username, pw string) (database.Da
 	ks.lock.Lock()
 	defer ks.lock.Unlock()
 
+	bcDB, err := ks.getRawDatabase(bID, username, pw)
+	if err != nil {
+		return nil, err
+	}
+	// Callers of GetRawDatabase encrypt values with the user's password, which
+	// doesn't match the data key of users created with a KeyStorage. The values
+	// of those users are re-encrypted with the password.
+	hasDataKey, err := ks.keyDB.Has([]byte(username))
+	if err != nil {
+		return nil, err
+	}
+	if !hasDataKey {
+		return bcDB, nil
+	}
+	key, err := ks.getDataKey(username, pw)
+	if err != nil {
+		return nil, err
+	}
+	dataDB, err := encdb.New(key, bcDB)
+	if err != nil {
+		return nil, err
+	}
+	return encdb.NewRaw([]byte(pw), dataDB)
+}
+
+// getRawDatabase assumes [ks.lock] is held
+func (ks *keystore) getRawDatabase(bID ids.ID, username, pw string) (database.Database, error) {
 	passwordHash, err := ks.getPassword(username)
 	if err != nil {
 		return nil, err
@@ -200,10 +268,24 @@ func (ks *keystore) CreateUser(username, pw string) error {
 		return err
 	}
 
-	if err := ks.userDB.Put([]byte(username), passwordBytes); err != nil {
+	userBatch := ks.userDB.NewBatch()
+	if err := userBatch.Put([]byte(username), passwordBytes); err != nil {
+		return err
+	}
+
+	keyBatch := ks.keyDB.NewBatch()
+	dataKey, err := ks.putNewDataKey(keyBatch, username)
+	if err != nil {
+		return err
+	}
+
+	if err := atomic.WriteAll(userBatch, keyBatch); err != nil {
 		return err
 	}
 	ks.usernameToPassword[username] = passwordHash
+	if dataKey != nil {
+		ks.cacheDataKey(username, dataKey)
+	}
 
 	return nil
 }
@@ -235,6 +317,10 @@ func (ks *keystore) DeleteUser(username, pw string) error {
 	if err := userBatch.Delete(userNameBytes); err != nil {
 		return err
 	}
+	keyBatch := ks.keyDB.NewBatch()
+	if err := keyBatch.Delete(userNameBytes); err != nil {
+		return err
+	}
 
 	userDataDB := prefixdb.New(userNameBytes, ks.bcDB)
 	dataBatch := userDataDB.NewBatch()
@@ -252,12 +338,13 @@ func (ks *keystore) DeleteUser(username, pw string) error {
 		return err
 	}
 
-	if err := atomic.WriteAll(dataBatch, userBatch); err != nil {
+	if err := atomic.WriteAll(dataBatch, userBatch, keyBatch); err != nil {
 		return err
 	}
 
 	// delete from users map.
 	delete(ks.usernameToPassword, username)
+	ks.evictDataKey(username)
 	return nil
 }
 
@@ -312,18 +399,36 @@ func (ks *keystore) ImportUser(username, pw string, userBytes []byte) error {
 		return err
 	}
 
+	// Exported values are encrypted with the user's password. If new users
+	// get a data key, the values are encrypted with it instead.
+	keyBatch := ks.keyDB.NewBatch()
+	dataKey, err := ks.putNewDataKey(keyBatch, username)
+	if err != nil {
+		return err
+	}
+	data := userData.Data
+	if dataKey != nil {
+		data, err = reencrypt(data, []byte(pw), dataKey)
+		if err != nil {
+			return err
+		}
+	}
+
 	userDataDB := prefixdb.New([]byte(username), ks.bcDB)
 	dataBatch := userDataDB.NewBatch()
-	for _, kvp := range userData.Data {
+	for _, kvp := range data {
 		if err := dataBatch.Put(kvp.Key, kvp.Value); err != nil {
 			return fmt.Errorf("error on database put: %w", err)
 		}
 	}
 
-	if err := atomic.WriteAll(dataBatch, userBatch); err != nil {
+	if err := atomic.WriteAll(dataBatch, userBatch, keyBatch); err != nil {
 		return err
 	}
 	ks.usernameToPassword[username] = &userData.Hash
+	if dataKey != nil {
+		ks.cacheDataKey(username, dataKey)
+	}
 	return nil
 }
 
@@ -361,6 +466,23 @@ func (ks *keystore) ExportUser(username, pw string) ([]byte, error) {
 		return nil, err
 	}
 
+	// Exported values are always encrypted with the user's password, so that
+	// they can be imported regardless of the key storage of the importer.
+	hasDataKey, err := ks.keyDB.Has([]byte(username))
+	if err != nil {
+		return nil, err
+	}
+	if hasDataKey {
+		dataKey, err := ks.getDataKey(username, pw)
+		if err != nil {
+			return nil, err
+		}
+		userData.Data, err = reencrypt(userData.Data, dataKey, []byte(pw))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Return the byte representation of the user
 	return c.Marshal(codecVersion, &userData)
 }
@@ -387,3 +509,126 @@ func (ks *keystore) getPassword(username string) (*password.Hash, error) {
 	_, err = c.Unmarshal(userBytes, passwordHash)
 	return passwordHash, err
 }
+
+// getDataKey returns the key that encrypts the data of [username]. Assumes
+// [ks.lock] is held and that [pw] is the password of [username].
+func (ks *keystore) getDataKey(username, pw string) ([]byte, error) {
+	if cached, exists := ks.usernameToKey[username]; exists {
+		return cached.key, nil
+	}
+
+	keyBytes, err := ks.keyDB.Get([]byte(username))
+	if err == database.ErrNotFound {
+		// The user's data is encrypted with a key derived from their password
+		return []byte(pw), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	storedKey := storedKey{}
+	if _, err := c.Unmarshal(keyBytes, &storedKey); err != nil {
+		return nil, err
+	}
+	if ks.storage == nil || ks.storage.Name() != storedKey.Backend {
+		return nil, fmt.Errorf("%w: %q", errKeyStorageUnavailable, storedKey.Backend)
+	}
+	key, err := ks.storage.UnwrapKey(username, storedKey.Key)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't unwrap data key of user %q: %w", username, err)
+	}
+	ks.cacheDataKey(username, key)
+	return key, nil
+}
+
+type cachedKey struct {
+	key   []byte
+	timer *time.Timer
+}
+
+// cacheDataKey keeps the unwrapped data [key] of [username] in memory for
+// [ks.dataKeyTTL]. Assumes [ks.lock] is held.
+func (ks *keystore) cacheDataKey(username string, key []byte) {
+	ks.evictDataKey(username)
+
+	cached := &cachedKey{key: key}
+	cached.timer = time.AfterFunc(ks.dataKeyTTL, func() {
+		ks.lock.Lock()
+		defer ks.lock.Unlock()
+
+		// The key may have been replaced since the timer was set
+		if ks.usernameToKey[username] == cached {
+			delete(ks.usernameToKey, username)
+		}
+	})
+	ks.usernameToKey[username] = cached
+}
+
+// evictDataKey removes the data key of [username] from memory. Assumes
+// [ks.lock] is held.
+func (ks *keystore) evictDataKey(username string) {
+	if cached, exists := ks.usernameToKey[username]; exists {
+		cached.timer.Stop()
+		delete(ks.usernameToKey, username)
+	}
+}
+
+// putNewDataKey writes a new wrapped data key for [username] to [keyBatch]
+// and returns the unwrapped key. Returns nil if the keystore has no
+// KeyStorage.
+func (ks *keystore) putNewDataKey(keyBatch database.Batch, username string) ([]byte, error) {
+	if ks.storage == nil {
+		return nil, nil
+	}
+	key, storedKey, err := newDataKey(ks.storage, username)
+	if err != nil {
+		return nil, err
+	}
+	keyBytes, err := c.Marshal(codecVersion, storedKey)
+	if err != nil {
+		return nil, err
+	}
+	return key, keyBatch.Put([]byte(username), keyBytes)
+}
+
+// reencrypt returns [pairs] with their values, which are encrypted with
+// [fromKey], encrypted with [toKey]
+func reencrypt(pairs []kvPair, fromKey, toKey []byte) ([]kvPair, error) {
+	fromDB := memdb.New()
+	for _, kvp := range pairs {
+		if err := fromDB.Put(kvp.Key, kvp.Value); err != nil {
+			return nil, err
+		}
+	}
+	decryptedDB, err := encdb.New(fromKey, fromDB)
+	if err != nil {
+		return nil, err
+	}
+	toDB := memdb.New()
+	encryptedDB, err := encdb.New(toKey, toDB)
+	if err != nil {
+		return nil, err
+	}
+
+	it := decryptedDB.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		if err := encryptedDB.Put(it.Key(), it.Value()); err != nil {
+			return nil, err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("couldn't decrypt user data: %w", err)
+	}
+
+	reencrypted := make([]kvPair, 0, len(pairs))
+	toIt := toDB.NewIterator()
+	defer toIt.Release()
+	for toIt.Next() {
+		reencrypted = append(reencrypted, kvPair{
+			Key:   toIt.Key(),
+			Value: toIt.Value(),
+		})
+	}
+	return reencrypted, toIt.Error()
+}
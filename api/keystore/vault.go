@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// VaultKeyStorageName is the name of the Vault transit key storage
+	VaultKeyStorageName = "vault-transit"
+
+	DefaultVaultMountPath = "transit"
+	DefaultVaultTimeout   = 10 * time.Second
+
+	// Maximum size, in bytes, of a response read from Vault
+	maxVaultResponseSize = 1024 * 1024
+)
+
+var (
+	errNoVaultAddress = errors.New("no Vault address provided")
+	errNoVaultKeyName = errors.New("no Vault transit key name provided")
+	errNoCiphertext   = errors.New("vault returned no ciphertext")
+
+	_ KeyStorage = &vaultKeyStorage{}
+)
+
+// VaultConfig describes how to reach the transit secrets engine of a Vault
+// compatible server
+type VaultConfig struct {
+	// Address of the server's HTTP API, e.g. http://127.0.0.1:8200
+	Address string `json:"address"`
+	// Token sent in the X-Vault-Token header
+	Token string `json:"-"`
+	// Path the transit secrets engine is mounted at. Defaults to
+	// [DefaultVaultMountPath].
+	MountPath string `json:"mountPath"`
+	// Name of the transit key that wraps the data keys
+	KeyName string `json:"keyName"`
+	// Timeout of each request. Defaults to [DefaultVaultTimeout].
+	Timeout time.Duration `json:"timeout"`
+}
+
+type vaultKeyStorage struct {
+	client     *http.Client
+	token      string
+	encryptURL string
+	decryptURL string
+}
+
+// NewVaultKeyStorage returns a KeyStorage that wraps data keys with the
+// transit secrets engine of a Vault compatible server
+func NewVaultKeyStorage(config VaultConfig) (KeyStorage, error) {
+	if config.Address == "" {
+		return nil, errNoVaultAddress
+	}
+	if config.KeyName == "" {
+		return nil, errNoVaultKeyName
+	}
+	mountPath := strings.Trim(config.MountPath, "/")
+	if mountPath == "" {
+		mountPath = DefaultVaultMountPath
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = DefaultVaultTimeout
+	}
+
+	baseURL, err := url.Parse(strings.TrimRight(config.Address, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse Vault address: %w", err)
+	}
+	keyName := url.PathEscape(config.KeyName)
+	return &vaultKeyStorage{
+		client:     &http.Client{Timeout: timeout},
+		token:      config.Token,
+		encryptURL: fmt.Sprintf("%s/v1/%s/encrypt/%s", baseURL, mountPath, keyName),
+		decryptURL: fmt.Sprintf("%s/v1/%s/decrypt/%s", baseURL, mountPath, keyName),
+	}, nil
+}
+
+func (*vaultKeyStorage) Name() string { return VaultKeyStorageName }
+
+type vaultEncryptRequest struct {
+	Plaintext string `json:"plaintext"`
+}
+
+type vaultDecryptRequest struct {
+	Ciphertext string `json:"ciphertext"`
+}
+
+type vaultResponse struct {
+	Data struct {
+		Ciphertext string `json:"ciphertext"`
+		Plaintext  string `json:"plaintext"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+func (s *vaultKeyStorage) WrapKey(_ string, key []byte) ([]byte, error) {
+	reply, err := s.call(s.encryptURL, &vaultEncryptRequest{
+		Plaintext: base64.StdEncoding.EncodeToString(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reply.Data.Ciphertext == "" {
+		return nil, errNoCiphertext
+	}
+	return []byte(reply.Data.Ciphertext), nil
+}
+
+func (s *vaultKeyStorage) UnwrapKey(_ string, wrappedKey []byte) ([]byte, error) {
+	reply, err := s.call(s.decryptURL, &vaultDecryptRequest{
+		Ciphertext: string(wrappedKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(reply.Data.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode the plaintext returned by Vault: %w", err)
+	}
+	return key, nil
+}
+
+// call POSTs [args] to [url] and returns the decoded response
+func (s *vaultKeyStorage) call(url string, args interface{}) (*vaultResponse, error) {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", s.token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't reach Vault: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxVaultResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read Vault response: %w", err)
+	}
+	reply := &vaultResponse{}
+	if err := json.Unmarshal(respBytes, reply); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("couldn't decode Vault response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault responded with status %d: %s", resp.StatusCode, strings.Join(reply.Errors, "; "))
+	}
+	return reply, nil
+}
@@ -0,0 +1,312 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/database"
+	"github.com/lasthyphen/dijetsgo/database/encdb"
+	"github.com/lasthyphen/dijetsgo/database/manager"
+	"github.com/lasthyphen/dijetsgo/database/memdb"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+	"github.com/lasthyphen/dijetsgo/version"
+)
+
+const testVaultToken = "test-token"
+
+// fakeVault serves the encrypt and decrypt endpoints of a transit engine. It
+// "encrypts" plaintexts by remembering them.
+type fakeVault struct {
+	lock        sync.Mutex
+	plaintexts  map[string]string
+	unavailable bool
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.unavailable {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(map[string][]string{"errors": {"sealed"}})
+		return
+	}
+	if r.Header.Get("X-Vault-Token") != testVaultToken {
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(map[string][]string{"errors": {"permission denied"}})
+		return
+	}
+
+	args := map[string]string{}
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	reply := map[string]map[string]string{"data": {}}
+	switch r.URL.Path {
+	case "/v1/transit/encrypt/keystore":
+		ciphertext := "vault:v1:" + ids.GenerateTestID().String()
+		v.plaintexts[ciphertext] = args["plaintext"]
+		reply["data"]["ciphertext"] = ciphertext
+	case "/v1/transit/decrypt/keystore":
+		plaintext, ok := v.plaintexts[args["ciphertext"]]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string][]string{"errors": {"invalid ciphertext"}})
+			return
+		}
+		reply["data"]["plaintext"] = plaintext
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(reply)
+}
+
+func newTestVault(t *testing.T) (*fakeVault, *httptest.Server) {
+	vault := &fakeVault{plaintexts: make(map[string]string)}
+	server := httptest.NewServer(vault)
+	t.Cleanup(server.Close)
+	return vault, server
+}
+
+func newTestKeystore(t *testing.T, db database.Database, storage KeyStorage) *keystore {
+	dbManager, err := manager.NewManagerFromDBs([]*manager.VersionedDatabase{
+		{
+			Database: db,
+			Version:  version.DefaultVersion1_0_0,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewWithKeyStorage(logging.NoLog{}, dbManager, storage).(*keystore)
+}
+
+func TestVaultKeyStorageWrapKey(t *testing.T) {
+	assert := assert.New(t)
+
+	_, server := newTestVault(t)
+	storage, err := NewVaultKeyStorage(VaultConfig{
+		Address: server.URL + "/",
+		Token:   testVaultToken,
+		KeyName: "keystore",
+	})
+	assert.NoError(err)
+
+	key := []byte{1, 2, 3}
+	wrappedKey, err := storage.WrapKey("bob", key)
+	assert.NoError(err)
+	assert.True(strings.HasPrefix(string(wrappedKey), "vault:v1:"))
+
+	unwrappedKey, err := storage.UnwrapKey("bob", wrappedKey)
+	assert.NoError(err)
+	assert.Equal(key, unwrappedKey)
+
+	_, err = storage.UnwrapKey("bob", []byte("vault:v1:unknown"))
+	assert.Error(err)
+	assert.Contains(err.Error(), "invalid ciphertext")
+
+	badStorage, err := NewVaultKeyStorage(VaultConfig{
+		Address: server.URL,
+		Token:   "wrong-token",
+		KeyName: "keystore",
+	})
+	assert.NoError(err)
+	_, err = badStorage.WrapKey("bob", key)
+	assert.Error(err)
+	assert.Contains(err.Error(), "permission denied")
+
+	_, err = NewVaultKeyStorage(VaultConfig{KeyName: "keystore"})
+	assert.ErrorIs(err, errNoVaultAddress)
+	_, err = NewVaultKeyStorage(VaultConfig{Address: server.URL})
+	assert.ErrorIs(err, errNoVaultKeyName)
+}
+
+func TestKeystoreVaultKeyStorage(t *testing.T) {
+	assert := assert.New(t)
+
+	vault, server := newTestVault(t)
+	storage, err := NewVaultKeyStorage(VaultConfig{
+		Address: server.URL,
+		Token:   testVaultToken,
+		KeyName: "keystore",
+	})
+	assert.NoError(err)
+
+	baseDB := memdb.New()
+	ks := newTestKeystore(t, baseDB, storage)
+	assert.NoError(ks.CreateUser("bob", strongPassword))
+
+	bID := ids.GenerateTestID()
+	db, err := ks.GetDatabase(bID, "bob", strongPassword)
+	assert.NoError(err)
+	assert.NoError(db.Put([]byte("hello"), []byte("world")))
+
+	// The stored values aren't encrypted with the password
+	bcDB, err := ks.getRawDatabase(bID, "bob", strongPassword)
+	assert.NoError(err)
+	passwordDB, err := encdb.New([]byte(strongPassword), bcDB)
+	assert.NoError(err)
+	_, err = passwordDB.Get([]byte("hello"))
+	assert.Error(err)
+
+	// but the raw database re-encrypts them with the password
+	rawDB, err := ks.GetRawDatabase(bID, "bob", strongPassword)
+	assert.NoError(err)
+	passwordDB, err = encdb.New([]byte(strongPassword), rawDB)
+	assert.NoError(err)
+	value, err := passwordDB.Get([]byte("hello"))
+	assert.NoError(err)
+	assert.Equal([]byte("world"), value)
+	assert.NoError(passwordDB.Put([]byte("hi"), []byte("there")))
+	value, err = db.Get([]byte("hi"))
+	assert.NoError(err)
+	assert.Equal([]byte("there"), value)
+
+	// A restarted keystore unwraps the data key with Vault
+	restartedKS := newTestKeystore(t, baseDB, storage)
+	db, err = restartedKS.GetDatabase(bID, "bob", strongPassword)
+	assert.NoError(err)
+	value, err = db.Get([]byte("hello"))
+	assert.NoError(err)
+	assert.Equal([]byte("world"), value)
+
+	_, err = restartedKS.GetDatabase(bID, "bob", "wrong password")
+	assert.Error(err)
+
+	// Without Vault, the data can't be accessed
+	passwordKS := newTestKeystore(t, baseDB, nil)
+	_, err = passwordKS.GetDatabase(bID, "bob", strongPassword)
+	assert.True(errors.Is(err, errKeyStorageUnavailable))
+
+	vault.lock.Lock()
+	vault.unavailable = true
+	vault.lock.Unlock()
+	unavailableKS := newTestKeystore(t, baseDB, storage)
+	_, err = unavailableKS.GetDatabase(bID, "bob", strongPassword)
+	assert.Error(err)
+	assert.Error(unavailableKS.CreateUser("alice", strongPassword))
+	users, err := unavailableKS.ListUsers()
+	assert.NoError(err)
+	assert.Equal([]string{"bob"}, users)
+	vault.lock.Lock()
+	vault.unavailable = false
+	vault.lock.Unlock()
+
+	assert.NoError(restartedKS.DeleteUser("bob", strongPassword))
+	keys, err := database.Count(restartedKS.keyDB)
+	assert.NoError(err)
+	assert.Zero(keys)
+}
+
+func TestKeystoreEvictsDataKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	_, server := newTestVault(t)
+	storage, err := NewVaultKeyStorage(VaultConfig{
+		Address: server.URL,
+		Token:   testVaultToken,
+		KeyName: "keystore",
+	})
+	assert.NoError(err)
+
+	ks := newTestKeystore(t, memdb.New(), storage)
+	ks.dataKeyTTL = time.Millisecond
+	assert.NoError(ks.CreateUser("bob", strongPassword))
+
+	cachedKeys := func() int {
+		ks.lock.Lock()
+		defer ks.lock.Unlock()
+
+		return len(ks.usernameToKey)
+	}
+	assert.Eventually(func() bool { return cachedKeys() == 0 }, time.Second, time.Millisecond)
+
+	// The evicted key is unwrapped again
+	bID := ids.GenerateTestID()
+	db, err := ks.GetDatabase(bID, "bob", strongPassword)
+	assert.NoError(err)
+	assert.NoError(db.Put([]byte("hello"), []byte("world")))
+	assert.Eventually(func() bool { return cachedKeys() == 0 }, time.Second, time.Millisecond)
+
+	ks.dataKeyTTL = time.Hour
+	db, err = ks.GetDatabase(bID, "bob", strongPassword)
+	assert.NoError(err)
+	value, err := db.Get([]byte("hello"))
+	assert.NoError(err)
+	assert.Equal([]byte("world"), value)
+	assert.Equal(1, cachedKeys())
+
+	assert.NoError(ks.DeleteUser("bob", strongPassword))
+	assert.Zero(cachedKeys())
+}
+
+func TestKeystoreExportImportBetweenKeyStorages(t *testing.T) {
+	assert := assert.New(t)
+
+	_, server := newTestVault(t)
+	storage, err := NewVaultKeyStorage(VaultConfig{
+		Address: server.URL,
+		Token:   testVaultToken,
+		KeyName: "keystore",
+	})
+	assert.NoError(err)
+
+	bID := ids.GenerateTestID()
+	passwordKS := newTestKeystore(t, memdb.New(), nil)
+	assert.NoError(passwordKS.CreateUser("bob", strongPassword))
+	db, err := passwordKS.GetDatabase(bID, "bob", strongPassword)
+	assert.NoError(err)
+	assert.NoError(db.Put([]byte("hello"), []byte("world")))
+
+	// Password-derived key --> Vault
+	userBytes, err := passwordKS.ExportUser("bob", strongPassword)
+	assert.NoError(err)
+	vaultKS := newTestKeystore(t, memdb.New(), storage)
+	assert.NoError(vaultKS.ImportUser("bob", strongPassword, userBytes))
+
+	db, err = vaultKS.GetDatabase(bID, "bob", strongPassword)
+	assert.NoError(err)
+	value, err := db.Get([]byte("hello"))
+	assert.NoError(err)
+	assert.Equal([]byte("world"), value)
+	assert.NoError(db.Put([]byte("hi"), []byte("there")))
+
+	// Vault --> password-derived key
+	userBytes, err = vaultKS.ExportUser("bob", strongPassword)
+	assert.NoError(err)
+	importedKS := newTestKeystore(t, memdb.New(), nil)
+	assert.NoError(importedKS.ImportUser("bob", strongPassword, userBytes))
+
+	db, err = importedKS.GetDatabase(bID, "bob", strongPassword)
+	assert.NoError(err)
+	for key, expected := range map[string]string{"hello": "world", "hi": "there"} {
+		value, err := db.Get([]byte(key))
+		assert.NoError(err)
+		assert.Equal([]byte(expected), value)
+	}
+
+	// Exported data keeps the format of the password-derived key
+	rawDB, err := importedKS.GetRawDatabase(bID, "bob", strongPassword)
+	assert.NoError(err)
+	passwordDB, err := encdb.New([]byte(strongPassword), rawDB)
+	assert.NoError(err)
+	value, err = passwordDB.Get([]byte("hi"))
+	assert.NoError(err)
+	assert.Equal([]byte("there"), value)
+
+	assert.Error(vaultKS.ImportUser("alice", "wrong password", userBytes))
+}
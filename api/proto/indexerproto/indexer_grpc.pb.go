@@ -0,0 +1,355 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: indexerproto/indexer.proto
+
+package indexerproto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// IndexClient is the client API for Index service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IndexClient interface {
+	GetLastAccepted(ctx context.Context, in *GetLastAcceptedRequest, opts ...grpc.CallOption) (*GetLastAcceptedResponse, error)
+	GetContainerByIndex(ctx context.Context, in *GetContainerByIndexRequest, opts ...grpc.CallOption) (*GetContainerByIndexResponse, error)
+	GetContainerRange(ctx context.Context, in *GetContainerRangeRequest, opts ...grpc.CallOption) (*GetContainerRangeResponse, error)
+	GetIndex(ctx context.Context, in *GetIndexRequest, opts ...grpc.CallOption) (*GetIndexResponse, error)
+	IsAccepted(ctx context.Context, in *IsAcceptedRequest, opts ...grpc.CallOption) (*IsAcceptedResponse, error)
+	GetContainerByID(ctx context.Context, in *GetContainerByIDRequest, opts ...grpc.CallOption) (*GetContainerByIDResponse, error)
+	// StreamContainers sends every container from [start_index] onwards, in
+	// order of acceptance, and keeps the stream open to send newly accepted
+	// containers.
+	StreamContainers(ctx context.Context, in *StreamContainersRequest, opts ...grpc.CallOption) (Index_StreamContainersClient, error)
+}
+
+type indexClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIndexClient(cc grpc.ClientConnInterface) IndexClient {
+	return &indexClient{cc}
+}
+
+func (c *indexClient) GetLastAccepted(ctx context.Context, in *GetLastAcceptedRequest, opts ...grpc.CallOption) (*GetLastAcceptedResponse, error) {
+	out := new(GetLastAcceptedResponse)
+	err := c.cc.Invoke(ctx, "/indexerproto.Index/GetLastAccepted", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) GetContainerByIndex(ctx context.Context, in *GetContainerByIndexRequest, opts ...grpc.CallOption) (*GetContainerByIndexResponse, error) {
+	out := new(GetContainerByIndexResponse)
+	err := c.cc.Invoke(ctx, "/indexerproto.Index/GetContainerByIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) GetContainerRange(ctx context.Context, in *GetContainerRangeRequest, opts ...grpc.CallOption) (*GetContainerRangeResponse, error) {
+	out := new(GetContainerRangeResponse)
+	err := c.cc.Invoke(ctx, "/indexerproto.Index/GetContainerRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) GetIndex(ctx context.Context, in *GetIndexRequest, opts ...grpc.CallOption) (*GetIndexResponse, error) {
+	out := new(GetIndexResponse)
+	err := c.cc.Invoke(ctx, "/indexerproto.Index/GetIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) IsAccepted(ctx context.Context, in *IsAcceptedRequest, opts ...grpc.CallOption) (*IsAcceptedResponse, error) {
+	out := new(IsAcceptedResponse)
+	err := c.cc.Invoke(ctx, "/indexerproto.Index/IsAccepted", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) GetContainerByID(ctx context.Context, in *GetContainerByIDRequest, opts ...grpc.CallOption) (*GetContainerByIDResponse, error) {
+	out := new(GetContainerByIDResponse)
+	err := c.cc.Invoke(ctx, "/indexerproto.Index/GetContainerByID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) StreamContainers(ctx context.Context, in *StreamContainersRequest, opts ...grpc.CallOption) (Index_StreamContainersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Index_ServiceDesc.Streams[0], "/indexerproto.Index/StreamContainers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &indexStreamContainersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Index_StreamContainersClient interface {
+	Recv() (*StreamContainersResponse, error)
+	grpc.ClientStream
+}
+
+type indexStreamContainersClient struct {
+	grpc.ClientStream
+}
+
+func (x *indexStreamContainersClient) Recv() (*StreamContainersResponse, error) {
+	m := new(StreamContainersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// IndexServer is the server API for Index service.
+// All implementations must embed UnimplementedIndexServer
+// for forward compatibility
+type IndexServer interface {
+	GetLastAccepted(context.Context, *GetLastAcceptedRequest) (*GetLastAcceptedResponse, error)
+	GetContainerByIndex(context.Context, *GetContainerByIndexRequest) (*GetContainerByIndexResponse, error)
+	GetContainerRange(context.Context, *GetContainerRangeRequest) (*GetContainerRangeResponse, error)
+	GetIndex(context.Context, *GetIndexRequest) (*GetIndexResponse, error)
+	IsAccepted(context.Context, *IsAcceptedRequest) (*IsAcceptedResponse, error)
+	GetContainerByID(context.Context, *GetContainerByIDRequest) (*GetContainerByIDResponse, error)
+	// StreamContainers sends every container from [start_index] onwards, in
+	// order of acceptance, and keeps the stream open to send newly accepted
+	// containers.
+	StreamContainers(*StreamContainersRequest, Index_StreamContainersServer) error
+	mustEmbedUnimplementedIndexServer()
+}
+
+// UnimplementedIndexServer must be embedded to have forward compatible implementations.
+type UnimplementedIndexServer struct {
+}
+
+func (UnimplementedIndexServer) GetLastAccepted(context.Context, *GetLastAcceptedRequest) (*GetLastAcceptedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLastAccepted not implemented")
+}
+func (UnimplementedIndexServer) GetContainerByIndex(context.Context, *GetContainerByIndexRequest) (*GetContainerByIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainerByIndex not implemented")
+}
+func (UnimplementedIndexServer) GetContainerRange(context.Context, *GetContainerRangeRequest) (*GetContainerRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainerRange not implemented")
+}
+func (UnimplementedIndexServer) GetIndex(context.Context, *GetIndexRequest) (*GetIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIndex not implemented")
+}
+func (UnimplementedIndexServer) IsAccepted(context.Context, *IsAcceptedRequest) (*IsAcceptedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsAccepted not implemented")
+}
+func (UnimplementedIndexServer) GetContainerByID(context.Context, *GetContainerByIDRequest) (*GetContainerByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainerByID not implemented")
+}
+func (UnimplementedIndexServer) StreamContainers(*StreamContainersRequest, Index_StreamContainersServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamContainers not implemented")
+}
+func (UnimplementedIndexServer) mustEmbedUnimplementedIndexServer() {}
+
+// UnsafeIndexServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IndexServer will
+// result in compilation errors.
+type UnsafeIndexServer interface {
+	mustEmbedUnimplementedIndexServer()
+}
+
+func RegisterIndexServer(s grpc.ServiceRegistrar, srv IndexServer) {
+	s.RegisterService(&Index_ServiceDesc, srv)
+}
+
+func _Index_GetLastAccepted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLastAcceptedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetLastAccepted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/indexerproto.Index/GetLastAccepted",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetLastAccepted(ctx, req.(*GetLastAcceptedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_GetContainerByIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerByIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetContainerByIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/indexerproto.Index/GetContainerByIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetContainerByIndex(ctx, req.(*GetContainerByIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_GetContainerRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetContainerRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/indexerproto.Index/GetContainerRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetContainerRange(ctx, req.(*GetContainerRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_GetIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/indexerproto.Index/GetIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetIndex(ctx, req.(*GetIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_IsAccepted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsAcceptedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).IsAccepted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/indexerproto.Index/IsAccepted",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).IsAccepted(ctx, req.(*IsAcceptedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_GetContainerByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetContainerByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/indexerproto.Index/GetContainerByID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetContainerByID(ctx, req.(*GetContainerByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_StreamContainers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamContainersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IndexServer).StreamContainers(m, &indexStreamContainersServer{stream})
+}
+
+type Index_StreamContainersServer interface {
+	Send(*StreamContainersResponse) error
+	grpc.ServerStream
+}
+
+type indexStreamContainersServer struct {
+	grpc.ServerStream
+}
+
+func (x *indexStreamContainersServer) Send(m *StreamContainersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Index_ServiceDesc is the grpc.ServiceDesc for Index service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Index_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "indexerproto.Index",
+	HandlerType: (*IndexServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLastAccepted",
+			Handler:    _Index_GetLastAccepted_Handler,
+		},
+		{
+			MethodName: "GetContainerByIndex",
+			Handler:    _Index_GetContainerByIndex_Handler,
+		},
+		{
+			MethodName: "GetContainerRange",
+			Handler:    _Index_GetContainerRange_Handler,
+		},
+		{
+			MethodName: "GetIndex",
+			Handler:    _Index_GetIndex_Handler,
+		},
+		{
+			MethodName: "IsAccepted",
+			Handler:    _Index_IsAccepted_Handler,
+		},
+		{
+			MethodName: "GetContainerByID",
+			Handler:    _Index_GetContainerByID_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamContainers",
+			Handler:       _Index_StreamContainers_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "indexerproto/indexer.proto",
+}
@@ -0,0 +1,574 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: platformproto/platform.proto
+
+package platformproto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PlatformClient is the client API for Platform service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PlatformClient interface {
+	GetHeight(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetHeightResponse, error)
+	GetTimestamp(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetTimestampResponse, error)
+	GetCurrentSupply(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetCurrentSupplyResponse, error)
+	GetMinStake(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetMinStakeResponse, error)
+	GetTotalStake(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetTotalStakeResponse, error)
+	GetStakingAssetID(ctx context.Context, in *GetStakingAssetIDRequest, opts ...grpc.CallOption) (*GetStakingAssetIDResponse, error)
+	GetSubnets(ctx context.Context, in *GetSubnetsRequest, opts ...grpc.CallOption) (*GetSubnetsResponse, error)
+	GetBlockchains(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetBlockchainsResponse, error)
+	GetBlockchainStatus(ctx context.Context, in *GetBlockchainStatusRequest, opts ...grpc.CallOption) (*GetBlockchainStatusResponse, error)
+	GetCurrentValidators(ctx context.Context, in *GetCurrentValidatorsRequest, opts ...grpc.CallOption) (*GetCurrentValidatorsResponse, error)
+	GetPendingValidators(ctx context.Context, in *GetPendingValidatorsRequest, opts ...grpc.CallOption) (*GetPendingValidatorsResponse, error)
+	GetValidatorsAt(ctx context.Context, in *GetValidatorsAtRequest, opts ...grpc.CallOption) (*GetValidatorsAtResponse, error)
+	GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error)
+	GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error)
+}
+
+type platformClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPlatformClient(cc grpc.ClientConnInterface) PlatformClient {
+	return &platformClient{cc}
+}
+
+func (c *platformClient) GetHeight(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetHeightResponse, error) {
+	out := new(GetHeightResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetTimestamp(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetTimestampResponse, error) {
+	out := new(GetTimestampResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetTimestamp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetCurrentSupply(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetCurrentSupplyResponse, error) {
+	out := new(GetCurrentSupplyResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetCurrentSupply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetMinStake(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetMinStakeResponse, error) {
+	out := new(GetMinStakeResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetMinStake", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetTotalStake(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetTotalStakeResponse, error) {
+	out := new(GetTotalStakeResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetTotalStake", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetStakingAssetID(ctx context.Context, in *GetStakingAssetIDRequest, opts ...grpc.CallOption) (*GetStakingAssetIDResponse, error) {
+	out := new(GetStakingAssetIDResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetStakingAssetID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetSubnets(ctx context.Context, in *GetSubnetsRequest, opts ...grpc.CallOption) (*GetSubnetsResponse, error) {
+	out := new(GetSubnetsResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetSubnets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetBlockchains(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetBlockchainsResponse, error) {
+	out := new(GetBlockchainsResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetBlockchains", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetBlockchainStatus(ctx context.Context, in *GetBlockchainStatusRequest, opts ...grpc.CallOption) (*GetBlockchainStatusResponse, error) {
+	out := new(GetBlockchainStatusResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetBlockchainStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetCurrentValidators(ctx context.Context, in *GetCurrentValidatorsRequest, opts ...grpc.CallOption) (*GetCurrentValidatorsResponse, error) {
+	out := new(GetCurrentValidatorsResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetCurrentValidators", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetPendingValidators(ctx context.Context, in *GetPendingValidatorsRequest, opts ...grpc.CallOption) (*GetPendingValidatorsResponse, error) {
+	out := new(GetPendingValidatorsResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetPendingValidators", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetValidatorsAt(ctx context.Context, in *GetValidatorsAtRequest, opts ...grpc.CallOption) (*GetValidatorsAtResponse, error) {
+	out := new(GetValidatorsAtResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetValidatorsAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error) {
+	out := new(GetTxResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error) {
+	out := new(GetTxStatusResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetTxStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlatformServer is the server API for Platform service.
+// All implementations must embed UnimplementedPlatformServer
+// for forward compatibility
+type PlatformServer interface {
+	GetHeight(context.Context, *emptypb.Empty) (*GetHeightResponse, error)
+	GetTimestamp(context.Context, *emptypb.Empty) (*GetTimestampResponse, error)
+	GetCurrentSupply(context.Context, *emptypb.Empty) (*GetCurrentSupplyResponse, error)
+	GetMinStake(context.Context, *emptypb.Empty) (*GetMinStakeResponse, error)
+	GetTotalStake(context.Context, *emptypb.Empty) (*GetTotalStakeResponse, error)
+	GetStakingAssetID(context.Context, *GetStakingAssetIDRequest) (*GetStakingAssetIDResponse, error)
+	GetSubnets(context.Context, *GetSubnetsRequest) (*GetSubnetsResponse, error)
+	GetBlockchains(context.Context, *emptypb.Empty) (*GetBlockchainsResponse, error)
+	GetBlockchainStatus(context.Context, *GetBlockchainStatusRequest) (*GetBlockchainStatusResponse, error)
+	GetCurrentValidators(context.Context, *GetCurrentValidatorsRequest) (*GetCurrentValidatorsResponse, error)
+	GetPendingValidators(context.Context, *GetPendingValidatorsRequest) (*GetPendingValidatorsResponse, error)
+	GetValidatorsAt(context.Context, *GetValidatorsAtRequest) (*GetValidatorsAtResponse, error)
+	GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error)
+	GetTxStatus(context.Context, *GetTxStatusRequest) (*GetTxStatusResponse, error)
+	mustEmbedUnimplementedPlatformServer()
+}
+
+// UnimplementedPlatformServer must be embedded to have forward compatible implementations.
+type UnimplementedPlatformServer struct {
+}
+
+func (UnimplementedPlatformServer) GetHeight(context.Context, *emptypb.Empty) (*GetHeightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeight not implemented")
+}
+func (UnimplementedPlatformServer) GetTimestamp(context.Context, *emptypb.Empty) (*GetTimestampResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimestamp not implemented")
+}
+func (UnimplementedPlatformServer) GetCurrentSupply(context.Context, *emptypb.Empty) (*GetCurrentSupplyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentSupply not implemented")
+}
+func (UnimplementedPlatformServer) GetMinStake(context.Context, *emptypb.Empty) (*GetMinStakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMinStake not implemented")
+}
+func (UnimplementedPlatformServer) GetTotalStake(context.Context, *emptypb.Empty) (*GetTotalStakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTotalStake not implemented")
+}
+func (UnimplementedPlatformServer) GetStakingAssetID(context.Context, *GetStakingAssetIDRequest) (*GetStakingAssetIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStakingAssetID not implemented")
+}
+func (UnimplementedPlatformServer) GetSubnets(context.Context, *GetSubnetsRequest) (*GetSubnetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubnets not implemented")
+}
+func (UnimplementedPlatformServer) GetBlockchains(context.Context, *emptypb.Empty) (*GetBlockchainsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockchains not implemented")
+}
+func (UnimplementedPlatformServer) GetBlockchainStatus(context.Context, *GetBlockchainStatusRequest) (*GetBlockchainStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockchainStatus not implemented")
+}
+func (UnimplementedPlatformServer) GetCurrentValidators(context.Context, *GetCurrentValidatorsRequest) (*GetCurrentValidatorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentValidators not implemented")
+}
+func (UnimplementedPlatformServer) GetPendingValidators(context.Context, *GetPendingValidatorsRequest) (*GetPendingValidatorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPendingValidators not implemented")
+}
+func (UnimplementedPlatformServer) GetValidatorsAt(context.Context, *GetValidatorsAtRequest) (*GetValidatorsAtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidatorsAt not implemented")
+}
+func (UnimplementedPlatformServer) GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTx not implemented")
+}
+func (UnimplementedPlatformServer) GetTxStatus(context.Context, *GetTxStatusRequest) (*GetTxStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxStatus not implemented")
+}
+func (UnimplementedPlatformServer) mustEmbedUnimplementedPlatformServer() {}
+
+// UnsafePlatformServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PlatformServer will
+// result in compilation errors.
+type UnsafePlatformServer interface {
+	mustEmbedUnimplementedPlatformServer()
+}
+
+func RegisterPlatformServer(s grpc.ServiceRegistrar, srv PlatformServer) {
+	s.RegisterService(&Platform_ServiceDesc, srv)
+}
+
+func _Platform_GetHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetHeight(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetTimestamp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetTimestamp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetTimestamp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetTimestamp(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetCurrentSupply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetCurrentSupply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetCurrentSupply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetCurrentSupply(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetMinStake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetMinStake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetMinStake",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetMinStake(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetTotalStake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetTotalStake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetTotalStake",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetTotalStake(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetStakingAssetID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStakingAssetIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetStakingAssetID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetStakingAssetID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetStakingAssetID(ctx, req.(*GetStakingAssetIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetSubnets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubnetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetSubnets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetSubnets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetSubnets(ctx, req.(*GetSubnetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetBlockchains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetBlockchains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetBlockchains",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetBlockchains(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetBlockchainStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockchainStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetBlockchainStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetBlockchainStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetBlockchainStatus(ctx, req.(*GetBlockchainStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetCurrentValidators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrentValidatorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetCurrentValidators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetCurrentValidators",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetCurrentValidators(ctx, req.(*GetCurrentValidatorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetPendingValidators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPendingValidatorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetPendingValidators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetPendingValidators",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetPendingValidators(ctx, req.(*GetPendingValidatorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetValidatorsAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValidatorsAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetValidatorsAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetValidatorsAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetValidatorsAt(ctx, req.(*GetValidatorsAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetTx(ctx, req.(*GetTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetTxStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetTxStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetTxStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetTxStatus(ctx, req.(*GetTxStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Platform_ServiceDesc is the grpc.ServiceDesc for Platform service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Platform_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "platformproto.Platform",
+	HandlerType: (*PlatformServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetHeight",
+			Handler:    _Platform_GetHeight_Handler,
+		},
+		{
+			MethodName: "GetTimestamp",
+			Handler:    _Platform_GetTimestamp_Handler,
+		},
+		{
+			MethodName: "GetCurrentSupply",
+			Handler:    _Platform_GetCurrentSupply_Handler,
+		},
+		{
+			MethodName: "GetMinStake",
+			Handler:    _Platform_GetMinStake_Handler,
+		},
+		{
+			MethodName: "GetTotalStake",
+			Handler:    _Platform_GetTotalStake_Handler,
+		},
+		{
+			MethodName: "GetStakingAssetID",
+			Handler:    _Platform_GetStakingAssetID_Handler,
+		},
+		{
+			MethodName: "GetSubnets",
+			Handler:    _Platform_GetSubnets_Handler,
+		},
+		{
+			MethodName: "GetBlockchains",
+			Handler:    _Platform_GetBlockchains_Handler,
+		},
+		{
+			MethodName: "GetBlockchainStatus",
+			Handler:    _Platform_GetBlockchainStatus_Handler,
+		},
+		{
+			MethodName: "GetCurrentValidators",
+			Handler:    _Platform_GetCurrentValidators_Handler,
+		},
+		{
+			MethodName: "GetPendingValidators",
+			Handler:    _Platform_GetPendingValidators_Handler,
+		},
+		{
+			MethodName: "GetValidatorsAt",
+			Handler:    _Platform_GetValidatorsAt_Handler,
+		},
+		{
+			MethodName: "GetTx",
+			Handler:    _Platform_GetTx_Handler,
+		},
+		{
+			MethodName: "GetTxStatus",
+			Handler:    _Platform_GetTxStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "platformproto/platform.proto",
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// buckets holds a token bucket per client for one limit. Not safe for
+// concurrent use.
+type buckets struct {
+	limit Limit
+	// Time an idle bucket takes to refill completely. A bucket that has been
+	// idle this long is indistinguishable from a new one, so it can be
+	// removed.
+	refillDuration time.Duration
+	// Client --> bucket
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+func newBuckets(limit Limit) *buckets {
+	return &buckets{
+		limit:          limit,
+		refillDuration: time.Duration(float64(limit.Burst) / limit.RequestsPerSecond * float64(time.Second)),
+		buckets:        make(map[string]*bucket),
+	}
+}
+
+// take removes a request from the bucket of [client]. Returns false if the
+// bucket is empty. Otherwise, returns the reservation of the request so that
+// it can be returned. If [b] is nil, requests aren't limited and the returned
+// reservation is nil.
+func (b *buckets) take(client string, now time.Time) (*rate.Reservation, bool) {
+	if b == nil {
+		return nil, true
+	}
+
+	clientBucket, ok := b.buckets[client]
+	if !ok {
+		clientBucket = &bucket{
+			limiter: rate.NewLimiter(rate.Limit(b.limit.RequestsPerSecond), b.limit.Burst),
+		}
+		b.buckets[client] = clientBucket
+	}
+	clientBucket.lastUsed = now
+
+	reservation := clientBucket.limiter.ReserveN(now, 1)
+	if reservation.DelayFrom(now) > 0 {
+		reservation.CancelAt(now)
+		return nil, false
+	}
+	return reservation, true
+}
+
+// prune removes the buckets that have refilled completely
+func (b *buckets) prune(now time.Time) {
+	if b == nil {
+		return
+	}
+	for client, clientBucket := range b.buckets {
+		if now.Sub(clientBucket.lastUsed) >= b.refillDuration {
+			delete(b.buckets, client)
+		}
+	}
+}
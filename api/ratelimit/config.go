@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	errNoLimit       = errors.New("rule must limit requests per IP or per auth token")
+	errInvalidRate   = errors.New("requestsPerSecond must be positive")
+	errInvalidBurst  = errors.New("burst must be positive")
+	errUnmatchedRule = errors.New("rule must specify an endpoint or a method")
+)
+
+// Config specifies the rate limits applied to API calls
+type Config struct {
+	// Rules are checked in order. A request is limited by the first rule that
+	// matches it. Requests that don't match any rule aren't limited.
+	Rules []Rule `json:"rules"`
+}
+
+// Rule limits the calls made to an endpoint and/or method
+type Rule struct {
+	// Name labels the metrics of the calls matched by this rule. If empty,
+	// the rule is named after its endpoint and method.
+	Name string `json:"name"`
+	// URL path of the endpoint this rule applies to, e.g. "/ext/bc/X". Chain
+	// aliases are matched literally, so "/ext/bc/X" doesn't match calls made
+	// to the chain's ID. If empty, matches every endpoint.
+	Endpoint string `json:"endpoint"`
+	// JSON-RPC method this rule applies to, e.g. "avm.getUTXOs". If empty,
+	// matches every method.
+	Method string `json:"method"`
+
+	// Limits the calls made from each client IP. If nil, calls aren't limited
+	// per IP.
+	PerIP *Limit `json:"perIP"`
+	// Limits the calls made with each auth token. If nil, calls aren't limited
+	// per auth token. Calls that don't provide an auth token are only limited
+	// per IP.
+	PerToken *Limit `json:"perToken"`
+}
+
+// Limit is a token bucket that refills at [RequestsPerSecond] and holds at
+// most [Burst] requests
+type Limit struct {
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	Burst             int     `json:"burst"`
+}
+
+// Verify returns an error if [c] is malformed
+func (c *Config) Verify() error {
+	for i, rule := range c.Rules {
+		if err := rule.verify(); err != nil {
+			return fmt.Errorf("rule %d is invalid: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (r *Rule) verify() error {
+	switch {
+	case r.Endpoint == "" && r.Method == "":
+		return errUnmatchedRule
+	case r.PerIP == nil && r.PerToken == nil:
+		return errNoLimit
+	}
+	if r.PerIP != nil {
+		if err := r.PerIP.verify(); err != nil {
+			return fmt.Errorf("invalid perIP limit: %w", err)
+		}
+	}
+	if r.PerToken != nil {
+		if err := r.PerToken.verify(); err != nil {
+			return fmt.Errorf("invalid perToken limit: %w", err)
+		}
+	}
+	return nil
+}
+
+func (l *Limit) verify() error {
+	switch {
+	case l.RequestsPerSecond <= 0:
+		return errInvalidRate
+	case l.Burst <= 0:
+		return errInvalidBurst
+	default:
+		return nil
+	}
+}
+
+// label returns the metrics label of the calls matched by [r]
+func (r *Rule) label() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return strings.TrimSpace(r.Endpoint + " " + r.Method)
+}
+
+func (r *Rule) matches(endpoint, method string) bool {
+	return (r.Endpoint == "" || r.Endpoint == endpoint) &&
+		(r.Method == "" || r.Method == method)
+}
@@ -0,0 +1,201 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/rpc/v2"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/dijetsgo/api"
+	"github.com/lasthyphen/dijetsgo/api/auth"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+	"github.com/lasthyphen/dijetsgo/utils/timer/mockable"
+)
+
+const (
+	// How often idle buckets are removed
+	pruneFrequency = time.Minute
+
+	authHeaderKey = "Authorization"
+
+	// Label of the metrics of calls that don't match any rule
+	otherLabel = "other"
+)
+
+var _ Limiter = &limiter{}
+
+// Limiter rate limits API calls
+type Limiter interface {
+	// WrapHandler returns a handler that rejects calls to [h] that exceed the
+	// configured limits and records metrics about the calls that are served.
+	WrapHandler(h http.Handler) http.Handler
+}
+
+type limiter struct {
+	log     logging.Logger
+	metrics *metrics
+	clock   mockable.Clock
+
+	// True if any rule matches on the JSON-RPC method, which requires reading
+	// the body of requests
+	matchesMethods bool
+
+	lock      sync.Mutex
+	rules     []Rule
+	perIP     []*buckets
+	perToken  []*buckets
+	lastPrune time.Time
+}
+
+// New returns a Limiter that enforces [config]. Metrics are registered under
+// [namespace].
+func New(log logging.Logger, config Config, namespace string, registerer prometheus.Registerer) (Limiter, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	l := &limiter{
+		log:      log,
+		rules:    config.Rules,
+		perIP:    make([]*buckets, len(config.Rules)),
+		perToken: make([]*buckets, len(config.Rules)),
+	}
+	for i, rule := range config.Rules {
+		if rule.Method != "" {
+			l.matchesMethods = true
+		}
+		if rule.PerIP != nil {
+			l.perIP[i] = newBuckets(*rule.PerIP)
+		}
+		if rule.PerToken != nil {
+			l.perToken[i] = newBuckets(*rule.PerToken)
+		}
+	}
+	l.lastPrune = l.clock.Time()
+
+	var err error
+	l.metrics, err = newMetrics(namespace, registerer)
+	return l, err
+}
+
+// WrapHandler implements the Limiter interface. Metrics are labeled by the
+// rule that matched the call, so that clients can't create arbitrary metrics.
+func (l *limiter) WrapHandler(h http.Handler) http.Handler {
+	if len(l.rules) == 0 {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := api.Call{}
+		if l.matchesMethods {
+			var err error
+			call, err = api.ReadCall(w, r)
+			if err != nil {
+				l.log.Debug("couldn't read API call to %s: %s", r.URL.Path, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		token, _ := auth.ParseBearerToken(r.Header.Get(authHeaderKey))
+		label, err := l.allow(r.URL.Path, call.Method, clientIP(r), token)
+		if err != nil {
+			l.log.Debug("rate limited call to %s %s: %s", r.URL.Path, call.Method, err)
+			l.metrics.rateLimited.With(prometheus.Labels{
+				"rule": label,
+			}).Inc()
+			writeRateLimitedResponse(w, call.ID, err)
+			return
+		}
+
+		info := &rpc.RequestInfo{
+			Method:  label,
+			Request: r,
+		}
+		info.Request = l.metrics.requests.InterceptRequest(info)
+
+		recorder := &statusRecorder{
+			ResponseWriter: w,
+			status:         http.StatusOK,
+		}
+		h.ServeHTTP(recorder, info.Request)
+
+		info.StatusCode = recorder.status
+		if recorder.status >= http.StatusBadRequest {
+			info.Error = errors.New(http.StatusText(recorder.status))
+		}
+		l.metrics.requests.AfterRequest(info)
+	})
+}
+
+// allow returns nil if a call to [method] of [endpoint] made from [ip] with
+// [token] is within the limits of the first rule that matches it. Takes a
+// request from the matching buckets if it is. Returns the label of the
+// matching rule.
+func (l *limiter) allow(endpoint, method, ip, token string) (string, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock.Time()
+	if now.Sub(l.lastPrune) >= pruneFrequency {
+		for i := range l.rules {
+			l.perIP[i].prune(now)
+			l.perToken[i].prune(now)
+		}
+		l.lastPrune = now
+	}
+
+	for i, rule := range l.rules {
+		if !rule.matches(endpoint, method) {
+			continue
+		}
+
+		// Both limits must allow the call, so a request taken from the IP's
+		// bucket is returned if the token's bucket is empty.
+		label := rule.label()
+		ipReservation, ok := l.perIP[i].take(ip, now)
+		if !ok {
+			return label, fmt.Errorf("exceeded %g requests per second from %s", rule.PerIP.RequestsPerSecond, ip)
+		}
+		if token == "" {
+			return label, nil
+		}
+		if _, ok := l.perToken[i].take(token, now); !ok {
+			if ipReservation != nil {
+				ipReservation.CancelAt(now)
+			}
+			return label, fmt.Errorf("exceeded %g requests per second with the provided auth token", rule.PerToken.RequestsPerSecond)
+		}
+		return label, nil
+	}
+	return otherLabel, nil
+}
+
+// clientIP returns the IP of the client that made [r]. Forwarding headers are
+// ignored, as they are set by the client.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// statusRecorder records the status code written to the response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,278 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/api"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+)
+
+var echoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	// Echo the body to check that it can be read after the limiter read it
+	body, _ := ioutil.ReadAll(r.Body)
+	_, _ = w.Write(body)
+})
+
+func newTestLimiter(t *testing.T, config Config) (*limiter, http.Handler) {
+	l, err := New(logging.NoLog{}, config, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	lim := l.(*limiter)
+	lim.clock.Set(time.Unix(1000, 0))
+	lim.lastPrune = lim.clock.Time()
+	return lim, l.WrapHandler(echoHandler)
+}
+
+func makeCall(h http.Handler, endpoint, method, ip, token string) *httptest.ResponseRecorder {
+	body := `{"jsonrpc":"2.0","id":7,"method":"` + method + `","params":{}}`
+	req := httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(body))
+	req.RemoteAddr = ip + ":1234"
+	if token != "" {
+		req.Header.Set(authHeaderKey, "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestConfigVerify(t *testing.T) {
+	limit := &Limit{RequestsPerSecond: 1, Burst: 1}
+	tests := []struct {
+		name   string
+		config Config
+		err    error
+	}{
+		{
+			name:   "no rules",
+			config: Config{},
+		},
+		{
+			name: "valid",
+			config: Config{Rules: []Rule{{
+				Method: "avm.getUTXOs",
+				PerIP:  limit,
+			}}},
+		},
+		{
+			name: "matches every call",
+			config: Config{Rules: []Rule{{
+				PerIP: limit,
+			}}},
+			err: errUnmatchedRule,
+		},
+		{
+			name: "no limit",
+			config: Config{Rules: []Rule{{
+				Endpoint: "/ext/bc/X",
+			}}},
+			err: errNoLimit,
+		},
+		{
+			name: "invalid rate",
+			config: Config{Rules: []Rule{{
+				Endpoint: "/ext/bc/X",
+				PerToken: &Limit{Burst: 1},
+			}}},
+			err: errInvalidRate,
+		},
+		{
+			name: "invalid burst",
+			config: Config{Rules: []Rule{{
+				Endpoint: "/ext/bc/X",
+				PerIP:    &Limit{RequestsPerSecond: 1},
+			}}},
+			err: errInvalidBurst,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestLimiterPerIP(t *testing.T) {
+	assert := assert.New(t)
+
+	l, h := newTestLimiter(t, Config{Rules: []Rule{{
+		Endpoint: "/ext/bc/X",
+		Method:   "avm.getUTXOs",
+		PerIP:    &Limit{RequestsPerSecond: 1, Burst: 2},
+	}}})
+
+	for i := 0; i < 2; i++ {
+		rr := makeCall(h, "/ext/bc/X", "avm.getUTXOs", "1.2.3.4", "")
+		assert.Equal(http.StatusOK, rr.Code)
+		assert.Contains(rr.Body.String(), "avm.getUTXOs")
+	}
+
+	rr := makeCall(h, "/ext/bc/X", "avm.getUTXOs", "1.2.3.4", "")
+	assert.Equal(http.StatusTooManyRequests, rr.Code)
+	assert.Regexp(`^{"jsonrpc":"2.0","error":{"code":-32000,"message":"exceeded 1 requests per second from 1.2.3.4"},"id":7}`, rr.Body.String())
+
+	// Other clients, methods and endpoints aren't limited
+	assert.Equal(http.StatusOK, makeCall(h, "/ext/bc/X", "avm.getUTXOs", "5.6.7.8", "").Code)
+	assert.Equal(http.StatusOK, makeCall(h, "/ext/bc/X", "avm.getBalance", "1.2.3.4", "").Code)
+	assert.Equal(http.StatusOK, makeCall(h, "/ext/bc/P", "avm.getUTXOs", "1.2.3.4", "").Code)
+
+	// The bucket refills over time
+	l.clock.Set(l.clock.Time().Add(time.Second))
+	assert.Equal(http.StatusOK, makeCall(h, "/ext/bc/X", "avm.getUTXOs", "1.2.3.4", "").Code)
+	assert.Equal(http.StatusTooManyRequests, makeCall(h, "/ext/bc/X", "avm.getUTXOs", "1.2.3.4", "").Code)
+}
+
+func TestLimiterPerToken(t *testing.T) {
+	assert := assert.New(t)
+
+	_, h := newTestLimiter(t, Config{Rules: []Rule{{
+		Method:   "avm.getUTXOs",
+		PerIP:    &Limit{RequestsPerSecond: 1, Burst: 2},
+		PerToken: &Limit{RequestsPerSecond: 1, Burst: 1},
+	}}})
+
+	assert.Equal(http.StatusOK, makeCall(h, "/ext/bc/X", "avm.getUTXOs", "1.2.3.4", "token").Code)
+	assert.Equal(http.StatusTooManyRequests, makeCall(h, "/ext/bc/X", "avm.getUTXOs", "5.6.7.8", "token").Code)
+
+	// The rejected call didn't count against the IP's limit
+	assert.Equal(http.StatusOK, makeCall(h, "/ext/bc/X", "avm.getUTXOs", "5.6.7.8", "other").Code)
+	assert.Equal(http.StatusOK, makeCall(h, "/ext/bc/X", "avm.getUTXOs", "5.6.7.8", "").Code)
+	assert.Equal(http.StatusTooManyRequests, makeCall(h, "/ext/bc/X", "avm.getUTXOs", "5.6.7.8", "").Code)
+}
+
+func TestLimiterFirstMatchingRule(t *testing.T) {
+	assert := assert.New(t)
+
+	_, h := newTestLimiter(t, Config{Rules: []Rule{
+		{
+			Method: "avm.getBalance",
+			PerIP:  &Limit{RequestsPerSecond: 1, Burst: 5},
+		},
+		{
+			Endpoint: "/ext/bc/X",
+			PerIP:    &Limit{RequestsPerSecond: 1, Burst: 1},
+		},
+	}})
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(http.StatusOK, makeCall(h, "/ext/bc/X", "avm.getBalance", "1.2.3.4", "").Code)
+	}
+	assert.Equal(http.StatusOK, makeCall(h, "/ext/bc/X", "avm.getUTXOs", "1.2.3.4", "").Code)
+	assert.Equal(http.StatusTooManyRequests, makeCall(h, "/ext/bc/X", "avm.issueTx", "1.2.3.4", "").Code)
+}
+
+func TestLimiterPrune(t *testing.T) {
+	assert := assert.New(t)
+
+	l, h := newTestLimiter(t, Config{Rules: []Rule{{
+		Method: "avm.getUTXOs",
+		PerIP:  &Limit{RequestsPerSecond: 1, Burst: 1},
+	}}})
+
+	assert.Equal(http.StatusOK, makeCall(h, "/ext/bc/X", "avm.getUTXOs", "1.2.3.4", "").Code)
+	assert.Len(l.perIP[0].buckets, 1)
+
+	l.clock.Set(l.clock.Time().Add(pruneFrequency))
+	assert.Equal(http.StatusOK, makeCall(h, "/ext/bc/X", "avm.getUTXOs", "5.6.7.8", "").Code)
+	assert.Len(l.perIP[0].buckets, 1)
+	assert.Contains(l.perIP[0].buckets, "5.6.7.8")
+}
+
+func TestLimiterMetricLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := prometheus.NewRegistry()
+	l, err := New(logging.NoLog{}, Config{Rules: []Rule{
+		{
+			Name:   "utxos",
+			Method: "avm.getUTXOs",
+			PerIP:  &Limit{RequestsPerSecond: 1, Burst: 1},
+		},
+		{
+			Endpoint: "/ext/bc/P",
+			PerIP:    &Limit{RequestsPerSecond: 1, Burst: 1},
+		},
+	}}, "", registry)
+	assert.NoError(err)
+	h := l.WrapHandler(echoHandler)
+
+	makeCall(h, "/ext/bc/X", "avm.getUTXOs", "1.2.3.4", "")
+	makeCall(h, "/ext/bc/X", "avm.getUTXOs", "1.2.3.4", "")
+	makeCall(h, "/ext/bc/P", "platform.getHeight", "1.2.3.4", "")
+	// Unmatched methods chosen by the client share a single label
+	for i := 0; i < 10; i++ {
+		makeCall(h, "/ext/bc/X", "made.up"+string(rune('a'+i)), "1.2.3.4", "")
+	}
+
+	metrics, err := registry.Gather()
+	assert.NoError(err)
+	labels := map[string]map[string]float64{}
+	for _, family := range metrics {
+		values := map[string]float64{}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				values[label.GetValue()] = metric.GetCounter().GetValue()
+			}
+		}
+		labels[family.GetName()] = values
+	}
+	assert.Equal(map[string]float64{
+		"utxos":     1,
+		"/ext/bc/P": 1,
+		otherLabel:  10,
+	}, labels["request_duration_count"])
+	assert.Equal(map[string]float64{
+		"utxos": 1,
+	}, labels["rate_limited_count"])
+}
+
+func TestLimiterNoRules(t *testing.T) {
+	l, err := New(logging.NoLog{}, Config{}, "", prometheus.NewRegistry())
+	assert.NoError(t, err)
+
+	// Without any rules, calls are served without being inspected
+	h := l.WrapHandler(echoHandler)
+	assert.Equal(t, http.StatusOK, makeCall(h, "/ext/bc/X", "avm.getUTXOs", "1.2.3.4", "").Code)
+}
+
+func TestLimiterBodyTooLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	_, h := newTestLimiter(t, Config{Rules: []Rule{{
+		Method: "avm.getUTXOs",
+		PerIP:  &Limit{RequestsPerSecond: 1, Burst: 1},
+	}}})
+
+	body := strings.Repeat(" ", api.MaxCallSize+1)
+	req := httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	assert.Equal(http.StatusBadRequest, rr.Code)
+
+	// Rules that only match endpoints don't read the body
+	_, h = newTestLimiter(t, Config{Rules: []Rule{{
+		Endpoint: "/ext/bc/X",
+		PerIP:    &Limit{RequestsPerSecond: 1, Burst: 1},
+	}}})
+	req = httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(body))
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	assert.Equal(http.StatusOK, rr.Code)
+}
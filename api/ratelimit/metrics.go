@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/dijetsgo/utils/metric"
+)
+
+// metrics of the calls matched by each rate limiting rule. The "method" label
+// of [requests] is the label of the rule that matched the call.
+type metrics struct {
+	requests    metric.APIInterceptor
+	rateLimited *prometheus.CounterVec
+}
+
+func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, error) {
+	requests, err := metric.NewAPIInterceptor(namespace, registerer)
+	if err != nil {
+		return nil, err
+	}
+	m := &metrics{
+		requests: requests,
+		rateLimited: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "rate_limited_count",
+				Help:      "Number of requests rejected for exceeding the limits of a rate limiting rule",
+			},
+			[]string{"rule"},
+		),
+	}
+	return m, registerer.Register(m.rateLimited)
+}
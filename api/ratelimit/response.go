@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"encoding/json"
+	"net/http"
+
+	rpc "github.com/gorilla/rpc/v2/json2"
+)
+
+type responseErr struct {
+	Code    rpc.ErrorCode `json:"code"`
+	Message string        `json:"message"`
+}
+
+type responseBody struct {
+	Version string          `json:"jsonrpc"`
+	Err     responseErr     `json:"error"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// Write a JSON-RPC formatted response saying that the API call was rate
+// limited. The response has header http.StatusTooManyRequests.
+// Errors while writing are ignored.
+func writeRateLimitedResponse(w http.ResponseWriter, id json.RawMessage, err error) {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+
+	// There isn't anything to do with the returned error, so it is dropped.
+	_ = json.NewEncoder(w).Encode(responseBody{
+		Version: rpc.Version,
+		Err: responseErr{
+			Code:    rpc.E_SERVER,
+			Message: err.Error(),
+		},
+		ID: id,
+	})
+}
@@ -0,0 +1,327 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/lasthyphen/dijetsgo/api/auth"
+	"github.com/lasthyphen/dijetsgo/utils"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+)
+
+// gRPC metadata keys are always lower case
+const authorizationKey = "authorization"
+
+var (
+	errDuplicateService    = errors.New("duplicate gRPC service")
+	errGRPCShutdownTimeout = errors.New("gRPC server didn't shut down gracefully before the timeout")
+
+	_ ServiceAdder = &GRPCServer{}
+)
+
+// Authenticator authorizes API calls made with an auth token
+type Authenticator interface {
+	// AuthenticateCall returns nil if [token] allows calls to [method] of the
+	// API at [url].
+	AuthenticateCall(token, url, method string) error
+}
+
+// EndpointScoper is implemented by gRPC services that serve several HTTP APIs
+// under the same base. Calls to such a service are authorized as if they were
+// made to the HTTP API at /ext/[base]/[endpoint], where [endpoint] is returned
+// by CallEndpoint for the request of the call.
+type EndpointScoper interface {
+	CallEndpoint(req interface{}) (string, error)
+}
+
+type ServiceAdder interface {
+	// AddService registers [impl] as the implementation of the gRPC service
+	// described by [desc]. Calls to the service are authorized as if they were
+	// made to the HTTP API at /ext/[base]. If [impl] is an EndpointScoper,
+	// calls are authorized per endpoint under /ext/[base].
+	AddService(desc *grpc.ServiceDesc, impl interface{}, base string) error
+}
+
+// GRPCServer serves the gRPC APIs of the node. Services must be added before
+// the server is dispatched.
+type GRPCServer struct {
+	// log this server writes to
+	log logging.Logger
+	// Listens for gRPC traffic on this address
+	listenHost string
+	listenPort uint16
+
+	shutdownTimeout time.Duration
+
+	// Authorizes calls if auth tokens are required. Nil otherwise.
+	authenticator Authenticator
+
+	lock sync.RWMutex
+	// Maps gRPC service names to the URL of the equivalent HTTP API
+	urls map[string]string
+	// Maps gRPC service names to the services that are scoped per endpoint
+	scopers map[string]EndpointScoper
+
+	srv *grpc.Server
+}
+
+// Initialize creates the gRPC server at the provided host and port. If
+// [authenticator] is non-nil, every call must provide an auth token that allows
+// access to the HTTP API equivalent to the called service.
+func (s *GRPCServer) Initialize(
+	log logging.Logger,
+	host string,
+	port uint16,
+	shutdownTimeout time.Duration,
+	authenticator Authenticator,
+) {
+	s.log = log
+	s.listenHost = host
+	s.listenPort = port
+	s.shutdownTimeout = shutdownTimeout
+	s.authenticator = authenticator
+	s.urls = make(map[string]string)
+	s.scopers = make(map[string]EndpointScoper)
+	s.srv = grpc.NewServer(
+		grpc.UnaryInterceptor(s.unaryInterceptor),
+		grpc.StreamInterceptor(s.streamInterceptor),
+	)
+}
+
+// AddService implements the ServiceAdder interface
+func (s *GRPCServer) AddService(desc *grpc.ServiceDesc, impl interface{}, base string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, exists := s.urls[desc.ServiceName]; exists {
+		return fmt.Errorf("%w: %s", errDuplicateService, desc.ServiceName)
+	}
+
+	url := fmt.Sprintf("%s/%s", baseURL, base)
+	s.log.Info("adding gRPC service %s for %s", desc.ServiceName, url)
+	s.urls[desc.ServiceName] = url
+	if scoper, ok := impl.(EndpointScoper); ok {
+		s.scopers[desc.ServiceName] = scoper
+	}
+	s.srv.RegisterService(desc, impl)
+	return nil
+}
+
+// Dispatch starts the gRPC server
+func (s *GRPCServer) Dispatch() error {
+	listenAddress := fmt.Sprintf("%s:%d", s.listenHost, s.listenPort)
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return err
+	}
+
+	ipDesc, err := utils.ToIPDesc(listener.Addr().String())
+	if err != nil {
+		s.log.Info("gRPC API server listening on %q", listenAddress)
+	} else {
+		s.log.Info("gRPC API server listening on \"%s:%d\"", s.listenHost, ipDesc.Port)
+	}
+
+	return s.srv.Serve(listener)
+}
+
+// DispatchTLS starts the gRPC server with the provided TLS certificate
+func (s *GRPCServer) DispatchTLS(certBytes, keyBytes []byte) error {
+	listenAddress := fmt.Sprintf("%s:%d", s.listenHost, s.listenPort)
+	cert, err := tls.X509KeyPair(certBytes, keyBytes)
+	if err != nil {
+		return err
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2"},
+	}
+
+	listener, err := tls.Listen("tcp", listenAddress, config)
+	if err != nil {
+		return err
+	}
+
+	ipDesc, err := utils.ToIPDesc(listener.Addr().String())
+	if err != nil {
+		s.log.Info("gRPC API server listening with TLS on %q", listenAddress)
+	} else {
+		s.log.Info("gRPC API server listening with TLS on \"%s:%d\"", s.listenHost, ipDesc.Port)
+	}
+
+	return s.srv.Serve(listener)
+}
+
+// Shutdown this server
+func (s *GRPCServer) Shutdown() error {
+	if s.srv == nil {
+		return nil
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(s.shutdownTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-timer.C:
+		// If shutdown times out, make sure the server is still shutdown.
+		s.srv.Stop()
+		return errGRPCShutdownTimeout
+	}
+}
+
+func (s *GRPCServer) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if err := s.authenticate(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *GRPCServer) streamInterceptor(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	serviceName, _ := splitFullMethod(info.FullMethod)
+	s.lock.RLock()
+	_, scoped := s.scopers[serviceName]
+	s.lock.RUnlock()
+	if scoped {
+		// The endpoint of the call is only known once its request is received
+		return handler(srv, &scopedStream{
+			ServerStream: stream,
+			server:       s,
+			fullMethod:   info.FullMethod,
+		})
+	}
+
+	if err := s.authenticate(stream.Context(), info.FullMethod, nil); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+// scopedStream authenticates a streaming call to an EndpointScoper when the
+// request of the call is received. Server streaming handlers always receive
+// the request before sending anything.
+type scopedStream struct {
+	grpc.ServerStream
+
+	server        *GRPCServer
+	fullMethod    string
+	authenticated bool
+}
+
+func (s *scopedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.authenticated {
+		return nil
+	}
+	if err := s.server.authenticate(s.Context(), s.fullMethod, m); err != nil {
+		return err
+	}
+	s.authenticated = true
+	return nil
+}
+
+// authenticate returns nil if the call to [fullMethod] with request [req] is
+// allowed. The auth token is read from the "authorization" metadata of the
+// call, which must be of the same form as the HTTP header:
+// "Bearer AUTH.TOKEN.HERE".
+func (s *GRPCServer) authenticate(ctx context.Context, fullMethod string, req interface{}) error {
+	s.log.Debug("gRPC API called: %s", fullMethod)
+
+	if s.authenticator == nil {
+		return nil
+	}
+
+	serviceName, methodName := splitFullMethod(fullMethod)
+
+	s.lock.RLock()
+	url, ok := s.urls[serviceName]
+	scoper := s.scopers[serviceName]
+	s.lock.RUnlock()
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "unknown service %s", serviceName)
+	}
+	if scoper != nil {
+		endpoint, err := scoper.CallEndpoint(req)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		url = fmt.Sprintf("%s/%s", url, endpoint)
+	}
+
+	var rawHeader string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(authorizationKey); len(values) > 0 {
+			rawHeader = values[0]
+		}
+	}
+	token, err := auth.ParseBearerToken(rawHeader)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err := s.authenticator.AuthenticateCall(token, url, jsonRPCMethod(serviceName, methodName)); err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	return nil
+}
+
+// splitFullMethod returns the service and method names of [fullMethod], which
+// is of the form "/package.Service/Method"
+func splitFullMethod(fullMethod string) (string, string) {
+	serviceName := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(serviceName, "/"); i >= 0 {
+		return serviceName[:i], serviceName[i+1:]
+	}
+	return serviceName, ""
+}
+
+// jsonRPCMethod returns the name of the JSON-RPC method equivalent to
+// [methodName] of the gRPC service [serviceName], so that tokens limited to
+// JSON-RPC methods also apply to gRPC calls. For example, GetHeight of
+// "platformproto.Platform" is equivalent to "platform.getHeight".
+func jsonRPCMethod(serviceName, methodName string) string {
+	if i := strings.LastIndex(serviceName, "."); i >= 0 {
+		serviceName = serviceName[i+1:]
+	}
+	return lowerFirst(serviceName) + "." + lowerFirst(methodName)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+)
+
+type testService interface{}
+
+var testServiceDesc = grpc.ServiceDesc{
+	ServiceName: "test.Service",
+	HandlerType: (*testService)(nil),
+}
+
+type testAuthenticator struct {
+	token  string
+	url    string
+	method string
+}
+
+func (a *testAuthenticator) AuthenticateCall(token, url, method string) error {
+	if token != a.token || url != a.url || method != a.method {
+		return errors.New("unauthorized")
+	}
+	return nil
+}
+
+func withAuthorization(header string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationKey, header))
+}
+
+func TestGRPCServerAddServiceDuplicate(t *testing.T) {
+	s := &GRPCServer{}
+	s.Initialize(logging.NoLog{}, "127.0.0.1", 0, time.Second, nil)
+
+	if err := s.AddService(&testServiceDesc, struct{}{}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddService(&testServiceDesc, struct{}{}, "test"); !errors.Is(err, errDuplicateService) {
+		t.Fatalf("expected %s but got %v", errDuplicateService, err)
+	}
+}
+
+func TestGRPCServerAuthenticate(t *testing.T) {
+	s := &GRPCServer{}
+	s.Initialize(logging.NoLog{}, "127.0.0.1", 0, time.Second, &testAuthenticator{
+		token:  "token",
+		url:    "/ext/test",
+		method: "service.method",
+	})
+	if err := s.AddService(&testServiceDesc, struct{}{}, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		fullMethod string
+		code       codes.Code
+	}{
+		{
+			name:       "valid token",
+			ctx:        withAuthorization("Bearer token"),
+			fullMethod: "/test.Service/Method",
+			code:       codes.OK,
+		},
+		{
+			name:       "no metadata",
+			ctx:        context.Background(),
+			fullMethod: "/test.Service/Method",
+			code:       codes.Unauthenticated,
+		},
+		{
+			name:       "malformed header",
+			ctx:        withAuthorization("token"),
+			fullMethod: "/test.Service/Method",
+			code:       codes.Unauthenticated,
+		},
+		{
+			name:       "wrong token",
+			ctx:        withAuthorization("Bearer other"),
+			fullMethod: "/test.Service/Method",
+			code:       codes.Unauthenticated,
+		},
+		{
+			name:       "other method",
+			ctx:        withAuthorization("Bearer token"),
+			fullMethod: "/test.Service/OtherMethod",
+			code:       codes.Unauthenticated,
+		},
+		{
+			name:       "unknown service",
+			ctx:        withAuthorization("Bearer token"),
+			fullMethod: "/test.Other/Method",
+			code:       codes.Unimplemented,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.authenticate(test.ctx, test.fullMethod, nil)
+			if code := status.Code(err); code != test.code {
+				t.Fatalf("expected code %s but got %s (%v)", test.code, code, err)
+			}
+		})
+	}
+}
+
+func TestGRPCServerAuthenticateDisabled(t *testing.T) {
+	s := &GRPCServer{}
+	s.Initialize(logging.NoLog{}, "127.0.0.1", 0, time.Second, nil)
+
+	if err := s.authenticate(context.Background(), "/test.Service/Method", nil); err != nil {
+		t.Fatalf("expected no error when auth is disabled but got %v", err)
+	}
+}
+
+type testRequest struct {
+	endpoint string
+}
+
+type testScoper struct{}
+
+func (testScoper) CallEndpoint(req interface{}) (string, error) {
+	testReq, ok := req.(*testRequest)
+	if !ok {
+		return "", errors.New("unexpected request")
+	}
+	return testReq.endpoint, nil
+}
+
+func TestGRPCServerAuthenticateScoped(t *testing.T) {
+	s := &GRPCServer{}
+	s.Initialize(logging.NoLog{}, "127.0.0.1", 0, time.Second, &testAuthenticator{
+		token:  "token",
+		url:    "/ext/test/X/tx",
+		method: "service.method",
+	})
+	if err := s.AddService(&testServiceDesc, testScoper{}, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		req  interface{}
+		code codes.Code
+	}{
+		{
+			name: "matching endpoint",
+			req:  &testRequest{endpoint: "X/tx"},
+			code: codes.OK,
+		},
+		{
+			name: "other endpoint",
+			req:  &testRequest{endpoint: "P/block"},
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "invalid request",
+			req:  nil,
+			code: codes.InvalidArgument,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.authenticate(withAuthorization("Bearer token"), "/test.Service/Method", test.req)
+			if code := status.Code(err); code != test.code {
+				t.Fatalf("expected code %s but got %s (%v)", test.code, code, err)
+			}
+		})
+	}
+}
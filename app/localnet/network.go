@@ -0,0 +1,409 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package localnet launches local networks of nodes, run as child processes,
+// for integration tests and demos.
+package localnet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lasthyphen/dijetsgo/genesis"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/staking"
+	"github.com/lasthyphen/dijetsgo/staking/local"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+	"github.com/lasthyphen/dijetsgo/utils/hashing"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+	"github.com/lasthyphen/dijetsgo/utils/perms"
+)
+
+const (
+	// DefaultNetworkID is the ID of the launched networks unless another one
+	// is configured. As it isn't a standard network, the nodes accept the
+	// generated genesis.
+	DefaultNetworkID = 1337
+
+	healthCheckFrequency = 500 * time.Millisecond
+	stopTimeout          = 30 * time.Second
+
+	genesisFileName = "genesis.json"
+	certFileName    = "staking.crt"
+	keyFileName     = "staking.key"
+	outputFileName  = "process.log"
+)
+
+var (
+	errNoNodes        = errors.New("a network needs at least one initial node")
+	errNoRootDir      = errors.New("no root directory provided")
+	errStopped        = errors.New("network is stopped")
+	errNoRunningNodes = errors.New("network has no running nodes")
+)
+
+// Config describes a local network
+type Config struct {
+	// BinaryPath is the node binary. Defaults to the running executable.
+	BinaryPath string
+	// RootDir holds the genesis and a directory per node
+	RootDir string
+	// NetworkID defaults to [DefaultNetworkID]
+	NetworkID uint32
+	// NumNodes is the number of nodes the network starts with. They are the
+	// validators of the network's genesis.
+	NumNodes int
+	// Flags are passed to every node, after the flags set by the network
+	Flags []string
+	// Log defaults to not logging
+	Log logging.Logger
+}
+
+// Network is a local network of nodes run as child processes. The first
+// nodes use the staking certificates of the local network.
+type Network struct {
+	config      Config
+	genesisPath string
+	// Consensus parameters fitting the number of genesis validators
+	sampleSize, quorumSize int
+
+	lock    sync.RWMutex
+	stopped bool
+	nodes   []*Node
+}
+
+// New starts a network of [config.NumNodes] nodes with a genesis in which they
+// validate the primary network. The nodes may not be healthy yet when New
+// returns.
+func New(config Config) (*Network, error) {
+	if config.NumNodes < 1 {
+		return nil, errNoNodes
+	}
+	if config.RootDir == "" {
+		return nil, errNoRootDir
+	}
+	if config.BinaryPath == "" {
+		binaryPath, err := os.Executable()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't find the node binary: %w", err)
+		}
+		config.BinaryPath = binaryPath
+	}
+	if config.NetworkID == 0 {
+		config.NetworkID = DefaultNetworkID
+	}
+	if config.Log == nil {
+		config.Log = logging.NoLog{}
+	}
+	if err := os.MkdirAll(config.RootDir, perms.ReadWriteExecute); err != nil {
+		return nil, err
+	}
+
+	n := &Network{
+		config:      config,
+		genesisPath: filepath.Join(config.RootDir, genesisFileName),
+		sampleSize:  config.NumNodes,
+		quorumSize:  config.NumNodes/2 + 1,
+	}
+
+	type keyPair struct{ cert, key []byte }
+	keyPairs := make([]keyPair, config.NumNodes)
+	nodeIDs := make([]ids.ShortID, config.NumNodes)
+	for i := range keyPairs {
+		certBytes, keyBytes, err := nodeCertAndKey(i + 1)
+		if err != nil {
+			return nil, err
+		}
+		nodeID, err := certToNodeID(certBytes, keyBytes)
+		if err != nil {
+			return nil, err
+		}
+		keyPairs[i] = keyPair{cert: certBytes, key: keyBytes}
+		nodeIDs[i] = nodeID
+	}
+
+	genesisBytes, err := newGenesis(config.NetworkID, nodeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't build genesis: %w", err)
+	}
+	if err := ioutil.WriteFile(n.genesisPath, genesisBytes, perms.ReadWrite); err != nil {
+		return nil, err
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	for _, keyPair := range keyPairs {
+		if _, err := n.startNode(keyPair.cert, keyPair.key, true); err != nil {
+			n.stop()
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// Nodes returns the nodes of the network, including those that were killed,
+// in the order they were started
+func (n *Network) Nodes() []*Node {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	nodes := make([]*Node, len(n.nodes))
+	copy(nodes, n.nodes)
+	return nodes
+}
+
+// GetNode returns the node named [name]
+func (n *Network) GetNode(name string) (*Node, error) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return n.getNode(name)
+}
+
+// AwaitHealthy returns once all the running nodes are healthy
+func (n *Network) AwaitHealthy(ctx context.Context) error {
+	for _, node := range n.Nodes() {
+		if !node.Running() {
+			continue
+		}
+		if err := node.AwaitHealthy(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddNode starts a node that isn't a validator and bootstraps from the
+// running validators
+func (n *Network) AddNode() (*Node, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.stopped {
+		return nil, errStopped
+	}
+	certBytes, keyBytes, err := nodeCertAndKey(len(n.nodes) + 1)
+	if err != nil {
+		return nil, err
+	}
+	return n.startNode(certBytes, keyBytes, false)
+}
+
+// KillNode kills the process of the node named [name]. Its data is kept.
+func (n *Network) KillNode(name string) error {
+	n.lock.RLock()
+	node, err := n.getNode(name)
+	n.lock.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	n.config.Log.Info("killing node %s", name)
+	node.kill()
+	return nil
+}
+
+// Stop gracefully shuts down the running nodes. Nodes that don't exit within
+// a timeout are killed.
+func (n *Network) Stop() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.stop()
+}
+
+func (n *Network) stop() {
+	if n.stopped {
+		return
+	}
+	n.stopped = true
+
+	for _, node := range n.nodes {
+		node.interrupt()
+	}
+	timeout := time.After(stopTimeout)
+	for _, node := range n.nodes {
+		select {
+		case <-node.exited:
+		case <-timeout:
+			n.config.Log.Warn("killing node %s as it didn't stop in time", node.Name)
+			node.kill()
+		}
+	}
+}
+
+func (n *Network) getNode(name string) (*Node, error) {
+	for _, node := range n.nodes {
+		if node.Name == name {
+			return node, nil
+		}
+	}
+	return nil, fmt.Errorf("node %q doesn't exist", name)
+}
+
+// startNode starts a node with the provided staking key pair. Assumes the
+// lock is held.
+func (n *Network) startNode(certBytes, keyBytes []byte, validator bool) (*Node, error) {
+	nodeID, err := certToNodeID(certBytes, keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("node%d", len(n.nodes)+1)
+	dir := filepath.Join(n.config.RootDir, name)
+	if err := os.MkdirAll(dir, perms.ReadWriteExecute); err != nil {
+		return nil, err
+	}
+	certPath := filepath.Join(dir, certFileName)
+	keyPath := filepath.Join(dir, keyFileName)
+	if err := ioutil.WriteFile(certPath, certBytes, perms.ReadWrite); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(keyPath, keyBytes, perms.ReadWrite); err != nil {
+		return nil, err
+	}
+
+	ports, err := freePorts(2)
+	if err != nil {
+		return nil, err
+	}
+	httpPort, stakingPort := ports[0], ports[1]
+
+	// Nodes bootstrap from the running validators
+	bootstrapIPs := []string(nil)
+	bootstrapIDs := []string(nil)
+	for _, node := range n.nodes {
+		if node.Validator && node.Running() {
+			bootstrapIPs = append(bootstrapIPs, node.StakingAddress)
+			bootstrapIDs = append(bootstrapIDs, node.NodeID.PrefixedString(constants.NodeIDPrefix))
+		}
+	}
+	if !validator && len(bootstrapIPs) == 0 {
+		return nil, errNoRunningNodes
+	}
+
+	// The flags are named rather than taken from the config package, as they
+	// are passed to a binary that may be built from another version
+	args := []string{
+		fmt.Sprintf("--network-id=%d", n.config.NetworkID),
+		fmt.Sprintf("--genesis=%s", n.genesisPath),
+		"--public-ip=127.0.0.1",
+		fmt.Sprintf("--http-port=%d", httpPort),
+		fmt.Sprintf("--staking-port=%d", stakingPort),
+		fmt.Sprintf("--staking-tls-cert-file=%s", certPath),
+		fmt.Sprintf("--staking-tls-key-file=%s", keyPath),
+		fmt.Sprintf("--db-dir=%s", filepath.Join(dir, "db")),
+		fmt.Sprintf("--log-dir=%s", filepath.Join(dir, "logs")),
+		fmt.Sprintf("--bootstrap-ips=%s", strings.Join(bootstrapIPs, ",")),
+		fmt.Sprintf("--bootstrap-ids=%s", strings.Join(bootstrapIDs, ",")),
+		fmt.Sprintf("--snow-sample-size=%d", n.sampleSize),
+		fmt.Sprintf("--snow-quorum-size=%d", n.quorumSize),
+	}
+	if n.config.NumNodes == 1 && validator {
+		// The only validator is healthy without peers
+		args = append(args,
+			"--network-health-min-conn-peers=0",
+			fmt.Sprintf("--network-health-max-time-since-msg-received=%s", time.Duration(math.MaxInt64)),
+			fmt.Sprintf("--network-health-max-time-since-msg-sent=%s", time.Duration(math.MaxInt64)),
+		)
+	}
+	args = append(args, n.config.Flags...)
+
+	output, err := os.OpenFile(filepath.Join(dir, outputFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, perms.ReadWrite)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(n.config.BinaryPath, args...) // #nosec G204
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if err := cmd.Start(); err != nil {
+		_ = output.Close()
+		return nil, fmt.Errorf("couldn't start %s: %w", name, err)
+	}
+
+	node := &Node{
+		Name:           name,
+		NodeID:         nodeID,
+		URI:            fmt.Sprintf("http://127.0.0.1:%d", httpPort),
+		StakingAddress: fmt.Sprintf("127.0.0.1:%d", stakingPort),
+		Validator:      validator,
+		Dir:            dir,
+		cmd:            cmd,
+		exited:         make(chan struct{}),
+	}
+	go func() {
+		node.exitErr = cmd.Wait()
+		_ = output.Close()
+		close(node.exited)
+		n.config.Log.Info("node %s exited: %v", name, node.exitErr)
+	}()
+	n.nodes = append(n.nodes, node)
+
+	n.config.Log.Info("started node %s (%s) with API at %s", name, nodeID.PrefixedString(constants.NodeIDPrefix), node.URI)
+	return node, nil
+}
+
+// nodeCertAndKey returns the staking key pair of the [i]th node, starting at
+// 1. The first nodes use the certificates of the local network.
+func nodeCertAndKey(i int) ([]byte, []byte, error) {
+	if i <= local.NumStakers {
+		return local.StakerCertAndKey(i)
+	}
+	return staking.NewCertAndKeyBytes()
+}
+
+func certToNodeID(certBytes, keyBytes []byte) (ids.ShortID, error) {
+	cert, err := staking.LoadTLSCertFromBytes(keyBytes, certBytes)
+	if err != nil {
+		return ids.ShortID{}, err
+	}
+	return ids.ToShortID(hashing.PubkeyBytesToAddress(cert.Leaf.Raw))
+}
+
+// newGenesis returns a genesis config, based on the local network's, in which
+// [nodeIDs] validate the primary network
+func newGenesis(networkID uint32, nodeIDs []ids.ShortID) ([]byte, error) {
+	genesisConfig := genesis.LocalConfig
+	genesisConfig.NetworkID = networkID
+	genesisConfig.StartTime = uint64(time.Now().Unix())
+	genesisConfig.Message = ""
+
+	localStaker := genesis.LocalConfig.InitialStakers[0]
+	genesisConfig.InitialStakers = make([]genesis.Staker, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		genesisConfig.InitialStakers[i] = genesis.Staker{
+			NodeID:        nodeID,
+			RewardAddress: localStaker.RewardAddress,
+			DelegationFee: localStaker.DelegationFee,
+		}
+	}
+
+	reply, err := genesis.Build(&genesisConfig)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(reply.Config, "", "\t")
+}
+
+// freePorts returns [num] ports that aren't in use
+func freePorts(num int) ([]uint16, error) {
+	ports := make([]uint16, num)
+	for i := range ports {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return nil, fmt.Errorf("couldn't find a free port: %w", err)
+		}
+		defer listener.Close()
+		ports[i] = uint16(listener.Addr().(*net.TCPAddr).Port)
+	}
+	return ports, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package localnet
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/genesis"
+	"github.com/lasthyphen/dijetsgo/ids"
+)
+
+func TestNodeCertAndKey(t *testing.T) {
+	assert := assert.New(t)
+
+	// The first nodes are the validators of the local network
+	for i, staker := range genesis.LocalConfig.InitialStakers {
+		certBytes, keyBytes, err := nodeCertAndKey(i + 1)
+		assert.NoError(err)
+		nodeID, err := certToNodeID(certBytes, keyBytes)
+		assert.NoError(err)
+		assert.Equal(staker.NodeID, nodeID)
+	}
+}
+
+func TestNewGenesis(t *testing.T) {
+	assert := assert.New(t)
+
+	nodeIDs := []ids.ShortID{ids.GenerateTestShortID(), ids.GenerateTestShortID()}
+	genesisBytes, err := newGenesis(DefaultNetworkID, nodeIDs)
+	assert.NoError(err)
+
+	genesisContent := base64.StdEncoding.EncodeToString(genesisBytes)
+	config, err := genesis.GetConfigContent(genesisContent)
+	assert.NoError(err)
+	assert.Equal(uint32(DefaultNetworkID), config.NetworkID)
+	assert.Len(config.InitialStakers, len(nodeIDs))
+	for i, staker := range config.InitialStakers {
+		assert.Equal(nodeIDs[i], staker.NodeID)
+	}
+
+	// The genesis is accepted by a node of the network
+	_, _, err = genesis.FromFlag(DefaultNetworkID, genesisContent)
+	assert.NoError(err)
+}
+
+func TestNewErrors(t *testing.T) {
+	_, err := New(Config{RootDir: t.TempDir()})
+	assert.ErrorIs(t, err, errNoNodes)
+	_, err = New(Config{NumNodes: 1})
+	assert.ErrorIs(t, err, errNoRootDir)
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package localnet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/lasthyphen/dijetsgo/api/health"
+	"github.com/lasthyphen/dijetsgo/ids"
+)
+
+// Node is a node of a local network, run as a child process
+type Node struct {
+	Name string
+	// NodeID is derived from the node's staking certificate
+	NodeID ids.ShortID
+	// URI of the node's HTTP API, e.g. http://127.0.0.1:9650
+	URI string
+	// StakingAddress is the IP and port of the node's P2P connections
+	StakingAddress string
+	// Validator is true if the node validates the primary network from
+	// genesis
+	Validator bool
+	// Dir holds the node's staking certificate, database and logs
+	Dir string
+
+	cmd *exec.Cmd
+
+	// exited is closed once the process exits
+	exited  chan struct{}
+	exitErr error
+
+	killOnce sync.Once
+}
+
+// Running returns true if the node's process hasn't exited
+func (n *Node) Running() bool {
+	select {
+	case <-n.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+// AwaitHealthy returns once the node reports that it's healthy. Returns an
+// error if the process exits first.
+func (n *Node) AwaitHealthy(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-n.exited:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	_, err := health.NewClient(n.URI).AwaitHealthy(ctx, healthCheckFrequency)
+	if !n.Running() {
+		return fmt.Errorf("node %s exited: %v", n.Name, n.exitErr)
+	}
+	if err != nil {
+		return fmt.Errorf("node %s isn't healthy: %w", n.Name, err)
+	}
+	return nil
+}
+
+// kill stops the process immediately and waits for it to exit
+func (n *Node) kill() {
+	n.killOnce.Do(func() {
+		_ = n.cmd.Process.Kill()
+	})
+	<-n.exited
+}
+
+// interrupt asks the process to shut down gracefully
+func (n *Node) interrupt() {
+	if err := n.cmd.Process.Signal(os.Interrupt); err != nil {
+		n.kill()
+	}
+}
@@ -4,11 +4,13 @@
 package process
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"sync"
 
 	"github.com/lasthyphen/dijetsgo/app"
+	"github.com/lasthyphen/dijetsgo/database/encdb"
 	"github.com/lasthyphen/dijetsgo/database/leveldb"
 	"github.com/lasthyphen/dijetsgo/database/manager"
 	"github.com/lasthyphen/dijetsgo/database/memdb"
@@ -98,6 +100,21 @@ func (p *process) Start() error {
 		return err
 	}
 
+	// [dbManager] is closed on shutdown, which closes the databases wrapped by
+	// [nodeDBManager]
+	nodeDBManager, err := encryptDBManager(dbManager, p.config.DatabaseConfig.EncryptionSecret)
+	if err != nil {
+		log.Fatal("couldn't open the db at %s: %s", p.config.DatabaseConfig.Path, err)
+		if err := dbManager.Close(); err != nil {
+			log.Warn("failed to close the node's DB: %s", err)
+		}
+		logFactory.Close()
+		return err
+	}
+	if p.config.DatabaseConfig.EncryptionSecret != nil {
+		log.Info("the keys and values of the db are encrypted")
+	}
+
 	// Track if sybil control is enforced
 	if !p.config.EnableStaking {
 		log.Warn("Staking is disabled. Sybil control is not enforced.")
@@ -160,7 +177,7 @@ func (p *process) Start() error {
 		&p.config.IP,
 	)
 
-	if err := p.node.Initialize(&p.config, dbManager, log, logFactory); err != nil {
+	if err := p.node.Initialize(&p.config, nodeDBManager, log, logFactory); err != nil {
 		log.Fatal("error initializing node: %s", err)
 		mapper.UnmapAllPorts()
 		externalIPUpdater.Stop()
@@ -215,3 +232,17 @@ func (p *process) ExitCode() (int, error) {
 	p.exitWG.Wait()
 	return p.node.ExitCode(), nil
 }
+
+// encryptDBManager returns [dbManager] with its databases encrypted with a key
+// derived from [secret]. If [secret] is nil, verifies that the databases
+// aren't encrypted and returns [dbManager].
+func encryptDBManager(dbManager manager.Manager, secret []byte) (manager.Manager, error) {
+	if secret == nil {
+		return dbManager, manager.VerifyNotEncrypted(dbManager)
+	}
+	encryptedManager, err := manager.NewEncrypted(dbManager, secret)
+	if errors.Is(err, encdb.ErrNotEncrypted) {
+		return nil, fmt.Errorf("%w. It can be encrypted with the encrypt-db command", err)
+	}
+	return encryptedManager, err
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/vms"
 	"github.com/lasthyphen/dijetsgo/vms/metervm"
 	"github.com/lasthyphen/dijetsgo/vms/proposervm"
+	"github.com/lasthyphen/dijetsgo/vms/proposervm/proposer"
 
 	dbManager "github.com/lasthyphen/dijetsgo/database/manager"
 
@@ -422,8 +423,10 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 	}
 
 	consensusParams := m.ConsensusParams
+	proposerParams := proposer.DefaultParameters
 	if sbConfigs, ok := m.SubnetConfigs[chainParams.SubnetID]; ok && chainParams.SubnetID != constants.PrimaryNetworkID {
 		consensusParams = sbConfigs.ConsensusParameters
+		proposerParams = sbConfigs.ProposerParameters
 	}
 
 	// The validators of this blockchain
@@ -471,6 +474,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 			vm,
 			fxs,
 			consensusParams.Parameters,
+			proposerParams,
 			bootstrapWeight,
 			sb,
 		)
@@ -702,6 +706,7 @@ func (m *manager) createSnowmanChain(
 	vm block.ChainVM,
 	fxs []*common.Fx,
 	consensusParams snowball.Parameters,
+	proposerParams proposer.Parameters,
 	bootstrapWeight uint64,
 	sb Subnet,
 ) (*chain, error) {
@@ -772,7 +777,7 @@ func (m *manager) createSnowmanChain(
 	}
 
 	// enable ProposerVM on this VM
-	vm = proposervm.New(vm, m.ApricotPhase4Time, m.ApricotPhase4MinPChainHeight, m.ResetProposerVMHeightIndex)
+	vm = proposervm.New(vm, m.ApricotPhase4Time, m.ApricotPhase4MinPChainHeight, m.ResetProposerVMHeightIndex, proposerParams)
 
 	if m.MeterVMEnabled {
 		vm = metervm.NewBlockVM(vm)
@@ -4,11 +4,13 @@
 package chains
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/lasthyphen/dijetsgo/ids"
 	"github.com/lasthyphen/dijetsgo/snow/consensus/avalanche"
 	"github.com/lasthyphen/dijetsgo/snow/engine/common"
+	"github.com/lasthyphen/dijetsgo/vms/proposervm/proposer"
 )
 
 var _ Subnet = &subnet{}
@@ -29,6 +31,27 @@ type SubnetConfig struct {
 	// ValidatorOnly indicates that this Subnet's Chains are available to only subnet validators.
 	ValidatorOnly       bool                 `json:"validatorOnly"`
 	ConsensusParameters avalanche.Parameters `json:"consensusParameters"`
+	// ProposerParameters configure the proposer windows of this Subnet's
+	// Snowman++ chains. All validators of the Subnet must agree on them.
+	//
+	// Subnet configs are local to each node and are never exchanged with
+	// peers or committed to the P-chain, so a node can only check that its
+	// own parameters are valid. A validator using different parameters than
+	// the rest of the Subnet will reject blocks the others accept (or the
+	// other way around), which shows up as failed block verifications.
+	// Omitted fields fall back to proposer.DefaultParameters.
+	ProposerParameters proposer.Parameters `json:"proposerParameters"`
+}
+
+// Valid returns nil if the Subnet config describes a valid configuration.
+func (c *SubnetConfig) Valid() error {
+	if err := c.ConsensusParameters.Valid(); err != nil {
+		return err
+	}
+	if err := c.ProposerParameters.Valid(); err != nil {
+		return fmt.Errorf("invalid proposer parameters: %w", err)
+	}
+	return nil
 }
 
 type subnet struct {
@@ -6,6 +6,7 @@ package hierarchycodec
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/lasthyphen/dijetsgo/codec"
@@ -23,12 +24,14 @@ var (
 	_ codec.Codec        = &hierarchyCodec{}
 	_ codec.Registry     = &hierarchyCodec{}
 	_ codec.GeneralCodec = &hierarchyCodec{}
+	_ codec.Introspector = &hierarchyCodec{}
 )
 
 // Codec marshals and unmarshals
 type Codec interface {
 	codec.Registry
 	codec.Codec
+	codec.Introspector
 	SkipRegistrations(int)
 	NextGroup()
 }
@@ -101,6 +104,27 @@ func (c *hierarchyCodec) RegisterType(val interface{}) error {
 	return nil
 }
 
+func (c *hierarchyCodec) RegisteredTypes() []codec.RegisteredType {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	types := make([]codec.RegisteredType, 0, len(c.typeIDToType))
+	for typeID, valType := range c.typeIDToType {
+		types = append(types, codec.RegisteredType{
+			GroupID: typeID.groupID,
+			TypeID:  uint32(typeID.typeID),
+			Type:    valType,
+		})
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if types[i].GroupID != types[j].GroupID {
+			return types[i].GroupID < types[j].GroupID
+		}
+		return types[i].TypeID < types[j].TypeID
+	})
+	return types
+}
+
 func (c *hierarchyCodec) PackPrefix(p *wrappers.Packer, valueType reflect.Type) error {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
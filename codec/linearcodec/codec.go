@@ -6,6 +6,7 @@ package linearcodec
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/lasthyphen/dijetsgo/codec"
@@ -23,12 +24,14 @@ var (
 	_ codec.Codec        = &linearCodec{}
 	_ codec.Registry     = &linearCodec{}
 	_ codec.GeneralCodec = &linearCodec{}
+	_ codec.Introspector = &linearCodec{}
 )
 
 // Codec marshals and unmarshals
 type Codec interface {
 	codec.Registry
 	codec.Codec
+	codec.Introspector
 	SkipRegistrations(int)
 }
 
@@ -80,6 +83,23 @@ func (c *linearCodec) RegisterType(val interface{}) error {
 	return nil
 }
 
+func (c *linearCodec) RegisteredTypes() []codec.RegisteredType {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	types := make([]codec.RegisteredType, 0, len(c.typeIDToType))
+	for typeID, valType := range c.typeIDToType {
+		types = append(types, codec.RegisteredType{
+			TypeID: typeID,
+			Type:   valType,
+		})
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].TypeID < types[j].TypeID
+	})
+	return types
+}
+
 func (c *linearCodec) PackPrefix(p *wrappers.Packer, valueType reflect.Type) error {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
@@ -3,7 +3,24 @@
 
 package codec
 
+import "reflect"
+
 // Registry registers new types that can be marshaled into
 type Registry interface {
 	RegisterType(interface{}) error
 }
+
+// RegisteredType is a type registered in a codec, along with the ID it's
+// prefixed with when it's marshaled as an interface
+type RegisteredType struct {
+	// GroupID is only used by codecs that register types in groups
+	GroupID uint16
+	TypeID  uint32
+	Type    reflect.Type
+}
+
+// Introspector reports the types that have been registered in a codec
+type Introspector interface {
+	// RegisteredTypes returns the registered types sorted by their ID
+	RegisteredTypes() []RegisteredType
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package schema
+
+import "fmt"
+
+// Incompatibility is a change to a schema that prevents bytes serialized with
+// the previous schema from being parsed with the current one
+type Incompatibility struct {
+	// Name of the type or struct that changed
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+func (i Incompatibility) String() string {
+	return fmt.Sprintf("%s: %s", i.Name, i.Reason)
+}
+
+type typeKey struct {
+	groupID uint16
+	typeID  uint32
+}
+
+func (k typeKey) String() string {
+	if k.groupID == 0 {
+		return fmt.Sprintf("%d", k.typeID)
+	}
+	return fmt.Sprintf("%d.%d", k.groupID, k.typeID)
+}
+
+// Compare returns the changes from [previous] to [current] that break the
+// serialization format.
+//
+// Registering new types after the existing ones and adding new structs is
+// compatible. Changing the ID of a registered type or the layout of a struct
+// is not. Renaming a field doesn't change the serialization format, so it's
+// allowed.
+func Compare(previous, current *Schema) []Incompatibility {
+	incompatibilities := []Incompatibility(nil)
+
+	currentTypes := make(map[typeKey]Type, len(current.Types))
+	for _, t := range current.Types {
+		currentTypes[typeKey{groupID: t.GroupID, typeID: t.TypeID}] = t
+	}
+	for _, previousType := range previous.Types {
+		key := typeKey{groupID: previousType.GroupID, typeID: previousType.TypeID}
+		currentType, ok := currentTypes[key]
+		switch {
+		case !ok:
+			incompatibilities = append(incompatibilities, Incompatibility{
+				Name:   previousType.Name,
+				Reason: fmt.Sprintf("type ID %s was removed", key),
+			})
+		case currentType.Name != previousType.Name:
+			incompatibilities = append(incompatibilities, Incompatibility{
+				Name:   previousType.Name,
+				Reason: fmt.Sprintf("type ID %s is now %s", key, currentType.Name),
+			})
+		}
+	}
+
+	currentStructs := make(map[string]Struct, len(current.Structs))
+	for _, s := range current.Structs {
+		currentStructs[s.Name] = s
+	}
+	for _, previousStruct := range previous.Structs {
+		currentStruct, ok := currentStructs[previousStruct.Name]
+		if !ok {
+			// If the struct is still serialized under another name, the type
+			// of a field or a type ID referencing it changed.
+			continue
+		}
+		incompatibilities = append(incompatibilities, compareFields(previousStruct, currentStruct)...)
+	}
+	return incompatibilities
+}
+
+func compareFields(previous, current Struct) []Incompatibility {
+	incompatibilities := []Incompatibility(nil)
+	for i, previousField := range previous.Fields {
+		if i >= len(current.Fields) {
+			incompatibilities = append(incompatibilities, Incompatibility{
+				Name:   previous.Name,
+				Reason: fmt.Sprintf("field %d (%s) was removed", i, previousField.Name),
+			})
+			continue
+		}
+		currentField := current.Fields[i]
+		if currentField.Type != previousField.Type {
+			incompatibilities = append(incompatibilities, Incompatibility{
+				Name: previous.Name,
+				Reason: fmt.Sprintf("field %d changed from %s %s to %s %s",
+					i, previousField.Name, previousField.Type, currentField.Name, currentField.Type),
+			})
+		}
+		// A maximum length of 0 is the maximum length of the codec, which is
+		// larger than any maximum length set on a field
+		if currentField.MaxSliceLen != 0 &&
+			(previousField.MaxSliceLen == 0 || currentField.MaxSliceLen < previousField.MaxSliceLen) {
+			incompatibilities = append(incompatibilities, Incompatibility{
+				Name: previous.Name,
+				Reason: fmt.Sprintf("maximum length of field %d (%s) was reduced from %d to %d",
+					i, currentField.Name, previousField.MaxSliceLen, currentField.MaxSliceLen),
+			})
+		}
+	}
+	for i := len(previous.Fields); i < len(current.Fields); i++ {
+		incompatibilities = append(incompatibilities, Incompatibility{
+			Name:   previous.Name,
+			Reason: fmt.Sprintf("field %d (%s) was added", i, current.Fields[i].Name),
+		})
+	}
+	return incompatibilities
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSchema() *Schema {
+	return &Schema{
+		Types: []Type{
+			{TypeID: 0, Name: "avm.BaseTx"},
+			{TypeID: 1, Name: "avm.CreateAssetTx"},
+		},
+		Structs: []Struct{
+			{
+				Name: "avm.BaseTx",
+				Fields: []Field{
+					{Name: "NetworkID", Type: "uint32"},
+					{Name: "Memo", Type: "[]uint8", MaxSliceLen: 256},
+				},
+			},
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name              string
+		change            func(s *Schema)
+		incompatibilities []Incompatibility
+	}{
+		{
+			name:   "unchanged",
+			change: func(*Schema) {},
+		},
+		{
+			name: "type appended",
+			change: func(s *Schema) {
+				s.Types = append(s.Types, Type{TypeID: 2, Name: "avm.OperationTx"})
+				s.Structs = append(s.Structs, Struct{Name: "avm.OperationTx"})
+			},
+		},
+		{
+			name: "field renamed",
+			change: func(s *Schema) {
+				s.Structs[0].Fields[0].Name = "Network"
+			},
+		},
+		{
+			name: "maximum length increased",
+			change: func(s *Schema) {
+				s.Structs[0].Fields[1].MaxSliceLen = 0
+			},
+		},
+		{
+			name: "types reordered",
+			change: func(s *Schema) {
+				s.Types[0].Name, s.Types[1].Name = s.Types[1].Name, s.Types[0].Name
+			},
+			incompatibilities: []Incompatibility{
+				{Name: "avm.BaseTx", Reason: "type ID 0 is now avm.CreateAssetTx"},
+				{Name: "avm.CreateAssetTx", Reason: "type ID 1 is now avm.BaseTx"},
+			},
+		},
+		{
+			name: "type removed",
+			change: func(s *Schema) {
+				s.Types = s.Types[:1]
+			},
+			incompatibilities: []Incompatibility{
+				{Name: "avm.CreateAssetTx", Reason: "type ID 1 was removed"},
+			},
+		},
+		{
+			name: "fields reordered",
+			change: func(s *Schema) {
+				fields := s.Structs[0].Fields
+				fields[0], fields[1] = fields[1], fields[0]
+				fields[0].MaxSliceLen = 0
+				fields[1].MaxSliceLen = 0
+			},
+			incompatibilities: []Incompatibility{
+				{Name: "avm.BaseTx", Reason: "field 0 changed from NetworkID uint32 to Memo []uint8"},
+				{Name: "avm.BaseTx", Reason: "field 1 changed from Memo []uint8 to NetworkID uint32"},
+			},
+		},
+		{
+			name: "field added",
+			change: func(s *Schema) {
+				s.Structs[0].Fields = append(s.Structs[0].Fields, Field{Name: "Locktime", Type: "uint64"})
+			},
+			incompatibilities: []Incompatibility{
+				{Name: "avm.BaseTx", Reason: "field 2 (Locktime) was added"},
+			},
+		},
+		{
+			name: "field removed",
+			change: func(s *Schema) {
+				s.Structs[0].Fields = s.Structs[0].Fields[:1]
+			},
+			incompatibilities: []Incompatibility{
+				{Name: "avm.BaseTx", Reason: "field 1 (Memo) was removed"},
+			},
+		},
+		{
+			name: "maximum length reduced",
+			change: func(s *Schema) {
+				s.Structs[0].Fields[1].MaxSliceLen = 128
+			},
+			incompatibilities: []Incompatibility{
+				{Name: "avm.BaseTx", Reason: "maximum length of field 1 (Memo) was reduced from 256 to 128"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			current := newTestSchema()
+			test.change(current)
+			assert.Equal(t, test.incompatibilities, Compare(newTestSchema(), current))
+		})
+	}
+}
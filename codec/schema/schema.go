@@ -0,0 +1,162 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package schema describes the wire format of the types registered in a codec,
+// so that clients in other languages can be generated and checked against it.
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/lasthyphen/dijetsgo/codec"
+	"github.com/lasthyphen/dijetsgo/codec/reflectcodec"
+)
+
+// Schema describes the types registered in a codec and the layout of every
+// struct they serialize.
+//
+// Field types are written as their Go kind for booleans, strings and integers,
+// []T for a slice of T prefixed by its length, [N]T for an array of N T, the
+// name of a struct, whose layout is listed in Structs, or "interface "
+// followed by the name of an interface, whose value is prefixed by the ID of
+// its registered type.
+type Schema struct {
+	// Types are the registered types, sorted by their ID
+	Types []Type `json:"types"`
+	// Structs are the structs serialized by the registered types and the
+	// directly marshaled types, sorted by their name
+	Structs []Struct `json:"structs"`
+}
+
+// Type is a type registered in a codec
+type Type struct {
+	GroupID uint16 `json:"groupID,omitempty"`
+	TypeID  uint32 `json:"typeID"`
+	Name    string `json:"name"`
+}
+
+// Struct is the serialized layout of a struct
+type Struct struct {
+	Name string `json:"name"`
+	// Fields are the serialized fields, in the order they are serialized
+	Fields []Field `json:"fields"`
+}
+
+// Field is a serialized field of a struct
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// MaxSliceLen is the maximum length of a slice field, if it overrides the
+	// maximum length of the codec
+	MaxSliceLen uint32 `json:"maxSliceLen,omitempty"`
+}
+
+type builder struct {
+	fielder reflectcodec.StructFielder
+	// struct name --> struct type
+	types   map[string]reflect.Type
+	structs []Struct
+}
+
+// New returns the schema of the types registered in [c]. [tagName] is the tag
+// that marks the serialized fields of a struct. The layouts of the types of
+// [values], which are marshaled directly rather than as an interface, are
+// described as well.
+func New(c codec.Introspector, tagName string, values ...interface{}) (*Schema, error) {
+	b := builder{
+		fielder: reflectcodec.NewStructFielder(tagName, 0),
+		types:   make(map[string]reflect.Type),
+	}
+
+	registeredTypes := c.RegisteredTypes()
+	s := &Schema{
+		Types: make([]Type, len(registeredTypes)),
+	}
+	for i, registeredType := range registeredTypes {
+		name, err := b.describe(registeredType.Type)
+		if err != nil {
+			return nil, err
+		}
+		s.Types[i] = Type{
+			GroupID: registeredType.GroupID,
+			TypeID:  registeredType.TypeID,
+			Name:    name,
+		}
+	}
+
+	for _, value := range values {
+		if _, err := b.describe(reflect.TypeOf(value)); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(b.structs, func(i, j int) bool {
+		return b.structs[i].Name < b.structs[j].Name
+	})
+	s.Structs = b.structs
+	return s, nil
+}
+
+// describe returns the type expression of [t] and adds the layout of the
+// structs it serializes
+func (b *builder) describe(t reflect.Type) (string, error) {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return t.Kind().String(), nil
+	case reflect.Ptr:
+		return b.describe(t.Elem())
+	case reflect.Slice:
+		elem, err := b.describe(t.Elem())
+		return "[]" + elem, err
+	case reflect.Array:
+		elem, err := b.describe(t.Elem())
+		return "[" + strconv.Itoa(t.Len()) + "]" + elem, err
+	case reflect.Interface:
+		return "interface " + t.String(), nil
+	case reflect.Struct:
+		return t.String(), b.addStruct(t)
+	default:
+		return "", fmt.Errorf("can't describe kind %s of %s", t.Kind(), t)
+	}
+}
+
+func (b *builder) addStruct(t reflect.Type) error {
+	name := t.String()
+	if existing, ok := b.types[name]; ok {
+		if existing != t {
+			return fmt.Errorf("types %s and %s are both named %s", existing.PkgPath(), t.PkgPath(), name)
+		}
+		return nil
+	}
+	// Mark the struct before describing its fields, so recursive structs
+	// terminate
+	b.types[name] = t
+
+	fieldDescs, err := b.fielder.GetSerializedFields(t)
+	if err != nil {
+		return err
+	}
+	s := Struct{
+		Name:   name,
+		Fields: make([]Field, len(fieldDescs)),
+	}
+	for i, fieldDesc := range fieldDescs {
+		field := t.Field(fieldDesc.Index)
+		fieldType, err := b.describe(field.Type)
+		if err != nil {
+			return fmt.Errorf("couldn't describe field %s of %s: %w", field.Name, name, err)
+		}
+		s.Fields[i] = Field{
+			Name:        field.Name,
+			Type:        fieldType,
+			MaxSliceLen: fieldDesc.MaxSliceLen,
+		}
+	}
+	b.structs = append(b.structs, s)
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/codec/hierarchycodec"
+	"github.com/lasthyphen/dijetsgo/codec/linearcodec"
+	"github.com/lasthyphen/dijetsgo/codec/reflectcodec"
+	"github.com/lasthyphen/dijetsgo/ids"
+)
+
+type testInterface interface {
+	Foo() int
+}
+
+type testInner struct {
+	ID        ids.ID `serialize:"true"`
+	Ignored   int
+	Addresses []ids.ShortID `serialize:"true" len:"16"`
+}
+
+type testOuter struct {
+	Inner   testInner       `serialize:"true"`
+	Values  []testInterface `serialize:"true"`
+	Next    *testOuter      `serialize:"true"`
+	Enabled bool            `serialize:"true"`
+}
+
+func (*testOuter) Foo() int { return 0 }
+
+type testEmpty struct{}
+
+func (*testEmpty) Foo() int { return 1 }
+
+type testUnsupported struct {
+	Values map[string]int `serialize:"true"`
+}
+
+func TestNewLinearCodec(t *testing.T) {
+	assert := assert.New(t)
+
+	c := linearcodec.NewDefault()
+	c.SkipRegistrations(2)
+	assert.NoError(c.RegisterType(&testOuter{}))
+	assert.NoError(c.RegisterType(&testEmpty{}))
+
+	s, err := New(c, reflectcodec.DefaultTagName)
+	assert.NoError(err)
+	assert.Equal(&Schema{
+		Types: []Type{
+			{TypeID: 2, Name: "schema.testOuter"},
+			{TypeID: 3, Name: "schema.testEmpty"},
+		},
+		Structs: []Struct{
+			{
+				Name:   "schema.testEmpty",
+				Fields: []Field{},
+			},
+			{
+				Name: "schema.testInner",
+				Fields: []Field{
+					{Name: "ID", Type: "[32]uint8"},
+					{Name: "Addresses", Type: "[][20]uint8", MaxSliceLen: 16},
+				},
+			},
+			{
+				Name: "schema.testOuter",
+				Fields: []Field{
+					{Name: "Inner", Type: "schema.testInner"},
+					{Name: "Values", Type: "[]interface schema.testInterface"},
+					{Name: "Next", Type: "schema.testOuter"},
+					{Name: "Enabled", Type: "bool"},
+				},
+			},
+		},
+	}, s)
+}
+
+func TestNewHierarchyCodec(t *testing.T) {
+	assert := assert.New(t)
+
+	c := hierarchycodec.NewDefault()
+	assert.NoError(c.RegisterType(&testOuter{}))
+	c.NextGroup()
+	assert.NoError(c.RegisterType(&testEmpty{}))
+
+	s, err := New(c, reflectcodec.DefaultTagName, &testInner{})
+	assert.NoError(err)
+	assert.Equal([]Type{
+		{GroupID: 0, TypeID: 0, Name: "schema.testOuter"},
+		{GroupID: 1, TypeID: 0, Name: "schema.testEmpty"},
+	}, s.Types)
+	assert.Len(s.Structs, 3)
+}
+
+func TestNewUnsupportedKind(t *testing.T) {
+	c := linearcodec.NewDefault()
+	assert.NoError(t, c.RegisterType(&testUnsupported{}))
+
+	_, err := New(c, reflectcodec.DefaultTagName)
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ package config
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -19,6 +20,8 @@ import (
 
 	"github.com/spf13/viper"
 
+	"github.com/lasthyphen/dijetsgo/api/keystore"
+	"github.com/lasthyphen/dijetsgo/api/ratelimit"
 	"github.com/lasthyphen/dijetsgo/app/runner"
 	"github.com/lasthyphen/dijetsgo/chains"
 	"github.com/lasthyphen/dijetsgo/genesis"
@@ -44,7 +47,9 @@ import (
 	"github.com/lasthyphen/dijetsgo/utils/storage"
 	"github.com/lasthyphen/dijetsgo/utils/timer"
 	"github.com/lasthyphen/dijetsgo/utils/ulimit"
+	"github.com/lasthyphen/dijetsgo/version"
 	"github.com/lasthyphen/dijetsgo/vms"
+	"github.com/lasthyphen/dijetsgo/vms/proposervm/proposer"
 )
 
 const (
@@ -61,7 +66,13 @@ var (
 
 	errInvalidStakerWeights          = errors.New("staking weights must be positive")
 	errStakingDisableOnPublicNetwork = errors.New("staking disabled on public network")
+	errNetworkUpgradesOverride       = errors.New("network upgrades can't be overridden on public network")
 	errAuthPasswordTooWeak           = errors.New("API auth password is not strong enough")
+	errPrincipalPasswordTooWeak      = errors.New("API principal password is not strong enough")
+	errIPCTLSIncomplete              = fmt.Errorf("--%s, --%s and --%s must be set together", IpcsTLSCertFileKey, IpcsTLSKeyFileKey, IpcsTLSClientCAFileKey)
+	errIPCTLSNoClientCA              = errors.New("IPC TLS client CA file has no certificates")
+	errEmptyDBEncryptionSecret       = errors.New("database encryption secret is empty")
+	errNoKeystoreVaultToken          = fmt.Errorf("--%s must be set when --%s is set", KeystoreVaultTokenFileKey, KeystoreVaultAddressKey)
 	errInvalidUptimeRequirement      = errors.New("uptime requirement must be in the range [0, 1]")
 	errMinValidatorStakeAboveMax     = errors.New("minimum validator stake can't be greater than maximum validator stake")
 	errInvalidDelegationFee          = errors.New("delegation fee must be in the range [0, 1,000,000]")
@@ -181,10 +192,27 @@ func getAPIAuthConfig(v *viper.Viper) (node.APIAuthConfig, error) {
 	if !password.SufficientlyStrong(config.APIAuthPassword, password.OK) {
 		return node.APIAuthConfig{}, errAuthPasswordTooWeak
 	}
+
+	if !v.IsSet(APIAuthPrincipalsFileKey) {
+		return config, nil
+	}
+	principalsFilePath := os.ExpandEnv(v.GetString(APIAuthPrincipalsFileKey))
+	principalsBytes, err := ioutil.ReadFile(filepath.Clean(principalsFilePath))
+	if err != nil {
+		return node.APIAuthConfig{}, fmt.Errorf("API auth principals file %q failed to be read: %w", principalsFilePath, err)
+	}
+	if err := json.Unmarshal(principalsBytes, &config.APIAuthPrincipals); err != nil {
+		return node.APIAuthConfig{}, fmt.Errorf("couldn't parse API auth principals file %q: %w", principalsFilePath, err)
+	}
+	for name, pw := range config.APIAuthPrincipals {
+		if !password.SufficientlyStrong(pw, password.OK) {
+			return node.APIAuthConfig{}, fmt.Errorf("%w: %q", errPrincipalPasswordTooWeak, name)
+		}
+	}
 	return config, nil
 }
 
-func getIPCConfig(v *viper.Viper) node.IPCConfig {
+func getIPCConfig(v *viper.Viper) (node.IPCConfig, error) {
 	config := node.IPCConfig{
 		IPCAPIEnabled: v.GetBool(IpcAPIEnabledKey),
 		IPCPath:       ipcs.DefaultBaseURL,
@@ -195,7 +223,66 @@ func getIPCConfig(v *viper.Viper) node.IPCConfig {
 	if v.IsSet(IpcsPathKey) {
 		config.IPCPath = os.ExpandEnv(v.GetString(IpcsPathKey))
 	}
-	return config
+
+	var err error
+	config.IPCTLSConfig, err = getIPCTLSConfig(v)
+	return config, err
+}
+
+// getIPCTLSConfig returns the mutual TLS config of the IPC sockets published
+// over TCP or gRPC. Returns nil if it isn't configured.
+func getIPCTLSConfig(v *viper.Viper) (*tls.Config, error) {
+	certPath := os.ExpandEnv(v.GetString(IpcsTLSCertFileKey))
+	keyPath := os.ExpandEnv(v.GetString(IpcsTLSKeyFileKey))
+	caPath := os.ExpandEnv(v.GetString(IpcsTLSClientCAFileKey))
+	switch {
+	case certPath == "" && keyPath == "" && caPath == "":
+		return nil, nil
+	case certPath == "" || keyPath == "" || caPath == "":
+		return nil, errIPCTLSIncomplete
+	}
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load IPC TLS certificate: %w", err)
+	}
+	caBytes, err := ioutil.ReadFile(caPath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read IPC TLS client CA file: %w", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caBytes) {
+		return nil, errIPCTLSNoClientCA
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// getKeystoreVaultConfig returns the config of the Vault server that wraps the
+// data keys of keystore users. Returns nil if it isn't configured.
+func getKeystoreVaultConfig(v *viper.Viper) (*keystore.VaultConfig, error) {
+	address := v.GetString(KeystoreVaultAddressKey)
+	if address == "" {
+		return nil, nil
+	}
+	tokenPath := os.ExpandEnv(v.GetString(KeystoreVaultTokenFileKey))
+	if tokenPath == "" {
+		return nil, errNoKeystoreVaultToken
+	}
+	token, err := ioutil.ReadFile(filepath.Clean(tokenPath))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read keystore Vault token file: %w", err)
+	}
+	return &keystore.VaultConfig{
+		Address:   address,
+		Token:     strings.TrimSpace(string(token)),
+		MountPath: v.GetString(KeystoreVaultMountPathKey),
+		KeyName:   v.GetString(KeystoreVaultKeyNameKey),
+	}, nil
 }
 
 func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
@@ -251,6 +338,9 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		HTTPSCert:         httpsCert,
 		APIAllowedOrigins: v.GetStringSlice(HTTPAllowedOrigins),
 
+		GRPCAPIEnabled: v.GetBool(GRPCAPIEnabledKey),
+		GRPCPort:       uint16(v.GetUint(GRPCPortKey)),
+
 		ShutdownTimeout: v.GetDuration(HTTPShutdownTimeoutKey),
 		ShutdownWait:    v.GetDuration(HTTPShutdownWaitKey),
 	}
@@ -259,7 +349,50 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 	if err != nil {
 		return node.HTTPConfig{}, err
 	}
-	config.IPCConfig = getIPCConfig(v)
+	config.IPCConfig, err = getIPCConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	config.KeystoreVaultConfig, err = getKeystoreVaultConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	config.APIRateLimitConfig, err = getAPIRateLimitConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	return config, nil
+}
+
+func getAPIRateLimitConfig(v *viper.Viper) (ratelimit.Config, error) {
+	var (
+		configBytes []byte
+		err         error
+	)
+	switch {
+	case v.IsSet(APIRateLimitConfigContentKey):
+		rawContent := v.GetString(APIRateLimitConfigContentKey)
+		configBytes, err = base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return ratelimit.Config{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(APIRateLimitConfigFileKey):
+		path := os.ExpandEnv(v.GetString(APIRateLimitConfigFileKey))
+		configBytes, err = ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return ratelimit.Config{}, err
+		}
+	default:
+		return ratelimit.Config{}, nil
+	}
+
+	config := ratelimit.Config{}
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return ratelimit.Config{}, fmt.Errorf("couldn't parse API rate limit config: %w", err)
+	}
+	if err := config.Verify(); err != nil {
+		return ratelimit.Config{}, fmt.Errorf("invalid API rate limit config: %w", err)
+	}
 	return config, nil
 }
 
@@ -694,6 +827,59 @@ func getGenesisData(v *viper.Viper, networkID uint32) ([]byte, ids.ID, error) {
 	return genesis.FromConfig(config)
 }
 
+// getCustomGenesisConfig returns the genesis config provided by flag, or nil if
+// there is none
+func getCustomGenesisConfig(v *viper.Viper) (*genesis.Config, error) {
+	switch {
+	case v.IsSet(GenesisConfigContentKey):
+		return genesis.GetConfigContent(v.GetString(GenesisConfigContentKey))
+	case v.IsSet(GenesisConfigFileKey):
+		return genesis.GetConfigFile(os.ExpandEnv(v.GetString(GenesisConfigFileKey)))
+	default:
+		return nil, nil
+	}
+}
+
+// getNetworkUpgrades returns the upgrade times of the network. The times
+// compiled in for [networkID] are overridden by those of the genesis, which
+// are overridden by those of the network upgrade file.
+func getNetworkUpgrades(v *viper.Viper, networkID uint32) (version.NetworkUpgrades, error) {
+	upgrades := version.GetNetworkUpgrades(networkID)
+
+	overrides := [][]byte(nil)
+	genesisConfig, err := getCustomGenesisConfig(v)
+	if err != nil {
+		return upgrades, err
+	}
+	if genesisConfig != nil && len(genesisConfig.NetworkUpgrades) > 0 {
+		overrides = append(overrides, genesisConfig.NetworkUpgrades)
+	}
+	if v.IsSet(NetworkUpgradeConfigFileKey) {
+		upgradesFileName := os.ExpandEnv(v.GetString(NetworkUpgradeConfigFileKey))
+		upgradesBytes, err := ioutil.ReadFile(upgradesFileName)
+		if err != nil {
+			return upgrades, fmt.Errorf("unable to read network upgrade file %s: %w", upgradesFileName, err)
+		}
+		overrides = append(overrides, upgradesBytes)
+	}
+
+	if len(overrides) > 0 {
+		switch networkID {
+		case constants.MainnetID, constants.FujiID:
+			return upgrades, fmt.Errorf("%w: %s", errNetworkUpgradesOverride, constants.NetworkName(networkID))
+		}
+	}
+	for _, override := range overrides {
+		if err := upgrades.Override(override); err != nil {
+			return upgrades, fmt.Errorf("couldn't parse network upgrades: %w", err)
+		}
+	}
+	if err := upgrades.Verify(); err != nil {
+		return upgrades, fmt.Errorf("invalid network upgrades: %w", err)
+	}
+	return upgrades, nil
+}
+
 func getWhitelistedSubnets(v *viper.Viper) (ids.Set, error) {
 	whitelistedSubnetIDs := ids.Set{}
 	for _, subnet := range strings.Split(v.GetString(WhitelistedSubnetsKey), ",") {
@@ -731,16 +917,41 @@ func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, e
 		}
 	}
 
+	var encryptionSecret []byte
+	if v.IsSet(DBEncryptionPassphraseKey) {
+		encryptionSecret = []byte(v.GetString(DBEncryptionPassphraseKey))
+	} else if v.IsSet(DBEncryptionKeyFileKey) {
+		path := os.ExpandEnv(v.GetString(DBEncryptionKeyFileKey))
+		encryptionSecret, err = ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return node.DatabaseConfig{}, fmt.Errorf("couldn't read database encryption key file: %w", err)
+		}
+	}
+	if encryptionSecret != nil && len(encryptionSecret) == 0 {
+		return node.DatabaseConfig{}, errEmptyDBEncryptionSecret
+	}
+
 	return node.DatabaseConfig{
 		Name: v.GetString(DBTypeKey),
 		Path: filepath.Join(
 			os.ExpandEnv(v.GetString(DBPathKey)),
 			constants.NetworkName(networkID),
 		),
-		Config: configBytes,
+		Config:           configBytes,
+		EncryptionSecret: encryptionSecret,
 	}, nil
 }
 
+// GetDatabaseConfig returns the config of the database of the network
+// specified by [v]
+func GetDatabaseConfig(v *viper.Viper) (node.DatabaseConfig, error) {
+	networkID, err := constants.NetworkID(v.GetString(NetworkNameKey))
+	if err != nil {
+		return node.DatabaseConfig{}, err
+	}
+	return getDatabaseConfig(v, networkID)
+}
+
 func getVMAliases(v *viper.Viper) (map[ids.ID][]string, error) {
 	var fileBytes []byte
 	if v.IsSet(VMAliasesContentKey) {
@@ -896,21 +1107,31 @@ func getSubnetConfigsFromFlags(v *viper.Viper, subnetIDs []ids.ID) (map[ids.ID]c
 		return nil, fmt.Errorf("unable to decode base64 content: %w", err)
 	}
 
-	// Note: no default values are loaded here. All Subnet parameters must be
-	// explicitly defined.
-	subnetConfigs := make(map[ids.ID]chains.SubnetConfig, len(subnetIDs))
+	subnetConfigs := make(map[string]json.RawMessage, len(subnetIDs))
 	if err := json.Unmarshal(subnetConfigContent, &subnetConfigs); err != nil {
 		return nil, fmt.Errorf("could not unmarshal JSON: %w", err)
 	}
 
 	res := make(map[ids.ID]chains.SubnetConfig)
 	for _, subnetID := range subnetIDs {
-		if subnetConfig, ok := subnetConfigs[subnetID]; ok {
-			if err := subnetConfig.ConsensusParameters.Valid(); err != nil {
-				return nil, err
-			}
-			res[subnetID] = subnetConfig
+		subnetConfigBytes, ok := subnetConfigs[subnetID.String()]
+		if !ok {
+			continue
+		}
+
+		// Note: no default consensus parameters are loaded here. They must be
+		// explicitly defined. Proposer parameters were added after the
+		// consensus parameters, so any omitted field falls back to its default.
+		subnetConfig := chains.SubnetConfig{
+			ProposerParameters: proposer.DefaultParameters,
+		}
+		if err := json.Unmarshal(subnetConfigBytes, &subnetConfig); err != nil {
+			return nil, fmt.Errorf("could not unmarshal JSON: %w", err)
 		}
+		if err := subnetConfig.Valid(); err != nil {
+			return nil, err
+		}
+		res[subnetID] = subnetConfig
 	}
 	return res, nil
 }
@@ -966,7 +1187,7 @@ func readSubnetConfigs(subnetConfigPath string, subnetIDs []ids.ID, defaultSubne
 		if err := json.Unmarshal(file, &configData); err != nil {
 			return nil, err
 		}
-		if err := configData.ConsensusParameters.Valid(); err != nil {
+		if err := configData.Valid(); err != nil {
 			return nil, err
 		}
 		subnetConfigs[subnetID] = configData
@@ -979,6 +1200,7 @@ func defaultSubnetConfig(v *viper.Viper) chains.SubnetConfig {
 	return chains.SubnetConfig{
 		ConsensusParameters: getConsensusConfig(v),
 		ValidatorOnly:       false,
+		ProposerParameters:  proposer.DefaultParameters,
 	}
 }
 
@@ -1108,6 +1330,10 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	if err != nil {
 		return node.Config{}, fmt.Errorf("unable to load genesis file: %w", err)
 	}
+	nodeConfig.NetworkUpgrades, err = getNetworkUpgrades(v, nodeConfig.NetworkID)
+	if err != nil {
+		return node.Config{}, err
+	}
 
 	// Assertions
 	nodeConfig.EnableAssertions = v.GetBool(AssertionsEnabledKey)
@@ -12,16 +12,21 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lasthyphen/dijetsgo/api/keystore"
 	"github.com/lasthyphen/dijetsgo/chains"
 	"github.com/lasthyphen/dijetsgo/ids"
 	"github.com/lasthyphen/dijetsgo/snow/consensus/avalanche"
 	"github.com/lasthyphen/dijetsgo/snow/consensus/snowball"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+	"github.com/lasthyphen/dijetsgo/version"
+	"github.com/lasthyphen/dijetsgo/vms/proposervm/proposer"
 )
 
 func TestGetChainConfigsFromFiles(t *testing.T) {
@@ -432,6 +437,27 @@ func TestGetSubnetConfigsFromFile(t *testing.T) {
 			},
 			errMessage: "fails the condition that: alpha <= k",
 		},
+		"invalid proposer parameters": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.json",
+			givenJSON: `{"proposerParameters":{"maxWindows": 0} }`,
+			testF: func(assert *assert.Assertions, given map[ids.ID]chains.SubnetConfig) {
+				assert.Nil(given)
+			},
+			errMessage: "fails the condition that: 0 < maxWindows",
+		},
+		"correct proposer parameters": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.json",
+			givenJSON: `{"proposerParameters":{"maxWindows": 1} }`,
+			testF: func(assert *assert.Assertions, given map[ids.ID]chains.SubnetConfig) {
+				id, _ := ids.FromString("2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
+				config, ok := given[id]
+				assert.True(ok)
+
+				assert.Equal(1, config.ProposerParameters.MaxWindows)
+				// must still respect defaults
+				assert.Equal(proposer.WindowDuration, config.ProposerParameters.WindowDuration)
+			},
+		},
 		"correct config": {
 			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.json",
 			givenJSON: `{"validatorOnly": true, "consensusParameters":{"parents": 111, "alpha":16} }`,
@@ -572,6 +598,41 @@ func TestGetSubnetConfigsFromFlags(t *testing.T) {
 			},
 			errMessage: "",
 		},
+		"partial proposer parameters": {
+			cfgsMap: func() map[ids.ID]chains.SubnetConfig {
+				res := make(map[ids.ID]chains.SubnetConfig)
+				id, _ := ids.FromString("2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
+				res[id] = chains.SubnetConfig{
+					ConsensusParameters: avalanche.Parameters{
+						Parents:   111,
+						BatchSize: 1,
+						Parameters: snowball.Parameters{
+							Alpha:                 20,
+							K:                     30,
+							BetaVirtuous:          5,
+							BetaRogue:             6,
+							ConcurrentRepolls:     6,
+							OptimalProcessing:     2,
+							MaxOutstandingItems:   2,
+							MaxItemProcessingTime: 2,
+						},
+					},
+					ProposerParameters: proposer.Parameters{
+						MaxWindows: 2,
+					},
+				}
+				return res
+			}(),
+			testF: func(assert *assert.Assertions, given map[ids.ID]chains.SubnetConfig) {
+				id, _ := ids.FromString("2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
+				config, ok := given[id]
+				assert.True(ok)
+				assert.Equal(2, config.ProposerParameters.MaxWindows)
+				// must still respect defaults
+				assert.Equal(proposer.WindowDuration, config.ProposerParameters.WindowDuration)
+			},
+			errMessage: "",
+		},
 	}
 
 	for name, test := range tests {
@@ -603,6 +664,104 @@ func TestGetSubnetConfigsFromFlags(t *testing.T) {
 	}
 }
 
+func TestGetDatabaseConfigEncryption(t *testing.T) {
+	assert := assert.New(t)
+
+	v := setupViperFlags()
+	config, err := GetDatabaseConfig(v)
+	assert.NoError(err)
+	assert.Nil(config.EncryptionSecret)
+
+	keyPath := filepath.Join(t.TempDir(), "key")
+	assert.NoError(ioutil.WriteFile(keyPath, []byte{0, 1, 2}, 0o600))
+	v.Set(DBEncryptionKeyFileKey, keyPath)
+	config, err = GetDatabaseConfig(v)
+	assert.NoError(err)
+	assert.Equal([]byte{0, 1, 2}, config.EncryptionSecret)
+
+	v.Set(DBEncryptionPassphraseKey, "passphrase")
+	config, err = GetDatabaseConfig(v)
+	assert.NoError(err)
+	assert.Equal([]byte("passphrase"), config.EncryptionSecret)
+
+	v.Set(DBEncryptionPassphraseKey, "")
+	_, err = GetDatabaseConfig(v)
+	assert.ErrorIs(err, errEmptyDBEncryptionSecret)
+}
+
+func TestGetNetworkUpgrades(t *testing.T) {
+	assert := assert.New(t)
+
+	v := setupViperFlags()
+	upgrades, err := getNetworkUpgrades(v, constants.MainnetID)
+	assert.NoError(err)
+	assert.Equal(version.GetNetworkUpgrades(constants.MainnetID), upgrades)
+
+	dir := t.TempDir()
+	genesisPath := filepath.Join(dir, "genesis.json")
+	assert.NoError(ioutil.WriteFile(genesisPath, []byte(`{
+		"networkID": 9999,
+		"networkUpgrades": {
+			"apricotPhase4Time": "2030-01-01T00:00:00Z",
+			"apricotPhase5Time": "2030-01-01T00:00:00Z",
+			"apricotPhase6Time": "2030-01-01T00:00:00Z"
+		}
+	}`), 0o600))
+	v.Set(GenesisConfigFileKey, genesisPath)
+	upgrades, err = getNetworkUpgrades(v, 9999)
+	assert.NoError(err)
+	ap4Time := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	assert.True(ap4Time.Equal(upgrades.ApricotPhase4Time))
+	assert.Equal(version.GetApricotPhase3Time(9999), upgrades.ApricotPhase3Time)
+
+	// The network upgrade file overrides the genesis
+	upgradesPath := filepath.Join(dir, "upgrades.json")
+	assert.NoError(ioutil.WriteFile(upgradesPath, []byte(`{"apricotPhase5Time": "2031-01-01T00:00:00Z", "apricotPhase6Time": "2031-01-01T00:00:00Z", "apricotPhase4MinPChainHeight": 10}`), 0o600))
+	v.Set(NetworkUpgradeConfigFileKey, upgradesPath)
+	upgrades, err = getNetworkUpgrades(v, 9999)
+	assert.NoError(err)
+	assert.True(ap4Time.Equal(upgrades.ApricotPhase4Time))
+	assert.Equal(2031, upgrades.ApricotPhase5Time.Year())
+	assert.Equal(uint64(10), upgrades.ApricotPhase4MinPChainHeight)
+
+	// Upgrades must be activated in order
+	assert.NoError(ioutil.WriteFile(upgradesPath, []byte(`{"apricotPhase5Time": "2021-01-01T00:00:00Z"}`), 0o600))
+	_, err = getNetworkUpgrades(v, 9999)
+	assert.Error(err)
+
+	assert.NoError(ioutil.WriteFile(upgradesPath, []byte(`{"apricotPhase9Time": "2031-01-01T00:00:00Z"}`), 0o600))
+	_, err = getNetworkUpgrades(v, 9999)
+	assert.Error(err)
+
+	v = setupViperFlags()
+	v.Set(NetworkUpgradeConfigFileKey, upgradesPath)
+	_, err = getNetworkUpgrades(v, constants.MainnetID)
+	assert.ErrorIs(err, errNetworkUpgradesOverride)
+}
+
+func TestGetKeystoreVaultConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	v := setupViperFlags()
+	config, err := getKeystoreVaultConfig(v)
+	assert.NoError(err)
+	assert.Nil(config)
+
+	v.Set(KeystoreVaultAddressKey, "http://127.0.0.1:8200")
+	_, err = getKeystoreVaultConfig(v)
+	assert.ErrorIs(err, errNoKeystoreVaultToken)
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	assert.NoError(ioutil.WriteFile(tokenPath, []byte("s.token\n"), 0o600))
+	v.Set(KeystoreVaultTokenFileKey, tokenPath)
+	config, err = getKeystoreVaultConfig(v)
+	assert.NoError(err)
+	assert.Equal("http://127.0.0.1:8200", config.Address)
+	assert.Equal("s.token", config.Token)
+	assert.Equal(keystore.DefaultVaultMountPath, config.MountPath)
+	assert.Equal("dijetsgo-keystore", config.KeyName)
+}
+
 // setups config json file and writes content
 func setupConfigJSON(t *testing.T, rootPath string, value string) string {
 	configFilePath := filepath.Join(rootPath, "config.json")
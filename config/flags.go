@@ -13,6 +13,7 @@ import (
 
 	"github.com/kardianos/osext"
 
+	"github.com/lasthyphen/dijetsgo/api/keystore"
 	"github.com/lasthyphen/dijetsgo/database/leveldb"
 	"github.com/lasthyphen/dijetsgo/database/memdb"
 	"github.com/lasthyphen/dijetsgo/database/rocksdb"
@@ -84,6 +85,7 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.String(GenesisConfigFileKey, "", fmt.Sprintf("Specifies a genesis config file (ignored when running standard networks or if %s is specified)",
 		GenesisConfigContentKey))
 	fs.String(GenesisConfigContentKey, "", "Specifies base64 encoded genesis content")
+	fs.String(NetworkUpgradeConfigFileKey, "", "Specifies a JSON file of network upgrade times that override those of the genesis (ignored when running mainnet or fuji)")
 
 	// Network ID
 	fs.String(NetworkNameKey, defaultNetworkName, "Network ID this node will connect to")
@@ -99,6 +101,8 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.String(DBPathKey, defaultDBDir, "Path to database directory")
 	fs.String(DBConfigFileKey, "", fmt.Sprintf("Path to database config file. Ignored if %s is specified", DBConfigContentKey))
 	fs.String(DBConfigContentKey, "", "Specifies base64 encoded database config content")
+	fs.String(DBEncryptionKeyFileKey, "", fmt.Sprintf("If set, the keys and values of the database are encrypted with a key derived from the content of this file. Ignored if %s is specified", DBEncryptionPassphraseKey))
+	fs.String(DBEncryptionPassphraseKey, "", "If set, the keys and values of the database are encrypted with a key derived from this passphrase")
 
 	// Logging
 	fs.String(LogsDirKey, "", "Logging directory for Avalanche")
@@ -193,11 +197,16 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.String(HTTPAllowedOrigins, "https://*.dijets.co.uk https://*.dijets.io http://127.0.0.1 http://*.dijets.io", "Origins to allow on the HTTP port. Defaults to * which allows all origins. Example: https://*.djtx.network https://*.djtx-test.network")
 	fs.Duration(HTTPShutdownWaitKey, 0, "Duration to wait after receiving SIGTERM or SIGINT before initiating shutdown. The /health endpoint will return unhealthy during this duration")
 	fs.Duration(HTTPShutdownTimeoutKey, 10*time.Second, "Maximum duration to wait for existing connections to complete during node shutdown")
+	fs.Bool(GRPCAPIEnabledKey, false, fmt.Sprintf("If true, the info, health, index and platform APIs are also served over gRPC on %s:%s. TLS and authorization are shared with the HTTP server", HTTPHostKey, GRPCPortKey))
+	fs.Uint(GRPCPortKey, 9652, "Port of the gRPC server")
 	fs.Bool(APIAuthRequiredKey, false, "Require authorization token to call HTTP APIs")
 	fs.String(APIAuthPasswordFileKey, "",
 		fmt.Sprintf("Password file used to initially create/validate API authorization tokens. Ignored if %s is specified. Leading and trailing whitespace is removed from the password. Can be changed via API call",
 			APIAuthPasswordKey))
 	fs.String(APIAuthPasswordKey, "", "Specifies password for API authorization tokens")
+	fs.String(APIAuthPrincipalsFileKey, "", "Specifies a JSON file that maps the names of additional API principals to their passwords")
+	fs.String(APIRateLimitConfigFileKey, "", fmt.Sprintf("Specifies a JSON file with the rate limits of API calls. Ignored if %s is specified", APIRateLimitConfigContentKey))
+	fs.String(APIRateLimitConfigContentKey, "", "Specifies base64 encoded rate limits of API calls")
 
 	// Enable/Disable APIs
 	fs.Bool(AdminAPIEnabledKey, false, "If true, this node exposes the Admin API")
@@ -207,6 +216,12 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Bool(HealthAPIEnabledKey, true, "If true, this node exposes the Health API")
 	fs.Bool(IpcAPIEnabledKey, false, "If true, IPCs can be opened")
 
+	// Keystore
+	fs.String(KeystoreVaultAddressKey, "", "Address of a Vault compatible server whose transit engine wraps the data keys of new keystore users. If empty, their data is encrypted with a key derived from their password")
+	fs.String(KeystoreVaultTokenFileKey, "", "File containing the token used to authenticate to the keystore's Vault server")
+	fs.String(KeystoreVaultMountPathKey, keystore.DefaultVaultMountPath, "Path the transit engine of the keystore's Vault server is mounted at")
+	fs.String(KeystoreVaultKeyNameKey, "dijetsgo-keystore", "Name of the transit key that wraps the data keys of keystore users")
+
 	// Health Checks
 	fs.Duration(HealthCheckFreqKey, 30*time.Second, "Time between health checks")
 	fs.Duration(HealthCheckAveragerHalflifeKey, 10*time.Second, "Halflife of averager when calculating a running average in a health check")
@@ -280,6 +295,9 @@ func addNodeFlags(fs *flag.FlagSet) {
 	// IPC
 	fs.String(IpcsChainIDsKey, "", "Comma separated list of chain ids to add to the IPC engine. Example: 11111111111111111111111111111111LpoYY,4R5p2RXDGLqaifZE4hHWH9owe34pfoBULn1DrQTWivjg8o4aH")
 	fs.String(IpcsPathKey, "", "The directory (Unix) or named pipe name prefix (Windows) for IPC sockets")
+	fs.String(IpcsTLSCertFileKey, "", "TLS certificate used by IPC sockets published over TCP or gRPC")
+	fs.String(IpcsTLSKeyFileKey, "", "TLS private key used by IPC sockets published over TCP or gRPC")
+	fs.String(IpcsTLSClientCAFileKey, "", "PEM file of the CAs that sign the certificates IPC clients must present to connect over TCP or gRPC")
 
 	// Indexer
 	fs.Bool(ResetProposerVMHeightIndexKey, false, "if true, proposervm height index is wiped on startup")
@@ -11,6 +11,7 @@ const (
 	VersionKey                                  = "version"
 	GenesisConfigFileKey                        = "genesis"
 	GenesisConfigContentKey                     = "genesis-content"
+	NetworkUpgradeConfigFileKey                 = "network-upgrade-file"
 	NetworkNameKey                              = "network-id"
 	TxFeeKey                                    = "tx-fee"
 	CreateAssetTxFeeKey                         = "create-asset-tx-fee"
@@ -33,6 +34,8 @@ const (
 	DBPathKey                                   = "db-dir"
 	DBConfigFileKey                             = "db-config-file"
 	DBConfigContentKey                          = "db-config-file-content"
+	DBEncryptionKeyFileKey                      = "db-encryption-key-file"
+	DBEncryptionPassphraseKey                   = "db-encryption-passphrase"
 	PublicIPKey                                 = "public-ip"
 	DynamicUpdateDurationKey                    = "dynamic-update-duration"
 	DynamicPublicIPResolverKey                  = "dynamic-public-ip"
@@ -51,9 +54,14 @@ const (
 	HTTPAllowedOrigins                          = "http-allowed-origins"
 	HTTPShutdownTimeoutKey                      = "http-shutdown-timeout"
 	HTTPShutdownWaitKey                         = "http-shutdown-wait"
+	GRPCAPIEnabledKey                           = "grpc-api-enabled"
+	GRPCPortKey                                 = "grpc-port"
 	APIAuthRequiredKey                          = "api-auth-required"
 	APIAuthPasswordKey                          = "api-auth-password"
 	APIAuthPasswordFileKey                      = "api-auth-password-file"
+	APIAuthPrincipalsFileKey                    = "api-auth-principals-file"
+	APIRateLimitConfigFileKey                   = "api-rate-limit-config-file"
+	APIRateLimitConfigContentKey                = "api-rate-limit-config-file-content"
 	BootstrapIPsKey                             = "bootstrap-ips"
 	BootstrapIDsKey                             = "bootstrap-ids"
 	StakingPortKey                              = "staking-port"
@@ -113,11 +121,18 @@ const (
 	AdminAPIEnabledKey                          = "api-admin-enabled"
 	InfoAPIEnabledKey                           = "api-info-enabled"
 	KeystoreAPIEnabledKey                       = "api-keystore-enabled"
+	KeystoreVaultAddressKey                     = "keystore-vault-address"
+	KeystoreVaultTokenFileKey                   = "keystore-vault-token-file"
+	KeystoreVaultMountPathKey                   = "keystore-vault-mount-path"
+	KeystoreVaultKeyNameKey                     = "keystore-vault-key-name"
 	MetricsAPIEnabledKey                        = "api-metrics-enabled"
 	HealthAPIEnabledKey                         = "api-health-enabled"
 	IpcAPIEnabledKey                            = "api-ipcs-enabled"
 	IpcsChainIDsKey                             = "ipcs-chain-ids"
 	IpcsPathKey                                 = "ipcs-path"
+	IpcsTLSCertFileKey                          = "ipcs-tls-cert-file"
+	IpcsTLSKeyFileKey                           = "ipcs-tls-key-file"
+	IpcsTLSClientCAFileKey                      = "ipcs-tls-client-ca-file"
 	MeterVMsEnabledKey                          = "meter-vms-enabled"
 	ConsensusGossipFrequencyKey                 = "consensus-gossip-frequency"
 	ConsensusGossipAcceptedFrontierSizeKey      = "consensus-accepted-frontier-gossip-size"
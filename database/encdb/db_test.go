@@ -4,10 +4,14 @@
 package encdb
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/lasthyphen/dijetsgo/database"
 	"github.com/lasthyphen/dijetsgo/database/memdb"
+	"github.com/lasthyphen/dijetsgo/utils"
 )
 
 func TestInterface(t *testing.T) {
@@ -37,3 +41,187 @@ func BenchmarkInterface(b *testing.B) {
 		}
 	}
 }
+
+func TestRawInterface(t *testing.T) {
+	pw := "lol totally a secure password" // #nosec G101
+	for _, test := range database.Tests {
+		plaintextDB := memdb.New()
+		rawDB, err := NewRaw([]byte(pw), plaintextDB)
+		if err != nil {
+			t.Fatal(err)
+		}
+		db, err := New([]byte(pw), rawDB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, db)
+	}
+}
+
+func TestRawDecryptsValues(t *testing.T) {
+	assert := assert.New(t)
+
+	pw := []byte("lol totally a secure password") // #nosec G101
+	plaintextDB := memdb.New()
+	rawDB, err := NewRaw(pw, plaintextDB)
+	assert.NoError(err)
+	db, err := New(pw, rawDB)
+	assert.NoError(err)
+
+	assert.NoError(db.Put([]byte("hello"), []byte("world")))
+	value, err := plaintextDB.Get([]byte("hello"))
+	assert.NoError(err)
+	assert.Equal([]byte("world"), value)
+
+	// Values that aren't encrypted with the password are rejected
+	assert.Error(rawDB.Put([]byte("hello"), []byte("world")))
+
+	otherDB, err := New([]byte("another password"), rawDB)
+	assert.NoError(err)
+	_, err = otherDB.Get([]byte("hello"))
+	assert.Error(err)
+}
+
+func TestFullInterface(t *testing.T) {
+	secret := []byte("lol totally a secure secret") // #nosec G101
+	for _, test := range database.Tests {
+		unencryptedDB := memdb.New()
+		db, err := NewFull(secret, unencryptedDB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, db)
+	}
+}
+
+func TestFullEncryptsKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	secret := []byte("lol totally a secure secret") // #nosec G101
+	baseDB := memdb.New()
+	db, err := NewFull(secret, baseDB)
+	assert.NoError(err)
+
+	prefix := bytes.Repeat([]byte{'p'}, 32)
+	keys := [][]byte{
+		append(utils.CopyBytes(prefix), "hello"...),
+		append(utils.CopyBytes(prefix), "hi"...),
+		[]byte("other"),
+	}
+	for _, key := range keys {
+		assert.NoError(db.Put(key, []byte("value")))
+	}
+
+	it := baseDB.NewIterator()
+	for it.Next() {
+		assert.False(bytes.Contains(it.Key(), []byte("hello")))
+		assert.False(bytes.Contains(it.Key(), []byte("other")))
+		assert.False(bytes.Contains(it.Value(), []byte("value")))
+	}
+	it.Release()
+
+	// Keys that share a prefix share the prefix of their encryptions
+	encKey0 := db.encryptKey(keys[0])
+	encKey1 := db.encryptKey(keys[1])
+	assert.Equal(encKey0[:1+keyCodeLen*len(prefix)], encKey1[:1+keyCodeLen*len(prefix)])
+
+	it = db.NewIteratorWithPrefix(prefix[:20])
+	var iterated [][]byte
+	for it.Next() {
+		iterated = append(iterated, it.Key())
+	}
+	assert.NoError(it.Error())
+	it.Release()
+	assert.Equal(keys[:2], iterated)
+
+	// Values can't be moved to other keys
+	encValue, err := baseDB.Get(encKey0)
+	assert.NoError(err)
+	assert.NoError(baseDB.Put(encKey1, encValue))
+	_, err = db.Get(keys[1])
+	assert.Error(err)
+}
+
+func TestFullPreservesOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	secret := []byte("lol totally a secure secret") // #nosec G101
+	baseDB := memdb.New()
+	db, err := NewFull(secret, baseDB)
+	assert.NoError(err)
+
+	expectedDB := memdb.New()
+	for i := 0; i < 256; i++ {
+		key := []byte{byte(i), byte(i * 7), byte(i % 5)}
+		for _, key := range [][]byte{key, key[:1], key[:2]} {
+			assert.NoError(db.Put(key, []byte{byte(i)}))
+			assert.NoError(expectedDB.Put(key, []byte{byte(i)}))
+		}
+	}
+
+	// The underlying database holds the entries in the order of their keys
+	it := baseDB.NewIteratorWithPrefix(dataPrefix)
+	var prevKey []byte
+	for it.Next() {
+		key, err := db.decryptKey(it.Key())
+		assert.NoError(err)
+		assert.Less(bytes.Compare(prevKey, key), 0)
+		prevKey = key
+	}
+	assert.NoError(it.Error())
+	it.Release()
+
+	for _, bounds := range [][2][]byte{
+		{nil, nil},
+		{{100}, nil},
+		{{100, 200}, {100}},
+		{nil, {42, 38}},
+		{{255, 0}, {255}},
+	} {
+		start, prefix := bounds[0], bounds[1]
+		it := db.NewIteratorWithStartAndPrefix(start, prefix)
+		expectedIt := expectedDB.NewIteratorWithStartAndPrefix(start, prefix)
+		for expectedIt.Next() {
+			assert.True(it.Next())
+			assert.Equal(expectedIt.Key(), it.Key())
+			assert.Equal(expectedIt.Value(), it.Value())
+		}
+		assert.False(it.Next())
+		assert.NoError(it.Error())
+		it.Release()
+		expectedIt.Release()
+	}
+}
+
+func TestFullSecret(t *testing.T) {
+	assert := assert.New(t)
+
+	secret := []byte("lol totally a secure secret") // #nosec G101
+	baseDB := memdb.New()
+	db, err := NewFull(secret, baseDB)
+	assert.NoError(err)
+	assert.NoError(db.Put([]byte("key"), []byte("value")))
+
+	encrypted, err := IsFullyEncrypted(baseDB)
+	assert.NoError(err)
+	assert.True(encrypted)
+
+	reopenedDB, err := NewFull(secret, baseDB)
+	assert.NoError(err)
+	value, err := reopenedDB.Get([]byte("key"))
+	assert.NoError(err)
+	assert.Equal([]byte("value"), value)
+
+	_, err = NewFull([]byte("wrong secret"), baseDB)
+	assert.ErrorIs(err, ErrWrongSecret)
+
+	unencryptedDB := memdb.New()
+	assert.NoError(unencryptedDB.Put([]byte("key"), []byte("value")))
+	_, err = NewFull(secret, unencryptedDB)
+	assert.ErrorIs(err, ErrNotEncrypted)
+	encrypted, err = IsFullyEncrypted(unencryptedDB)
+	assert.NoError(err)
+	assert.False(encrypted)
+}
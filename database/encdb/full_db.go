@@ -0,0 +1,497 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/lasthyphen/dijetsgo/database"
+	"github.com/lasthyphen/dijetsgo/database/nodb"
+	"github.com/lasthyphen/dijetsgo/utils"
+	"github.com/lasthyphen/dijetsgo/utils/wrappers"
+)
+
+const (
+	saltLen = 32
+
+	// scrypt parameters used to derive the database key from the secret
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+
+	// Length of the encoding of each byte of a key
+	keyCodeLen = 2
+	// Number of steps of the encoding of a byte. Each step is between 1 and
+	// 256, so the largest code is 256*256-1, which fits in [keyCodeLen] bytes.
+	keyStreamLen = 256
+)
+
+var (
+	// Encrypted entries are stored under [dataPrefix] and the parameters of
+	// the encryption under [paramsKey], so they never collide.
+	dataPrefix = []byte{0}
+	paramsKey  = []byte{1, 'p', 'a', 'r', 'a', 'm', 's'}
+
+	keyLabel   = []byte("keys")
+	stateLabel = []byte("key states")
+	valueLabel = []byte("values")
+	checkLabel = []byte("check")
+
+	ErrNotEncrypted = errors.New("database isn't encrypted")
+	ErrWrongSecret  = errors.New("wrong database encryption secret")
+
+	errInvalidParams = errors.New("invalid database encryption parameters")
+
+	_ database.Database = &FullDatabase{}
+	_ database.Batch    = &fullBatch{}
+	_ database.Iterator = &fullIterator{}
+)
+
+// FullDatabase encrypts both the keys and the values that are provided.
+//
+// Keys are encrypted deterministically with an order-preserving encoding, so
+// that the encryptions of the keys are ordered like the keys and keys sharing
+// a prefix share the prefix of their encryptions. This allows iterators to
+// stream the entries of the underlying database. The encryptions leak the
+// order of the keys, the length of their common prefixes and roughly half of
+// the bits of each byte, but not the keys themselves.
+//
+// Values are encrypted with XChaCha20-Poly1305 and bound to their key, so
+// values can't be swapped between keys.
+type FullDatabase struct {
+	lock        sync.RWMutex
+	keyCipher   cipher.Block
+	stateCipher cipher.Block
+	valueCipher cipher.AEAD
+	db          database.Database
+}
+
+// NewFull returns a new database that encrypts the keys and values written to
+// [db] with a key derived from [secret].
+//
+// If [db] is empty, the parameters of the encryption are written to it.
+// Returns ErrNotEncrypted if [db] contains unencrypted entries and
+// ErrWrongSecret if [db] was encrypted with a different secret.
+func NewFull(secret []byte, db database.Database) (*FullDatabase, error) {
+	salt, check, err := getParams(db)
+	if err == ErrNotEncrypted {
+		empty, err := isEmpty(db)
+		if err != nil {
+			return nil, err
+		}
+		if !empty {
+			return nil, ErrNotEncrypted
+		}
+		salt = make([]byte, saltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		check = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	masterKey, err := scrypt.Key(secret, salt, scryptN, scryptR, scryptP, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	expectedCheck := deriveKey(masterKey, checkLabel)
+	if check == nil {
+		p := wrappers.Packer{MaxSize: 2 * (wrappers.IntLen + sha256.Size)}
+		p.PackBytes(salt)
+		p.PackBytes(expectedCheck)
+		if p.Err != nil {
+			return nil, p.Err
+		}
+		if err := db.Put(paramsKey, p.Bytes); err != nil {
+			return nil, err
+		}
+	} else if !hmac.Equal(check, expectedCheck) {
+		return nil, ErrWrongSecret
+	}
+
+	keyCipher, err := aes.NewCipher(deriveKey(masterKey, keyLabel))
+	if err != nil {
+		return nil, err
+	}
+	stateCipher, err := aes.NewCipher(deriveKey(masterKey, stateLabel))
+	if err != nil {
+		return nil, err
+	}
+	valueCipher, err := chacha20poly1305.NewX(deriveKey(masterKey, valueLabel))
+	if err != nil {
+		return nil, err
+	}
+	return &FullDatabase{
+		keyCipher:   keyCipher,
+		stateCipher: stateCipher,
+		valueCipher: valueCipher,
+		db:          db,
+	}, nil
+}
+
+// IsFullyEncrypted returns true if [db] was encrypted by a FullDatabase
+func IsFullyEncrypted(db database.KeyValueReader) (bool, error) {
+	return db.Has(paramsKey)
+}
+
+// getParams returns the salt and the secret check of the encryption of [db].
+// Returns ErrNotEncrypted if [db] isn't encrypted.
+func getParams(db database.KeyValueReader) ([]byte, []byte, error) {
+	paramsBytes, err := db.Get(paramsKey)
+	if err == database.ErrNotFound {
+		return nil, nil, ErrNotEncrypted
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	p := wrappers.Packer{Bytes: paramsBytes}
+	salt := p.UnpackBytes()
+	check := p.UnpackBytes()
+	if p.Errored() || len(salt) != saltLen || len(check) != sha256.Size {
+		return nil, nil, errInvalidParams
+	}
+	return salt, check, nil
+}
+
+func isEmpty(db database.Iteratee) (bool, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	next := it.Next()
+	return !next, it.Error()
+}
+
+func deriveKey(masterKey, label []byte) []byte {
+	mac := hmac.New(sha256.New, masterKey)
+	_, _ = mac.Write(label)
+	return mac.Sum(nil)
+}
+
+func (db *FullDatabase) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return false, database.ErrClosed
+	}
+	return db.db.Has(db.encryptKey(key))
+}
+
+func (db *FullDatabase) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	encVal, err := db.db.Get(db.encryptKey(key))
+	if err != nil {
+		return nil, err
+	}
+	return db.decryptValue(key, encVal)
+}
+
+func (db *FullDatabase) Put(key, value []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+
+	encValue, err := db.encryptValue(key, value)
+	if err != nil {
+		return err
+	}
+	return db.db.Put(db.encryptKey(key), encValue)
+}
+
+func (db *FullDatabase) Delete(key []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return db.db.Delete(db.encryptKey(key))
+}
+
+func (db *FullDatabase) NewBatch() database.Batch {
+	return &fullBatch{
+		Batch: db.db.NewBatch(),
+		db:    db,
+	}
+}
+
+func (db *FullDatabase) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *FullDatabase) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *FullDatabase) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *FullDatabase) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &fullIterator{
+		Iterator: db.db.NewIteratorWithStartAndPrefix(db.encryptKey(start), db.encryptKey(prefix)),
+		db:       db,
+	}
+}
+
+func (db *FullDatabase) Stat(stat string) (string, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return "", database.ErrClosed
+	}
+	return db.db.Stat(stat)
+}
+
+func (db *FullDatabase) Compact(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	var encLimit []byte
+	if limit != nil {
+		encLimit = db.encryptKey(limit)
+	}
+	return db.db.Compact(db.encryptKey(start), encLimit)
+}
+
+func (db *FullDatabase) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	db.db = nil
+	return nil
+}
+
+func (db *FullDatabase) isClosed() bool {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return db.db == nil
+}
+
+// encryptKey returns [dataPrefix] followed by the encryption of [key]. Each
+// byte of [key] is encoded as 2 bytes by a strictly increasing function that
+// depends on the bytes before it, so that the encryptions are ordered like the
+// keys.
+func (db *FullDatabase) encryptKey(key []byte) []byte {
+	encKey := make([]byte, len(dataPrefix)+keyCodeLen*len(key))
+	copy(encKey, dataPrefix)
+	out := encKey[len(dataPrefix):]
+
+	state := make([]byte, aes.BlockSize)
+	stream := make([]byte, keyStreamLen)
+	for i, b := range key {
+		db.keyStream(state, stream)
+		code := -1
+		for _, step := range stream[:int(b)+1] {
+			code += int(step) + 1
+		}
+		binary.BigEndian.PutUint16(out[keyCodeLen*i:], uint16(code))
+		db.nextKeyState(state, b)
+	}
+	return encKey
+}
+
+func (db *FullDatabase) decryptKey(encKey []byte) ([]byte, error) {
+	if !bytes.HasPrefix(encKey, dataPrefix) {
+		return nil, errInvalidParams
+	}
+	encKey = encKey[len(dataPrefix):]
+	if len(encKey)%keyCodeLen != 0 {
+		return nil, errInvalidParams
+	}
+	key := make([]byte, len(encKey)/keyCodeLen)
+
+	state := make([]byte, aes.BlockSize)
+	stream := make([]byte, keyStreamLen)
+	for i := range key {
+		db.keyStream(state, stream)
+		code := int(binary.BigEndian.Uint16(encKey[keyCodeLen*i:]))
+		b, sum := 0, -1
+		for ; b < keyStreamLen; b++ {
+			sum += int(stream[b]) + 1
+			if sum >= code {
+				break
+			}
+		}
+		if sum != code {
+			return nil, errInvalidParams
+		}
+		key[i] = byte(b)
+		db.nextKeyState(state, key[i])
+	}
+	return key, nil
+}
+
+// keyStream fills [stream] with the pseudorandom steps of the encoding of the
+// next byte of a key, given the [state] of the bytes before it
+func (db *FullDatabase) keyStream(state, stream []byte) {
+	counter := make([]byte, aes.BlockSize)
+	for i := 0; i < len(stream); i += aes.BlockSize {
+		copy(counter, state)
+		counter[aes.BlockSize-1] ^= byte(i / aes.BlockSize)
+		db.keyCipher.Encrypt(stream[i:], counter)
+	}
+}
+
+// nextKeyState updates [state] to account for the next byte [b] of a key
+func (db *FullDatabase) nextKeyState(state []byte, b byte) {
+	state[0] ^= b
+	db.stateCipher.Encrypt(state, state)
+}
+
+// encryptValue returns the nonce followed by the ciphertext of [value]
+func (db *FullDatabase) encryptValue(key, value []byte) ([]byte, error) {
+	nonceSize := db.valueCipher.NonceSize()
+	encValue := make([]byte, nonceSize, nonceSize+len(value)+db.valueCipher.Overhead())
+	if _, err := rand.Read(encValue); err != nil {
+		return nil, err
+	}
+	return db.valueCipher.Seal(encValue, encValue, value, key), nil
+}
+
+func (db *FullDatabase) decryptValue(key, encValue []byte) ([]byte, error) {
+	nonceSize := db.valueCipher.NonceSize()
+	if len(encValue) < nonceSize {
+		return nil, errInvalidParams
+	}
+	return db.valueCipher.Open(nil, encValue[:nonceSize], encValue[nonceSize:], key)
+}
+
+type fullBatch struct {
+	database.Batch
+
+	db     *FullDatabase
+	writes []keyValue
+}
+
+func (b *fullBatch) Put(key, value []byte) error {
+	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(value), false})
+	encValue, err := b.db.encryptValue(key, value)
+	if err != nil {
+		return err
+	}
+	return b.Batch.Put(b.db.encryptKey(key), encValue)
+}
+
+func (b *fullBatch) Delete(key []byte) error {
+	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true})
+	return b.Batch.Delete(b.db.encryptKey(key))
+}
+
+func (b *fullBatch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	if b.db.db == nil {
+		return database.ErrClosed
+	}
+
+	return b.Batch.Write()
+}
+
+// Reset resets the batch for reuse.
+func (b *fullBatch) Reset() {
+	if cap(b.writes) > len(b.writes)*database.MaxExcessCapacityFactor {
+		b.writes = make([]keyValue, 0, cap(b.writes)/database.CapacityReductionFactor)
+	} else {
+		b.writes = b.writes[:0]
+	}
+	b.Batch.Reset()
+}
+
+// Replay replays the batch contents.
+func (b *fullBatch) Replay(w database.KeyValueWriterDeleter) error {
+	for _, keyvalue := range b.writes {
+		if keyvalue.delete {
+			if err := w.Delete(keyvalue.key); err != nil {
+				return err
+			}
+		} else if err := w.Put(keyvalue.key, keyvalue.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fullIterator struct {
+	database.Iterator
+	db *FullDatabase
+
+	val, key []byte
+	err      error
+}
+
+func (it *fullIterator) Next() bool {
+	// Short-circuit and set an error if the underlying database has been closed.
+	if it.db.isClosed() {
+		it.val = nil
+		it.key = nil
+		it.err = database.ErrClosed
+		return false
+	}
+
+	if !it.Iterator.Next() {
+		it.val = nil
+		it.key = nil
+		return false
+	}
+	key, err := it.db.decryptKey(it.Iterator.Key())
+	if err != nil {
+		it.err = err
+		return false
+	}
+	val, err := it.db.decryptValue(key, it.Iterator.Value())
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.key = key
+	it.val = val
+	return true
+}
+
+func (it *fullIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.Iterator.Error()
+}
+
+func (it *fullIterator) Key() []byte { return it.key }
+
+func (it *fullIterator) Value() []byte { return it.val }
@@ -0,0 +1,261 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"sync"
+
+	"github.com/lasthyphen/dijetsgo/database"
+	"github.com/lasthyphen/dijetsgo/database/nodb"
+	"github.com/lasthyphen/dijetsgo/utils"
+)
+
+var (
+	_ database.Database = &RawDatabase{}
+	_ database.Batch    = &rawBatch{}
+	_ database.Iterator = &rawIterator{}
+)
+
+// RawDatabase is the inverse of Database. It exposes the plaintext values of
+// the underlying database as values encrypted with a password, the way a
+// Database created with that password would store them. Values written to a
+// RawDatabase must be encrypted with the password, and are decrypted before
+// they are written to the underlying database.
+type RawDatabase struct {
+	lock sync.RWMutex
+	// Only used to encrypt and decrypt values
+	enc *Database
+	db  database.Database
+}
+
+// NewRaw returns a database that exposes the values of [db] as values
+// encrypted with [password]
+func NewRaw(password []byte, db database.Database) (*RawDatabase, error) {
+	enc, err := New(password, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RawDatabase{
+		enc: enc,
+		db:  db,
+	}, nil
+}
+
+func (db *RawDatabase) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return false, database.ErrClosed
+	}
+	return db.db.Has(key)
+}
+
+func (db *RawDatabase) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	value, err := db.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return db.enc.encrypt(value)
+}
+
+func (db *RawDatabase) Put(key, encValue []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	value, err := db.enc.decrypt(encValue)
+	if err != nil {
+		return err
+	}
+	return db.db.Put(key, value)
+}
+
+func (db *RawDatabase) Delete(key []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return db.db.Delete(key)
+}
+
+func (db *RawDatabase) NewBatch() database.Batch {
+	return &rawBatch{
+		Batch: db.db.NewBatch(),
+		db:    db,
+	}
+}
+
+func (db *RawDatabase) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *RawDatabase) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *RawDatabase) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *RawDatabase) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &rawIterator{
+		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       db,
+	}
+}
+
+func (db *RawDatabase) Stat(stat string) (string, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return "", database.ErrClosed
+	}
+	return db.db.Stat(stat)
+}
+
+func (db *RawDatabase) Compact(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return db.db.Compact(start, limit)
+}
+
+func (db *RawDatabase) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	db.db = nil
+	return nil
+}
+
+func (db *RawDatabase) isClosed() bool {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return db.db == nil
+}
+
+type rawBatch struct {
+	database.Batch
+
+	db     *RawDatabase
+	writes []keyValue
+}
+
+func (b *rawBatch) Put(key, encValue []byte) error {
+	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(encValue), false})
+	value, err := b.db.enc.decrypt(encValue)
+	if err != nil {
+		return err
+	}
+	return b.Batch.Put(key, value)
+}
+
+func (b *rawBatch) Delete(key []byte) error {
+	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true})
+	return b.Batch.Delete(key)
+}
+
+func (b *rawBatch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	if b.db.db == nil {
+		return database.ErrClosed
+	}
+
+	return b.Batch.Write()
+}
+
+// Reset resets the batch for reuse.
+func (b *rawBatch) Reset() {
+	if cap(b.writes) > len(b.writes)*database.MaxExcessCapacityFactor {
+		b.writes = make([]keyValue, 0, cap(b.writes)/database.CapacityReductionFactor)
+	} else {
+		b.writes = b.writes[:0]
+	}
+	b.Batch.Reset()
+}
+
+// Replay replays the batch contents.
+func (b *rawBatch) Replay(w database.KeyValueWriterDeleter) error {
+	for _, keyvalue := range b.writes {
+		if keyvalue.delete {
+			if err := w.Delete(keyvalue.key); err != nil {
+				return err
+			}
+		} else if err := w.Put(keyvalue.key, keyvalue.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type rawIterator struct {
+	database.Iterator
+	db *RawDatabase
+
+	val, key []byte
+	err      error
+}
+
+func (it *rawIterator) Next() bool {
+	// Short-circuit and set an error if the underlying database has been closed.
+	if it.db.isClosed() {
+		it.val = nil
+		it.key = nil
+		it.err = database.ErrClosed
+		return false
+	}
+
+	next := it.Iterator.Next()
+	if next {
+		encVal, err := it.db.enc.encrypt(it.Iterator.Value())
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.val = encVal
+		it.key = it.Iterator.Key()
+	} else {
+		it.val = nil
+		it.key = nil
+	}
+	return next
+}
+
+func (it *rawIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.Iterator.Error()
+}
+
+func (it *rawIterator) Key() []byte { return it.key }
+
+func (it *rawIterator) Value() []byte { return it.val }
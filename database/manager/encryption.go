@@ -0,0 +1,198 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/lasthyphen/dijetsgo/database"
+	"github.com/lasthyphen/dijetsgo/database/encdb"
+	"github.com/lasthyphen/dijetsgo/database/leveldb"
+	"github.com/lasthyphen/dijetsgo/database/rocksdb"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+	"github.com/lasthyphen/dijetsgo/utils/units"
+	"github.com/lasthyphen/dijetsgo/utils/wrappers"
+	"github.com/lasthyphen/dijetsgo/version"
+)
+
+const (
+	// Suffix of the directory an encrypted copy of a database is written to
+	encryptingSuffix = ".encrypting"
+	// Suffix of the directory the unencrypted database is moved to once it
+	// has been encrypted
+	UnencryptedSuffix = ".unencrypted"
+
+	// Size of the batches written while encrypting a database
+	encryptBatchSize = 4 * units.MiB
+)
+
+var errEncryptedDB = errors.New("database is encrypted but no encryption secret was provided")
+
+// NewEncrypted returns a database manager with each of the databases of [m]
+// wrapped with an encdb.FullDatabase that encrypts their keys and values with
+// a key derived from [secret].
+//
+// Returns encdb.ErrNotEncrypted if one of the databases contains unencrypted
+// entries. EncryptLevelDB or EncryptRocksDB encrypt an existing database.
+func NewEncrypted(m Manager, secret []byte) (Manager, error) {
+	dbs := &manager{databases: m.GetDatabases()}
+	return dbs.wrapManager(func(vdb *VersionedDatabase) (*VersionedDatabase, error) {
+		db, err := encdb.NewFull(secret, vdb.Database)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't open database %s: %w", vdb.Version, err)
+		}
+		return &VersionedDatabase{
+			Database: db,
+			Version:  vdb.Version,
+		}, nil
+	})
+}
+
+// VerifyNotEncrypted returns an error if one of the databases of [m] is
+// encrypted, so that it isn't mistaken for an empty database.
+func VerifyNotEncrypted(m Manager) error {
+	for _, vdb := range m.GetDatabases() {
+		encrypted, err := encdb.IsFullyEncrypted(vdb.Database)
+		if err != nil {
+			return err
+		}
+		if encrypted {
+			return fmt.Errorf("%w: %s", errEncryptedDB, vdb.Version)
+		}
+	}
+	return nil
+}
+
+// EncryptLevelDB encrypts each of the levelDBs at [dbDirPath] with a key
+// derived from [secret]. The unencrypted databases are kept in directories
+// with the [UnencryptedSuffix].
+func EncryptLevelDB(dbDirPath string, dbConfig []byte, log logging.Logger, secret []byte) error {
+	return encrypt(leveldb.New, dbDirPath, dbConfig, log, secret)
+}
+
+// EncryptRocksDB encrypts each of the rocksDBs at [dbDirPath] with a key
+// derived from [secret]. The unencrypted databases are kept in directories
+// with the [UnencryptedSuffix].
+func EncryptRocksDB(dbDirPath string, dbConfig []byte, log logging.Logger, secret []byte) error {
+	return encrypt(rocksdb.New, dbDirPath, dbConfig, log, secret)
+}
+
+func encrypt(
+	newDB func(string, []byte, logging.Logger) (database.Database, error),
+	dbDirPath string,
+	dbConfig []byte,
+	log logging.Logger,
+	secret []byte,
+) error {
+	parser := version.NewDefaultParser()
+	files, err := ioutil.ReadDir(dbDirPath)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+		// Only encrypt the directories of database versions
+		if _, err := parser.Parse(file.Name()); err != nil {
+			continue
+		}
+		if err := encryptDB(newDB, filepath.Join(dbDirPath, file.Name()), dbConfig, log, secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encryptDB writes an encrypted copy of the database at [path] and replaces
+// the database with it
+func encryptDB(
+	newDB func(string, []byte, logging.Logger) (database.Database, error),
+	path string,
+	dbConfig []byte,
+	log logging.Logger,
+	secret []byte,
+) error {
+	srcDB, err := newDB(path, dbConfig, log)
+	if err != nil {
+		return fmt.Errorf("couldn't open db at %s: %w", path, err)
+	}
+	encrypted, err := encdb.IsFullyEncrypted(srcDB)
+	if err != nil || encrypted {
+		errs := wrappers.Errs{Err: err}
+		errs.Add(srcDB.Close())
+		if errs.Err == nil {
+			log.Info("db at %s is already encrypted", path)
+		}
+		return errs.Err
+	}
+
+	encryptingPath := path + encryptingSuffix
+	unencryptedPath := path + UnencryptedSuffix
+	for _, p := range []string{encryptingPath, unencryptedPath} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			_ = srcDB.Close()
+			return fmt.Errorf("couldn't encrypt db at %s: %s already exists", path, p)
+		}
+	}
+
+	dstDB, err := newDB(encryptingPath, dbConfig, log)
+	if err != nil {
+		_ = srcDB.Close()
+		return fmt.Errorf("couldn't create db at %s: %w", encryptingPath, err)
+	}
+
+	log.Info("encrypting db at %s", path)
+	errs := wrappers.Errs{}
+	errs.Add(copyEncrypted(srcDB, dstDB, secret))
+	errs.Add(
+		srcDB.Close(),
+		dstDB.Close(),
+	)
+	if errs.Errored() {
+		return fmt.Errorf("couldn't encrypt db at %s: %w", path, errs.Err)
+	}
+
+	if err := os.Rename(path, unencryptedPath); err != nil {
+		return err
+	}
+	if err := os.Rename(encryptingPath, path); err != nil {
+		return err
+	}
+	log.Info("encrypted db at %s. The unencrypted db was moved to %s", path, unencryptedPath)
+	return nil
+}
+
+// copyEncrypted writes the entries of [srcDB] to [dstDB], encrypted with a
+// key derived from [secret]
+func copyEncrypted(srcDB, dstDB database.Database, secret []byte) error {
+	encDB, err := encdb.NewFull(secret, dstDB)
+	if err != nil {
+		return err
+	}
+	batch := encDB.NewBatch()
+
+	it := srcDB.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		if err := batch.Put(it.Key(), it.Value()); err != nil {
+			return err
+		}
+		if batch.Size() < encryptBatchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/database/encdb"
+	"github.com/lasthyphen/dijetsgo/database/leveldb"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+	"github.com/lasthyphen/dijetsgo/version"
+)
+
+func TestEncryptLevelDB(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	v1 := version.DefaultVersion1_0_0
+	secret := []byte("lol totally a secure secret") // #nosec G101
+
+	dbPath := filepath.Join(dir, v1.String())
+	db, err := leveldb.New(dbPath, nil, logging.NoLog{})
+	assert.NoError(err)
+	assert.NoError(db.Put([]byte("hello"), []byte("world")))
+	assert.NoError(db.Close())
+
+	assert.NoError(EncryptLevelDB(dir, nil, logging.NoLog{}, secret))
+	_, err = os.Stat(dbPath + UnencryptedSuffix)
+	assert.NoError(err)
+
+	// Encrypting again is a no-op
+	assert.NoError(EncryptLevelDB(dir, nil, logging.NoLog{}, secret))
+
+	manager, err := NewLevelDB(dir, nil, logging.NoLog{}, v1)
+	assert.NoError(err)
+	defer manager.Close()
+
+	assert.ErrorIs(VerifyNotEncrypted(manager), errEncryptedDB)
+
+	_, err = NewEncrypted(manager, []byte("wrong secret"))
+	assert.ErrorIs(err, encdb.ErrWrongSecret)
+
+	encryptedManager, err := NewEncrypted(manager, secret)
+	assert.NoError(err)
+	value, err := encryptedManager.Current().Database.Get([]byte("hello"))
+	assert.NoError(err)
+	assert.Equal([]byte("world"), value)
+
+	// The keys aren't stored in plaintext
+	has, err := manager.Current().Database.Has([]byte("hello"))
+	assert.NoError(err)
+	assert.False(has)
+}
+
+func TestNewEncryptedRejectsUnencryptedDB(t *testing.T) {
+	assert := assert.New(t)
+
+	v1 := version.DefaultVersion1_0_0
+	manager := NewMemDB(v1)
+	assert.NoError(manager.Current().Database.Put([]byte("hello"), []byte("world")))
+
+	assert.NoError(VerifyNotEncrypted(manager))
+	_, err := NewEncrypted(manager, []byte("secret"))
+	assert.ErrorIs(err, encdb.ErrNotEncrypted)
+
+	emptyManager := NewMemDB(v1)
+	encryptedManager, err := NewEncrypted(emptyManager, []byte("secret"))
+	assert.NoError(err)
+	assert.NoError(encryptedManager.Current().Database.Put([]byte("hello"), []byte("world")))
+	assert.ErrorIs(VerifyNotEncrypted(emptyManager), errEncryptedDB)
+}
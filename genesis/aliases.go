@@ -6,6 +6,7 @@ package genesis
 import (
 	"github.com/lasthyphen/dijetsgo/ids"
 	"github.com/lasthyphen/dijetsgo/utils/constants"
+	"github.com/lasthyphen/dijetsgo/vms/compliancefx"
 	"github.com/lasthyphen/dijetsgo/vms/nftfx"
 	"github.com/lasthyphen/dijetsgo/vms/platformvm"
 	"github.com/lasthyphen/dijetsgo/vms/propertyfx"
@@ -63,5 +64,6 @@ func GetVMAliases() map[ids.ID][]string {
 		secp256k1fx.ID:         {"secp256k1fx"},
 		nftfx.ID:               {"nftfx"},
 		propertyfx.ID:          {"propertyfx"},
+		compliancefx.ID:        {"compliancefx"},
 	}
 }
@@ -0,0 +1,231 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+	"github.com/lasthyphen/dijetsgo/utils/formatting"
+	"github.com/lasthyphen/dijetsgo/vms/platformvm"
+)
+
+var (
+	errUnpairedUnlock  = errors.New("unlock schedule must be made of amount and locktime pairs")
+	errNoXChainGenesis = errors.New("genesis doesn't create the X-Chain")
+)
+
+// Asset is an asset created by the X-Chain's genesis
+type Asset struct {
+	Alias string `json:"alias"`
+	ID    ids.ID `json:"assetID"`
+}
+
+// Chain is a blockchain that exists upon the network's creation
+type Chain struct {
+	Name string `json:"name"`
+	ID   ids.ID `json:"blockchainID"`
+	VMID ids.ID `json:"vmID"`
+}
+
+// BuildReply describes a genesis built from a Config
+type BuildReply struct {
+	// Config is the genesis config in the format accepted by the node
+	Config UnparsedConfig `json:"config"`
+	// Bytes is the genesis state of the Platform Chain
+	Bytes  []byte  `json:"-"`
+	Chains []Chain `json:"chains"`
+	Assets []Asset `json:"assets"`
+}
+
+// Build checks that [config] is a valid genesis for a custom network and
+// returns the IDs of the chains and assets it creates.
+func Build(config *Config) (*BuildReply, error) {
+	switch config.NetworkID {
+	case constants.MainnetID, constants.TestnetID, constants.LocalID:
+		return nil, fmt.Errorf(
+			"cannot override genesis config for standard network %s (%d)",
+			constants.NetworkName(config.NetworkID),
+			config.NetworkID,
+		)
+	}
+
+	if err := validateConfig(config.NetworkID, config); err != nil {
+		return nil, fmt.Errorf("genesis config validation failed: %w", err)
+	}
+
+	unparsedConfig, err := config.Unparse()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't format genesis config: %w", err)
+	}
+
+	genesisBytes, _, err := FromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	genesis := platformvm.Genesis{}
+	if _, err := platformvm.GenesisCodec.Unmarshal(genesisBytes, &genesis); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal genesis bytes due to: %w", err)
+	}
+	if err := genesis.Initialize(); err != nil {
+		return nil, err
+	}
+
+	reply := &BuildReply{
+		Config: unparsedConfig,
+		Bytes:  genesisBytes,
+		Chains: []Chain{{
+			Name: "P-Chain",
+			ID:   constants.PlatformChainID,
+			VMID: constants.PlatformVMID,
+		}},
+	}
+	var avmGenesisBytes []byte
+	for _, chain := range genesis.Chains {
+		uChain := chain.UnsignedTx.(*platformvm.UnsignedCreateChainTx)
+		reply.Chains = append(reply.Chains, Chain{
+			Name: uChain.ChainName,
+			ID:   chain.ID(),
+			VMID: uChain.VMID,
+		})
+		if uChain.VMID == constants.AVMID {
+			avmGenesisBytes = uChain.GenesisData
+		}
+	}
+	if avmGenesisBytes == nil {
+		return nil, errNoXChainGenesis
+	}
+
+	reply.Assets, err = genesisAssets(avmGenesisBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate asset IDs: %w", err)
+	}
+	return reply, nil
+}
+
+// ParseAllocationsCSV parses allocations from CSV records of the form:
+//
+//	ethAddr,djtxAddr,initialAmount[,unlockAmount,unlockLocktime]...
+//
+// A header record starting with "ethAddr" and lines starting with '#' are
+// ignored.
+func ParseAllocationsCSV(r io.Reader) ([]Allocation, error) {
+	records, err := readCSV(r, "ethAddr")
+	if err != nil {
+		return nil, err
+	}
+
+	allocations := make([]Allocation, len(records))
+	for i, record := range records {
+		if len(record) < 3 || (len(record)-3)%2 != 0 {
+			return nil, fmt.Errorf("allocation %d: %w", i, errUnpairedUnlock)
+		}
+		initialAmount, err := strconv.ParseUint(record[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("allocation %d: couldn't parse initial amount: %w", i, err)
+		}
+		unparsedAllocation := UnparsedAllocation{
+			ETHAddr:       record[0],
+			DJTXAddr:      record[1],
+			InitialAmount: initialAmount,
+		}
+		for j := 3; j < len(record); j += 2 {
+			amount, err := strconv.ParseUint(record[j], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("allocation %d: couldn't parse unlock amount: %w", i, err)
+			}
+			locktime, err := strconv.ParseUint(record[j+1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("allocation %d: couldn't parse unlock locktime: %w", i, err)
+			}
+			unparsedAllocation.UnlockSchedule = append(unparsedAllocation.UnlockSchedule, LockedAmount{
+				Amount:   amount,
+				Locktime: locktime,
+			})
+		}
+		allocations[i], err = unparsedAllocation.Parse()
+		if err != nil {
+			return nil, fmt.Errorf("allocation %d: %w", i, err)
+		}
+	}
+	return allocations, nil
+}
+
+// ParseStakersCSV parses initial stakers from CSV records of the form:
+//
+//	nodeID,rewardAddress,delegationFee
+//
+// A header record starting with "nodeID" and lines starting with '#' are
+// ignored.
+func ParseStakersCSV(r io.Reader) ([]Staker, error) {
+	records, err := readCSV(r, "nodeID")
+	if err != nil {
+		return nil, err
+	}
+
+	stakers := make([]Staker, len(records))
+	for i, record := range records {
+		if len(record) != 3 {
+			return nil, fmt.Errorf("staker %d: expected 3 fields but got %d", i, len(record))
+		}
+		delegationFee, err := strconv.ParseUint(record[2], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("staker %d: couldn't parse delegation fee: %w", i, err)
+		}
+		stakers[i], err = UnparsedStaker{
+			NodeID:        record[0],
+			RewardAddress: record[1],
+			DelegationFee: uint32(delegationFee),
+		}.Parse()
+		if err != nil {
+			return nil, fmt.Errorf("staker %d: %w", i, err)
+		}
+	}
+	return stakers, nil
+}
+
+// ParseAddresses parses comma separated X-Chain addresses
+func ParseAddresses(addrsStr string) ([]ids.ShortID, error) {
+	addrs := []ids.ShortID(nil)
+	for _, addrStr := range strings.Split(addrsStr, ",") {
+		addrStr = strings.TrimSpace(addrStr)
+		if addrStr == "" {
+			continue
+		}
+		_, _, addrBytes, err := formatting.ParseAddress(addrStr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+		}
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// readCSV returns the records of [r], skipping a header record that starts
+// with [header]
+func readCSV(r io.Reader, header string) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 0 && len(records[0]) > 0 && records[0][0] == header {
+		records = records[1:]
+	}
+	return records, nil
+}
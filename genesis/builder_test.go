@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+)
+
+const (
+	testAllocationsCSV = `ethAddr,djtxAddr,initialAmount,unlockAmount,unlockLocktime
+# staked by the initial stakers
+0xb3d82b1367d362de99ab59a658165aff520cbd4d,X-local1g65uqn6t77p656w64023nh8nd9updzmxyymev2,0,10000000000000000,1633824000
+0xb3d82b1367d362de99ab59a658165aff520cbd4d,X-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u,300000000000000000,20000000000000000,0,10000000000000000,1633824000
+`
+	testStakersCSV = `nodeID,rewardAddress,delegationFee
+NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg,X-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u,1000000
+NodeID-MFrZFVCXPv5iCn6M9K6XduxGTYp891xXZ,X-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u,500000
+`
+)
+
+func testBuildConfig(t *testing.T) *Config {
+	assert := assert.New(t)
+
+	allocations, err := ParseAllocationsCSV(strings.NewReader(testAllocationsCSV))
+	assert.NoError(err)
+	stakers, err := ParseStakersCSV(strings.NewReader(testStakersCSV))
+	assert.NoError(err)
+	stakedFunds, err := ParseAddresses("X-local1g65uqn6t77p656w64023nh8nd9updzmxyymev2")
+	assert.NoError(err)
+	return &Config{
+		NetworkID:                  9999,
+		Allocations:                allocations,
+		StartTime:                  1599696000,
+		InitialStakeDuration:       31536000,
+		InitialStakeDurationOffset: 5400,
+		InitialStakedFunds:         stakedFunds,
+		InitialStakers:             stakers,
+		CChainGenesis:              LocalConfig.CChainGenesis,
+	}
+}
+
+func TestParseAllocationsCSV(t *testing.T) {
+	assert := assert.New(t)
+
+	allocations, err := ParseAllocationsCSV(strings.NewReader(testAllocationsCSV))
+	assert.NoError(err)
+	assert.Len(allocations, 2)
+	assert.Equal(uint64(300000000000000000), allocations[1].InitialAmount)
+	assert.Equal([]LockedAmount{
+		{Amount: 20000000000000000},
+		{Amount: 10000000000000000, Locktime: 1633824000},
+	}, allocations[1].UnlockSchedule)
+
+	_, err = ParseAllocationsCSV(strings.NewReader("0xb3d82b1367d362de99ab59a658165aff520cbd4d,X-local1g65uqn6t77p656w64023nh8nd9updzmxyymev2,0,10"))
+	assert.ErrorIs(err, errUnpairedUnlock)
+	_, err = ParseStakersCSV(strings.NewReader("NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg,X-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u"))
+	assert.Error(err)
+}
+
+func TestBuild(t *testing.T) {
+	assert := assert.New(t)
+
+	config := testBuildConfig(t)
+	config.XChainAssets = []XChainAsset{{
+		Alias:        "GOLD",
+		Name:         "Gold",
+		Symbol:       "GLD",
+		Denomination: 2,
+		Holders: []AssetHolder{{
+			Address: config.Allocations[1].DJTXAddr,
+			Amount:  1000,
+		}},
+	}}
+
+	reply, err := Build(config)
+	assert.NoError(err)
+
+	genesisBytes, djtxAssetID, err := FromConfig(config)
+	assert.NoError(err)
+	assert.Equal(genesisBytes, reply.Bytes)
+
+	// DJTX remains the first asset, which the X-Chain pays fees with
+	assert.Len(reply.Assets, 2)
+	assert.Equal(Asset{Alias: "DJTX", ID: djtxAssetID}, reply.Assets[0])
+	assert.Equal("GOLD", reply.Assets[1].Alias)
+
+	assert.Len(reply.Chains, 3)
+	assert.Equal(constants.PlatformChainID, reply.Chains[0].ID)
+	xChain, err := VMGenesis(genesisBytes, constants.AVMID)
+	assert.NoError(err)
+	assert.Equal(Chain{Name: "X-Chain", ID: xChain.ID(), VMID: constants.AVMID}, reply.Chains[1])
+	cChain, err := VMGenesis(genesisBytes, constants.EVMID)
+	assert.NoError(err)
+	assert.Equal(Chain{Name: "C-Chain", ID: cChain.ID(), VMID: constants.EVMID}, reply.Chains[2])
+
+	// The printed config builds the same genesis
+	parsedConfig, err := reply.Config.Parse()
+	assert.NoError(err)
+	parsedGenesisBytes, _, err := FromConfig(&parsedConfig)
+	assert.NoError(err)
+	assert.Equal(genesisBytes, parsedGenesisBytes)
+}
+
+func TestBuildInvalid(t *testing.T) {
+	tests := map[string]struct {
+		modify func(*Config)
+		err    string
+	}{
+		"standard network": {
+			modify: func(c *Config) { c.NetworkID = constants.LocalID },
+			err:    "cannot override genesis config for standard network",
+		},
+		"unstaked funds": {
+			modify: func(c *Config) { c.InitialStakedFunds = nil },
+			err:    errNoInitiallyStakedFunds.Error(),
+		},
+		"offset too large": {
+			modify: func(c *Config) { c.InitialStakeDurationOffset = c.InitialStakeDuration + 1 },
+			err:    "initial stake duration is",
+		},
+		"asset sorted before DJTX": {
+			modify: func(c *Config) {
+				c.XChainAssets = []XChainAsset{{Alias: "ABC", Name: "abc", Symbol: "ABC"}}
+			},
+			err: `asset alias "ABC" must sort after "DJTX"`,
+		},
+		"duplicated asset": {
+			modify: func(c *Config) {
+				asset := XChainAsset{
+					Alias:   "GOLD",
+					Name:    "Gold",
+					Symbol:  "GLD",
+					Holders: []AssetHolder{{Amount: 1}},
+				}
+				c.XChainAssets = []XChainAsset{asset, asset}
+			},
+			err: `asset alias "GOLD" is duplicated`,
+		},
+		"invalid symbol": {
+			modify: func(c *Config) {
+				c.XChainAssets = []XChainAsset{{
+					Alias:   "GOLD",
+					Name:    "Gold",
+					Symbol:  "gld",
+					Holders: []AssetHolder{{Amount: 1}},
+				}}
+			},
+			err: "may only contain uppercase letters",
+		},
+		"no asset holders": {
+			modify: func(c *Config) {
+				c.XChainAssets = []XChainAsset{{Alias: "GOLD", Name: "Gold", Symbol: "GLD"}}
+			},
+			err: errNoAssetHolders.Error(),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testBuildConfig(t)
+			test.modify(config)
+			_, err := Build(config)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
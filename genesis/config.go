@@ -65,6 +65,46 @@ func (s Staker) Unparse(networkID uint32) (UnparsedStaker, error) {
 	}, err
 }
 
+type AssetHolder struct {
+	Address ids.ShortID `json:"address"`
+	Amount  uint64      `json:"amount"`
+}
+
+// XChainAsset is an asset, other than DJTX, that exists upon the X-Chain's
+// creation
+type XChainAsset struct {
+	Alias        string        `json:"alias"`
+	Name         string        `json:"name"`
+	Symbol       string        `json:"symbol"`
+	Denomination uint8         `json:"denomination"`
+	Holders      []AssetHolder `json:"holders"`
+}
+
+func (a XChainAsset) Unparse(networkID uint32) (UnparsedXChainAsset, error) {
+	ua := UnparsedXChainAsset{
+		Alias:        a.Alias,
+		Name:         a.Name,
+		Symbol:       a.Symbol,
+		Denomination: a.Denomination,
+		Holders:      make([]UnparsedAssetHolder, len(a.Holders)),
+	}
+	for i, holder := range a.Holders {
+		addr, err := formatting.FormatAddress(
+			"X",
+			constants.GetHRP(networkID),
+			holder.Address.Bytes(),
+		)
+		if err != nil {
+			return ua, err
+		}
+		ua.Holders[i] = UnparsedAssetHolder{
+			Address: addr,
+			Amount:  holder.Amount,
+		}
+	}
+	return ua, nil
+}
+
 // Config contains the genesis addresses used to construct a genesis
 type Config struct {
 	NetworkID uint32 `json:"networkID"`
@@ -79,6 +119,13 @@ type Config struct {
 
 	CChainGenesis string `json:"cChainGenesis"`
 
+	// XChainAssets are created on the X-Chain in addition to DJTX
+	XChainAssets []XChainAsset `json:"xChainAssets,omitempty"`
+
+	// NetworkUpgrades override the upgrade times of the network. See
+	// version.NetworkUpgrades.
+	NetworkUpgrades json.RawMessage `json:"networkUpgrades,omitempty"`
+
 	Message string `json:"message"`
 }
 
@@ -92,6 +139,7 @@ func (c Config) Unparse() (UnparsedConfig, error) {
 		InitialStakedFunds:         make([]string, len(c.InitialStakedFunds)),
 		InitialStakers:             make([]UnparsedStaker, len(c.InitialStakers)),
 		CChainGenesis:              c.CChainGenesis,
+		NetworkUpgrades:            c.NetworkUpgrades,
 		Message:                    c.Message,
 	}
 	for i, a := range c.Allocations {
@@ -119,6 +167,13 @@ func (c Config) Unparse() (UnparsedConfig, error) {
 		}
 		uc.InitialStakers[i] = uis
 	}
+	for _, asset := range c.XChainAssets {
+		uasset, err := asset.Unparse(c.NetworkID)
+		if err != nil {
+			return uc, err
+		}
+		uc.XChainAssets = append(uc.XChainAssets, uasset)
+	}
 
 	return uc, nil
 }
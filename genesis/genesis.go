@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/lasthyphen/dijetsgo/codec"
 	"github.com/lasthyphen/dijetsgo/codec/linearcodec"
@@ -30,6 +32,13 @@ const (
 	defaultEncoding    = formatting.Hex
 	codecVersion       = 0
 	configChainIDAlias = "X"
+	djtxAlias          = "DJTX"
+
+	minAssetNameLen      = 1
+	maxAssetNameLen      = 128
+	minAssetSymbolLen    = 1
+	maxAssetSymbolLen    = 4
+	maxAssetDenomination = 32
 )
 
 var (
@@ -39,6 +48,8 @@ var (
 	errNoStakers              = errors.New("initial stakers must be > 0")
 	errNoCChainGenesis        = errors.New("C-Chain genesis cannot be empty")
 	errNoTxs                  = errors.New("genesis creates no transactions")
+	errNoAssetHolders         = errors.New("asset must have at least one holder")
+	errNoAssetAmount          = errors.New("asset holder amount must be > 0")
 )
 
 // validateInitialStakedFunds ensures all staked
@@ -161,6 +172,64 @@ func validateConfig(networkID uint32, config *Config) error {
 		return errNoCChainGenesis
 	}
 
+	if err := validateXChainAssets(config); err != nil {
+		return fmt.Errorf("X-Chain assets validation failed: %w", err)
+	}
+
+	return nil
+}
+
+// validateXChainAssets ensures the additional X-Chain assets are well formed.
+//
+// The X-Chain pays fees with the first asset of its genesis, ordered by alias,
+// so every alias must sort after DJTX.
+func validateXChainAssets(config *Config) error {
+	aliases := make(map[string]struct{}, len(config.XChainAssets))
+	for _, asset := range config.XChainAssets {
+		if asset.Alias <= djtxAlias {
+			return fmt.Errorf("asset alias %q must sort after %q", asset.Alias, djtxAlias)
+		}
+		if _, ok := aliases[asset.Alias]; ok {
+			return fmt.Errorf("asset alias %q is duplicated", asset.Alias)
+		}
+		aliases[asset.Alias] = struct{}{}
+
+		if err := validateXChainAsset(asset); err != nil {
+			return fmt.Errorf("asset %q is invalid: %w", asset.Alias, err)
+		}
+	}
+	return nil
+}
+
+// validateXChainAsset applies the X-Chain's rules for creating an asset
+func validateXChainAsset(asset XChainAsset) error {
+	switch {
+	case len(asset.Name) < minAssetNameLen || len(asset.Name) > maxAssetNameLen:
+		return fmt.Errorf("name must be between %d and %d characters", minAssetNameLen, maxAssetNameLen)
+	case strings.TrimSpace(asset.Name) != asset.Name:
+		return fmt.Errorf("name %q has leading or trailing whitespace", asset.Name)
+	case len(asset.Symbol) < minAssetSymbolLen || len(asset.Symbol) > maxAssetSymbolLen:
+		return fmt.Errorf("symbol must be between %d and %d characters", minAssetSymbolLen, maxAssetSymbolLen)
+	case asset.Denomination > maxAssetDenomination:
+		return fmt.Errorf("denomination must be <= %d", maxAssetDenomination)
+	case len(asset.Holders) == 0:
+		return errNoAssetHolders
+	}
+	for _, r := range asset.Name {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsNumber(r) || r == ' ') {
+			return fmt.Errorf("name %q may only contain letters, numbers and spaces", asset.Name)
+		}
+	}
+	for _, r := range asset.Symbol {
+		if r > unicode.MaxASCII || !unicode.IsUpper(r) {
+			return fmt.Errorf("symbol %q may only contain uppercase letters", asset.Symbol)
+		}
+	}
+	for _, holder := range asset.Holders {
+		if holder.Amount == 0 {
+			return errNoAssetAmount
+		}
+	}
 	return nil
 }
 
@@ -296,8 +365,27 @@ func FromConfig(config *Config) ([]byte, ids.ID, error) {
 			return nil, ids.Empty, fmt.Errorf("couldn't parse memo bytes to string: %w", err)
 		}
 		avmArgs.GenesisData = map[string]avm.AssetDefinition{
-			"DJTX": djtx, // The AVM starts out with one asset: DJTX
+			djtxAlias: djtx, // The AVM starts out with DJTX
+		}
+	}
+	for _, asset := range config.XChainAssets {
+		assetDefinition := avm.AssetDefinition{
+			Name:         asset.Name,
+			Symbol:       asset.Symbol,
+			Denomination: json.Uint8(asset.Denomination),
+			InitialState: map[string][]interface{}{},
 		}
+		for _, holder := range asset.Holders {
+			addr, err := formatting.FormatBech32(hrp, holder.Address.Bytes())
+			if err != nil {
+				return nil, ids.ID{}, err
+			}
+			assetDefinition.InitialState["fixedCap"] = append(assetDefinition.InitialState["fixedCap"], avm.Holder{
+				Amount:  json.Uint64(holder.Amount),
+				Address: addr,
+			})
+		}
+		avmArgs.GenesisData[asset.Alias] = assetDefinition
 	}
 	avmReply := avm.BuildGenesisReply{}
 
@@ -536,6 +624,16 @@ func VMGenesis(genesisBytes []byte, vmID ids.ID) (*platformvm.Tx, error) {
 }
 
 func DJTXAssetID(avmGenesisBytes []byte) (ids.ID, error) {
+	assets, err := genesisAssets(avmGenesisBytes)
+	if err != nil {
+		return ids.ID{}, err
+	}
+	return assets[0].ID, nil
+}
+
+// genesisAssets returns the assets created by [avmGenesisBytes], in the order
+// they are created
+func genesisAssets(avmGenesisBytes []byte) ([]Asset, error) {
 	c := linearcodec.New(reflectcodec.DefaultTagName, 1<<20)
 	m := codec.NewManager(math.MaxInt32)
 	errs := wrappers.Errs{}
@@ -553,31 +651,37 @@ func DJTXAssetID(avmGenesisBytes []byte) (ids.ID, error) {
 		m.RegisterCodec(codecVersion, c),
 	)
 	if errs.Errored() {
-		return ids.ID{}, errs.Err
+		return nil, errs.Err
 	}
 
 	genesis := avm.Genesis{}
 	if _, err := m.Unmarshal(avmGenesisBytes, &genesis); err != nil {
-		return ids.ID{}, err
+		return nil, err
 	}
 
 	if len(genesis.Txs) == 0 {
-		return ids.ID{}, errNoTxs
+		return nil, errNoTxs
 	}
-	genesisTx := genesis.Txs[0]
 
-	tx := avm.Tx{UnsignedTx: &genesisTx.CreateAssetTx}
-	unsignedBytes, err := m.Marshal(codecVersion, tx.UnsignedTx)
-	if err != nil {
-		return ids.ID{}, err
-	}
-	signedBytes, err := m.Marshal(codecVersion, &tx)
-	if err != nil {
-		return ids.ID{}, err
-	}
-	tx.Initialize(unsignedBytes, signedBytes)
+	assets := make([]Asset, len(genesis.Txs))
+	for i, genesisTx := range genesis.Txs {
+		tx := avm.Tx{UnsignedTx: &genesisTx.CreateAssetTx}
+		unsignedBytes, err := m.Marshal(codecVersion, tx.UnsignedTx)
+		if err != nil {
+			return nil, err
+		}
+		signedBytes, err := m.Marshal(codecVersion, &tx)
+		if err != nil {
+			return nil, err
+		}
+		tx.Initialize(unsignedBytes, signedBytes)
 
-	return tx.ID(), nil
+		assets[i] = Asset{
+			Alias: genesisTx.Alias,
+			ID:    tx.ID(),
+		}
+	}
+	return assets, nil
 }
 
 type innerSortXAllocation []Allocation
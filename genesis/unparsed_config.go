@@ -5,6 +5,7 @@ package genesis
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 
 	"github.com/lasthyphen/dijetsgo/ids"
@@ -83,6 +84,44 @@ func (us UnparsedStaker) Parse() (Staker, error) {
 	return s, nil
 }
 
+type UnparsedAssetHolder struct {
+	Address string `json:"address"`
+	Amount  uint64 `json:"amount"`
+}
+
+type UnparsedXChainAsset struct {
+	Alias        string                `json:"alias"`
+	Name         string                `json:"name"`
+	Symbol       string                `json:"symbol"`
+	Denomination uint8                 `json:"denomination"`
+	Holders      []UnparsedAssetHolder `json:"holders"`
+}
+
+func (ua UnparsedXChainAsset) Parse() (XChainAsset, error) {
+	a := XChainAsset{
+		Alias:        ua.Alias,
+		Name:         ua.Name,
+		Symbol:       ua.Symbol,
+		Denomination: ua.Denomination,
+		Holders:      make([]AssetHolder, len(ua.Holders)),
+	}
+	for i, holder := range ua.Holders {
+		_, _, addrBytes, err := formatting.ParseAddress(holder.Address)
+		if err != nil {
+			return a, err
+		}
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			return a, err
+		}
+		a.Holders[i] = AssetHolder{
+			Address: addr,
+			Amount:  holder.Amount,
+		}
+	}
+	return a, nil
+}
+
 // UnparsedConfig contains the genesis addresses used to construct a genesis
 type UnparsedConfig struct {
 	NetworkID uint32 `json:"networkID"`
@@ -97,6 +136,10 @@ type UnparsedConfig struct {
 
 	CChainGenesis string `json:"cChainGenesis"`
 
+	XChainAssets []UnparsedXChainAsset `json:"xChainAssets,omitempty"`
+
+	NetworkUpgrades json.RawMessage `json:"networkUpgrades,omitempty"`
+
 	Message string `json:"message"`
 }
 
@@ -110,6 +153,7 @@ func (uc UnparsedConfig) Parse() (Config, error) {
 		InitialStakedFunds:         make([]ids.ShortID, len(uc.InitialStakedFunds)),
 		InitialStakers:             make([]Staker, len(uc.InitialStakers)),
 		CChainGenesis:              uc.CChainGenesis,
+		NetworkUpgrades:            uc.NetworkUpgrades,
 		Message:                    uc.Message,
 	}
 	for i, ua := range uc.Allocations {
@@ -137,5 +181,12 @@ func (uc UnparsedConfig) Parse() (Config, error) {
 		}
 		c.InitialStakers[i] = is
 	}
+	for _, uasset := range uc.XChainAssets {
+		asset, err := uasset.Parse()
+		if err != nil {
+			return c, err
+		}
+		c.XChainAssets = append(c.XChainAssets, asset)
+	}
 	return c, nil
 }
@@ -0,0 +1,252 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/lasthyphen/dijetsgo/api/proto/indexerproto"
+	"github.com/lasthyphen/dijetsgo/api/server"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils/math"
+)
+
+var (
+	_ indexerproto.IndexServer = &grpcService{}
+	_ server.EndpointScoper    = &grpcService{}
+)
+
+// grpcService serves every index of the indexer over gRPC. Indices are
+// referred to by the same name as their HTTP endpoint, e.g. "X/tx", and calls
+// are authorized as if they were made to that endpoint.
+type grpcService struct {
+	indexerproto.UnimplementedIndexServer
+
+	lock sync.RWMutex
+	// Index name --> index
+	indices map[string]Index
+}
+
+func newGRPCService() *grpcService {
+	return &grpcService{
+		indices: make(map[string]Index),
+	}
+}
+
+func (s *grpcService) addIndex(name string, index Index) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.indices[name] = index
+}
+
+// CallEndpoint implements the server.EndpointScoper interface
+func (s *grpcService) CallEndpoint(req interface{}) (string, error) {
+	indexReq, ok := req.(interface{ GetIndex() string })
+	if !ok {
+		return "", fmt.Errorf("unexpected request type %T", req)
+	}
+	return indexReq.GetIndex(), nil
+}
+
+func (s *grpcService) getIndex(name string) (Index, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	index, ok := s.indices[name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "there is no index named %q", name)
+	}
+	return index, nil
+}
+
+func (s *grpcService) GetLastAccepted(_ context.Context, req *indexerproto.GetLastAcceptedRequest) (*indexerproto.GetLastAcceptedResponse, error) {
+	index, err := s.getIndex(req.Index)
+	if err != nil {
+		return nil, err
+	}
+	container, err := index.GetLastAccepted()
+	if err != nil {
+		return nil, err
+	}
+	protoContainer, err := containerToProto(index, container)
+	return &indexerproto.GetLastAcceptedResponse{
+		Container: protoContainer,
+	}, err
+}
+
+func (s *grpcService) GetContainerByIndex(_ context.Context, req *indexerproto.GetContainerByIndexRequest) (*indexerproto.GetContainerByIndexResponse, error) {
+	index, err := s.getIndex(req.Index)
+	if err != nil {
+		return nil, err
+	}
+	container, err := index.GetContainerByIndex(req.ContainerIndex)
+	if err != nil {
+		return nil, err
+	}
+	protoContainer, err := containerToProto(index, container)
+	return &indexerproto.GetContainerByIndexResponse{
+		Container: protoContainer,
+	}, err
+}
+
+func (s *grpcService) GetContainerRange(_ context.Context, req *indexerproto.GetContainerRangeRequest) (*indexerproto.GetContainerRangeResponse, error) {
+	index, err := s.getIndex(req.Index)
+	if err != nil {
+		return nil, err
+	}
+	containers, err := index.GetContainerRange(req.StartIndex, req.NumToFetch)
+	if err != nil {
+		return nil, err
+	}
+
+	protoContainers := make([]*indexerproto.Container, len(containers))
+	for i, container := range containers {
+		protoContainers[i], err = containerToProto(index, container)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &indexerproto.GetContainerRangeResponse{
+		Containers: protoContainers,
+	}, nil
+}
+
+func (s *grpcService) GetIndex(_ context.Context, req *indexerproto.GetIndexRequest) (*indexerproto.GetIndexResponse, error) {
+	index, err := s.getIndex(req.Index)
+	if err != nil {
+		return nil, err
+	}
+	containerID, err := ids.ToID(req.ContainerId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	reply := GetIndexResponse{}
+	if err := (&service{Index: index}).GetIndex(nil, &GetIndexArgs{ContainerID: containerID}, &reply); err != nil {
+		return nil, err
+	}
+	return &indexerproto.GetIndexResponse{
+		Index: uint64(reply.Index),
+	}, nil
+}
+
+func (s *grpcService) IsAccepted(_ context.Context, req *indexerproto.IsAcceptedRequest) (*indexerproto.IsAcceptedResponse, error) {
+	index, err := s.getIndex(req.Index)
+	if err != nil {
+		return nil, err
+	}
+	containerID, err := ids.ToID(req.ContainerId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	reply := IsAcceptedResponse{}
+	if err := (&service{Index: index}).IsAccepted(nil, &GetIndexArgs{ContainerID: containerID}, &reply); err != nil {
+		return nil, err
+	}
+	return &indexerproto.IsAcceptedResponse{
+		IsAccepted: reply.IsAccepted,
+	}, nil
+}
+
+func (s *grpcService) GetContainerByID(_ context.Context, req *indexerproto.GetContainerByIDRequest) (*indexerproto.GetContainerByIDResponse, error) {
+	index, err := s.getIndex(req.Index)
+	if err != nil {
+		return nil, err
+	}
+	containerID, err := ids.ToID(req.ContainerId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	container, err := index.GetContainerByID(containerID)
+	if err != nil {
+		return nil, err
+	}
+	protoContainer, err := containerToProto(index, container)
+	return &indexerproto.GetContainerByIDResponse{
+		Container: protoContainer,
+	}, err
+}
+
+func (s *grpcService) StreamContainers(req *indexerproto.StreamContainersRequest, stream indexerproto.Index_StreamContainersServer) error {
+	index, err := s.getIndex(req.Index)
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	nextIndex := req.StartIndex
+	for {
+		// Fetch the notification channel before reading the last accepted
+		// index so that no acceptance can be missed in between
+		accepted := index.Accepted()
+		lastAcceptedIndex, ok, err := getLastAcceptedIndex(index)
+		if err != nil {
+			return err
+		}
+
+		// Send containers until we are caught up with the index before waiting
+		// for more to be accepted
+		for ok && nextIndex <= lastAcceptedIndex {
+			numToFetch := math.Min64(lastAcceptedIndex-nextIndex+1, MaxFetchedByRange)
+			containers, err := index.GetContainerRange(nextIndex, numToFetch)
+			if err != nil {
+				return err
+			}
+			for i := range containers {
+				err := stream.Send(&indexerproto.StreamContainersResponse{
+					Container: &indexerproto.Container{
+						Id:        containers[i].ID[:],
+						Bytes:     containers[i].Bytes,
+						Timestamp: containers[i].Timestamp,
+						Index:     nextIndex,
+					},
+				})
+				if err != nil {
+					return err
+				}
+				nextIndex++
+			}
+		}
+
+		select {
+		case <-accepted:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// getLastAcceptedIndex returns the index of the last accepted container of
+// [index]. Returns false if no container has been accepted.
+func getLastAcceptedIndex(index Index) (uint64, bool, error) {
+	container, err := index.GetLastAccepted()
+	if err == errNoneAccepted {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	lastAcceptedIndex, err := index.GetIndex(container.ID)
+	return lastAcceptedIndex, err == nil, err
+}
+
+func containerToProto(index Index, container Container) (*indexerproto.Container, error) {
+	containerIndex, err := index.GetIndex(container.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &indexerproto.Container{
+		Id:        container.ID[:],
+		Bytes:     container.Bytes,
+		Timestamp: container.Timestamp,
+		Index:     containerIndex,
+	}, nil
+}
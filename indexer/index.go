@@ -49,6 +49,9 @@ type Index interface {
 	GetLastAccepted() (Container, error)
 	GetIndex(containerID ids.ID) (uint64, error)
 	GetContainerByID(containerID ids.ID) (Container, error)
+	// Accepted returns a channel that is closed once a container is accepted
+	// after this call, or once the index is closed.
+	Accepted() <-chan struct{}
 	io.Closer
 }
 
@@ -68,6 +71,8 @@ type index struct {
 	// Container ID --> Index
 	containerToIndex database.Database
 	log              logging.Logger
+	// Closed, and replaced, whenever a container is accepted
+	accepted chan struct{}
 }
 
 // Returns a new, thread-safe Index.
@@ -90,6 +95,7 @@ func newIndex(
 		indexToContainer: indexToContainer,
 		containerToIndex: containerToIndex,
 		log:              log,
+		accepted:         make(chan struct{}),
 	}
 
 	// Get next accepted index from db
@@ -109,6 +115,16 @@ func newIndex(
 
 // Close this index
 func (i *index) Close() error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	// Wake up anyone waiting for containers to be accepted
+	select {
+	case <-i.accepted:
+	default:
+		close(i.accepted)
+	}
+
 	errs := wrappers.Errs{}
 	errs.Add(
 		i.indexToContainer.Close(),
@@ -166,7 +182,22 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 	}
 
 	// Atomically commit [i.vDB], [i.indexToContainer], [i.containerToIndex] to [i.baseDB]
-	return i.vDB.Commit()
+	if err := i.vDB.Commit(); err != nil {
+		return err
+	}
+
+	// Notify anyone waiting for containers to be accepted
+	close(i.accepted)
+	i.accepted = make(chan struct{})
+	return nil
+}
+
+// Accepted implements the Index interface
+func (i *index) Accepted() <-chan struct{} {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.accepted
 }
 
 // Returns the ID of the [index]th accepted container and the container itself.
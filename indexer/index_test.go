@@ -173,3 +173,43 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	assert.NoError(err)
 	assert.EqualValues(gotContainer.Bytes, []byte{1, 2, 3}, "should not have accepted same container twice")
 }
+
+func TestIndexAcceptedNotification(t *testing.T) {
+	assert := assert.New(t)
+	codec := codec.NewDefaultManager()
+	err := codec.RegisterCodec(codecVersion, linearcodec.NewDefault())
+	assert.NoError(err)
+	ctx := snow.DefaultConsensusContextTest()
+
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, codec, mockable.Clock{})
+	assert.NoError(err)
+
+	accepted := idx.Accepted()
+	select {
+	case <-accepted:
+		t.Fatal("shouldn't be notified before a container is accepted")
+	default:
+	}
+
+	assert.NoError(idx.Accept(ctx, ids.GenerateTestID(), utils.RandomBytes(32)))
+	select {
+	case <-accepted:
+	default:
+		t.Fatal("should be notified once a container is accepted")
+	}
+
+	// A new notification channel is used for the next acceptance
+	accepted = idx.Accepted()
+	select {
+	case <-accepted:
+		t.Fatal("shouldn't be notified of an earlier acceptance")
+	default:
+	}
+
+	assert.NoError(idx.Close())
+	select {
+	case <-accepted:
+	default:
+		t.Fatal("should be notified once the index is closed")
+	}
+}
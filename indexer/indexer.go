@@ -9,6 +9,7 @@ import (
 	"math"
 	"sync"
 
+	"github.com/lasthyphen/dijetsgo/api/proto/indexerproto"
 	"github.com/lasthyphen/dijetsgo/api/server"
 	"github.com/lasthyphen/dijetsgo/chains"
 	"github.com/lasthyphen/dijetsgo/utils/constants"
@@ -62,7 +63,9 @@ type Config struct {
 	AllowIncompleteIndex                    bool
 	DecisionDispatcher, ConsensusDispatcher *triggers.EventDispatcher
 	APIServer                               server.RouteAdder
-	ShutdownF                               func()
+	// If non-nil, the indices are also served over gRPC
+	GRPCServer server.ServiceAdder
+	ShutdownF  func()
 }
 
 // Indexer causes accepted containers for a given chain
@@ -89,6 +92,7 @@ func NewIndexer(config Config) (Indexer, error) {
 		vtxIndices:           map[ids.ID]Index{},
 		blockIndices:         map[ids.ID]Index{},
 		routeAdder:           config.APIServer,
+		grpcService:          newGRPCService(),
 		shutdownF:            config.ShutdownF,
 	}
 	if err := indexer.codec.RegisterCodec(
@@ -102,6 +106,11 @@ func NewIndexer(config Config) (Indexer, error) {
 		return nil, err
 	}
 	indexer.hasRunBefore = hasRun
+	if config.GRPCServer != nil {
+		if err := config.GRPCServer.AddService(&indexerproto.Index_ServiceDesc, indexer.grpcService, "index"); err != nil {
+			return nil, fmt.Errorf("couldn't add gRPC service: %w", err)
+		}
+	}
 	return indexer, indexer.markHasRun()
 }
 
@@ -122,6 +131,9 @@ type indexer struct {
 	// Used to add API endpoint for new indices
 	routeAdder server.RouteAdder
 
+	// Serves the indices over gRPC
+	grpcService *grpcService
+
 	// If true, allow running in such a way that could allow the creation
 	// of an index which could be missing accepted containers.
 	allowIncompleteIndex bool
@@ -298,6 +310,7 @@ func (i *indexer) registerChainHelper(
 		_ = index.Close()
 		return nil, err
 	}
+	i.grpcService.addIndex(name+"/"+endpoint, index)
 	return index, nil
 }
 
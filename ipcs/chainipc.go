@@ -4,9 +4,13 @@
 package ipcs
 
 import (
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"path/filepath"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/lasthyphen/dijetsgo/ids"
 	"github.com/lasthyphen/dijetsgo/snow/triggers"
 	"github.com/lasthyphen/dijetsgo/utils/logging"
@@ -17,15 +21,80 @@ const (
 	// DefaultBaseURL can be used as a reasonable default value for the base URL
 	DefaultBaseURL = "/tmp"
 
+	// DefaultAddress is the TCP address sockets listen on when none is
+	// provided. The port is picked by the OS.
+	DefaultAddress = "127.0.0.1:0"
+
 	ipcIdentifierPrefix    = "ipc"
 	ipcConsensusIdentifier = "consensus"
 	ipcDecisionsIdentifier = "decisions"
 )
 
+var (
+	errUnknownTransport  = errors.New("unknown transport")
+	errNoTLSConfig       = errors.New("TLS must be configured to publish over TCP or gRPC")
+	errAlreadyPublished  = errors.New("blockchain is already published over another transport")
+	errAddressNotAllowed = errors.New("addresses can only be provided for the TCP and gRPC transports")
+	errSameSocketAddress = errors.New("consensus and decisions sockets must listen on different addresses")
+)
+
+// Transport is the kind of socket events are published over
+type Transport string
+
+const (
+	// UnixTransport publishes over Unix sockets, or named pipes on Windows
+	UnixTransport Transport = "unix"
+	// TCPTransport publishes over TCP with mutual TLS
+	TCPTransport Transport = "tcp"
+	// GRPCTransport publishes over a gRPC server stream with mutual TLS
+	GRPCTransport Transport = "grpc"
+)
+
+// PublishConfig specifies how the events of a chain are published
+type PublishConfig struct {
+	// Defaults to UnixTransport
+	Transport Transport
+	// TCP addresses the consensus and decisions sockets listen on when
+	// publishing over TCP or gRPC. Default to [DefaultAddress].
+	ConsensusAddress string
+	DecisionsAddress string
+}
+
+// Verify returns an error if [c] is malformed. Default values are filled in.
+func (c *PublishConfig) Verify() error {
+	switch c.Transport {
+	case "":
+		c.Transport = UnixTransport
+		fallthrough
+	case UnixTransport:
+		if c.ConsensusAddress != "" || c.DecisionsAddress != "" {
+			return errAddressNotAllowed
+		}
+		return nil
+	case TCPTransport, GRPCTransport:
+		if c.ConsensusAddress == "" {
+			c.ConsensusAddress = DefaultAddress
+		}
+		if c.DecisionsAddress == "" {
+			c.DecisionsAddress = DefaultAddress
+		}
+		if c.ConsensusAddress == c.DecisionsAddress && c.ConsensusAddress != DefaultAddress {
+			return errSameSocketAddress
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w %q", errUnknownTransport, c.Transport)
+	}
+}
+
 type context struct {
 	log       logging.Logger
 	networkID uint32
 	path      string
+	// Used by the TCP and gRPC transports. May be nil, in which case only the
+	// Unix transport can be used.
+	tlsConfig *tls.Config
+	metrics   *metrics
 }
 
 // ChainIPCs maintains IPCs for a set of chains
@@ -37,13 +106,29 @@ type ChainIPCs struct {
 }
 
 // NewChainIPCs creates a new *ChainIPCs that writes consensus and decision
-// events to IPC sockets
-func NewChainIPCs(log logging.Logger, path string, networkID uint32, consensusEvents *triggers.EventDispatcher, decisionEvents *triggers.EventDispatcher, defaultChainIDs []ids.ID) (*ChainIPCs, error) {
+// events to IPC sockets. The default chains are published over Unix sockets.
+// [tlsConfig] is used by the TCP and gRPC transports and may be nil.
+func NewChainIPCs(
+	log logging.Logger,
+	path string,
+	networkID uint32,
+	consensusEvents *triggers.EventDispatcher,
+	decisionEvents *triggers.EventDispatcher,
+	defaultChainIDs []ids.ID,
+	tlsConfig *tls.Config,
+	registerer prometheus.Registerer,
+) (*ChainIPCs, error) {
+	metrics, err := newMetrics("ipcs", registerer)
+	if err != nil {
+		return nil, err
+	}
 	cipcs := &ChainIPCs{
 		context: context{
 			log:       log,
 			networkID: networkID,
 			path:      path,
+			tlsConfig: tlsConfig,
+			metrics:   metrics,
 		},
 		chains:          make(map[ids.ID]*EventSockets),
 		consensusEvents: consensusEvents,
@@ -57,14 +142,30 @@ func NewChainIPCs(log logging.Logger, path string, networkID uint32, consensusEv
 	return cipcs, nil
 }
 
-// Publish creates a set of eventSockets for the given chainID
+// Publish creates a set of eventSockets for the given chainID that publish
+// over Unix sockets
 func (cipcs *ChainIPCs) Publish(chainID ids.ID) (*EventSockets, error) {
+	return cipcs.PublishWithConfig(chainID, PublishConfig{})
+}
+
+// PublishWithConfig creates a set of eventSockets for the given chainID that
+// publish as specified by [config]
+func (cipcs *ChainIPCs) PublishWithConfig(chainID ids.ID, config PublishConfig) (*EventSockets, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
 	if es, ok := cipcs.chains[chainID]; ok {
+		if es.transport != config.Transport {
+			return nil, fmt.Errorf("%w: %s", errAlreadyPublished, es.transport)
+		}
 		cipcs.log.Info("returning existing blockchainID %s", chainID.String())
 		return es, nil
 	}
+	if config.Transport != UnixTransport && cipcs.tlsConfig == nil {
+		return nil, errNoTLSConfig
+	}
 
-	es, err := newEventSockets(cipcs.context, chainID, cipcs.consensusEvents, cipcs.decisionEvents)
+	es, err := newEventSockets(cipcs.context, chainID, config, cipcs.consensusEvents, cipcs.decisionEvents)
 	if err != nil {
 		cipcs.log.Error("can't create ipcs: %s", err)
 		return nil, err
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishConfigVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   PublishConfig
+		expected PublishConfig
+		err      error
+	}{
+		{
+			name:     "default transport",
+			config:   PublishConfig{},
+			expected: PublishConfig{Transport: UnixTransport},
+		},
+		{
+			name:   "unix with address",
+			config: PublishConfig{Transport: UnixTransport, ConsensusAddress: "127.0.0.1:9660"},
+			err:    errAddressNotAllowed,
+		},
+		{
+			name:   "tcp default addresses",
+			config: PublishConfig{Transport: TCPTransport},
+			expected: PublishConfig{
+				Transport:        TCPTransport,
+				ConsensusAddress: DefaultAddress,
+				DecisionsAddress: DefaultAddress,
+			},
+		},
+		{
+			name: "grpc same addresses",
+			config: PublishConfig{
+				Transport:        GRPCTransport,
+				ConsensusAddress: "0.0.0.0:9660",
+				DecisionsAddress: "0.0.0.0:9660",
+			},
+			err: errSameSocketAddress,
+		},
+		{
+			name:   "unknown transport",
+			config: PublishConfig{Transport: "udp"},
+			err:    errUnknownTransport,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, test.config)
+		})
+	}
+}
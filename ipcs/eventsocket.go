@@ -5,6 +5,8 @@ package ipcs
 
 import (
 	"errors"
+	"fmt"
+	"net"
 	"os"
 	"syscall"
 
@@ -20,23 +22,28 @@ var _ snow.Acceptor = &EventSockets{}
 
 // EventSockets is a set of named eventSockets
 type EventSockets struct {
+	transport       Transport
 	consensusSocket *eventSocket
 	decisionsSocket *eventSocket
 }
 
 // newEventSockets creates a *ChainIPCs with both consensus and decisions IPCs
-func newEventSockets(ctx context, chainID ids.ID, consensusEvents *triggers.EventDispatcher, decisionEvents *triggers.EventDispatcher) (*EventSockets, error) {
-	consensusIPC, err := newEventIPCSocket(ctx, chainID, ipcConsensusIdentifier, consensusEvents)
+func newEventSockets(ctx context, chainID ids.ID, config PublishConfig, consensusEvents *triggers.EventDispatcher, decisionEvents *triggers.EventDispatcher) (*EventSockets, error) {
+	consensusIPC, err := newEventIPCSocket(ctx, chainID, ipcConsensusIdentifier, config.Transport, config.ConsensusAddress, consensusEvents)
 	if err != nil {
 		return nil, err
 	}
 
-	decisionsIPC, err := newEventIPCSocket(ctx, chainID, ipcDecisionsIdentifier, decisionEvents)
+	decisionsIPC, err := newEventIPCSocket(ctx, chainID, ipcDecisionsIdentifier, config.Transport, config.DecisionsAddress, decisionEvents)
 	if err != nil {
+		if err := consensusIPC.stop(); err != nil {
+			ctx.log.Debug("failed to stop consensus IPC: %s", err)
+		}
 		return nil, err
 	}
 
 	return &EventSockets{
+		transport:       config.Transport,
 		consensusSocket: consensusIPC,
 		decisionsSocket: decisionsIPC,
 	}, nil
@@ -74,6 +81,11 @@ func (ipcs *EventSockets) stop() error {
 	return errs.Err
 }
 
+// Transport returns the kind of socket the events are published over
+func (ipcs *EventSockets) Transport() Transport {
+	return ipcs.transport
+}
+
 // ConsensusURL returns the URL of socket receiving consensus events
 func (ipcs *EventSockets) ConsensusURL() string {
 	return ipcs.consensusSocket.URL()
@@ -84,42 +96,60 @@ func (ipcs *EventSockets) DecisionsURL() string {
 	return ipcs.decisionsSocket.URL()
 }
 
+// publisher sends messages to the clients of a socket
+type publisher interface {
+	SetMetrics(metrics socket.Metrics)
+	Listen() error
+	Send(msg []byte)
+	Addr() net.Addr
+	Close() error
+}
+
 // eventSocket is a single IPC socket for a single chain
 type eventSocket struct {
 	url          string
 	log          logging.Logger
-	socket       *socket.Socket
+	socket       publisher
 	unregisterFn func() error
 }
 
 // newEventIPCSocket creates a *eventSocket for the given chain and
-// EventDispatcher that writes to a local IPC socket
-func newEventIPCSocket(ctx context, chainID ids.ID, name string, events *triggers.EventDispatcher) (*eventSocket, error) {
-	var (
-		url     = ipcURL(ctx, chainID, name)
-		ipcName = ipcIdentifierPrefix + "-" + name
-	)
-
-	err := os.Remove(url)
-	if err != nil && !errors.Is(err, syscall.ENOENT) {
-		return nil, err
-	}
+// EventDispatcher that writes to a socket of the given transport. [addr] is
+// the TCP address the socket listens on, if the transport isn't Unix.
+func newEventIPCSocket(ctx context, chainID ids.ID, name string, transport Transport, addr string, events *triggers.EventDispatcher) (*eventSocket, error) {
+	ipcName := ipcIdentifierPrefix + "-" + name
 
 	eis := &eventSocket{
-		log:    ctx.log,
-		url:    url,
-		socket: socket.NewSocket(url, ctx.log),
+		log: ctx.log,
 		unregisterFn: func() error {
+			ctx.metrics.remove(chainID, name)
 			return events.DeregisterChain(chainID, ipcName)
 		},
 	}
-
-	if err := eis.socket.Listen(); err != nil {
-		if err := eis.socket.Close(); err != nil {
+	switch transport {
+	case UnixTransport:
+		eis.url = ipcURL(ctx, chainID, name)
+		err := os.Remove(eis.url)
+		if err != nil && !errors.Is(err, syscall.ENOENT) {
 			return nil, err
 		}
+		eis.socket = socket.NewSocket(eis.url, ctx.log)
+	case TCPTransport:
+		eis.socket = socket.NewTLSSocket(addr, ctx.tlsConfig, ctx.log)
+	case GRPCTransport:
+		eis.socket = socket.NewGRPCSocket(addr, ctx.tlsConfig, ctx.log)
+	default:
+		return nil, fmt.Errorf("%w %q", errUnknownTransport, transport)
+	}
+	eis.socket.SetMetrics(ctx.metrics.socketMetrics(chainID, name))
+
+	if err := eis.socket.Listen(); err != nil {
+		ctx.metrics.remove(chainID, name)
 		return nil, err
 	}
+	if transport != UnixTransport {
+		eis.url = fmt.Sprintf("%s://%s", transport, eis.socket.Addr())
+	}
 
 	if err := events.RegisterChain(chainID, ipcName, eis, false); err != nil {
 		if err := eis.stop(); err != nil {
@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/ipcs/socket"
+	"github.com/lasthyphen/dijetsgo/utils/wrappers"
+)
+
+var eventSocketLabels = []string{"chain", "event"}
+
+type metrics struct {
+	sent    *prometheus.CounterVec
+	dropped *prometheus.CounterVec
+	clients *prometheus.GaugeVec
+}
+
+func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		sent: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "sent",
+				Help:      "Number of events queued for IPC clients",
+			},
+			eventSocketLabels,
+		),
+		dropped: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "dropped",
+				Help:      "Number of events dropped because an IPC client read them too slowly",
+			},
+			eventSocketLabels,
+		),
+		clients: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "clients",
+				Help:      "Number of connected IPC clients",
+			},
+			eventSocketLabels,
+		),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.sent),
+		registerer.Register(m.dropped),
+		registerer.Register(m.clients),
+	)
+	return m, errs.Err
+}
+
+// socketMetrics returns the metrics of the socket that publishes the [event]
+// events of [chainID]
+func (m *metrics) socketMetrics(chainID ids.ID, event string) socket.Metrics {
+	labels := prometheus.Labels{
+		"chain": chainID.String(),
+		"event": event,
+	}
+	return socket.Metrics{
+		Sent:    m.sent.With(labels),
+		Dropped: m.dropped.With(labels),
+		Clients: m.clients.With(labels),
+	}
+}
+
+// remove deletes the metrics of the socket that published the [event] events
+// of [chainID]
+func (m *metrics) remove(chainID ids.ID, event string) {
+	labels := prometheus.Labels{
+		"chain": chainID.String(),
+		"event": event,
+	}
+	m.sent.Delete(labels)
+	m.dropped.Delete(labels)
+	m.clients.Delete(labels)
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package socket
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Maximum number of messages queued for a client
+	maxQueuedMessages = 1024
+
+	// Maximum time Send waits for slow clients to make room in their queues
+	// before dropping the message for them
+	maxSendDelay = time.Second
+)
+
+// Metrics tracks the delivery of messages to the clients of a socket
+type Metrics struct {
+	// Messages queued for a client
+	Sent prometheus.Counter
+	// Messages dropped because a client read them too slowly
+	Dropped prometheus.Counter
+	// Connected clients
+	Clients prometheus.Gauge
+}
+
+func newUnregisteredMetrics() Metrics {
+	return Metrics{
+		Sent:    prometheus.NewCounter(prometheus.CounterOpts{Name: "sent"}),
+		Dropped: prometheus.NewCounter(prometheus.CounterOpts{Name: "dropped"}),
+		Clients: prometheus.NewGauge(prometheus.GaugeOpts{Name: "clients"}),
+	}
+}
+
+// queue holds the messages that haven't been written to a client yet
+type queue struct {
+	msgs chan []byte
+	// closed when the client is removed
+	closed chan struct{}
+}
+
+// fanout queues every message sent for each of its clients
+type fanout struct {
+	lock    sync.RWMutex
+	queues  map[*queue]struct{}
+	metrics Metrics
+}
+
+func newFanout() *fanout {
+	return &fanout{
+		queues:  make(map[*queue]struct{}),
+		metrics: newUnregisteredMetrics(),
+	}
+}
+
+// add returns the queue of a new client. Returns nil if the fanout is closed.
+func (f *fanout) add() *queue {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.queues == nil {
+		return nil
+	}
+	q := &queue{
+		msgs:   make(chan []byte, maxQueuedMessages),
+		closed: make(chan struct{}),
+	}
+	f.queues[q] = struct{}{}
+	f.metrics.Clients.Inc()
+	return q
+}
+
+// remove stops queueing messages for [q]
+func (f *fanout) remove(q *queue) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, ok := f.queues[q]; !ok {
+		return
+	}
+	delete(f.queues, q)
+	close(q.closed)
+	f.metrics.Clients.Dec()
+}
+
+// send queues [msg] for every client. If a client's queue is full, waits up
+// to [maxSendDelay] for it to make room, so that slow clients apply
+// backpressure without blocking the caller indefinitely.
+func (f *fanout) send(msg []byte) {
+	f.lock.RLock()
+	queues := make([]*queue, 0, len(f.queues))
+	for q := range f.queues {
+		queues = append(queues, q)
+	}
+	f.lock.RUnlock()
+
+	var (
+		timer   *time.Timer
+		expired bool
+	)
+	for _, q := range queues {
+		select {
+		case q.msgs <- msg:
+			f.metrics.Sent.Inc()
+			continue
+		case <-q.closed:
+			continue
+		default:
+		}
+
+		if expired {
+			f.metrics.Dropped.Inc()
+			continue
+		}
+		if timer == nil {
+			timer = time.NewTimer(maxSendDelay)
+			defer timer.Stop()
+		}
+		select {
+		case q.msgs <- msg:
+			f.metrics.Sent.Inc()
+		case <-q.closed:
+		case <-timer.C:
+			expired = true
+			f.metrics.Dropped.Inc()
+		}
+	}
+}
+
+// close removes every client and stops accepting new ones
+func (f *fanout) close() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for q := range f.queues {
+		close(q.closed)
+		f.metrics.Clients.Dec()
+	}
+	f.queues = nil
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package socket
+
+import (
+	"crypto/tls"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/lasthyphen/dijetsgo/api/proto/ipcsproto"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+)
+
+var _ ipcsproto.EventsServer = &GRPCSocket{}
+
+// GRPCSocket manages streaming messages over gRPC to many subscribed clients
+type GRPCSocket struct {
+	ipcsproto.UnimplementedEventsServer
+
+	log       logging.Logger
+	addr      string
+	tlsConfig *tls.Config
+	fanout    *fanout
+	server    *grpc.Server
+	listener  net.Listener
+}
+
+// NewGRPCSocket creates a new socket object that serves the Events gRPC
+// service over TLS on the given TCP address. It does not open the socket until
+// Listen is called.
+func NewGRPCSocket(addr string, tlsConfig *tls.Config, log logging.Logger) *GRPCSocket {
+	return &GRPCSocket{
+		log:       log,
+		addr:      addr,
+		tlsConfig: tlsConfig,
+		fanout:    newFanout(),
+	}
+}
+
+// SetMetrics sets the metrics that track the delivery of messages. Must be
+// called before Listen.
+func (s *GRPCSocket) SetMetrics(metrics Metrics) {
+	s.fanout.metrics = metrics
+}
+
+// Listen starts serving streams on the socket
+func (s *GRPCSocket) Listen() error {
+	l, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	s.listener = l
+	s.server = grpc.NewServer(grpc.Creds(credentials.NewTLS(s.tlsConfig)))
+	ipcsproto.RegisterEventsServer(s.server, s)
+
+	go func() {
+		if err := s.server.Serve(l); err != nil {
+			s.log.Debug("gRPC socket stopped serving: %s", err)
+		}
+	}()
+	return nil
+}
+
+// Stream sends the messages sent to the socket to the client until the client
+// cancels the stream or the socket is closed
+func (s *GRPCSocket) Stream(_ *ipcsproto.StreamRequest, stream ipcsproto.Events_StreamServer) error {
+	q := s.fanout.add()
+	if q == nil {
+		return nil
+	}
+	defer s.fanout.remove(q)
+
+	ctx := stream.Context()
+	for {
+		select {
+		case msg := <-q.msgs:
+			if err := stream.Send(&ipcsproto.Event{Container: msg}); err != nil {
+				s.log.Debug("closing stream after failing to send message: %s", err)
+				return err
+			}
+		case <-q.closed:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Send queues the given message for all connected clients. Clients that read
+// too slowly miss the message, and the drop is recorded in the metrics.
+func (s *GRPCSocket) Send(msg []byte) {
+	s.fanout.send(msg)
+}
+
+// Addr returns the address the socket is listening on
+func (s *GRPCSocket) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Close stops serving streams and closes all of them
+func (s *GRPCSocket) Close() error {
+	s.fanout.close()
+	if s.server != nil {
+		s.server.Stop()
+	}
+	return nil
+}
@@ -4,6 +4,7 @@
 package socket
 
 import (
+	"crypto/tls"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -13,11 +14,16 @@ import (
 	"sync"
 	"sync/atomic"
 	"syscall"
+	"time"
 
+	"github.com/lasthyphen/dijetsgo/utils/constants"
 	"github.com/lasthyphen/dijetsgo/utils/logging"
 	"github.com/lasthyphen/dijetsgo/utils/wrappers"
 )
 
+// Time allowed for a TLS client to complete the handshake
+const handshakeTimeout = 10 * time.Second
+
 var (
 	// ErrMessageTooLarge is returned when reading a message that is larger than
 	// our max size
@@ -30,9 +36,11 @@ var (
 type Socket struct {
 	log      logging.Logger
 	addr     string
+	listen   listenFn
 	accept   acceptFn
 	connLock *sync.RWMutex
 	conns    map[net.Conn]struct{}
+	fanout   *fanout
 	quitCh   chan struct{}
 	doneCh   chan struct{}
 	listener net.Listener // the current listener
@@ -44,17 +52,40 @@ func NewSocket(addr string, log logging.Logger) *Socket {
 	return &Socket{
 		log:      log,
 		addr:     addr,
+		listen:   listen,
 		accept:   accept,
 		connLock: &sync.RWMutex{},
 		conns:    map[net.Conn]struct{}{},
+		fanout:   newFanout(),
 		quitCh:   make(chan struct{}),
 		doneCh:   make(chan struct{}),
 	}
 }
 
+// NewTLSSocket creates a new socket object that listens for TLS connections on
+// the given TCP address. Clients must present a certificate if [tlsConfig]
+// requires it. It does not open the socket until Listen is called.
+func NewTLSSocket(addr string, tlsConfig *tls.Config, log logging.Logger) *Socket {
+	s := NewSocket(addr, log)
+	s.listen = func(addr string) (net.Listener, error) {
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		return tls.NewListener(l, tlsConfig), nil
+	}
+	return s
+}
+
+// SetMetrics sets the metrics that track the delivery of messages. Must be
+// called before Listen.
+func (s *Socket) SetMetrics(metrics Metrics) {
+	s.fanout.metrics = metrics
+}
+
 // Listen starts listening on the socket for new connection
 func (s *Socket) Listen() error {
-	l, err := listen(s.addr)
+	l, err := s.listen(s.addr)
 	if err != nil {
 		return err
 	}
@@ -76,38 +107,15 @@ func (s *Socket) Listen() error {
 	return nil
 }
 
-// Send writes the given message to all connection clients
+// Send queues the given message for all connected clients. Clients that read
+// too slowly miss the message, and the drop is recorded in the metrics.
 func (s *Socket) Send(msg []byte) {
-	var conns []net.Conn
-
-	// Get a copy of connections
-	s.connLock.RLock()
-	if len(s.conns) > 0 {
-		conns = make([]net.Conn, len(s.conns))
-		i := 0
-		for conn := range s.conns {
-			conns[i] = conn
-			i++
-		}
-	}
-	s.connLock.RUnlock()
-
-	// Write to each connection
-	if len(conns) == 0 {
-		return
-	}
+	s.fanout.send(msg)
+}
 
-	// Prefix the message with an 8 byte length
-	lenBytes := [8]byte{}
-	binary.BigEndian.PutUint64(lenBytes[:], uint64(len(msg)))
-	for _, conn := range conns {
-		for _, byteSlice := range [][]byte{lenBytes[:], msg} {
-			if _, err := conn.Write(byteSlice); err != nil {
-				s.removeConn(conn)
-				s.log.Debug("failed to write message to %s: %s", conn.RemoteAddr(), err)
-			}
-		}
-	}
+// Addr returns the address the socket is listening on
+func (s *Socket) Addr() net.Addr {
+	return s.listener.Addr()
 }
 
 // Close closes the socket by cutting off new connections, closing all
@@ -124,6 +132,9 @@ func (s *Socket) Close() error {
 
 	<-s.doneCh
 
+	// Stop the writers of all connections
+	s.fanout.close()
+
 	// Zero out the connection pool but save a reference so we can close them all
 	s.connLock.Lock()
 	conns := s.conns
@@ -144,10 +155,90 @@ func (s *Socket) Running() bool {
 	return s.listener != nil
 }
 
-func (s *Socket) removeConn(c net.Conn) {
+// addConn starts writing the messages sent to the socket to [c]
+func (s *Socket) addConn(c net.Conn) {
+	q := s.fanout.add()
+	if q == nil {
+		// The socket is closed
+		_ = c.Close()
+		return
+	}
+
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+
+	if s.conns == nil {
+		// The socket was closed after [q] was added
+		_ = c.Close()
+		return
+	}
+	s.conns[c] = struct{}{}
+	go s.write(c, q)
+}
+
+// write writes the messages in [q] to [c] until [q] is closed or a write fails
+func (s *Socket) write(c net.Conn, q *queue) {
+	// Verify TLS clients as soon as they connect, rather than when the first
+	// message is sent to them
+	if tlsConn, ok := c.(*tls.Conn); ok {
+		if err := handshake(tlsConn); err != nil {
+			s.log.Debug("closing connection to %s after failed TLS handshake: %s", c.RemoteAddr(), err)
+			s.removeConn(c, q)
+			return
+		}
+	}
+
+	for {
+		select {
+		case msg := <-q.msgs:
+			// Prefix the message with an 8 byte length
+			lenBytes := [8]byte{}
+			binary.BigEndian.PutUint64(lenBytes[:], uint64(len(msg)))
+			for _, byteSlice := range [][]byte{lenBytes[:], msg} {
+				if _, err := c.Write(byteSlice); err != nil {
+					s.log.Debug("closing connection to %s after failing to write message: %s", c.RemoteAddr(), err)
+					s.removeConn(c, q)
+					return
+				}
+			}
+		case <-q.closed:
+			return
+		}
+	}
+}
+
+// handshake runs the TLS handshake of [c] within [handshakeTimeout]
+func handshake(c *tls.Conn) error {
+	if err := c.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return err
+	}
+	if err := c.Handshake(); err != nil {
+		return err
+	}
+	return c.SetDeadline(time.Time{})
+}
+
+func (s *Socket) removeConn(c net.Conn, q *queue) {
+	s.fanout.remove(q)
+
 	s.connLock.Lock()
+	_, ok := s.conns[c]
 	delete(s.conns, c)
 	s.connLock.Unlock()
+
+	// If the socket is being closed, Close closes the connection
+	if ok {
+		_ = c.Close()
+	}
+}
+
+// DialTLS creates a new *Client connected to the given TCP address over TLS
+func DialTLS(addr string, tlsConfig *tls.Config) (*Client, error) {
+	c, err := tls.Dial("tcp", addr, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{Conn: c, maxMessageSize: int64(constants.DefaultMaxMessageSize)}, nil
 }
 
 // Client is a read-only connection to a socket
@@ -203,6 +294,9 @@ func (e errReadTimeout) Error() string {
 	return fmt.Sprintf("read from %s timed out", e.addr)
 }
 
+// listenFn returns a Listener for the given address
+type listenFn func(addr string) (net.Listener, error)
+
 // acceptFn takes accepts connections from a Listener and gives them to a Socket
 type acceptFn func(*Socket, net.Listener)
 
@@ -215,6 +309,7 @@ func accept(s *Socket, l net.Listener) {
 			return
 		}
 		s.log.Error("socket accept error: %s", err.Error())
+		return
 	}
 	if conn, ok := conn.(*net.TCPConn); ok {
 		if err := conn.SetLinger(0); err != nil {
@@ -224,9 +319,7 @@ func accept(s *Socket, l net.Listener) {
 			s.log.Warn("failed to set socket nodelay due to: %s", err)
 		}
 	}
-	s.connLock.Lock()
-	s.conns[conn] = struct{}{}
-	s.connLock.Unlock()
+	s.addConn(conn)
 }
 
 // isTimeoutError checks if an error is a timeout as per the net.Error interface
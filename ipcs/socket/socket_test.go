@@ -6,6 +6,8 @@ package socket
 import (
 	"net"
 	"testing"
+
+	"github.com/lasthyphen/dijetsgo/utils/logging"
 )
 
 func TestSocketSendAndReceive(t *testing.T) {
@@ -17,7 +19,7 @@ func TestSocketSendAndReceive(t *testing.T) {
 	)
 
 	// Create socket and client; wait for client to connect
-	socket := NewSocket(socketName, nil)
+	socket := NewSocket(socketName, logging.NoLog{})
 	socket.accept, connCh = newTestAcceptFn()
 	if err := socket.Listen(); err != nil {
 		t.Fatal("Failed to listen on socket:", err.Error())
@@ -67,9 +69,7 @@ func newTestAcceptFn() (acceptFn, chan net.Conn) {
 			s.log.Error("socket accept error: %s", err.Error())
 		}
 
-		s.connLock.Lock()
-		s.conns[conn] = struct{}{}
-		s.connLock.Unlock()
+		s.addConn(conn)
 
 		connCh <- conn
 	}, connCh
@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package socket
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/api/proto/ipcsproto"
+	"github.com/lasthyphen/dijetsgo/staking"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+)
+
+// newTestTLSConfigs returns the configs of a server that requires clients to
+// present the client's certificate, and of that client
+func newTestTLSConfigs(t *testing.T) (*tls.Config, *tls.Config) {
+	serverCert, err := staking.NewTLSCert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCert, err := staking.NewTLSCert()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert.Leaf)
+	serverConfig := &tls.Config{
+		Certificates: []tls.Certificate{*serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	}
+	clientConfig := &tls.Config{
+		Certificates: []tls.Certificate{*clientCert},
+		// Staking certificates don't name a host
+		InsecureSkipVerify: true, //nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+	}
+	return serverConfig, clientConfig
+}
+
+// waitForClients waits until [metrics] reports [numClients] connected clients
+func waitForClients(t *testing.T, metrics Metrics, numClients float64) {
+	deadline := time.Now().Add(5 * time.Second)
+	for testutil.ToFloat64(metrics.Clients) != numClients {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %v clients", numClients)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTLSSocketSendAndReceive(t *testing.T) {
+	assert := assert.New(t)
+
+	serverConfig, clientConfig := newTestTLSConfigs(t)
+	socket := NewTLSSocket("127.0.0.1:0", serverConfig, logging.NoLog{})
+	metrics := newUnregisteredMetrics()
+	socket.SetMetrics(metrics)
+	assert.NoError(socket.Listen())
+	defer socket.Close()
+
+	// Clients without a certificate are rejected
+	client, err := DialTLS(socket.Addr().String(), &tls.Config{
+		InsecureSkipVerify: true, //nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+	})
+	if err == nil {
+		_, err = client.Recv()
+	}
+	assert.Error(err)
+
+	client, err = DialTLS(socket.Addr().String(), clientConfig)
+	assert.NoError(err)
+	defer client.Close()
+	waitForClients(t, metrics, 1)
+
+	socket.Send([]byte("dijets"))
+	msg, err := client.Recv()
+	assert.NoError(err)
+	assert.Equal([]byte("dijets"), msg)
+	assert.Equal(1.0, testutil.ToFloat64(metrics.Sent))
+}
+
+func TestFanoutDropsForSlowClients(t *testing.T) {
+	assert := assert.New(t)
+
+	f := newFanout()
+	slow := f.add()
+	fast := f.add()
+	for i := 0; i < maxQueuedMessages; i++ {
+		f.send([]byte{1})
+		<-fast.msgs
+	}
+
+	// The slow client's queue is full, so the message is dropped for it after
+	// waiting for it to make room
+	start := time.Now()
+	f.send([]byte{2})
+	assert.GreaterOrEqual(time.Since(start), maxSendDelay)
+	assert.Equal([]byte{2}, <-fast.msgs)
+	assert.Len(slow.msgs, maxQueuedMessages)
+	assert.Equal(1.0, testutil.ToFloat64(f.metrics.Dropped))
+	assert.Equal(float64(2*maxQueuedMessages+1), testutil.ToFloat64(f.metrics.Sent))
+
+	// The slow client is still connected
+	assert.Equal(2.0, testutil.ToFloat64(f.metrics.Clients))
+	f.remove(slow)
+	assert.Equal(1.0, testutil.ToFloat64(f.metrics.Clients))
+	f.close()
+	assert.Nil(f.add())
+}
+
+func TestGRPCSocketStream(t *testing.T) {
+	assert := assert.New(t)
+
+	serverConfig, clientConfig := newTestTLSConfigs(t)
+	socket := NewGRPCSocket("127.0.0.1:0", serverConfig, logging.NoLog{})
+	metrics := newUnregisteredMetrics()
+	socket.SetMetrics(metrics)
+	assert.NoError(socket.Listen())
+	defer socket.Close()
+
+	conn, err := grpc.Dial(socket.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(clientConfig)))
+	assert.NoError(err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := ipcsproto.NewEventsClient(conn).Stream(ctx, &ipcsproto.StreamRequest{})
+	assert.NoError(err)
+	waitForClients(t, metrics, 1)
+
+	socket.Send([]byte("dijets"))
+	event, err := stream.Recv()
+	assert.NoError(err)
+	assert.Equal([]byte("dijets"), event.Container)
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/pflag"
+
+	"github.com/lasthyphen/dijetsgo/codec/schema"
+	"github.com/lasthyphen/dijetsgo/vms/avm"
+	"github.com/lasthyphen/dijetsgo/vms/nftfx"
+	"github.com/lasthyphen/dijetsgo/vms/platformvm"
+	"github.com/lasthyphen/dijetsgo/vms/propertyfx"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+var errIncompatibleSchema = errors.New("codec schema is incompatible with the previous schema")
+
+// codecSchema prints the schema of the codec of a VM, or compares it with a
+// previously printed schema.
+func codecSchema(args []string) error {
+	fs := pflag.NewFlagSet("codec-schema", pflag.ContinueOnError)
+	vmName := fs.String("vm", "avm", "VM whose codec is described: avm or platformvm")
+	outputFile := fs.String("output", "", "File the schema is written to. Defaults to stdout")
+	previousFile := fs.String("compare", "", "File of a previous schema. If set, the changes that break compatibility with it are reported instead")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	var (
+		current *schema.Schema
+		err     error
+	)
+	switch *vmName {
+	case "avm":
+		// The fxs are ordered as in the X-Chain genesis
+		current, err = avm.NewCodecSchema([]avm.Fx{
+			&secp256k1fx.Fx{},
+			&nftfx.Fx{},
+			&propertyfx.Fx{},
+		})
+	case "platformvm":
+		current, err = platformvm.CodecSchema()
+	default:
+		return fmt.Errorf("unknown vm %q", *vmName)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *previousFile != "" {
+		previousBytes, err := ioutil.ReadFile(*previousFile)
+		if err != nil {
+			return err
+		}
+		previous := &schema.Schema{}
+		if err := json.Unmarshal(previousBytes, previous); err != nil {
+			return fmt.Errorf("couldn't parse %s: %w", *previousFile, err)
+		}
+		incompatibilities := schema.Compare(previous, current)
+		for _, incompatibility := range incompatibilities {
+			fmt.Println(incompatibility)
+		}
+		if len(incompatibilities) > 0 {
+			return errIncompatibleSchema
+		}
+		return nil
+	}
+
+	schemaJSON, err := json.MarshalIndent(current, "", "\t")
+	if err != nil {
+		return err
+	}
+	if *outputFile == "" {
+		fmt.Println(string(schemaJSON))
+		return nil
+	}
+	return ioutil.WriteFile(*outputFile, schemaJSON, 0o600)
+}
@@ -0,0 +1,211 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/lasthyphen/dijetsgo/config"
+	"github.com/lasthyphen/dijetsgo/database/leveldb"
+	"github.com/lasthyphen/dijetsgo/database/manager"
+	"github.com/lasthyphen/dijetsgo/database/rocksdb"
+	"github.com/lasthyphen/dijetsgo/genesis"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+)
+
+var (
+	errNoEncryptionSecret = fmt.Errorf("--%s or --%s must be set", config.DBEncryptionKeyFileKey, config.DBEncryptionPassphraseKey)
+	errMissingGenesisFlag = errors.New("--network-id, --allocations-file, --stakers-file, --staked-funds and --c-chain-genesis-file must be set")
+)
+
+// commands are run instead of the node when their name is the first argument.
+// They are given the remaining arguments.
+var commands = map[string]func(args []string) error{
+	"codec-schema":  codecSchema,
+	"encrypt-db":    encryptDB,
+	"genesis":       buildGenesis,
+	"local-network": runLocalNetwork,
+}
+
+// encryptDB encrypts the keys and values of the node's database with the
+// configured encryption secret. It accepts the node's flags.
+func encryptDB(args []string) error {
+	fs := config.BuildFlagSet()
+	v, err := config.BuildViper(fs, args)
+	if errors.Is(err, pflag.ErrHelp) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	dbConfig, err := config.GetDatabaseConfig(v)
+	if err != nil {
+		return err
+	}
+	if dbConfig.EncryptionSecret == nil {
+		return errNoEncryptionSecret
+	}
+
+	logConfig, err := logging.DefaultConfig()
+	if err != nil {
+		return err
+	}
+	logFactory := logging.NewFactory(logConfig)
+	defer logFactory.Close()
+	log, err := logFactory.Make("encrypt-db")
+	if err != nil {
+		return err
+	}
+	defer log.Stop()
+
+	switch dbConfig.Name {
+	case leveldb.Name:
+		return manager.EncryptLevelDB(dbConfig.Path, dbConfig.Config, log, dbConfig.EncryptionSecret)
+	case rocksdb.Name:
+		path := filepath.Join(dbConfig.Path, rocksdb.Name)
+		return manager.EncryptRocksDB(path, dbConfig.Config, log, dbConfig.EncryptionSecret)
+	default:
+		return fmt.Errorf("db-type %q can't be encrypted in place", dbConfig.Name)
+	}
+}
+
+// buildGenesis builds a genesis config for a custom network, checks that the
+// node would accept it and prints the IDs of the chains and assets it creates
+func buildGenesis(args []string) error {
+	fs := pflag.NewFlagSet("genesis", pflag.ContinueOnError)
+	networkName := fs.String("network-id", "", "Network ID of the custom network")
+	allocationsFile := fs.String("allocations-file", "", "CSV file of allocations: ethAddr,djtxAddr,initialAmount[,unlockAmount,unlockLocktime]...")
+	stakersFile := fs.String("stakers-file", "", "CSV file of initial stakers: nodeID,rewardAddress,delegationFee")
+	stakedFunds := fs.String("staked-funds", "", "Comma separated addresses whose unlock schedules are staked by the initial stakers")
+	startTimeStr := fs.String("start-time", "", "Genesis time, as a unix timestamp or in RFC3339 format. Defaults to the current time")
+	stakeDuration := fs.Duration("stake-duration", 365*24*time.Hour, "Staking duration of the first initial staker")
+	stakeDurationOffset := fs.Duration("stake-duration-offset", 90*time.Minute, "Reduction of the staking duration of each subsequent initial staker")
+	cChainGenesisFile := fs.String("c-chain-genesis-file", "", "File containing the C-Chain genesis")
+	xChainAssetsFile := fs.String("x-chain-assets-file", "", "JSON file of X-Chain assets to create in addition to DJTX")
+	message := fs.String("message", "", "Message included in the genesis")
+	outputFile := fs.String("output", "", "File the genesis config is written to. Defaults to stdout")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if *networkName == "" || *allocationsFile == "" || *stakersFile == "" || *stakedFunds == "" || *cChainGenesisFile == "" {
+		return errMissingGenesisFlag
+	}
+
+	networkID, err := constants.NetworkID(*networkName)
+	if err != nil {
+		return err
+	}
+	startTime, err := parseStartTime(*startTimeStr)
+	if err != nil {
+		return err
+	}
+	genesisConfig := &genesis.Config{
+		NetworkID:                  networkID,
+		StartTime:                  uint64(startTime.Unix()),
+		InitialStakeDuration:       uint64(stakeDuration.Seconds()),
+		InitialStakeDurationOffset: uint64(stakeDurationOffset.Seconds()),
+		Message:                    *message,
+	}
+
+	allocations, err := os.Open(*allocationsFile)
+	if err != nil {
+		return err
+	}
+	genesisConfig.Allocations, err = genesis.ParseAllocationsCSV(allocations)
+	_ = allocations.Close()
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", *allocationsFile, err)
+	}
+
+	stakers, err := os.Open(*stakersFile)
+	if err != nil {
+		return err
+	}
+	genesisConfig.InitialStakers, err = genesis.ParseStakersCSV(stakers)
+	_ = stakers.Close()
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", *stakersFile, err)
+	}
+
+	genesisConfig.InitialStakedFunds, err = genesis.ParseAddresses(*stakedFunds)
+	if err != nil {
+		return err
+	}
+
+	cChainGenesis, err := ioutil.ReadFile(*cChainGenesisFile)
+	if err != nil {
+		return err
+	}
+	genesisConfig.CChainGenesis = string(cChainGenesis)
+
+	if *xChainAssetsFile != "" {
+		xChainAssetsBytes, err := ioutil.ReadFile(*xChainAssetsFile)
+		if err != nil {
+			return err
+		}
+		xChainAssets := []genesis.UnparsedXChainAsset(nil)
+		if err := json.Unmarshal(xChainAssetsBytes, &xChainAssets); err != nil {
+			return fmt.Errorf("couldn't parse %s: %w", *xChainAssetsFile, err)
+		}
+		for _, unparsedAsset := range xChainAssets {
+			asset, err := unparsedAsset.Parse()
+			if err != nil {
+				return fmt.Errorf("couldn't parse asset %q: %w", unparsedAsset.Alias, err)
+			}
+			genesisConfig.XChainAssets = append(genesisConfig.XChainAssets, asset)
+		}
+	}
+
+	reply, err := genesis.Build(genesisConfig)
+	if err != nil {
+		return err
+	}
+	configJSON, err := json.MarshalIndent(reply.Config, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	// The IDs are printed to stderr when stdout holds the genesis config
+	summary := io.Writer(os.Stdout)
+	if *outputFile == "" {
+		fmt.Println(string(configJSON))
+		summary = os.Stderr
+	} else if err := ioutil.WriteFile(*outputFile, configJSON, 0o600); err != nil {
+		return err
+	}
+	for _, chain := range reply.Chains {
+		fmt.Fprintf(summary, "%s: %s\n", chain.Name, chain.ID)
+	}
+	for _, asset := range reply.Assets {
+		fmt.Fprintf(summary, "%s asset: %s\n", asset.Alias, asset.ID)
+	}
+	return nil
+}
+
+// parseStartTime parses a unix timestamp or an RFC3339 time. An empty string
+// is the current time.
+func parseStartTime(startTimeStr string) (time.Time, error) {
+	if startTimeStr == "" {
+		return time.Now(), nil
+	}
+	if unixTime, err := strconv.ParseInt(startTimeStr, 10, 64); err == nil {
+		return time.Unix(unixTime, 0), nil
+	}
+	return time.Parse(time.RFC3339, startTimeStr)
+}
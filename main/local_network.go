@@ -0,0 +1,237 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/lasthyphen/dijetsgo/app/localnet"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+	"github.com/lasthyphen/dijetsgo/utils/perms"
+)
+
+const (
+	networkFileName = "network.json"
+
+	localNetworkUsage = `Commands:
+  add          start a node that bootstraps from the validators
+  kill <name>  kill a node
+  list         list the nodes
+  stop         stop the network and exit`
+)
+
+var errSignaled = errors.New("interrupted by a signal")
+
+// nodeInfo describes a node in the network file
+type nodeInfo struct {
+	Name           string `json:"name"`
+	NodeID         string `json:"nodeID"`
+	URI            string `json:"uri"`
+	StakingAddress string `json:"stakingAddress"`
+	Validator      bool   `json:"validator"`
+	Running        bool   `json:"running"`
+}
+
+// runLocalNetwork starts a local network and then adds and kills nodes as
+// instructed on stdin until it's stopped. Arguments after "--" are passed to
+// every node.
+func runLocalNetwork(args []string) error {
+	fs := pflag.NewFlagSet("local-network", pflag.ContinueOnError)
+	numNodes := fs.Int("nodes", 5, "Number of nodes started with the network. They validate the primary network from genesis")
+	rootDir := fs.String("dir", "", "Directory holding the genesis and the data of each node. Defaults to a temporary directory")
+	networkID := fs.Uint32("network-id", localnet.DefaultNetworkID, "Network ID of the local network")
+	binaryPath := fs.String("binary", "", "Path of the node binary. Defaults to this binary")
+	healthTimeout := fs.Duration("health-timeout", 2*time.Minute, "Maximum time to wait for the nodes to become healthy")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			fmt.Println(localNetworkUsage)
+			return nil
+		}
+		return err
+	}
+
+	if *rootDir == "" {
+		dir, err := ioutil.TempDir("", "local-network")
+		if err != nil {
+			return err
+		}
+		*rootDir = dir
+	}
+
+	logConfig, err := logging.DefaultConfig()
+	if err != nil {
+		return err
+	}
+	logConfig.Directory = filepath.Join(*rootDir, "logs")
+	logFactory := logging.NewFactory(logConfig)
+	defer logFactory.Close()
+	log, err := logFactory.Make("local-network")
+	if err != nil {
+		return err
+	}
+	defer log.Stop()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	network, err := localnet.New(localnet.Config{
+		BinaryPath: *binaryPath,
+		RootDir:    *rootDir,
+		NetworkID:  *networkID,
+		NumNodes:   *numNodes,
+		Flags:      fs.Args(),
+		Log:        log,
+	})
+	if err != nil {
+		return err
+	}
+	defer network.Stop()
+
+	if err := awaitHealthy(network.AwaitHealthy, *healthTimeout, signals); err != nil {
+		return err
+	}
+	log.Info("network is healthy. Its data is in %s", *rootDir)
+	if err := writeNetworkFile(network, *rootDir); err != nil {
+		return err
+	}
+	printNodes(network)
+	fmt.Println(localNetworkUsage)
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		// Without stdin, the network runs until it's signaled to stop
+	}()
+
+	for {
+		select {
+		case <-signals:
+			return nil
+		case line := <-lines:
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+			switch fields[0] {
+			case "add":
+				node, err := network.AddNode()
+				if err != nil {
+					fmt.Printf("couldn't add a node: %s\n", err)
+					continue
+				}
+				err = awaitHealthy(node.AwaitHealthy, *healthTimeout, signals)
+				if err == errSignaled {
+					return nil
+				}
+				if err != nil {
+					fmt.Println(err)
+					continue
+				}
+				fmt.Printf("%s is healthy at %s\n", node.Name, node.URI)
+			case "kill":
+				if len(fields) != 2 {
+					fmt.Println("usage: kill <name>")
+					continue
+				}
+				if err := network.KillNode(fields[1]); err != nil {
+					fmt.Println(err)
+					continue
+				}
+			case "list":
+				printNodes(network)
+				continue
+			case "stop":
+				return nil
+			default:
+				fmt.Println(localNetworkUsage)
+				continue
+			}
+			if err := writeNetworkFile(network, *rootDir); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// awaitHealthy calls [await] with a context that is cancelled after [timeout]
+// or when a signal is received. Returns errSignaled if a signal was received.
+func awaitHealthy(await func(context.Context) error, timeout time.Duration, signals <-chan os.Signal) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	signaled := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		select {
+		case <-signals:
+			close(signaled)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	err := await(ctx)
+	cancel()
+	<-done
+	select {
+	case <-signaled:
+		return errSignaled
+	default:
+		return err
+	}
+}
+
+func nodeInfos(network *localnet.Network) []nodeInfo {
+	nodes := network.Nodes()
+	infos := make([]nodeInfo, len(nodes))
+	for i, node := range nodes {
+		infos[i] = nodeInfo{
+			Name:           node.Name,
+			NodeID:         node.NodeID.PrefixedString(constants.NodeIDPrefix),
+			URI:            node.URI,
+			StakingAddress: node.StakingAddress,
+			Validator:      node.Validator,
+			Running:        node.Running(),
+		}
+	}
+	return infos
+}
+
+func printNodes(network *localnet.Network) {
+	for _, info := range nodeInfos(network) {
+		state := "running"
+		if !info.Running {
+			state = "stopped"
+		}
+		fmt.Printf("%s\t%s\t%s\t%s\n", info.Name, info.NodeID, info.URI, state)
+	}
+}
+
+// writeNetworkFile writes the nodes of [network] to a file in [rootDir], for
+// scripts and tests to find their URIs
+func writeNetworkFile(network *localnet.Network, rootDir string) error {
+	infoBytes, err := json.MarshalIndent(nodeInfos(network), "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(rootDir, networkFileName), infoBytes, perms.ReadWrite)
+}
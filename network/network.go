@@ -221,14 +221,16 @@ type Config struct {
 	DialerConfig dialer.Config `json:"dialerConfig"`
 	TLSConfig    *tls.Config   `json:"-"`
 
-	Namespace          string              `json:"namespace"`
-	MyNodeID           ids.ShortID         `json:"myNodeID"`
-	MyIP               utils.DynamicIPDesc `json:"myIP"`
-	NetworkID          uint32              `json:"networkID"`
-	MaxClockDifference time.Duration       `json:"maxClockDifference"`
-	PingFrequency      time.Duration       `json:"pingFrequency"`
-	AllowPrivateIPs    bool                `json:"allowPrivateIPs"`
-	CompressionEnabled bool                `json:"compressionEnabled"`
+	Namespace string              `json:"namespace"`
+	MyNodeID  ids.ShortID         `json:"myNodeID"`
+	MyIP      utils.DynamicIPDesc `json:"myIP"`
+	NetworkID uint32              `json:"networkID"`
+	// Upgrades of the network, which determine the compatible peer versions
+	NetworkUpgrades    version.NetworkUpgrades `json:"networkUpgrades"`
+	MaxClockDifference time.Duration           `json:"maxClockDifference"`
+	PingFrequency      time.Duration           `json:"pingFrequency"`
+	AllowPrivateIPs    bool                    `json:"allowPrivateIPs"`
+	CompressionEnabled bool                    `json:"compressionEnabled"`
 	// This node's TLS key
 	TLSKey crypto.Signer `json:"-"`
 	// WhitelistedSubnets of the node
@@ -286,7 +288,7 @@ func NewNetwork(
 		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
 		benchlistManager:            benchlistManager,
 		latestPeerIP:                make(map[ids.ShortID]signedPeerIP),
-		versionCompatibility:        version.GetCompatibility(config.NetworkID),
+		versionCompatibility:        config.NetworkUpgrades.Compatibility(),
 		config:                      config,
 		mc:                          msgCreator,
 	}
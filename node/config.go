@@ -7,6 +7,8 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/lasthyphen/dijetsgo/api/keystore"
+	"github.com/lasthyphen/dijetsgo/api/ratelimit"
 	"github.com/lasthyphen/dijetsgo/chains"
 	"github.com/lasthyphen/dijetsgo/genesis"
 	"github.com/lasthyphen/dijetsgo/ids"
@@ -21,6 +23,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/utils/logging"
 	"github.com/lasthyphen/dijetsgo/utils/profiler"
 	"github.com/lasthyphen/dijetsgo/utils/timer"
+	"github.com/lasthyphen/dijetsgo/version"
 	"github.com/lasthyphen/dijetsgo/vms"
 )
 
@@ -28,11 +31,17 @@ type IPCConfig struct {
 	IPCAPIEnabled      bool     `json:"ipcAPIEnabled"`
 	IPCPath            string   `json:"ipcPath"`
 	IPCDefaultChainIDs []string `json:"ipcDefaultChainIDs"`
+	// Used to publish over TCP and gRPC. Clients must present a certificate
+	// signed by one of the configured CAs. If nil, chains can only be
+	// published over Unix sockets.
+	IPCTLSConfig *tls.Config `json:"-"`
 }
 
 type APIAuthConfig struct {
 	APIRequireAuthToken bool   `json:"apiRequireAuthToken"`
 	APIAuthPassword     string `json:"-"`
+	// Principal name --> password of each API principal other than the admin
+	APIAuthPrincipals map[string]string `json:"-"`
 }
 
 type APIIndexerConfig struct {
@@ -51,6 +60,10 @@ type HTTPConfig struct {
 
 	APIAllowedOrigins []string `json:"apiAllowedOrigins"`
 
+	// If true, serves the gRPC APIs on [HTTPHost]:[GRPCPort]
+	GRPCAPIEnabled bool   `json:"grpcAPIEnabled"`
+	GRPCPort       uint16 `json:"grpcPort"`
+
 	ShutdownTimeout time.Duration `json:"shutdownTimeout"`
 	ShutdownWait    time.Duration `json:"shutdownWait"`
 }
@@ -66,6 +79,13 @@ type APIConfig struct {
 	KeystoreAPIEnabled bool `json:"keystoreAPIEnabled"`
 	MetricsAPIEnabled  bool `json:"metricsAPIEnabled"`
 	HealthAPIEnabled   bool `json:"healthAPIEnabled"`
+
+	// If non-nil, the data keys of new keystore users are wrapped by the
+	// transit engine of this Vault server
+	KeystoreVaultConfig *keystore.VaultConfig `json:"keystoreVaultConfig"`
+
+	// Rate limits of API calls. If there are no rules, calls aren't limited.
+	APIRateLimitConfig ratelimit.Config `json:"rateLimitConfig"`
 }
 
 type IPConfig struct {
@@ -123,6 +143,10 @@ type DatabaseConfig struct {
 
 	// Path to config file
 	Config []byte `json:"-"`
+
+	// If non-nil, the keys and values of the database are encrypted with a
+	// key derived from this secret
+	EncryptionSecret []byte `json:"-"`
 }
 
 // Config contains all of the configurations of an Avalanche node.
@@ -141,6 +165,9 @@ type Config struct {
 	// ID of the network this node should connect to
 	NetworkID uint32 `json:"networkID"`
 
+	// Times the network activates its upgrades
+	NetworkUpgrades version.NetworkUpgrades `json:"networkUpgrades"`
+
 	// Assertions configuration
 	EnableAssertions bool `json:"enableAssertions"`
 
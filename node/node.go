@@ -26,6 +26,10 @@ import (
 	"github.com/lasthyphen/dijetsgo/api/info"
 	"github.com/lasthyphen/dijetsgo/api/keystore"
 	"github.com/lasthyphen/dijetsgo/api/metrics"
+	"github.com/lasthyphen/dijetsgo/api/proto/healthproto"
+	"github.com/lasthyphen/dijetsgo/api/proto/infoproto"
+	"github.com/lasthyphen/dijetsgo/api/proto/platformproto"
+	"github.com/lasthyphen/dijetsgo/api/ratelimit"
 	"github.com/lasthyphen/dijetsgo/api/server"
 	"github.com/lasthyphen/dijetsgo/chains"
 	"github.com/lasthyphen/dijetsgo/chains/atomic"
@@ -56,6 +60,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/utils/wrappers"
 	"github.com/lasthyphen/dijetsgo/version"
 	"github.com/lasthyphen/dijetsgo/vms/avm"
+	"github.com/lasthyphen/dijetsgo/vms/compliancefx"
 	"github.com/lasthyphen/dijetsgo/vms/nftfx"
 	"github.com/lasthyphen/dijetsgo/vms/platformvm"
 	"github.com/lasthyphen/dijetsgo/vms/propertyfx"
@@ -136,6 +141,9 @@ type Node struct {
 	// Handles HTTP API calls
 	APIServer server.Server
 
+	// Handles gRPC API calls. Only used if the gRPC API is enabled.
+	GRPCServer server.GRPCServer
+
 	// This node's configuration
 	Config *Config
 
@@ -248,6 +256,7 @@ func (n *Node) initNetworking() error {
 	n.Config.NetworkConfig.MyNodeID = n.ID
 	n.Config.NetworkConfig.MyIP = n.Config.IP
 	n.Config.NetworkConfig.NetworkID = n.Config.NetworkID
+	n.Config.NetworkConfig.NetworkUpgrades = n.Config.NetworkUpgrades
 	n.Config.NetworkConfig.Validators = n.vdrs
 	n.Config.NetworkConfig.Beacons = n.beacons
 	n.Config.NetworkConfig.TLSConfig = tlsConfig
@@ -352,6 +361,26 @@ func (n *Node) Dispatch() error {
 		n.Shutdown(1)
 	})
 
+	// Start the gRPC API server
+	if n.Config.GRPCAPIEnabled {
+		go n.Log.RecoverAndPanic(func() {
+			var err error
+			if n.Config.HTTPSEnabled {
+				n.Log.Debug("initializing gRPC API server with TLS")
+				err = n.GRPCServer.DispatchTLS(n.Config.HTTPSCert, n.Config.HTTPSKey)
+			} else {
+				n.Log.Debug("initializing gRPC API server without TLS")
+				err = n.GRPCServer.Dispatch()
+			}
+			// When [n].Shutdown() is called, [n.GRPCServer].Shutdown() is
+			// called, which causes [n.GRPCServer].Dispatch() to return.
+			if !n.shuttingDown.GetValue() {
+				n.Log.Fatal("gRPC API server dispatch failed with %s", err)
+			}
+			n.Shutdown(1)
+		})
+	}
+
 	// Add bootstrap nodes to the peer network
 	for _, peerIP := range n.Config.BootstrapIPs {
 		if !peerIP.Equal(n.Config.IP.IP()) {
@@ -436,7 +465,16 @@ func (n *Node) initIPCs() error {
 	}
 
 	var err error
-	n.IPCs, err = ipcs.NewChainIPCs(n.Log, n.Config.IPCPath, n.Config.NetworkID, n.ConsensusDispatcher, n.DecisionDispatcher, chainIDs)
+	n.IPCs, err = ipcs.NewChainIPCs(
+		n.Log,
+		n.Config.IPCPath,
+		n.Config.NetworkID,
+		n.ConsensusDispatcher,
+		n.DecisionDispatcher,
+		chainIDs,
+		n.Config.IPCTLSConfig,
+		n.MetricsRegisterer,
+	)
 	return err
 }
 
@@ -454,6 +492,7 @@ func (n *Node) initIndexer() error {
 		DecisionDispatcher:   n.DecisionDispatcher,
 		ConsensusDispatcher:  n.ConsensusDispatcher,
 		APIServer:            &n.APIServer,
+		GRPCServer:           n.grpcServiceAdder(),
 		ShutdownF:            func() { n.Shutdown(0) }, // TODO put exit code here
 	})
 	if err != nil {
@@ -485,6 +524,11 @@ func (n *Node) initChains(genesisBytes []byte) {
 func (n *Node) initAPIServer() error {
 	n.Log.Info("initializing API server")
 
+	limiter, err := ratelimit.New(n.Log, n.Config.APIRateLimitConfig, "api", n.MetricsRegisterer)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize API rate limiter: %w", err)
+	}
+
 	if !n.Config.APIRequireAuthToken {
 		n.APIServer.Initialize(
 			n.Log,
@@ -494,15 +538,28 @@ func (n *Node) initAPIServer() error {
 			n.Config.APIAllowedOrigins,
 			n.Config.ShutdownTimeout,
 			n.ID,
+			limiter,
 		)
+		n.initGRPCServer(nil)
 		return nil
 	}
 
-	a, err := auth.New(n.Log, "auth", n.Config.APIAuthPassword)
+	auditLog, err := n.LogFactory.Make("api-audit")
+	if err != nil {
+		return fmt.Errorf("problem initializing API audit logger: %w", err)
+	}
+	a, err := auth.New(n.Log, auditLog, "auth", n.Config.APIAuthPassword)
 	if err != nil {
 		return err
 	}
+	for name, pw := range n.Config.APIAuthPrincipals {
+		if err := a.AddPrincipal(n.Config.APIAuthPassword, name, pw); err != nil {
+			return fmt.Errorf("couldn't add API principal %q: %w", name, err)
+		}
+	}
 
+	// The rate limiter wraps the auth handler so that calls with invalid auth
+	// tokens are also limited.
 	n.APIServer.Initialize(
 		n.Log,
 		n.LogFactory,
@@ -512,7 +569,9 @@ func (n *Node) initAPIServer() error {
 		n.Config.ShutdownTimeout,
 		n.ID,
 		a,
+		limiter,
 	)
+	n.initGRPCServer(a)
 
 	// only create auth service if token authorization is required
 	n.Log.Info("API authorization is enabled. Auth tokens must be passed in the header of API requests, except requests to the auth service.")
@@ -527,6 +586,33 @@ func (n *Node) initAPIServer() error {
 	return n.APIServer.AddRoute(handler, &sync.RWMutex{}, "auth", "", n.Log)
 }
 
+// initGRPCServer initializes the server that handles gRPC calls, if it is
+// enabled. If [authenticator] is non-nil, gRPC calls require the same auth
+// tokens as HTTP calls.
+func (n *Node) initGRPCServer(authenticator server.Authenticator) {
+	if !n.Config.GRPCAPIEnabled {
+		return
+	}
+
+	n.Log.Info("initializing gRPC API server")
+	n.GRPCServer.Initialize(
+		n.Log,
+		n.Config.HTTPHost,
+		n.Config.GRPCPort,
+		n.Config.ShutdownTimeout,
+		authenticator,
+	)
+}
+
+// grpcServiceAdder returns the server that gRPC services should be added to,
+// or nil if the gRPC API is disabled.
+func (n *Node) grpcServiceAdder() server.ServiceAdder {
+	if !n.Config.GRPCAPIEnabled {
+		return nil
+	}
+	return &n.GRPCServer
+}
+
 // Add the default VM aliases
 func (n *Node) addDefaultVMAliases() error {
 	n.Log.Info("adding the default VM aliases")
@@ -632,8 +718,8 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 		BootstrapMaxTimeGetAncestors:            n.Config.BootstrapMaxTimeGetAncestors,
 		BootstrapAncestorsMaxContainersSent:     n.Config.BootstrapAncestorsMaxContainersSent,
 		BootstrapAncestorsMaxContainersReceived: n.Config.BootstrapAncestorsMaxContainersReceived,
-		ApricotPhase4Time:                       version.GetApricotPhase4Time(n.Config.NetworkID),
-		ApricotPhase4MinPChainHeight:            version.GetApricotPhase4MinPChainHeight(n.Config.NetworkID),
+		ApricotPhase4Time:                       n.Config.NetworkUpgrades.ApricotPhase4Time,
+		ApricotPhase4MinPChainHeight:            n.Config.NetworkUpgrades.ApricotPhase4MinPChainHeight,
 		ResetProposerVMHeightIndex:              n.Config.ResetProposerVMHeightIndex,
 	})
 
@@ -646,6 +732,20 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 		vdrs = validators.NewManager()
 	}
 
+	// The platform chain serves its gRPC API once it is running
+	var platformGRPCService *platformvm.GRPCService
+	if n.Config.GRPCAPIEnabled {
+		platformGRPCService = platformvm.NewGRPCService()
+		err := n.GRPCServer.AddService(
+			&platformproto.Platform_ServiceDesc,
+			platformGRPCService,
+			constants.ChainAliasPrefix+"P",
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Register the VMs that Avalanche supports
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -653,6 +753,7 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 			Chains:                 n.chainManager,
 			Validators:             vdrs,
 			UptimeLockedCalculator: n.uptimeCalculator,
+			GRPCService:            platformGRPCService,
 			StakingEnabled:         n.Config.EnableStaking,
 			WhitelistedSubnets:     n.Config.WhitelistedSubnets,
 			TxFee:                  n.Config.TxFee,
@@ -667,9 +768,10 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 			MinStakeDuration:       n.Config.MinStakeDuration,
 			MaxStakeDuration:       n.Config.MaxStakeDuration,
 			RewardConfig:           n.Config.RewardConfig,
-			ApricotPhase3Time:      version.GetApricotPhase3Time(n.Config.NetworkID),
-			ApricotPhase4Time:      version.GetApricotPhase4Time(n.Config.NetworkID),
-			ApricotPhase5Time:      version.GetApricotPhase5Time(n.Config.NetworkID),
+			ApricotPhase3Time:      n.Config.NetworkUpgrades.ApricotPhase3Time,
+			ApricotPhase4Time:      n.Config.NetworkUpgrades.ApricotPhase4Time,
+			ApricotPhase5Time:      n.Config.NetworkUpgrades.ApricotPhase5Time,
+			ApricotPhase6Time:      n.Config.NetworkUpgrades.ApricotPhase6Time,
 		}),
 		n.Config.VMManager.RegisterFactory(constants.AVMID, &avm.Factory{
 			TxFee:            n.Config.TxFee,
@@ -678,6 +780,7 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 		n.Config.VMManager.RegisterFactory(secp256k1fx.ID, &secp256k1fx.Factory{}),
 		n.Config.VMManager.RegisterFactory(nftfx.ID, &nftfx.Factory{}),
 		n.Config.VMManager.RegisterFactory(propertyfx.ID, &propertyfx.Factory{}),
+		n.Config.VMManager.RegisterFactory(compliancefx.ID, &compliancefx.Factory{}),
 		n.Config.VMManager.RegisterFactory(constants.EVMID, &dijeth.Factory{}),
 		rpcchainvm.RegisterPlugins(n.Config.PluginDir, n.Config.VMManager),
 	)
@@ -769,7 +872,16 @@ func (n *Node) initSharedMemory() error {
 func (n *Node) initKeystoreAPI() error {
 	n.Log.Info("initializing keystore")
 	keystoreDB := n.DBManager.NewPrefixDBManager([]byte("keystore"))
-	n.keystore = keystore.New(n.Log, keystoreDB)
+	if n.Config.KeystoreVaultConfig == nil {
+		n.keystore = keystore.New(n.Log, keystoreDB)
+	} else {
+		n.Log.Info("wrapping the data keys of new keystore users with Vault at %s", n.Config.KeystoreVaultConfig.Address)
+		storage, err := keystore.NewVaultKeyStorage(*n.Config.KeystoreVaultConfig)
+		if err != nil {
+			return fmt.Errorf("couldn't create keystore key storage: %w", err)
+		}
+		n.keystore = keystore.NewWithKeyStorage(n.Log, keystoreDB, storage)
+	}
 	keystoreHandler, err := n.keystore.CreateHandler()
 	if err != nil {
 		return err
@@ -786,12 +898,16 @@ func (n *Node) initKeystoreAPI() error {
 	return n.APIServer.AddRoute(handler, &sync.RWMutex{}, "keystore", "", n.HTTPLog)
 }
 
-// initMetricsAPI initializes the Metrics API
-// Assumes n.APIServer is already set
-func (n *Node) initMetricsAPI() error {
+// initMetrics initializes the registry that the node's metrics are registered
+// in
+func (n *Node) initMetrics() {
 	n.MetricsRegisterer = prometheus.NewRegistry()
 	n.MetricsGatherer = metrics.NewMultiGatherer()
+}
 
+// initMetricsAPI initializes the Metrics API
+// Assumes n.APIServer and the metrics registry are already set
+func (n *Node) initMetricsAPI() error {
 	if !n.Config.MetricsAPIEnabled {
 		n.Log.Info("skipping metrics API initialization because it has been disabled")
 		return nil
@@ -885,7 +1001,7 @@ func (n *Node) initInfoAPI() error {
 	n.Log.Info("initializing info API")
 
 	primaryValidators, _ := n.vdrs.GetValidators(constants.PrimaryNetworkID)
-	service, err := info.NewService(
+	service := info.New(
 		info.Parameters{
 			Version:               version.CurrentApp,
 			NodeID:                n.ID,
@@ -894,6 +1010,7 @@ func (n *Node) initInfoAPI() error {
 			CreateAssetTxFee:      n.Config.CreateAssetTxFee,
 			CreateSubnetTxFee:     n.Config.CreateSubnetTxFee,
 			CreateBlockchainTxFee: n.Config.CreateBlockchainTxFee,
+			NetworkUpgrades:       n.Config.NetworkUpgrades,
 		},
 		n.Log,
 		n.chainManager,
@@ -902,10 +1019,18 @@ func (n *Node) initInfoAPI() error {
 		version.NewDefaultApplicationParser(),
 		primaryValidators,
 	)
+	handler, err := info.NewHandler(service)
 	if err != nil {
 		return err
 	}
-	return n.APIServer.AddRoute(service, &sync.RWMutex{}, "info", "", n.HTTPLog)
+	if err := n.APIServer.AddRoute(handler, &sync.RWMutex{}, "info", "", n.HTTPLog); err != nil {
+		return err
+	}
+
+	if !n.Config.GRPCAPIEnabled {
+		return nil
+	}
+	return n.GRPCServer.AddService(&infoproto.Info_ServiceDesc, info.NewGRPCService(service), "info")
 }
 
 // initHealthAPI initializes the Health API service
@@ -1028,7 +1153,7 @@ func (n *Node) initHealthAPI() error {
 		return err
 	}
 
-	return n.APIServer.AddRoute(
+	err = n.APIServer.AddRoute(
 		&common.HTTPHandler{
 			LockOptions: common.NoLock,
 			Handler:     health.NewGetHandler(healthChecker.Liveness),
@@ -1038,6 +1163,18 @@ func (n *Node) initHealthAPI() error {
 		"/liveness",
 		n.HTTPLog,
 	)
+	if err != nil {
+		return err
+	}
+
+	if !n.Config.GRPCAPIEnabled {
+		return nil
+	}
+	return n.GRPCServer.AddService(
+		&healthproto.Health_ServiceDesc,
+		health.NewGRPCService(n.Log, healthChecker),
+		"health",
+	)
 }
 
 // initIPCAPI initializes the IPC API service
@@ -1122,6 +1259,8 @@ func (n *Node) Initialize(
 	if err = n.initBeacons(); err != nil { // Configure the beacons
 		return fmt.Errorf("problem initializing node beacons: %w", err)
 	}
+	n.initMetrics()
+
 	// Start HTTP APIs
 	if err := n.initAPIServer(); err != nil { // Start the API Server
 		return fmt.Errorf("couldn't initialize API server: %w", err)
@@ -1244,6 +1383,9 @@ func (n *Node) shutdown() {
 	if err := n.APIServer.Shutdown(); err != nil {
 		n.Log.Debug("error during API shutdown: %s", err)
 	}
+	if err := n.GRPCServer.Shutdown(); err != nil {
+		n.Log.Debug("error during gRPC API shutdown: %s", err)
+	}
 	if err := n.indexer.Close(); err != nil {
 		n.Log.Debug("error closing tx indexer: %s", err)
 	}
@@ -7,24 +7,38 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
 
+	"github.com/lasthyphen/dijetsgo/ids"
 	"github.com/lasthyphen/dijetsgo/utils/bloom"
 )
 
 var (
 	ErrFilterNotInitialized        = errors.New("filter not initialized")
 	ErrAddressLimit                = errors.New("address limit exceeded")
+	ErrAssetIDLimit                = errors.New("asset ID limit exceeded")
+	ErrTxTypeLimit                 = errors.New("tx type limit exceeded")
+	ErrNodeIDLimit                 = errors.New("node ID limit exceeded")
+	ErrSubnetIDLimit               = errors.New("subnet ID limit exceeded")
 	ErrInvalidFilterParam          = errors.New("invalid bloom filter params")
 	ErrInvalidCommand              = errors.New("invalid command")
+	ErrSessionLimit                = errors.New("session limit exceeded")
+	ErrUnknownSession              = errors.New("unknown session")
+	ErrSequenceUnavailable         = errors.New("sequence no longer available")
+	ErrSessionStarted              = errors.New("connection already has a session")
+	ErrNoSession                   = errors.New("connection has no session")
 	_                       Filter = &connection{}
 )
 
 type Filter interface {
+	// Check returns true if [addr] matches the address filter
 	Check(addr []byte) bool
+	// CheckTx returns true if [tx] matches every filter that has been set
+	CheckTx(tx *Tx) bool
 }
 
 // connection is a representation of the websocket connection.
@@ -37,15 +51,27 @@ type connection struct {
 	// Buffered channel of outbound messages.
 	send chan interface{}
 
+	// Signaled when the session has messages to send.
+	notify chan struct{}
+
 	fp *FilterParam
 
 	active uint32
+
+	sessionLock sync.Mutex
+	// The session this connection sends messages of. Nil if the connection
+	// doesn't use a session.
+	session *session
 }
 
 func (c *connection) Check(addr []byte) bool {
 	return c.fp.Check(addr)
 }
 
+func (c *connection) CheckTx(tx *Tx) bool {
+	return c.fp.CheckTx(tx)
+}
+
 func (c *connection) isActive() bool {
 	active := atomic.LoadUint32(&c.active)
 	return active != 0
@@ -55,6 +81,39 @@ func (c *connection) deactivate() {
 	atomic.StoreUint32(&c.active, 0)
 }
 
+func (c *connection) getSession() *session {
+	c.sessionLock.Lock()
+	defer c.sessionLock.Unlock()
+
+	return c.session
+}
+
+// notifySession wakes up the writePump to send the session's messages
+func (c *connection) notifySession() {
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+}
+
+// filters returns the filters modified by the commands of this connection
+func (c *connection) filters() *FilterParam {
+	if sess := c.getSession(); sess != nil {
+		return sess.fp
+	}
+	return c.fp
+}
+
+// subscribe starts sending the published messages that match the filters to
+// this connection, or to its session
+func (c *connection) subscribe() {
+	if sess := c.getSession(); sess != nil {
+		c.s.subscribedConnections.Add(sess)
+		return
+	}
+	c.s.subscribedConnections.Add(c)
+}
+
 func (c *connection) Send(msg interface{}) bool {
 	if !c.isActive() {
 		return false
@@ -135,6 +194,20 @@ func (c *connection) writePump() {
 			if err := c.conn.WriteJSON(message); err != nil {
 				return
 			}
+		case <-c.notify:
+			sess := c.getSession()
+			if sess == nil {
+				continue
+			}
+			for _, message := range sess.unsent(c) {
+				if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+					c.s.log.Debug("failed to set the write deadline, closing the connection due to %s", err)
+					return
+				}
+				if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+					return
+				}
+			}
 		case <-ticker.C:
 			if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
 				c.s.log.Debug("failed to set the write deadline, closing the connection due to %s", err)
@@ -165,6 +238,22 @@ func (c *connection) readMessage() error {
 		c.handleNewSet(cmd.NewSet)
 	case cmd.AddAddresses != nil:
 		err = c.handleAddAddresses(cmd.AddAddresses)
+	case cmd.AddAssetIDs != nil:
+		err = c.handleAddAssetIDs(cmd.AddAssetIDs)
+	case cmd.AddTxTypes != nil:
+		err = c.handleAddTxTypes(cmd.AddTxTypes)
+	case cmd.SetMinAmount != nil:
+		c.handleSetMinAmount(cmd.SetMinAmount)
+	case cmd.AddNodeIDs != nil:
+		err = c.handleAddNodeIDs(cmd.AddNodeIDs)
+	case cmd.AddSubnetIDs != nil:
+		err = c.handleAddSubnetIDs(cmd.AddSubnetIDs)
+	case cmd.NewSession != nil:
+		err = c.handleNewSession(cmd.NewSession)
+	case cmd.ResumeSession != nil:
+		err = c.handleResumeSession(cmd.ResumeSession)
+	case cmd.Ack != nil:
+		err = c.handleAck(cmd.Ack)
 	default:
 		err = ErrInvalidCommand
 	}
@@ -184,22 +273,128 @@ func (c *connection) handleNewBloom(cmd *NewBloom) error {
 	if err != nil {
 		return fmt.Errorf("bloom filter creation failed %w", err)
 	}
-	c.fp.SetFilter(filter)
+	c.filters().SetFilter(filter)
 	return nil
 }
 
 func (c *connection) handleNewSet(_ *NewSet) {
-	c.fp.NewSet()
+	c.filters().NewSet()
 }
 
 func (c *connection) handleAddAddresses(cmd *AddAddresses) error {
 	if err := cmd.parseAddresses(); err != nil {
 		return fmt.Errorf("address parse failed %w", err)
 	}
-	err := c.fp.Add(cmd.addressIds...)
+	err := c.filters().Add(cmd.addressIds...)
 	if err != nil {
 		return fmt.Errorf("address append failed %w", err)
 	}
-	c.s.subscribedConnections.Add(c)
+	c.subscribe()
+	return nil
+}
+
+func (c *connection) handleAddAssetIDs(cmd *AddAssetIDs) error {
+	if err := cmd.parseAssetIDs(); err != nil {
+		return fmt.Errorf("asset ID parse failed %w", err)
+	}
+	if err := c.filters().AddAssetIDs(cmd.assetIDs...); err != nil {
+		return fmt.Errorf("asset ID append failed %w", err)
+	}
+	c.subscribe()
+	return nil
+}
+
+func (c *connection) handleAddTxTypes(cmd *AddTxTypes) error {
+	if err := c.filters().AddTxTypes(cmd.TxTypes...); err != nil {
+		return fmt.Errorf("tx type append failed %w", err)
+	}
+	c.subscribe()
+	return nil
+}
+
+func (c *connection) handleSetMinAmount(cmd *SetMinAmount) {
+	c.filters().SetMinAmount(uint64(cmd.MinAmount))
+	c.subscribe()
+}
+
+func (c *connection) handleAddNodeIDs(cmd *AddNodeIDs) error {
+	if err := cmd.parseNodeIDs(); err != nil {
+		return fmt.Errorf("node ID parse failed %w", err)
+	}
+	if err := c.filters().AddNodeIDs(cmd.nodeIDs...); err != nil {
+		return fmt.Errorf("node ID append failed %w", err)
+	}
+	c.subscribe()
+	return nil
+}
+
+func (c *connection) handleAddSubnetIDs(cmd *AddSubnetIDs) error {
+	if err := cmd.parseSubnetIDs(); err != nil {
+		return fmt.Errorf("subnet ID parse failed %w", err)
+	}
+	if err := c.filters().AddSubnetIDs(cmd.subnetIDs...); err != nil {
+		return fmt.Errorf("subnet ID append failed %w", err)
+	}
+	c.subscribe()
+	return nil
+}
+
+func (c *connection) handleNewSession(_ *NewSession) error {
+	c.sessionLock.Lock()
+	defer c.sessionLock.Unlock()
+
+	if c.session != nil {
+		return ErrSessionStarted
+	}
+	sess, err := c.s.createSession(c.fp)
+	if err != nil {
+		return err
+	}
+	// The writePump waits for [c.sessionLock] before sending the messages of
+	// the session, so the session is set before it is attached.
+	c.session = sess
+	if err := sess.attach(c, 0); err != nil {
+		c.session = nil
+		return err
+	}
+	if c.s.subscribedConnections.Contains(c) {
+		c.s.subscribedConnections.Remove(c)
+		c.s.subscribedConnections.Add(sess)
+	}
+	return nil
+}
+
+func (c *connection) handleResumeSession(cmd *ResumeSession) error {
+	sessionID, err := ids.FromString(cmd.SessionID)
+	if err != nil {
+		return fmt.Errorf("session ID parse failed %w", err)
+	}
+
+	c.sessionLock.Lock()
+	defer c.sessionLock.Unlock()
+
+	if c.session != nil {
+		return ErrSessionStarted
+	}
+	sess, err := c.s.getSession(sessionID)
+	if err != nil {
+		return err
+	}
+	c.session = sess
+	if err := sess.attach(c, uint64(cmd.LastSequence)); err != nil {
+		c.session = nil
+		return err
+	}
+	// The filters of the session replace the ones set on this connection
+	c.s.subscribedConnections.Remove(c)
+	return nil
+}
+
+func (c *connection) handleAck(cmd *Ack) error {
+	sess := c.getSession()
+	if sess == nil {
+		return ErrNoSession
+	}
+	sess.ack(uint64(cmd.Sequence))
 	return nil
 }
@@ -5,15 +5,22 @@ package pubsub
 
 import "sync"
 
+// subscriber is notified of the published messages that match its filters
+type subscriber interface {
+	Filter
+	// Send returns false if [msg] was dropped
+	Send(msg interface{}) bool
+}
+
 type connections struct {
 	lock      sync.RWMutex
-	conns     map[*connection]struct{}
+	conns     map[subscriber]struct{}
 	connsList []Filter
 }
 
 func newConnections() *connections {
 	return &connections{
-		conns: make(map[*connection]struct{}),
+		conns: make(map[subscriber]struct{}),
 	}
 }
 
@@ -24,7 +31,15 @@ func (c *connections) Conns() []Filter {
 	return append([]Filter{}, c.connsList...)
 }
 
-func (c *connections) Remove(conn *connection) {
+func (c *connections) Contains(conn subscriber) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	_, ok := c.conns[conn]
+	return ok
+}
+
+func (c *connections) Remove(conn subscriber) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -32,7 +47,7 @@ func (c *connections) Remove(conn *connection) {
 	c.createConnsList()
 }
 
-func (c *connections) Add(conn *connection) {
+func (c *connections) Add(conn subscriber) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
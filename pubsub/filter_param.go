@@ -6,6 +6,7 @@ package pubsub
 import (
 	"sync"
 
+	"github.com/lasthyphen/dijetsgo/ids"
 	"github.com/lasthyphen/dijetsgo/utils/bloom"
 )
 
@@ -13,11 +14,26 @@ type FilterParam struct {
 	lock   sync.RWMutex
 	set    map[string]struct{}
 	filter bloom.Filter
+
+	// If non-empty, only txs producing outputs of these assets are matched
+	assetIDs map[ids.ID]struct{}
+	// If non-empty, only txs of these types are matched
+	txTypes map[string]struct{}
+	// Only txs producing an output holding at least this amount are matched
+	minAmount uint64
+	// If non-empty, only txs referring to one of these nodes are matched
+	nodeIDs map[ids.ShortID]struct{}
+	// If non-empty, only txs referring to one of these subnets are matched
+	subnetIDs map[ids.ID]struct{}
 }
 
 func NewFilterParam() *FilterParam {
 	return &FilterParam{
-		set: make(map[string]struct{}),
+		set:       make(map[string]struct{}),
+		assetIDs:  make(map[ids.ID]struct{}),
+		txTypes:   make(map[string]struct{}),
+		nodeIDs:   make(map[ids.ShortID]struct{}),
+		subnetIDs: make(map[ids.ID]struct{}),
 	}
 }
 
@@ -85,3 +101,145 @@ func (f *FilterParam) Len() int {
 
 	return len(f.set)
 }
+
+func (f *FilterParam) AddAssetIDs(assetIDs ...ids.ID) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if len(f.assetIDs)+len(assetIDs) > MaxAssetIDs {
+		return ErrAssetIDLimit
+	}
+	for _, assetID := range assetIDs {
+		f.assetIDs[assetID] = struct{}{}
+	}
+	return nil
+}
+
+func (f *FilterParam) AddTxTypes(txTypes ...string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if len(f.txTypes)+len(txTypes) > MaxTxTypes {
+		return ErrTxTypeLimit
+	}
+	for _, txType := range txTypes {
+		f.txTypes[txType] = struct{}{}
+	}
+	return nil
+}
+
+func (f *FilterParam) AddNodeIDs(nodeIDs ...ids.ShortID) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if len(f.nodeIDs)+len(nodeIDs) > MaxNodeIDs {
+		return ErrNodeIDLimit
+	}
+	for _, nodeID := range nodeIDs {
+		f.nodeIDs[nodeID] = struct{}{}
+	}
+	return nil
+}
+
+func (f *FilterParam) AddSubnetIDs(subnetIDs ...ids.ID) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if len(f.subnetIDs)+len(subnetIDs) > MaxSubnetIDs {
+		return ErrSubnetIDLimit
+	}
+	for _, subnetID := range subnetIDs {
+		f.subnetIDs[subnetID] = struct{}{}
+	}
+	return nil
+}
+
+func (f *FilterParam) SetMinAmount(minAmount uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.minAmount = minAmount
+}
+
+// CheckTx returns true if [tx] is matched by every filter that has been set.
+// If any of the address, asset ID and minimum amount filters are set, a
+// single output of [tx] must match all of them. Exported outputs can only be
+// matched when the address filter isn't set, so that address filters keep
+// matching only the outputs that are spendable on this chain. A filter that
+// has nothing set matches no tx.
+func (f *FilterParam) CheckTx(tx *Tx) bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	if len(f.txTypes) > 0 {
+		if _, ok := f.txTypes[tx.Type]; !ok {
+			return false
+		}
+	}
+	if len(f.nodeIDs) > 0 && !f.checkNodeIDs(tx.NodeIDs) {
+		return false
+	}
+	if len(f.subnetIDs) > 0 && !f.checkSubnetIDs(tx.SubnetIDs) {
+		return false
+	}
+
+	filtersAddresses := f.filter != nil || len(f.set) > 0
+	if !filtersAddresses && len(f.assetIDs) == 0 && f.minAmount == 0 {
+		// Only the tx type, node ID and subnet ID filters can have matched
+		// [tx], so it's matched only if one of them was set.
+		return len(f.txTypes) > 0 || len(f.nodeIDs) > 0 || len(f.subnetIDs) > 0
+	}
+	for _, out := range tx.Outputs {
+		if len(f.assetIDs) > 0 {
+			if _, ok := f.assetIDs[out.AssetID]; !ok {
+				continue
+			}
+		}
+		if out.Amount < f.minAmount {
+			continue
+		}
+		if !filtersAddresses {
+			return true
+		}
+		if !out.Exported && f.checkAddresses(out.Addresses) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAddresses returns true if any of [addrs] match the address filter.
+// Assumes [f.lock] is held.
+func (f *FilterParam) checkAddresses(addrs [][]byte) bool {
+	for _, addr := range addrs {
+		if f.filter != nil && f.filter.Check(addr) {
+			return true
+		}
+		if _, ok := f.set[string(addr)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkNodeIDs returns true if any of [nodeIDs] match the node ID filter.
+// Assumes [f.lock] is held.
+func (f *FilterParam) checkNodeIDs(nodeIDs []ids.ShortID) bool {
+	for _, nodeID := range nodeIDs {
+		if _, ok := f.nodeIDs[nodeID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSubnetIDs returns true if any of [subnetIDs] match the subnet ID
+// filter. Assumes [f.lock] is held.
+func (f *FilterParam) checkSubnetIDs(subnetIDs []ids.ID) bool {
+	for _, subnetID := range subnetIDs {
+		if _, ok := f.subnetIDs[subnetID]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -92,3 +92,150 @@ func TestNewBloom(t *testing.T) {
 		t.Fatalf("new filter check failed")
 	}
 }
+
+func TestAddAssetIDsParseAssetIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	assetID := ids.GenerateTestID()
+	msg := &AddAssetIDs{AssetIDs: []string{assetID.String()}}
+	assert.NoError(msg.parseAssetIDs())
+	assert.Equal([]ids.ID{assetID}, msg.assetIDs)
+
+	msg = &AddAssetIDs{AssetIDs: []string{"not an ID"}}
+	assert.Error(msg.parseAssetIDs())
+}
+
+func TestFilterParamCheckTx(t *testing.T) {
+	assert := assert.New(t)
+
+	assetID := ids.GenerateTestID()
+	addr := ids.GenerateTestShortID()
+	otherAddr := ids.GenerateTestShortID()
+	tx := &Tx{
+		Type: "BaseTx",
+		Outputs: []Output{
+			{
+				AssetID:   assetID,
+				Amount:    10,
+				Addresses: [][]byte{otherAddr[:]},
+			},
+			{
+				AssetID:   ids.GenerateTestID(),
+				Amount:    1000,
+				Addresses: [][]byte{addr[:]},
+			},
+		},
+	}
+
+	fp := NewFilterParam()
+	assert.NoError(fp.AddTxTypes("BaseTx"))
+	assert.True(fp.CheckTx(tx))
+
+	assert.NoError(fp.AddAssetIDs(assetID))
+	assert.True(fp.CheckTx(tx))
+
+	// The output of [assetID] isn't sent to [addr]
+	assert.NoError(fp.Add(addr[:]))
+	assert.False(fp.CheckTx(tx))
+
+	fp = NewFilterParam()
+	fp.SetMinAmount(100)
+	assert.True(fp.CheckTx(tx))
+	assert.NoError(fp.AddAssetIDs(assetID))
+	assert.False(fp.CheckTx(tx))
+
+	fp = NewFilterParam()
+	assert.NoError(fp.AddTxTypes("CreateAssetTx", "OperationTx"))
+	assert.False(fp.CheckTx(tx))
+}
+
+func TestFilterParamCheckTxEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	addr := ids.GenerateTestShortID()
+	tx := &Tx{
+		Type: "BaseTx",
+		Outputs: []Output{
+			{
+				AssetID:   ids.GenerateTestID(),
+				Amount:    10,
+				Addresses: [][]byte{addr[:]},
+			},
+		},
+	}
+
+	fp := NewFilterParam()
+	assert.False(fp.CheckTx(tx), "an empty filter shouldn't match any tx")
+
+	fp.NewSet()
+	assert.False(fp.CheckTx(tx), "an empty address set shouldn't match any tx")
+}
+
+func TestFilterParamCheckTxExported(t *testing.T) {
+	assert := assert.New(t)
+
+	assetID := ids.GenerateTestID()
+	addr := ids.GenerateTestShortID()
+	tx := &Tx{
+		Type: "ExportTx",
+		Outputs: []Output{
+			{
+				AssetID:   assetID,
+				Amount:    10,
+				Addresses: [][]byte{addr[:]},
+				Exported:  true,
+			},
+		},
+	}
+
+	fp := NewFilterParam()
+	assert.NoError(fp.Add(addr[:]))
+	assert.False(fp.CheckTx(tx), "address filters shouldn't match exported outputs")
+
+	fp = NewFilterParam()
+	assert.NoError(fp.AddAssetIDs(assetID))
+	assert.True(fp.CheckTx(tx))
+
+	tx.Outputs[0].Exported = false
+	fp = NewFilterParam()
+	assert.NoError(fp.Add(addr[:]))
+	assert.True(fp.CheckTx(tx))
+}
+
+func TestAddNodeIDsParseNodeIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	nodeID := ids.GenerateTestShortID()
+	msg := &AddNodeIDs{NodeIDs: []string{nodeID.PrefixedString(constants.NodeIDPrefix)}}
+	assert.NoError(msg.parseNodeIDs())
+	assert.Equal([]ids.ShortID{nodeID}, msg.nodeIDs)
+
+	msg = &AddNodeIDs{NodeIDs: []string{nodeID.String()}}
+	assert.Error(msg.parseNodeIDs(), "node IDs must be prefixed")
+}
+
+func TestFilterParamCheckTxNodeAndSubnet(t *testing.T) {
+	assert := assert.New(t)
+
+	nodeID := ids.GenerateTestShortID()
+	subnetID := ids.GenerateTestID()
+	tx := &Tx{
+		Type:      "AddSubnetValidatorTx",
+		NodeIDs:   []ids.ShortID{nodeID},
+		SubnetIDs: []ids.ID{subnetID},
+	}
+
+	fp := NewFilterParam()
+	assert.NoError(fp.AddNodeIDs(nodeID))
+	assert.True(fp.CheckTx(tx))
+	assert.NoError(fp.AddSubnetIDs(subnetID))
+	assert.True(fp.CheckTx(tx))
+
+	fp = NewFilterParam()
+	assert.NoError(fp.AddSubnetIDs(ids.GenerateTestID()))
+	assert.False(fp.CheckTx(tx))
+
+	fp = NewFilterParam()
+	assert.NoError(fp.AddNodeIDs(ids.GenerateTestShortID()))
+	assert.False(fp.CheckTx(tx))
+}
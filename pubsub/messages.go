@@ -5,6 +5,8 @@ package pubsub
 
 import (
 	"github.com/lasthyphen/dijetsgo/api"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
 	"github.com/lasthyphen/dijetsgo/utils/formatting"
 	"github.com/lasthyphen/dijetsgo/utils/json"
 )
@@ -28,11 +30,79 @@ type AddAddresses struct {
 	addressIds [][]byte
 }
 
+// AddAssetIDs command to only be notified of txs that produce outputs of the
+// provided assets
+type AddAssetIDs struct {
+	AssetIDs []string `json:"assetIDs"`
+
+	// assetIDs parsed from [AssetIDs]
+	assetIDs []ids.ID
+}
+
+// AddTxTypes command to only be notified of txs of the provided types, e.g.
+// "CreateAssetTx"
+type AddTxTypes struct {
+	TxTypes []string `json:"txTypes"`
+}
+
+// SetMinAmount command to only be notified of txs that produce an output
+// holding at least the provided amount
+type SetMinAmount struct {
+	MinAmount json.Uint64 `json:"minAmount"`
+}
+
+// AddNodeIDs command to only be notified of txs that refer to the provided
+// nodes, e.g. "NodeID-..."
+type AddNodeIDs struct {
+	NodeIDs []string `json:"nodeIDs"`
+
+	// nodeIDs parsed from [NodeIDs]
+	nodeIDs []ids.ShortID
+}
+
+// AddSubnetIDs command to only be notified of txs that refer to the provided
+// subnets
+type AddSubnetIDs struct {
+	SubnetIDs []string `json:"subnetIDs"`
+
+	// subnetIDs parsed from [SubnetIDs]
+	subnetIDs []ids.ID
+}
+
+// NewSession command to start a session. Messages sent in a session are
+// numbered, and the ones that haven't been acknowledged are sent again when
+// the session is resumed. The filters set on the connection are used by the
+// session.
+type NewSession struct{}
+
+// ResumeSession command to continue a session after reconnecting. The
+// messages after [LastSequence] are sent again. If some of those messages were
+// already dropped from the session's replay buffer, the session can't be
+// resumed and a new one must be started.
+type ResumeSession struct {
+	SessionID    string      `json:"sessionID"`
+	LastSequence json.Uint64 `json:"lastSequence"`
+}
+
+// Ack command to acknowledge the messages of the session up to and including
+// [Sequence], so that they aren't sent again
+type Ack struct {
+	Sequence json.Uint64 `json:"sequence"`
+}
+
 // Command execution command
 type Command struct {
-	NewBloom     *NewBloom     `json:"newBloom,omitempty"`
-	NewSet       *NewSet       `json:"newSet,omitempty"`
-	AddAddresses *AddAddresses `json:"addAddresses,omitempty"`
+	NewBloom      *NewBloom      `json:"newBloom,omitempty"`
+	NewSet        *NewSet        `json:"newSet,omitempty"`
+	AddAddresses  *AddAddresses  `json:"addAddresses,omitempty"`
+	AddAssetIDs   *AddAssetIDs   `json:"addAssetIDs,omitempty"`
+	AddTxTypes    *AddTxTypes    `json:"addTxTypes,omitempty"`
+	SetMinAmount  *SetMinAmount  `json:"setMinAmount,omitempty"`
+	AddNodeIDs    *AddNodeIDs    `json:"addNodeIDs,omitempty"`
+	AddSubnetIDs  *AddSubnetIDs  `json:"addSubnetIDs,omitempty"`
+	NewSession    *NewSession    `json:"newSession,omitempty"`
+	ResumeSession *ResumeSession `json:"resumeSession,omitempty"`
+	Ack           *Ack           `json:"ack,omitempty"`
 }
 
 func (c *Command) String() string {
@@ -43,6 +113,22 @@ func (c *Command) String() string {
 		return "newSet"
 	case c.AddAddresses != nil:
 		return "addAddresses"
+	case c.AddAssetIDs != nil:
+		return "addAssetIDs"
+	case c.AddTxTypes != nil:
+		return "addTxTypes"
+	case c.SetMinAmount != nil:
+		return "setMinAmount"
+	case c.AddNodeIDs != nil:
+		return "addNodeIDs"
+	case c.AddSubnetIDs != nil:
+		return "addSubnetIDs"
+	case c.NewSession != nil:
+		return "newSession"
+	case c.ResumeSession != nil:
+		return "resumeSession"
+	case c.Ack != nil:
+		return "ack"
 	default:
 		return "unknown"
 	}
@@ -67,3 +153,42 @@ func (c *AddAddresses) parseAddresses() error {
 	}
 	return nil
 }
+
+// parseAssetIDs converts the asset IDs to their ids.ID format.
+func (c *AddAssetIDs) parseAssetIDs() error {
+	c.assetIDs = make([]ids.ID, len(c.AssetIDs))
+	for i, assetIDStr := range c.AssetIDs {
+		assetID, err := ids.FromString(assetIDStr)
+		if err != nil {
+			return err
+		}
+		c.assetIDs[i] = assetID
+	}
+	return nil
+}
+
+// parseNodeIDs converts the node IDs to their ids.ShortID format.
+func (c *AddNodeIDs) parseNodeIDs() error {
+	c.nodeIDs = make([]ids.ShortID, len(c.NodeIDs))
+	for i, nodeIDStr := range c.NodeIDs {
+		nodeID, err := ids.ShortFromPrefixedString(nodeIDStr, constants.NodeIDPrefix)
+		if err != nil {
+			return err
+		}
+		c.nodeIDs[i] = nodeID
+	}
+	return nil
+}
+
+// parseSubnetIDs converts the subnet IDs to their ids.ID format.
+func (c *AddSubnetIDs) parseSubnetIDs() error {
+	c.subnetIDs = make([]ids.ID, len(c.SubnetIDs))
+	for i, subnetIDStr := range c.SubnetIDs {
+		subnetID, err := ids.FromString(subnetIDStr)
+		if err != nil {
+			return err
+		}
+		c.subnetIDs[i] = subnetID
+	}
+	return nil
+}
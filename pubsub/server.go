@@ -4,12 +4,14 @@
 package pubsub
 
 import (
+	"crypto/rand"
 	"net/http"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 
+	"github.com/lasthyphen/dijetsgo/ids"
 	"github.com/lasthyphen/dijetsgo/utils/logging"
 	"github.com/lasthyphen/dijetsgo/utils/units"
 )
@@ -41,6 +43,30 @@ const (
 
 	// MaxAddresses the max number of addresses allowed
 	MaxAddresses = 10000
+
+	// MaxAssetIDs the max number of asset IDs allowed
+	MaxAssetIDs = 1000
+
+	// MaxTxTypes the max number of tx types allowed
+	MaxTxTypes = 64
+
+	// MaxNodeIDs the max number of node IDs allowed
+	MaxNodeIDs = 10000
+
+	// MaxSubnetIDs the max number of subnet IDs allowed
+	MaxSubnetIDs = 1000
+
+	// MaxSessions the max number of sessions kept by the server
+	MaxSessions = 1024
+
+	// Maximum number of unacknowledged messages a session holds.
+	maxReplayMessages = 1024 // messages
+
+	// Time a session is kept after its subscriber disconnected.
+	sessionTimeout = 5 * time.Minute
+
+	// How often expired sessions are removed.
+	sessionPruneFrequency = time.Minute
 )
 
 type errorMsg struct {
@@ -59,8 +85,17 @@ type Server struct {
 	lock sync.RWMutex
 	// conns a list of all our connections
 	conns map[*connection]struct{}
-	// subscribedConnections the connections that have activated subscriptions
+	// subscribedConnections the connections and sessions that have activated
+	// subscriptions
 	subscribedConnections *connections
+
+	// sessions that subscribers can resume after reconnecting
+	sessions map[ids.ID]*session
+	// pruneOnce starts removing expired sessions when the first session is
+	// created
+	pruneOnce sync.Once
+	closeOnce sync.Once
+	closer    chan struct{}
 }
 
 func New(networkID uint32, log logging.Logger) *Server {
@@ -68,9 +103,18 @@ func New(networkID uint32, log logging.Logger) *Server {
 		log:                   log,
 		conns:                 make(map[*connection]struct{}),
 		subscribedConnections: newConnections(),
+		sessions:              make(map[ids.ID]*session),
+		closer:                make(chan struct{}),
 	}
 }
 
+// Close stops removing expired sessions
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closer)
+	})
+}
+
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	wsConn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -81,6 +125,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		s:      s,
 		conn:   wsConn,
 		send:   make(chan interface{}, maxPendingMessages),
+		notify: make(chan struct{}, 1),
 		fp:     NewFilterParam(),
 		active: 1,
 	}
@@ -94,7 +139,7 @@ func (s *Server) Publish(parser Filterer) {
 		if !shouldNotify {
 			continue
 		}
-		conn := conns[i].(*connection)
+		conn := conns[i].(subscriber)
 		if !conn.Send(msg) {
 			s.log.Verbo("dropping message to subscribed connection due to too many pending messages")
 		}
@@ -113,9 +158,78 @@ func (s *Server) addConnection(conn *connection) {
 
 func (s *Server) removeConnection(conn *connection) {
 	s.subscribedConnections.Remove(conn)
+	if sess := conn.getSession(); sess != nil {
+		sess.detach(conn, time.Now())
+	}
 
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
 	delete(s.conns, conn)
 }
+
+// createSession returns a new session that uses the filters in [fp]
+func (s *Server) createSession(fp *FilterParam) (*session, error) {
+	s.pruneOnce.Do(func() {
+		go s.pruneSessionsLoop()
+	})
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.sessions) >= MaxSessions {
+		return nil, ErrSessionLimit
+	}
+	id := ids.ID{}
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	sess := newSession(id, s.log, fp)
+	s.sessions[id] = sess
+	return sess, nil
+}
+
+// getSession returns the session with ID [id]
+func (s *Server) getSession(id ids.ID) (*session, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	sess, ok := s.sessions[id]
+	if !ok {
+		return nil, ErrUnknownSession
+	}
+	return sess, nil
+}
+
+// pruneSessionsLoop removes the expired sessions every
+// [sessionPruneFrequency] until the server is closed
+func (s *Server) pruneSessionsLoop() {
+	ticker := time.NewTicker(sessionPruneFrequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			s.pruneSessions(now)
+		case <-s.closer:
+			return
+		}
+	}
+}
+
+// pruneSessions removes the sessions whose subscriber disconnected more than
+// [sessionTimeout] before [now]
+func (s *Server) pruneSessions(now time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	deadline := now.Add(-sessionTimeout)
+	for id, sess := range s.sessions {
+		if !sess.expired(deadline) {
+			continue
+		}
+		s.subscribedConnections.Remove(sess)
+		sess.close()
+		delete(s.sessions, id)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+)
+
+type testFilterer struct {
+	txType string
+	msg    int
+}
+
+func (f *testFilterer) Filter(filters []Filter) ([]bool, interface{}) {
+	resp := make([]bool, len(filters))
+	for i, filter := range filters {
+		resp[i] = filter.CheckTx(&Tx{Type: f.txType})
+	}
+	return resp, f.msg
+}
+
+func dial(t *testing.T, url string) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(url, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+func readJSON(t *testing.T, conn *websocket.Conn, v interface{}) {
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.ReadJSON(v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServerResumeSession(t *testing.T) {
+	assert := assert.New(t)
+
+	s := New(0, logging.NoLog{})
+	httpServer := httptest.NewServer(s)
+	defer httpServer.Close()
+
+	conn := dial(t, httpServer.URL)
+	assert.NoError(conn.WriteJSON(&Command{AddTxTypes: &AddTxTypes{TxTypes: []string{"BaseTx"}}}))
+	assert.NoError(conn.WriteJSON(&Command{NewSession: &NewSession{}}))
+
+	started := sessionMsg{}
+	readJSON(t, conn, &started)
+	assert.Equal(uint64(1), started.NextSequence)
+
+	s.Publish(&testFilterer{txType: "ExportTx", msg: 1})
+	s.Publish(&testFilterer{txType: "BaseTx", msg: 2})
+	msg := sequencedMessage{}
+	readJSON(t, conn, &msg)
+	assert.Equal(sequencedMessage{Sequence: 1, Message: float64(2)}, msg)
+	assert.NoError(conn.Close())
+
+	// Wait for the server to notice the disconnection
+	for {
+		s.lock.RLock()
+		numConns := len(s.conns)
+		s.lock.RUnlock()
+		if numConns == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Messages published while disconnected are kept by the session
+	s.Publish(&testFilterer{txType: "BaseTx", msg: 3})
+
+	conn = dial(t, httpServer.URL)
+	defer conn.Close()
+	assert.NoError(conn.WriteJSON(&Command{ResumeSession: &ResumeSession{
+		SessionID: started.SessionID.String(),
+	}}))
+
+	resumed := sessionMsg{}
+	readJSON(t, conn, &resumed)
+	assert.Equal(started.SessionID, resumed.SessionID)
+	assert.Equal(uint64(1), resumed.NextSequence)
+	for i := 1; i <= 2; i++ {
+		msg := sequencedMessage{}
+		readJSON(t, conn, &msg)
+		assert.Equal(sequencedMessage{Sequence: uint64(i), Message: float64(i + 1)}, msg)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+)
+
+var _ subscriber = &session{}
+
+// sequencedMessage is sent to subscribers that use a session
+type sequencedMessage struct {
+	Sequence uint64      `json:"sequence"`
+	Message  interface{} `json:"message"`
+}
+
+// sessionMsg is sent to a subscriber when it starts or resumes a session
+type sessionMsg struct {
+	SessionID ids.ID `json:"sessionID"`
+	// Sequence of the first message that will be sent
+	NextSequence uint64 `json:"nextSequence"`
+}
+
+// session keeps the filters of a subscriber and the messages it hasn't
+// acknowledged yet, so that a subscriber can reconnect without missing
+// messages.
+//
+// Messages [first, nextSequence) are held in [buffer].
+type session struct {
+	id  ids.ID
+	log logging.Logger
+	fp  *FilterParam
+
+	lock sync.Mutex
+	// The connection messages are currently sent to. Nil if the subscriber is
+	// disconnected.
+	conn *connection
+	// When [conn] was detached
+	detachedAt time.Time
+	// Sequence of the next message published to this session
+	nextSequence uint64
+	// Sequence of the oldest message that hasn't been acknowledged
+	first  uint64
+	buffer [][]byte
+	// Sequence of the last message sent to [conn]
+	sent uint64
+	// Sent to [conn] before any other message after it is attached
+	greeting []byte
+}
+
+func newSession(id ids.ID, log logging.Logger, fp *FilterParam) *session {
+	return &session{
+		id:           id,
+		log:          log,
+		fp:           fp,
+		nextSequence: 1,
+		first:        1,
+	}
+}
+
+func (s *session) Check(addr []byte) bool {
+	return s.fp.Check(addr)
+}
+
+func (s *session) CheckTx(tx *Tx) bool {
+	return s.fp.CheckTx(tx)
+}
+
+// Send adds [msg] to the replay buffer and notifies the attached connection.
+// If the buffer is full, the oldest message is dropped.
+func (s *session) Send(msg interface{}) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	msgBytes, err := json.Marshal(&sequencedMessage{
+		Sequence: s.nextSequence,
+		Message:  msg,
+	})
+	if err != nil {
+		s.log.Debug("failed to marshal message for session %s: %s", s.id, err)
+		return false
+	}
+
+	s.buffer = append(s.buffer, msgBytes)
+	s.nextSequence++
+	if len(s.buffer) > maxReplayMessages {
+		s.buffer[0] = nil
+		s.buffer = s.buffer[1:]
+		s.first++
+	}
+	if s.conn != nil {
+		s.conn.notifySession()
+	}
+	return true
+}
+
+// ack releases the messages up to and including [sequence]
+func (s *session) ack(sequence uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.ackLocked(sequence)
+}
+
+// ackLocked assumes [s.lock] is held
+func (s *session) ackLocked(sequence uint64) {
+	if sequence >= s.nextSequence {
+		sequence = s.nextSequence - 1
+	}
+	for ; s.first <= sequence; s.first++ {
+		s.buffer[0] = nil
+		s.buffer = s.buffer[1:]
+	}
+	if s.sent < s.first-1 {
+		s.sent = s.first - 1
+	}
+}
+
+// attach sends the messages after [lastSequence] to [conn] from now on,
+// preceded by the session's ID and the sequence of the next message sent. The
+// messages up to [lastSequence] are acknowledged. Returns
+// [ErrSequenceUnavailable] if messages after [lastSequence] were already
+// dropped from the replay buffer.
+func (s *session) attach(conn *connection, lastSequence uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if lastSequence+1 < s.first {
+		return ErrSequenceUnavailable
+	}
+	s.ackLocked(lastSequence)
+	greeting, err := json.Marshal(&sessionMsg{
+		SessionID:    s.id,
+		NextSequence: s.first,
+	})
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	s.sent = s.first - 1
+	s.greeting = greeting
+	conn.notifySession()
+	return nil
+}
+
+// detach stops sending messages to [conn], if it is attached
+func (s *session) detach(conn *connection, now time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.conn == conn {
+		s.conn = nil
+		s.detachedAt = now
+	}
+}
+
+// expired returns true if the session has been detached since before
+// [deadline]
+func (s *session) expired(deadline time.Time) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.conn == nil && s.detachedAt.Before(deadline)
+}
+
+// unsent returns the messages that haven't been sent to [conn] yet, and marks
+// them as sent. Returns nothing if [conn] isn't attached.
+func (s *session) unsent(conn *connection) [][]byte {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.conn != conn {
+		return nil
+	}
+
+	// Messages that were dropped from the replay buffer are skipped
+	if s.sent < s.first-1 {
+		s.sent = s.first - 1
+	}
+
+	var msgs [][]byte
+	if s.greeting != nil {
+		msgs = append(msgs, s.greeting)
+		s.greeting = nil
+	}
+	for ; s.sent+1 < s.nextSequence; s.sent++ {
+		msgs = append(msgs, s.buffer[s.sent+1-s.first])
+	}
+	return msgs
+}
+
+// close releases the messages held by the session
+func (s *session) close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.buffer = nil
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+)
+
+func newTestConnection() *connection {
+	return &connection{notify: make(chan struct{}, 1)}
+}
+
+// sequences returns the sequence numbers of [msgs], skipping the greeting
+func sequences(t *testing.T, msgs [][]byte) []uint64 {
+	seqs := []uint64{}
+	for _, msgBytes := range msgs {
+		msg := sequencedMessage{}
+		if err := json.Unmarshal(msgBytes, &msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.Sequence != 0 {
+			seqs = append(seqs, msg.Sequence)
+		}
+	}
+	return seqs
+}
+
+func TestSessionResume(t *testing.T) {
+	assert := assert.New(t)
+
+	sess := newSession(ids.GenerateTestID(), logging.NoLog{}, NewFilterParam())
+	conn := newTestConnection()
+	assert.NoError(sess.attach(conn, 0))
+
+	for i := 0; i < 3; i++ {
+		assert.True(sess.Send(i))
+	}
+	msgs := sess.unsent(conn)
+	assert.Len(msgs, 4)
+	greeting := sessionMsg{}
+	assert.NoError(json.Unmarshal(msgs[0], &greeting))
+	assert.Equal(sessionMsg{SessionID: sess.id, NextSequence: 1}, greeting)
+	assert.Equal([]uint64{1, 2, 3}, sequences(t, msgs))
+	assert.Empty(sess.unsent(conn))
+
+	sess.ack(1)
+	sess.detach(conn, time.Unix(1, 0))
+	assert.True(sess.Send(3))
+	assert.True(sess.expired(time.Unix(2, 0)))
+
+	// The subscriber received message 2 before disconnecting
+	newConn := newTestConnection()
+	assert.NoError(sess.attach(newConn, 2))
+	assert.False(sess.expired(time.Unix(2, 0)))
+	assert.Empty(sess.unsent(conn), "a detached connection shouldn't receive messages")
+	assert.Equal([]uint64{3, 4}, sequences(t, sess.unsent(newConn)))
+}
+
+func TestSessionReplayBufferOverflow(t *testing.T) {
+	assert := assert.New(t)
+
+	sess := newSession(ids.GenerateTestID(), logging.NoLog{}, NewFilterParam())
+	for i := 0; i < maxReplayMessages+2; i++ {
+		assert.True(sess.Send(i))
+	}
+
+	// The oldest messages were dropped, so the session can't be resumed from
+	// before them
+	conn := newTestConnection()
+	assert.ErrorIs(sess.attach(conn, 1), ErrSequenceUnavailable)
+	assert.Empty(sess.unsent(conn))
+
+	assert.NoError(sess.attach(conn, 2))
+	seqs := sequences(t, sess.unsent(conn))
+	assert.Len(seqs, maxReplayMessages)
+	assert.Equal(uint64(3), seqs[0])
+}
+
+func TestServerPruneSessions(t *testing.T) {
+	assert := assert.New(t)
+
+	s := New(0, logging.NoLog{})
+	defer s.Close()
+
+	sess, err := s.createSession(NewFilterParam())
+	assert.NoError(err)
+	conn := newTestConnection()
+	assert.NoError(sess.attach(conn, 0))
+
+	// Attached sessions are kept
+	s.pruneSessions(time.Unix(0, 0).Add(2 * sessionTimeout))
+	_, err = s.getSession(sess.id)
+	assert.NoError(err)
+
+	sess.detach(conn, time.Unix(0, 0))
+	s.pruneSessions(time.Unix(0, 0).Add(sessionTimeout))
+	_, err = s.getSession(sess.id)
+	assert.NoError(err)
+
+	s.pruneSessions(time.Unix(0, 0).Add(2 * sessionTimeout))
+	_, err = s.getSession(sess.id)
+	assert.ErrorIs(err, ErrUnknownSession)
+}
@@ -0,0 +1,35 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"github.com/lasthyphen/dijetsgo/ids"
+)
+
+// Tx describes a published tx so that it can be matched against the filters
+// of the subscribed connections
+type Tx struct {
+	// Type of the tx, e.g. "CreateAssetTx"
+	Type string
+	// Outputs produced by the tx
+	Outputs []Output
+	// Nodes the tx refers to, e.g. the validator added by the tx
+	NodeIDs []ids.ShortID
+	// Subnets the tx refers to, e.g. the subnet a chain is created in
+	SubnetIDs []ids.ID
+}
+
+// Output describes an output produced by a published tx
+type Output struct {
+	AssetID ids.ID
+	// Amount of [AssetID] held by the output. Zero if the output doesn't hold
+	// an amount.
+	Amount uint64
+	// Addresses that can spend the output
+	Addresses [][]byte
+	// Exported is true if the output is exported to another chain rather than
+	// produced on this chain. Exported outputs are never matched by the
+	// address filter.
+	Exported bool
+}
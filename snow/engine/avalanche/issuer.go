@@ -7,6 +7,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/ids"
 	"github.com/lasthyphen/dijetsgo/snow/consensus/avalanche"
 	"github.com/lasthyphen/dijetsgo/snow/consensus/snowstorm"
+	"github.com/lasthyphen/dijetsgo/snow/engine/avalanche/vertex"
 )
 
 // issuer issues [vtx] into consensus after its dependencies are met.
@@ -87,6 +88,15 @@ func (i *issuer) Update() {
 		return
 	}
 
+	// Notify the VM of the vertex its transactions were issued in.
+	if handler, ok := i.t.VM.(vertex.IssuanceHandler); ok {
+		txIDs := make([]ids.ID, len(txs))
+		for j, tx := range txs {
+			txIDs[j] = tx.ID()
+		}
+		handler.TxsIssued(vtxID, txIDs)
+	}
+
 	// Issue a poll for this vertex.
 	p := i.t.Consensus.Parameters()
 	vdrs, err := i.t.Validators.Sample(p.K) // Validators to sample
@@ -257,10 +257,12 @@ func (t *Transitive) Shutdown() error {
 
 func (t *Transitive) Notify(msg common.Message) error {
 	switch msg {
-	case common.PendingTxs:
+	case common.PendingTxs, common.UrgentTxs:
 		t.pendingTxs = append(t.pendingTxs, t.VM.PendingTxs()...)
 		t.metrics.pendingTxs.Set(float64(len(t.pendingTxs)))
 		return t.attemptToIssueTxs()
+	case common.NoPendingTxs:
+		// Txs are pulled from the VM, so there is nothing to clear.
 	default:
 		t.Ctx.Log.Warn("unexpected message from the VM: %s", msg)
 	}
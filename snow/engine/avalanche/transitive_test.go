@@ -4654,3 +4654,103 @@ func TestAbandonTx(t *testing.T) {
 	// sanity check that there is indeed an outstanding vertex request
 	assert.True(te.outstandingVtxReqs.Len() == 1)
 }
+
+type issuanceTestVM struct {
+	*vertex.TestVM
+
+	issuedVtxIDs []ids.ID
+	issuedTxIDs  [][]ids.ID
+}
+
+func (vm *issuanceTestVM) TxsIssued(vtxID ids.ID, txIDs []ids.ID) {
+	vm.issuedVtxIDs = append(vm.issuedVtxIDs, vtxID)
+	vm.issuedTxIDs = append(vm.issuedTxIDs, txIDs)
+}
+
+// Test that a VM implementing IssuanceHandler is told which vertex its txs
+// were issued in
+func TestEngineNotifiesIssuance(t *testing.T) {
+	assert := assert.New(t)
+	_, bootCfg, engCfg := DefaultConfig()
+
+	sender := &common.SenderTest{T: t}
+	sender.Default(true)
+	sender.CantSendGetAcceptedFrontier = false
+	bootCfg.Sender = sender
+	engCfg.Sender = sender
+
+	vals := validators.NewSet()
+	wt := tracker.NewWeightTracker(vals, bootCfg.StartupAlpha)
+	bootCfg.Validators = vals
+	bootCfg.WeightTracker = wt
+	engCfg.Validators = vals
+
+	vdr := ids.GenerateTestShortID()
+	assert.NoError(vals.AddWeight(vdr, 1))
+
+	manager := vertex.NewTestManager(t)
+	manager.Default(true)
+	bootCfg.Manager = manager
+	engCfg.Manager = manager
+
+	vm := &issuanceTestVM{TestVM: &vertex.TestVM{TestVM: common.TestVM{T: t}}}
+	vm.Default(true)
+	bootCfg.VM = vm
+	engCfg.VM = vm
+
+	gVtx := &avalanche.TestVertex{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Accepted,
+	}}
+	gTx := &snowstorm.TestTx{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Accepted,
+	}}
+	tx := &snowstorm.TestTx{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		DependenciesV: []snowstorm.Tx{gTx},
+		InputIDsV:     []ids.ID{ids.GenerateTestID()},
+	}
+
+	manager.EdgeF = func() []ids.ID { return []ids.ID{gVtx.ID()} }
+	manager.GetVtxF = func(id ids.ID) (avalanche.Vertex, error) {
+		if id == gVtx.ID() {
+			return gVtx, nil
+		}
+		t.Fatalf("Unknown vertex")
+		panic("Should have errored")
+	}
+
+	vm.CantSetState = false
+	te, err := newTransitive(engCfg)
+	assert.NoError(err)
+	assert.NoError(te.Start(0))
+	vm.CantSetState = true
+
+	manager.BuildVtxF = func(_ []ids.ID, txs []snowstorm.Tx) (avalanche.Vertex, error) {
+		return &avalanche.TestVertex{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			ParentsV: []avalanche.Vertex{gVtx},
+			HeightV:  1,
+			TxsV:     txs,
+			BytesV:   []byte{1},
+		}, nil
+	}
+
+	var vtxID ids.ID
+	sender.SendPushQueryF = func(_ ids.ShortSet, _ uint32, vID ids.ID, _ []byte) {
+		vtxID = vID
+	}
+
+	vm.PendingTxsF = func() []snowstorm.Tx { return []snowstorm.Tx{tx} }
+	assert.NoError(te.Notify(common.PendingTxs))
+
+	assert.Equal([]ids.ID{vtxID}, vm.issuedVtxIDs)
+	assert.Equal([][]ids.ID{{tx.ID()}}, vm.issuedTxIDs)
+}
@@ -23,3 +23,11 @@ type DAGVM interface {
 	// Retrieve a transaction that was submitted previously
 	GetTx(ids.ID) (snowstorm.Tx, error)
 }
+
+// IssuanceHandler is an optional interface a DAGVM can implement to be notified
+// of the vertices its transactions are issued into consensus in
+type IssuanceHandler interface {
+	// TxsIssued is called after the vertex [vtxID], containing the
+	// transactions [txIDs], has been added to consensus
+	TxsIssued(vtxID ids.ID, txIDs []ids.ID)
+}
@@ -17,12 +17,25 @@ const (
 	// its VM has pending transactions
 	// (i.e. it would like to add a new block/vertex to consensus)
 	PendingTxs Message = iota
+
+	// UrgentTxs notifies a consensus engine that its VM has pending
+	// transactions that should be added to consensus as soon as the VM is
+	// allowed to
+	UrgentTxs
+
+	// NoPendingTxs notifies a consensus engine that its VM no longer has
+	// pending transactions, so previous notifications can be ignored
+	NoPendingTxs
 )
 
 func (msg Message) String() string {
 	switch msg {
 	case PendingTxs:
 		return "Pending Transactions"
+	case UrgentTxs:
+		return "Urgent Transactions"
+	case NoPendingTxs:
+		return "No Pending Transactions"
 	default:
 		return fmt.Sprintf("Unknown Message: %d", msg)
 	}
@@ -270,10 +270,14 @@ func (t *Transitive) Shutdown() error {
 func (t *Transitive) Notify(msg common.Message) error {
 	t.Ctx.Log.Verbo("snowman engine notified of %s from the vm", msg)
 	switch msg {
-	case common.PendingTxs:
+	case common.PendingTxs, common.UrgentTxs:
 		// the pending txs message means we should attempt to build a block.
 		t.pendingBuildBlocks++
 		return t.buildBlocks()
+	case common.NoPendingTxs:
+		// the VM has nothing left to build, so outstanding build requests
+		// would only fail.
+		t.pendingBuildBlocks = 0
 	default:
 		t.Ctx.Log.Warn("unexpected message from the VM: %s", msg)
 	}
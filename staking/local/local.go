@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package local holds the staking certificates of the validators of the local
+// network.
+package local
+
+import (
+	"embed"
+	"fmt"
+)
+
+// NumStakers is the number of staking certificates in this package
+const NumStakers = 6
+
+//go:embed staker*.crt staker*.key
+var files embed.FS
+
+// StakerCertAndKey returns the PEM encoded certificate and key of staker [i],
+// starting at 1
+func StakerCertAndKey(i int) ([]byte, []byte, error) {
+	if i < 1 || i > NumStakers {
+		return nil, nil, fmt.Errorf("staker %d doesn't exist", i)
+	}
+	certBytes, err := files.ReadFile(fmt.Sprintf("staker%d.crt", i))
+	if err != nil {
+		return nil, nil, err
+	}
+	keyBytes, err := files.ReadFile(fmt.Sprintf("staker%d.key", i))
+	return certBytes, keyBytes, err
+}
@@ -65,6 +65,13 @@ var (
 		constants.FujiID:    time.Date(2021, time.November, 24, 15, 0, 0, 0, time.UTC),
 	}
 	ApricotPhase5DefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	// ApricotPhase6 isn't scheduled on the public networks yet
+	ApricotPhase6Times = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.FujiID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	ApricotPhase6DefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
 )
 
 func GetApricotPhase0Time(networkID uint32) time.Time {
@@ -116,14 +123,14 @@ func GetApricotPhase5Time(networkID uint32) time.Time {
 	return ApricotPhase5DefaultTime
 }
 
+func GetApricotPhase6Time(networkID uint32) time.Time {
+	if upgradeTime, exists := ApricotPhase6Times[networkID]; exists {
+		return upgradeTime
+	}
+	return ApricotPhase6DefaultTime
+}
+
 func GetCompatibility(networkID uint32) Compatibility {
-	return NewCompatibility(
-		CurrentApp,
-		MinimumCompatibleVersion,
-		GetApricotPhase5Time(networkID),
-		PrevMinimumCompatibleVersion,
-		MinimumUnmaskedVersion,
-		GetApricotPhase0Time(networkID),
-		PrevMinimumUnmaskedVersion,
-	)
+	upgrades := GetNetworkUpgrades(networkID)
+	return upgrades.Compatibility()
 }
@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package version
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// NetworkUpgrades are the times a network activates its upgrades.
+//
+// The upgrades of the C-Chain are configured in its genesis.
+type NetworkUpgrades struct {
+	ApricotPhase0Time            time.Time `json:"apricotPhase0Time"`
+	ApricotPhase1Time            time.Time `json:"apricotPhase1Time"`
+	ApricotPhase2Time            time.Time `json:"apricotPhase2Time"`
+	ApricotPhase3Time            time.Time `json:"apricotPhase3Time"`
+	ApricotPhase4Time            time.Time `json:"apricotPhase4Time"`
+	ApricotPhase4MinPChainHeight uint64    `json:"apricotPhase4MinPChainHeight"`
+	ApricotPhase5Time            time.Time `json:"apricotPhase5Time"`
+	ApricotPhase6Time            time.Time `json:"apricotPhase6Time"`
+}
+
+// GetNetworkUpgrades returns the upgrade times compiled in for [networkID]
+func GetNetworkUpgrades(networkID uint32) NetworkUpgrades {
+	return NetworkUpgrades{
+		ApricotPhase0Time:            GetApricotPhase0Time(networkID),
+		ApricotPhase1Time:            GetApricotPhase1Time(networkID),
+		ApricotPhase2Time:            GetApricotPhase2Time(networkID),
+		ApricotPhase3Time:            GetApricotPhase3Time(networkID),
+		ApricotPhase4Time:            GetApricotPhase4Time(networkID),
+		ApricotPhase4MinPChainHeight: GetApricotPhase4MinPChainHeight(networkID),
+		ApricotPhase5Time:            GetApricotPhase5Time(networkID),
+		ApricotPhase6Time:            GetApricotPhase6Time(networkID),
+	}
+}
+
+// Override replaces the upgrades that are specified in the JSON object
+// [overrides]. Unknown fields are an error.
+func (u *NetworkUpgrades) Override(overrides []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(overrides))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(u)
+}
+
+// Verify returns an error if the upgrades aren't activated in order
+func (u *NetworkUpgrades) Verify() error {
+	times := []struct {
+		name string
+		time time.Time
+	}{
+		{name: "apricotPhase0Time", time: u.ApricotPhase0Time},
+		{name: "apricotPhase1Time", time: u.ApricotPhase1Time},
+		{name: "apricotPhase2Time", time: u.ApricotPhase2Time},
+		{name: "apricotPhase3Time", time: u.ApricotPhase3Time},
+		{name: "apricotPhase4Time", time: u.ApricotPhase4Time},
+		{name: "apricotPhase5Time", time: u.ApricotPhase5Time},
+		{name: "apricotPhase6Time", time: u.ApricotPhase6Time},
+	}
+	for i := 1; i < len(times); i++ {
+		prev, next := times[i-1], times[i]
+		if next.time.Before(prev.time) {
+			return fmt.Errorf("%s (%s) is before %s (%s)", next.name, next.time, prev.name, prev.time)
+		}
+	}
+	return nil
+}
+
+// Compatibility returns the compatibility of this node's version with its
+// peers' on a network with these upgrades
+func (u *NetworkUpgrades) Compatibility() Compatibility {
+	return NewCompatibility(
+		CurrentApp,
+		MinimumCompatibleVersion,
+		u.ApricotPhase5Time,
+		PrevMinimumCompatibleVersion,
+		MinimumUnmaskedVersion,
+		u.ApricotPhase0Time,
+		PrevMinimumUnmaskedVersion,
+	)
+}
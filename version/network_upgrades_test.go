@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package version
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+)
+
+func TestGetNetworkUpgrades(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, networkID := range []uint32{constants.MainnetID, constants.FujiID, constants.LocalID} {
+		upgrades := GetNetworkUpgrades(networkID)
+		assert.Equal(GetApricotPhase0Time(networkID), upgrades.ApricotPhase0Time)
+		assert.Equal(GetApricotPhase4Time(networkID), upgrades.ApricotPhase4Time)
+		assert.Equal(GetApricotPhase4MinPChainHeight(networkID), upgrades.ApricotPhase4MinPChainHeight)
+		assert.Equal(GetApricotPhase5Time(networkID), upgrades.ApricotPhase5Time)
+		assert.Equal(GetApricotPhase6Time(networkID), upgrades.ApricotPhase6Time)
+		assert.NoError(upgrades.Verify())
+	}
+}
+
+func TestNetworkUpgradesOverride(t *testing.T) {
+	assert := assert.New(t)
+
+	upgrades := GetNetworkUpgrades(constants.LocalID)
+	assert.NoError(upgrades.Override([]byte(`{"apricotPhase5Time":"2030-01-01T00:00:00Z","apricotPhase6Time":"2030-01-01T00:00:00Z","apricotPhase4MinPChainHeight":5}`)))
+	assert.True(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC).Equal(upgrades.ApricotPhase5Time))
+	assert.Equal(uint64(5), upgrades.ApricotPhase4MinPChainHeight)
+	assert.Equal(GetApricotPhase4Time(constants.LocalID), upgrades.ApricotPhase4Time)
+	assert.NoError(upgrades.Verify())
+
+	// Peers on the previous minimum compatible version remain compatible until
+	// ApricotPhase5 activates
+	assert.NoError(upgrades.Compatibility().Compatible(PrevMinimumCompatibleVersion))
+	assert.Error(GetCompatibility(constants.LocalID).Compatible(PrevMinimumCompatibleVersion))
+
+	assert.Error(upgrades.Override([]byte(`{"apricotPhase9Time":"2030-01-01T00:00:00Z"}`)))
+	assert.Error(upgrades.Override([]byte(`{"apricotPhase3Time":"yesterday"}`)))
+}
+
+func TestNetworkUpgradesVerify(t *testing.T) {
+	upgrades := GetNetworkUpgrades(constants.LocalID)
+	upgrades.ApricotPhase3Time = upgrades.ApricotPhase4Time.Add(time.Hour)
+	err := upgrades.Verify()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "apricotPhase4Time")
+}
@@ -32,6 +32,14 @@ type Client interface {
 	ConfirmTx(ctx context.Context, txID ids.ID, freq time.Duration) (choices.Status, error)
 	// GetTx returns the byte representation of [txID]
 	GetTx(ctx context.Context, txID ids.ID) ([]byte, error)
+	// GetMempool returns the txs the node hasn't decided yet
+	GetMempool(ctx context.Context) (*GetMempoolReply, error)
+	// GetProcessingTxs returns the txs issued into consensus that the node
+	// hasn't decided yet
+	GetProcessingTxs(ctx context.Context) ([]MempoolTx, error)
+	// VerifyTx verifies [txBytes] against the node's current state without
+	// issuing it
+	VerifyTx(ctx context.Context, txBytes []byte) (*VerifyTxReply, error)
 	// GetUTXOs returns the byte representation of the UTXOs controlled by [addrs]
 	GetUTXOs(
 		ctx context.Context,
@@ -58,6 +66,17 @@ type Client interface {
 	// GetAllBalances returns all asset balances for [addr]
 	// CreateAsset creates a new asset and returns its assetID
 	GetAllBalances(context.Context, string, bool) (*GetAllBalancesReply, error)
+	// GetOwnedNFTs returns the NFTs currently owned by [addr]. If [assetID] is
+	// not empty, only NFTs of that collection are returned. [cursor] is the
+	// cursor of the previous reply, or empty to read the first page.
+	GetOwnedNFTs(ctx context.Context, addr string, assetID string, cursor string, pageSize uint64) (*GetNFTsReply, error)
+	// GetNFTHolders returns the NFTs of the collection [assetID] and who holds
+	// them. [cursor] is the cursor of the previous reply, or empty to read the
+	// first page.
+	GetNFTHolders(ctx context.Context, assetID string, cursor string, pageSize uint64) (*GetNFTsReply, error)
+	// GetNFTHistory returns the outputs group [groupID] of the collection
+	// [assetID] was minted and transferred with
+	GetNFTHistory(ctx context.Context, assetID string, groupID uint32, cursor, pageSize uint64) (*GetNFTHistoryReply, error)
 	CreateAsset(
 		ctx context.Context,
 		user api.UserPass,
@@ -188,6 +207,31 @@ func (c *client) GetTxStatus(ctx context.Context, txID ids.ID) (choices.Status,
 	return res.Status, err
 }
 
+func (c *client) GetMempool(ctx context.Context) (*GetMempoolReply, error) {
+	res := &GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "getMempool", struct{}{}, res)
+	return res, err
+}
+
+func (c *client) GetProcessingTxs(ctx context.Context) ([]MempoolTx, error) {
+	res := &GetProcessingTxsReply{}
+	err := c.requester.SendRequest(ctx, "getProcessingTxs", struct{}{}, res)
+	return res.Txs, err
+}
+
+func (c *client) VerifyTx(ctx context.Context, txBytes []byte) (*VerifyTxReply, error) {
+	txStr, err := formatting.EncodeWithChecksum(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &VerifyTxReply{}
+	err = c.requester.SendRequest(ctx, "verifyTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res)
+	return res, err
+}
+
 func (c *client) ConfirmTx(ctx context.Context, txID ids.ID, freq time.Duration) (choices.Status, error) {
 	ticker := time.NewTicker(freq)
 	defer ticker.Stop()
@@ -283,6 +327,40 @@ func (c *client) GetAllBalances(ctx context.Context, addr string, includePartial
 	return res, err
 }
 
+func (c *client) GetOwnedNFTs(ctx context.Context, addr string, assetID string, cursor string, pageSize uint64) (*GetNFTsReply, error) {
+	res := &GetNFTsReply{}
+	err := c.requester.SendRequest(ctx, "getOwnedNFTs", &GetOwnedNFTsArgs{
+		JSONAddress: api.JSONAddress{Address: addr},
+		GetNFTsArgs: GetNFTsArgs{
+			Cursor:   cursor,
+			PageSize: cjson.Uint64(pageSize),
+			AssetID:  assetID,
+		},
+	}, res)
+	return res, err
+}
+
+func (c *client) GetNFTHolders(ctx context.Context, assetID string, cursor string, pageSize uint64) (*GetNFTsReply, error) {
+	res := &GetNFTsReply{}
+	err := c.requester.SendRequest(ctx, "getNFTHolders", &GetNFTsArgs{
+		Cursor:   cursor,
+		PageSize: cjson.Uint64(pageSize),
+		AssetID:  assetID,
+	}, res)
+	return res, err
+}
+
+func (c *client) GetNFTHistory(ctx context.Context, assetID string, groupID uint32, cursor, pageSize uint64) (*GetNFTHistoryReply, error) {
+	res := &GetNFTHistoryReply{}
+	err := c.requester.SendRequest(ctx, "getNFTHistory", &GetNFTHistoryArgs{
+		Cursor:   cjson.Uint64(cursor),
+		PageSize: cjson.Uint64(pageSize),
+		AssetID:  assetID,
+		GroupID:  cjson.Uint32(groupID),
+	}, res)
+	return res, err
+}
+
 func (c *client) CreateAsset(
 	ctx context.Context,
 	user api.UserPass,
@@ -461,6 +539,73 @@ func (c *client) SendMultiple(
 	return res.TxID, err
 }
 
+func (c *client) Freeze(
+	ctx context.Context,
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetID string,
+	addrs []string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "freeze", &ComplianceArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		AssetID:   assetID,
+		Addresses: addrs,
+	}, res)
+	return res.TxID, err
+}
+
+func (c *client) Unfreeze(
+	ctx context.Context,
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetID string,
+	addrs []string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "unfreeze", &ComplianceArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		AssetID:   assetID,
+		Addresses: addrs,
+	}, res)
+	return res.TxID, err
+}
+
+func (c *client) Clawback(
+	ctx context.Context,
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetID string,
+	addrs []string,
+	to string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "clawback", &ClawbackArgs{
+		ComplianceArgs: ComplianceArgs{
+			JSONSpendHeader: api.JSONSpendHeader{
+				UserPass:       user,
+				JSONFromAddrs:  api.JSONFromAddrs{From: from},
+				JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+			},
+			AssetID:   assetID,
+			Addresses: addrs,
+		},
+		To: to,
+	}, res)
+	return res.TxID, err
+}
+
 func (c *client) Mint(
 	ctx context.Context,
 	user api.UserPass,
@@ -10,9 +10,11 @@ import (
 	"github.com/lasthyphen/dijetsgo/codec"
 	"github.com/lasthyphen/dijetsgo/codec/linearcodec"
 	"github.com/lasthyphen/dijetsgo/codec/reflectcodec"
+	"github.com/lasthyphen/dijetsgo/codec/schema"
 	"github.com/lasthyphen/dijetsgo/utils/logging"
 	"github.com/lasthyphen/dijetsgo/utils/timer/mockable"
 	"github.com/lasthyphen/dijetsgo/utils/wrappers"
+	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
 	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
 )
 
@@ -61,29 +63,64 @@ func newCustomCodecs(
 ) (codec.Manager, codec.Manager, error) {
 	gc := linearcodec.New(reflectcodec.DefaultTagName, 1<<20)
 	c := linearcodec.NewDefault()
+	if err := registerCodecTypes([]codec.Registry{gc, c}, typeToFxIndex, clock, log, fxs); err != nil {
+		return nil, nil, err
+	}
 
 	gcm := codec.NewManager(math.MaxInt32)
 	cm := codec.NewDefaultManager()
 
 	errs := wrappers.Errs{}
 	errs.Add(
-		c.RegisterType(&BaseTx{}),
-		c.RegisterType(&CreateAssetTx{}),
-		c.RegisterType(&OperationTx{}),
-		c.RegisterType(&ImportTx{}),
-		c.RegisterType(&ExportTx{}),
 		cm.RegisterCodec(codecVersion, c),
-
-		gc.RegisterType(&BaseTx{}),
-		gc.RegisterType(&CreateAssetTx{}),
-		gc.RegisterType(&OperationTx{}),
-		gc.RegisterType(&ImportTx{}),
-		gc.RegisterType(&ExportTx{}),
 		gcm.RegisterCodec(codecVersion, gc),
 	)
 	if errs.Errored() {
 		return nil, nil, errs.Err
 	}
+	return gcm, cm, nil
+}
+
+// NewCodecSchema returns the schema of the codec for the provided feature
+// extensions.
+func NewCodecSchema(fxs []Fx) (*schema.Schema, error) {
+	c := linearcodec.NewDefault()
+	err := registerCodecTypes(
+		[]codec.Registry{c},
+		make(map[reflect.Type]int),
+		&mockable.Clock{},
+		logging.NoLog{},
+		fxs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return schema.New(c, reflectcodec.DefaultTagName, &Tx{}, &djtx.UTXO{})
+}
+
+// registerCodecTypes registers the txs and the types of [fxs] in [codecs]. The
+// order of the registrations determines the type IDs, so types must only be
+// registered after the existing ones.
+func registerCodecTypes(
+	codecs []codec.Registry,
+	typeToFxIndex map[reflect.Type]int,
+	clock *mockable.Clock,
+	log logging.Logger,
+	fxs []Fx,
+) error {
+	errs := wrappers.Errs{}
+	for _, c := range codecs {
+		errs.Add(
+			c.RegisterType(&BaseTx{}),
+			c.RegisterType(&CreateAssetTx{}),
+			c.RegisterType(&OperationTx{}),
+			c.RegisterType(&ImportTx{}),
+			c.RegisterType(&ExportTx{}),
+		)
+	}
+	if errs.Errored() {
+		return errs.Err
+	}
 
 	vm := &fxVM{
 		typeToFxIndex: typeToFxIndex,
@@ -92,15 +129,15 @@ func newCustomCodecs(
 	}
 	for i, fx := range fxs {
 		vm.codecRegistry = &codecRegistry{
-			codecs:      []codec.Registry{gc, c},
+			codecs:      codecs,
 			index:       i,
 			typeToIndex: vm.typeToFxIndex,
 		}
 		if err := fx.Initialize(vm); err != nil {
-			return nil, nil, err
+			return err
 		}
 	}
-	return gcm, cm, nil
+	return nil
 }
 
 type fxVM struct {
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/vms/nftfx"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+func TestNewCodecSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	s, err := NewCodecSchema([]Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+	})
+	assert.NoError(err)
+
+	names := make([]string, len(s.Types))
+	for i, typ := range s.Types {
+		assert.EqualValues(i, typ.TypeID)
+		names[i] = typ.Name
+	}
+	assert.Equal([]string{
+		"avm.BaseTx",
+		"avm.CreateAssetTx",
+		"avm.OperationTx",
+		"avm.ImportTx",
+		"avm.ExportTx",
+		"secp256k1fx.TransferInput",
+		"secp256k1fx.MintOutput",
+		"secp256k1fx.TransferOutput",
+		"secp256k1fx.MintOperation",
+		"secp256k1fx.Credential",
+		"nftfx.MintOutput",
+		"nftfx.TransferOutput",
+		"nftfx.MintOperation",
+		"nftfx.TransferOperation",
+		"nftfx.Credential",
+	}, names)
+
+	structNames := make(map[string]bool, len(s.Structs))
+	for _, st := range s.Structs {
+		structNames[st.Name] = true
+	}
+	assert.True(structNames["avm.Tx"])
+	assert.True(structNames["djtx.UTXO"])
+}
@@ -6,6 +6,7 @@ package avm
 import (
 	"github.com/lasthyphen/dijetsgo/ids"
 	"github.com/lasthyphen/dijetsgo/snow"
+	"github.com/lasthyphen/dijetsgo/vms/compliancefx"
 	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
 	"github.com/lasthyphen/dijetsgo/vms/components/verify"
 	"github.com/lasthyphen/dijetsgo/vms/nftfx"
@@ -17,6 +18,7 @@ var (
 	_ Fx = &secp256k1fx.Fx{}
 	_ Fx = &nftfx.Fx{}
 	_ Fx = &propertyfx.Fx{}
+	_ Fx = &compliancefx.Fx{}
 )
 
 type parsedFx struct {
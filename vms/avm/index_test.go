@@ -26,6 +26,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/version"
 	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
 	"github.com/lasthyphen/dijetsgo/vms/components/index"
+	"github.com/lasthyphen/dijetsgo/vms/nftfx"
 	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
 )
 
@@ -473,6 +474,99 @@ func TestIndexingAllowIncomplete(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestNFTIndexingAddedToExistingChain(t *testing.T) {
+	ctx := NewContext(t)
+
+	db := memdb.New()
+	// an NFT index added to a chain that already accepted txs is incomplete
+	_, err := index.NewNFTIndexer(db, ctx.Log, false, false)
+	assert.Error(t, err)
+
+	_, err = index.NewNFTIndexer(db, ctx.Log, true, false)
+	assert.NoError(t, err)
+
+	// the index stays incomplete after later restarts
+	_, err = index.NewNFTIndexer(db, ctx.Log, false, false)
+	assert.Error(t, err)
+}
+
+func TestNFTIndexingNewChain(t *testing.T) {
+	ctx := NewContext(t)
+
+	db := memdb.New()
+	_, err := index.NewNFTIndexer(db, ctx.Log, false, true)
+	assert.NoError(t, err)
+
+	_, err = index.NewNFTIndexer(db, ctx.Log, false, false)
+	assert.NoError(t, err)
+
+	// disabling the index makes it incomplete
+	_, err = index.NewNoNFTIndexer(db, false, false)
+	assert.Error(t, err)
+}
+
+func TestNFTIndexerPagination(t *testing.T) {
+	assert := assert.New(t)
+	ctx := NewContext(t)
+
+	indexer, err := index.NewNFTIndexer(memdb.New(), ctx.Log, false, true)
+	assert.NoError(err)
+
+	addr := ids.GenerateTestShortID()
+	assetID := ids.GenerateTestID()
+	utxos := make([]*djtx.UTXO, 5)
+	for i := range utxos {
+		utxos[i] = &djtx.UTXO{
+			UTXOID: djtx.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  djtx.Asset{ID: assetID},
+			Out: &nftfx.TransferOutput{
+				GroupID: uint32(i),
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		}
+	}
+	assert.NoError(indexer.Accept(ids.GenerateTestID(), nil, utxos))
+
+	var (
+		owned  []index.NFT
+		cursor []byte
+	)
+	for {
+		nfts, next, err := indexer.ReadOwned(addr, assetID, cursor, 2)
+		assert.NoError(err)
+		if len(nfts) == 0 {
+			assert.Equal(cursor, next)
+			break
+		}
+		owned = append(owned, nfts...)
+		cursor = next
+	}
+	assert.Len(owned, len(utxos))
+	for i, nft := range owned {
+		assert.EqualValues(i, nft.GroupID)
+	}
+
+	var holders []index.NFT
+	cursor = nil
+	for {
+		nfts, next, err := indexer.ReadHolders(assetID, cursor, 2)
+		assert.NoError(err)
+		if len(nfts) == 0 {
+			break
+		}
+		holders = append(holders, nfts...)
+		cursor = next
+	}
+	assert.Len(holders, len(utxos))
+	for i, nft := range holders {
+		assert.EqualValues(i, nft.GroupID)
+		assert.Equal(addr, nft.Owner)
+	}
+}
+
 func buildPlatformUTXO(utxoID djtx.UTXOID, txAssetID djtx.Asset, addr ids.ShortID) *djtx.UTXO {
 	return &djtx.UTXO{
 		UTXOID: utxoID,
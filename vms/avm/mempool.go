@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/snow/choices"
+)
+
+// processingTx is a tx that was issued into consensus but hasn't been decided
+type processingTx struct {
+	tx *UniqueTx
+	// ID of the vertex the tx was most recently issued in
+	vtxID ids.ID
+	// Time the tx was first issued into consensus
+	issuedAt time.Time
+}
+
+// mempoolTx describes a tx that this VM hasn't decided yet
+type mempoolTx struct {
+	txID ids.ID
+	// ID of the vertex the tx was issued in, or nil if the tx hasn't been
+	// issued into consensus yet
+	vtxID *ids.ID
+	// Time the tx was issued to this VM, or into consensus if it wasn't issued
+	// to this VM directly
+	issuedAt time.Time
+	// IDs of the processing txs that spend an input this tx spends
+	conflicts []ids.ID
+}
+
+// TxsIssued implements the vertex.IssuanceHandler interface
+func (vm *VM) TxsIssued(vtxID ids.ID, txIDs []ids.ID) {
+	now := vm.clock.Time()
+	for _, txID := range txIDs {
+		if ptx, ok := vm.processingTxs[txID]; ok {
+			// The tx was re-issued in a new vertex
+			ptx.vtxID = vtxID
+			continue
+		}
+
+		tx := &UniqueTx{
+			vm:   vm,
+			txID: txID,
+		}
+		// Txs without inputs are accepted as soon as they are issued
+		if tx.Status() != choices.Processing {
+			continue
+		}
+		vm.processingTxs[txID] = &processingTx{
+			tx:       tx,
+			vtxID:    vtxID,
+			issuedAt: now,
+		}
+	}
+}
+
+// getPendingTxs returns the txs issued to this VM that haven't been handed to
+// the consensus engine yet, ordered by issuance time
+func (vm *VM) getPendingTxs() []mempoolTx {
+	spenders := vm.processingSpenders()
+	txs := make([]mempoolTx, len(vm.txs))
+	for i, tx := range vm.txs {
+		txID := tx.ID()
+		txs[i] = mempoolTx{
+			txID:      txID,
+			issuedAt:  vm.txIssueTimes[txID],
+			conflicts: conflicts(txID, tx.InputIDs(), spenders),
+		}
+	}
+	sortMempoolTxs(txs)
+	return txs
+}
+
+// getProcessingTxs returns the txs that were issued into consensus but haven't
+// been decided yet, ordered by issuance time
+func (vm *VM) getProcessingTxs() []mempoolTx {
+	spenders := vm.processingSpenders()
+	txs := make([]mempoolTx, 0, len(vm.processingTxs))
+	for txID, ptx := range vm.processingTxs {
+		vtxID := ptx.vtxID
+		txs = append(txs, mempoolTx{
+			txID:      txID,
+			vtxID:     &vtxID,
+			issuedAt:  ptx.issuedAt,
+			conflicts: conflicts(txID, ptx.tx.InputIDs(), spenders),
+		})
+	}
+	sortMempoolTxs(txs)
+	return txs
+}
+
+// processingSpenders returns the processing txs spending each input, as
+// tracked by snowstorm to determine the conflicts of a tx
+func (vm *VM) processingSpenders() map[ids.ID]ids.Set {
+	spenders := make(map[ids.ID]ids.Set)
+	for txID, ptx := range vm.processingTxs {
+		for _, inputID := range ptx.tx.InputIDs() {
+			inputSpenders := spenders[inputID]
+			inputSpenders.Add(txID)
+			spenders[inputID] = inputSpenders
+		}
+	}
+	return spenders
+}
+
+// conflicts returns the sorted IDs of the txs, other than [txID], that spend
+// one of [inputIDs]
+func conflicts(txID ids.ID, inputIDs []ids.ID, spenders map[ids.ID]ids.Set) []ids.ID {
+	conflictIDs := ids.Set{}
+	for _, inputID := range inputIDs {
+		conflictIDs.Union(spenders[inputID])
+	}
+	conflictIDs.Remove(txID)
+
+	conflictList := conflictIDs.List()
+	ids.SortIDs(conflictList)
+	return conflictList
+}
+
+func sortMempoolTxs(txs []mempoolTx) {
+	sort.Slice(txs, func(i, j int) bool {
+		if !txs[i].issuedAt.Equal(txs[j].issuedAt) {
+			return txs[i].issuedAt.Before(txs[j].issuedAt)
+		}
+		return bytes.Compare(txs[i].txID[:], txs[j].txID[:]) == -1
+	})
+}
+
+// verifyTx verifies [tx] against the current state without issuing it or
+// persisting it
+func (vm *VM) verifyTx(tx *Tx) error {
+	if err := tx.SyntacticVerify(
+		vm.ctx,
+		vm.codec,
+		vm.feeAssetID,
+		vm.TxFee,
+		vm.CreateAssetTxFee,
+		len(vm.fxs),
+	); err != nil {
+		return err
+	}
+
+	if status, err := vm.state.GetStatus(tx.ID()); err == nil {
+		switch status {
+		case choices.Accepted:
+			return nil
+		case choices.Rejected:
+			return errRejectedTx
+		}
+	}
+	return tx.SemanticVerify(vm, tx.UnsignedTx)
+}
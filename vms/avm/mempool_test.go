@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/api"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils/formatting"
+	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
+)
+
+func TestServiceGetMempool(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, ctx, txs := setupIssueTx(t)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+	s := &Service{vm: vm}
+	firstTx, secondTx := txs[1], txs[2]
+
+	_, err := vm.IssueTx(firstTx.Bytes())
+	assert.NoError(err)
+	_, err = vm.IssueTx(secondTx.Bytes())
+	assert.NoError(err)
+
+	// Both txs are waiting to be issued into consensus
+	reply := GetMempoolReply{}
+	assert.NoError(s.GetMempool(nil, nil, &reply))
+	assert.Len(reply.PendingTxs, 2)
+	assert.Empty(reply.ProcessingTxs)
+	for _, tx := range reply.PendingTxs {
+		assert.Nil(tx.VertexID)
+		assert.Empty(tx.Conflicts)
+	}
+
+	// The engine issues both txs into a vertex
+	vtxID := ids.GenerateTestID()
+	pendingTxs := vm.PendingTxs()
+	assert.Len(pendingTxs, 2)
+	vm.TxsIssued(vtxID, []ids.ID{pendingTxs[0].ID(), pendingTxs[1].ID()})
+
+	reply = GetMempoolReply{}
+	assert.NoError(s.GetMempool(nil, nil, &reply))
+	assert.Empty(reply.PendingTxs)
+	assert.Len(reply.ProcessingTxs, 2)
+
+	processingReply := GetProcessingTxsReply{}
+	assert.NoError(s.GetProcessingTxs(nil, nil, &processingReply))
+	assert.Equal(reply.ProcessingTxs, processingReply.Txs)
+	for _, tx := range processingReply.Txs {
+		assert.Equal(&vtxID, tx.VertexID)
+		assert.False(tx.IssuedAt.IsZero())
+		switch tx.TxID {
+		case firstTx.ID():
+			assert.Equal([]ids.ID{secondTx.ID()}, tx.Conflicts)
+		case secondTx.ID():
+			assert.Equal([]ids.ID{firstTx.ID()}, tx.Conflicts)
+		default:
+			t.Fatalf("unexpected processing tx %s", tx.TxID)
+		}
+	}
+
+	// Deciding the txs removes them from the mempool
+	assert.NoError(pendingTxs[0].Accept())
+	assert.NoError(s.GetProcessingTxs(nil, nil, &processingReply))
+	assert.Len(processingReply.Txs, 1)
+	assert.Empty(processingReply.Txs[0].Conflicts)
+
+	assert.NoError(pendingTxs[1].Reject())
+	assert.NoError(s.GetProcessingTxs(nil, nil, &processingReply))
+	assert.Empty(processingReply.Txs)
+}
+
+func TestServiceVerifyTx(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, ctx, txs := setupIssueTx(t)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+	s := &Service{vm: vm}
+	firstTx, secondTx := txs[1], txs[2]
+
+	verify := func(tx *Tx) *VerifyTxReply {
+		txStr, err := formatting.EncodeWithChecksum(formatting.Hex, tx.Bytes())
+		assert.NoError(err)
+		reply := &VerifyTxReply{}
+		assert.NoError(s.VerifyTx(nil, &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}, reply))
+		assert.Equal(tx.ID(), reply.TxID)
+		return reply
+	}
+
+	reply := verify(firstTx)
+	assert.True(reply.Valid)
+	assert.Empty(reply.Error)
+
+	// Verifying the tx doesn't persist it
+	_, err := vm.state.GetTx(firstTx.ID())
+	assert.Error(err)
+	assert.Empty(vm.txs)
+
+	// A malformed tx fails syntactic verification
+	malformedTx := &Tx{UnsignedTx: &BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    networkID + 1,
+		BlockchainID: chainID,
+	}}}
+	assert.NoError(malformedTx.SignSECP256K1Fx(vm.codec, nil))
+	reply = verify(malformedTx)
+	assert.False(reply.Valid)
+	assert.NotEmpty(reply.Error)
+
+	// Once [firstTx] is accepted, [secondTx] spends a missing UTXO
+	_, err = vm.IssueTx(firstTx.Bytes())
+	assert.NoError(err)
+	pendingTxs := vm.PendingTxs()
+	assert.Len(pendingTxs, 1)
+	assert.NoError(pendingTxs[0].Accept())
+
+	reply = verify(firstTx)
+	assert.True(reply.Valid)
+	reply = verify(secondTx)
+	assert.False(reply.Valid)
+	assert.NotEmpty(reply.Error)
+}
@@ -5,6 +5,7 @@ package avm
 
 import (
 	"github.com/lasthyphen/dijetsgo/api"
+	"github.com/lasthyphen/dijetsgo/ids"
 	"github.com/lasthyphen/dijetsgo/pubsub"
 	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
 )
@@ -19,25 +20,61 @@ func NewPubSubFilterer(tx *Tx) pubsub.Filterer {
 	return &filterer{tx: tx}
 }
 
-// Apply the filter on the addresses.
+// Apply the filters on the type and the outputs of the tx.
 func (f *filterer) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
-	resp := make([]bool, len(filters))
+	tx := pubsub.Tx{
+		Type: txType(f.tx.UnsignedTx),
+	}
 	for _, utxo := range f.tx.UTXOs() {
-		addressable, ok := utxo.Out.(djtx.Addressable)
-		if !ok {
-			continue
+		tx.Outputs = append(tx.Outputs, pubsubOutput(utxo.AssetID(), utxo.Out))
+	}
+	// Exported outputs don't produce UTXOs on this chain, so they are only
+	// matched by the asset ID and minimum amount filters.
+	if exportTx, ok := f.tx.UnsignedTx.(*ExportTx); ok {
+		for _, out := range exportTx.ExportedOuts {
+			output := pubsubOutput(out.AssetID(), out.Out)
+			output.Exported = true
+			tx.Outputs = append(tx.Outputs, output)
 		}
+	}
 
-		for _, address := range addressable.Addresses() {
-			for i, c := range filters {
-				if resp[i] {
-					continue
-				}
-				resp[i] = c.Check(address)
-			}
-		}
+	resp := make([]bool, len(filters))
+	for i, c := range filters {
+		resp[i] = c.CheckTx(&tx)
 	}
 	return resp, api.JSONTxID{
 		TxID: f.tx.ID(),
 	}
 }
+
+func pubsubOutput(assetID ids.ID, out interface{}) pubsub.Output {
+	output := pubsub.Output{
+		AssetID: assetID,
+	}
+	if amounter, ok := out.(djtx.Amounter); ok {
+		output.Amount = amounter.Amount()
+	}
+	if addressable, ok := out.(djtx.Addressable); ok {
+		output.Addresses = addressable.Addresses()
+	}
+	return output
+}
+
+// txType returns the name that pubsub subscribers use to filter by the type of
+// [tx]
+func txType(tx UnsignedTx) string {
+	switch tx.(type) {
+	case *BaseTx:
+		return "BaseTx"
+	case *CreateAssetTx:
+		return "CreateAssetTx"
+	case *OperationTx:
+		return "OperationTx"
+	case *ImportTx:
+		return "ImportTx"
+	case *ExportTx:
+		return "ExportTx"
+	default:
+		return "unknown"
+	}
+}
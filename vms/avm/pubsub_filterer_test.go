@@ -23,6 +23,17 @@ func (f *mockFilter) Check(addr []byte) bool {
 	return bytes.Equal(addr, f.addr)
 }
 
+func (f *mockFilter) CheckTx(tx *pubsub.Tx) bool {
+	for _, out := range tx.Outputs {
+		for _, addr := range out.Addresses {
+			if f.Check(addr) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func TestFilter(t *testing.T) {
 	assert := assert.New(t)
 
@@ -48,3 +59,47 @@ func TestFilter(t *testing.T) {
 	fr, _ := parser.Filter([]pubsub.Filter{&mockFilter{addr: addrBytes}})
 	assert.Equal([]bool{true}, fr)
 }
+
+func TestFilterAssetAndTxType(t *testing.T) {
+	assert := assert.New(t)
+
+	assetID := ids.ID{2}
+	addrID := ids.ShortID{1}
+	tx := Tx{UnsignedTx: &ExportTx{
+		BaseTx: BaseTx{BaseTx: djtx.BaseTx{}},
+		ExportedOuts: []*djtx.TransferableOutput{
+			{
+				Asset: djtx.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: 100,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Addrs: []ids.ShortID{addrID},
+					},
+				},
+			},
+		},
+	}}
+
+	matching := pubsub.NewFilterParam()
+	assert.NoError(matching.AddAssetIDs(assetID))
+	assert.NoError(matching.AddTxTypes("ExportTx"))
+	matching.SetMinAmount(100)
+
+	// Exported outputs aren't spendable on this chain, so they aren't matched
+	// by the address filter
+	byAddress := pubsub.NewFilterParam()
+	assert.NoError(byAddress.Add(addrID[:]))
+
+	wrongType := pubsub.NewFilterParam()
+	assert.NoError(wrongType.AddTxTypes("CreateAssetTx"))
+
+	wrongAsset := pubsub.NewFilterParam()
+	assert.NoError(wrongAsset.AddAssetIDs(ids.ID{3}))
+
+	tooSmall := pubsub.NewFilterParam()
+	tooSmall.SetMinAmount(101)
+
+	parser := NewPubSubFilterer(&tx)
+	fr, _ := parser.Filter([]pubsub.Filter{matching, byAddress, wrongType, wrongAsset, tooSmall})
+	assert.Equal([]bool{true, false, false, false, false}, fr)
+}
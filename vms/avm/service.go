@@ -9,6 +9,7 @@ import (
 	"math"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/lasthyphen/dijetsgo/api"
 	"github.com/lasthyphen/dijetsgo/ids"
@@ -17,11 +18,14 @@ import (
 	"github.com/lasthyphen/dijetsgo/utils/crypto"
 	"github.com/lasthyphen/dijetsgo/utils/formatting"
 	"github.com/lasthyphen/dijetsgo/utils/json"
+	"github.com/lasthyphen/dijetsgo/vms/compliancefx"
 	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
+	"github.com/lasthyphen/dijetsgo/vms/components/index"
 	"github.com/lasthyphen/dijetsgo/vms/components/keystore"
 	"github.com/lasthyphen/dijetsgo/vms/components/verify"
 	"github.com/lasthyphen/dijetsgo/vms/nftfx"
 	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+	"github.com/lasthyphen/dijetsgo/vms/types"
 
 	safemath "github.com/lasthyphen/dijetsgo/utils/math"
 )
@@ -35,19 +39,21 @@ const (
 )
 
 var (
-	errUnknownAssetID         = errors.New("unknown asset ID")
-	errTxNotCreateAsset       = errors.New("transaction doesn't create an asset")
-	errNoMinters              = errors.New("no minters provided")
-	errNoHoldersOrMinters     = errors.New("no minters or initialHolders provided")
-	errZeroAmount             = errors.New("amount must be positive")
-	errNoOutputs              = errors.New("no outputs to send")
-	errSpendOverflow          = errors.New("spent amount overflows uint64")
-	errInvalidMintAmount      = errors.New("amount minted must be positive")
-	errAddressesCantMintAsset = errors.New("provided addresses don't have the authority to mint the provided asset")
-	errInvalidUTXO            = errors.New("invalid utxo")
-	errNilTxID                = errors.New("nil transaction ID")
-	errNoAddresses            = errors.New("no addresses provided")
-	errNoKeys                 = errors.New("from addresses have no keys or funds")
+	errUnknownAssetID                  = errors.New("unknown asset ID")
+	errTxNotCreateAsset                = errors.New("transaction doesn't create an asset")
+	errNoMinters                       = errors.New("no minters provided")
+	errNoHoldersOrMinters              = errors.New("no minters or initialHolders provided")
+	errZeroAmount                      = errors.New("amount must be positive")
+	errNoOutputs                       = errors.New("no outputs to send")
+	errSpendOverflow                   = errors.New("spent amount overflows uint64")
+	errInvalidMintAmount               = errors.New("amount minted must be positive")
+	errAddressesCantMintAsset          = errors.New("provided addresses don't have the authority to mint the provided asset")
+	errAddressesNotComplianceAuthority = errors.New("provided addresses don't have the compliance authority of the provided asset")
+	errNoComplianceUTXOs               = errors.New("provided addresses don't hold UTXOs of the provided asset that the operation applies to")
+	errInvalidUTXO                     = errors.New("invalid utxo")
+	errNilTxID                         = errors.New("nil transaction ID")
+	errNoAddresses                     = errors.New("no addresses provided")
+	errNoKeys                          = errors.New("from addresses have no keys or funds")
 )
 
 // Service defines the base service for the asset vm
@@ -135,6 +141,206 @@ func (service *Service) GetAddressTxs(r *http.Request, args *GetAddressTxsArgs,
 	return nil
 }
 
+// GetNFTsArgs are the arguments for listing indexed NFTs
+type GetNFTsArgs struct {
+	// Cursor returned by the previous page. Empty to read the first page.
+	Cursor string `json:"cursor"`
+	// PageSize num of items per page
+	PageSize json.Uint64 `json:"pageSize"`
+	// AssetID of the NFT collection. Optional in GetOwnedNFTs.
+	AssetID string `json:"assetID"`
+}
+
+// GetOwnedNFTsArgs are the arguments for GetOwnedNFTs
+type GetOwnedNFTsArgs struct {
+	api.JSONAddress
+	GetNFTsArgs
+}
+
+// NFTHolding is an unspent NFT held by an address
+type NFTHolding struct {
+	AssetID ids.ID              `json:"assetID"`
+	GroupID json.Uint32         `json:"groupID"`
+	UTXOID  ids.ID              `json:"utxoID"`
+	Owner   string              `json:"owner"`
+	Payload types.JSONByteSlice `json:"payload"`
+}
+
+// GetNFTsReply is the response from GetOwnedNFTs and GetNFTHolders
+type GetNFTsReply struct {
+	NFTs []NFTHolding `json:"nfts"`
+	// Cursor to read the next page from
+	Cursor string `json:"cursor"`
+}
+
+// GetOwnedNFTs returns the NFTs currently owned by [args.Address].
+// Requires the node to run with NFT indexing enabled.
+func (service *Service) GetOwnedNFTs(r *http.Request, args *GetOwnedNFTsArgs, reply *GetNFTsReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetOwnedNFTs called with address=%s, assetID=%s, cursor=%s, pageSize=%d", args.Address, args.AssetID, args.Cursor, args.PageSize)
+	pageSize, err := nftPageSize(args.PageSize)
+	if err != nil {
+		return err
+	}
+
+	address, err := service.vm.ParseLocalAddress(args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
+	}
+
+	assetID := ids.Empty
+	if args.AssetID != "" {
+		assetID, err = service.vm.lookupAssetID(args.AssetID)
+		if err != nil {
+			return fmt.Errorf("specified `assetID` is invalid: %w", err)
+		}
+	}
+
+	cursor, err := parseNFTCursor(args.Cursor)
+	if err != nil {
+		return err
+	}
+	nfts, cursor, err := service.vm.nftIndexer.ReadOwned(address, assetID, cursor, pageSize)
+	if err != nil {
+		return err
+	}
+	return service.formatNFTs(nfts, cursor, reply)
+}
+
+// GetNFTHolders returns the NFTs of the collection [args.AssetID] and the
+// addresses that hold them.
+// Requires the node to run with NFT indexing enabled.
+func (service *Service) GetNFTHolders(r *http.Request, args *GetNFTsArgs, reply *GetNFTsReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetNFTHolders called with assetID=%s, cursor=%s, pageSize=%d", args.AssetID, args.Cursor, args.PageSize)
+	pageSize, err := nftPageSize(args.PageSize)
+	if err != nil {
+		return err
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return fmt.Errorf("specified `assetID` is invalid: %w", err)
+	}
+
+	cursor, err := parseNFTCursor(args.Cursor)
+	if err != nil {
+		return err
+	}
+	nfts, cursor, err := service.vm.nftIndexer.ReadHolders(assetID, cursor, pageSize)
+	if err != nil {
+		return err
+	}
+	return service.formatNFTs(nfts, cursor, reply)
+}
+
+// parseNFTCursor returns the index cursor encoded by [cursor]
+func parseNFTCursor(cursor string) ([]byte, error) {
+	cursorBytes, err := formatting.Decode(formatting.Hex, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse argument 'cursor': %w", err)
+	}
+	return cursorBytes, nil
+}
+
+func (service *Service) formatNFTs(nfts []index.NFT, cursor []byte, reply *GetNFTsReply) error {
+	reply.NFTs = make([]NFTHolding, len(nfts))
+	for i, nft := range nfts {
+		owner, err := service.vm.FormatLocalAddress(nft.Owner)
+		if err != nil {
+			return fmt.Errorf("problem formatting address: %w", err)
+		}
+		reply.NFTs[i] = NFTHolding{
+			AssetID: nft.AssetID,
+			GroupID: json.Uint32(nft.GroupID),
+			UTXOID:  nft.UTXOID,
+			Owner:   owner,
+			Payload: nft.Payload,
+		}
+	}
+	var err error
+	reply.Cursor, err = formatting.EncodeWithChecksum(formatting.Hex, cursor)
+	return err
+}
+
+// GetNFTHistoryArgs are the arguments for GetNFTHistory
+type GetNFTHistoryArgs struct {
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+	// PageSize num of items per page
+	PageSize json.Uint64 `json:"pageSize"`
+	// AssetID of the NFT collection
+	AssetID string      `json:"assetID"`
+	GroupID json.Uint32 `json:"groupID"`
+}
+
+// NFTTransfer is an output an NFT was minted or transferred with
+type NFTTransfer struct {
+	TxID      ids.ID              `json:"txID"`
+	Payload   types.JSONByteSlice `json:"payload"`
+	Locktime  json.Uint64         `json:"locktime"`
+	Threshold json.Uint32         `json:"threshold"`
+	Owners    []string            `json:"owners"`
+}
+
+// GetNFTHistoryReply is the response from GetNFTHistory
+type GetNFTHistoryReply struct {
+	Transfers []NFTTransfer `json:"transfers"`
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+}
+
+// GetNFTHistory returns the outputs group [args.GroupID] of the collection
+// [args.AssetID] was minted and transferred with, in order of acceptance.
+// Requires the node to run with NFT indexing enabled.
+func (service *Service) GetNFTHistory(r *http.Request, args *GetNFTHistoryArgs, reply *GetNFTHistoryReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetNFTHistory called with assetID=%s, groupID=%d, cursor=%d, pageSize=%d", args.AssetID, args.GroupID, args.Cursor, args.PageSize)
+	pageSize, err := nftPageSize(args.PageSize)
+	if err != nil {
+		return err
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return fmt.Errorf("specified `assetID` is invalid: %w", err)
+	}
+
+	cursor := uint64(args.Cursor)
+	transfers, err := service.vm.nftIndexer.ReadHistory(assetID, uint32(args.GroupID), cursor, pageSize)
+	if err != nil {
+		return err
+	}
+
+	reply.Transfers = make([]NFTTransfer, len(transfers))
+	for i, transfer := range transfers {
+		owners := make([]string, len(transfer.Owners))
+		for j, addr := range transfer.Owners {
+			owners[j], err = service.vm.FormatLocalAddress(addr)
+			if err != nil {
+				return fmt.Errorf("problem formatting address: %w", err)
+			}
+		}
+		reply.Transfers[i] = NFTTransfer{
+			TxID:      transfer.TxID,
+			Payload:   transfer.Payload,
+			Locktime:  json.Uint64(transfer.Locktime),
+			Threshold: json.Uint32(transfer.Threshold),
+			Owners:    owners,
+		}
+	}
+	reply.Cursor = json.Uint64(cursor + uint64(len(transfers)))
+	return nil
+}
+
+func nftPageSize(pageSize json.Uint64) (uint64, error) {
+	switch size := uint64(pageSize); {
+	case size > maxPageSize:
+		return 0, fmt.Errorf("pageSize > maximum allowed (%d)", maxPageSize)
+	case size == 0:
+		return maxPageSize, nil
+	default:
+		return size, nil
+	}
+}
+
 // GetTxStatus returns the status of the specified transaction
 func (service *Service) GetTxStatus(r *http.Request, args *api.JSONTxID, reply *GetTxStatusReply) error {
 	service.vm.ctx.Log.Debug("AVM: GetTxStatus called with %s", args.TxID)
@@ -152,6 +358,99 @@ func (service *Service) GetTxStatus(r *http.Request, args *api.JSONTxID, reply *
 	return nil
 }
 
+// MempoolTx is a tx that this node hasn't decided yet
+type MempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// ID of the vertex the tx was issued into consensus in. Omitted if the tx
+	// hasn't been issued into consensus yet.
+	VertexID *ids.ID `json:"vertexID,omitempty"`
+	// Time the tx was issued
+	IssuedAt time.Time `json:"issuedAt"`
+	// IDs of the processing txs that conflict with this tx
+	Conflicts []ids.ID `json:"conflicts"`
+}
+
+func newMempoolTxs(txs []mempoolTx) []MempoolTx {
+	apiTxs := make([]MempoolTx, len(txs))
+	for i, tx := range txs {
+		apiTxs[i] = MempoolTx{
+			TxID:      tx.txID,
+			VertexID:  tx.vtxID,
+			IssuedAt:  tx.issuedAt,
+			Conflicts: tx.conflicts,
+		}
+	}
+	return apiTxs
+}
+
+// GetMempoolReply defines the GetMempool replies returned from the API
+type GetMempoolReply struct {
+	// Txs issued to this node that haven't been issued into consensus yet
+	PendingTxs []MempoolTx `json:"pendingTxs"`
+	// Txs issued into consensus that haven't been decided yet
+	ProcessingTxs []MempoolTx `json:"processingTxs"`
+}
+
+// GetMempool returns the txs this node hasn't decided yet
+func (service *Service) GetMempool(_ *http.Request, _ *struct{}, reply *GetMempoolReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetMempool called")
+
+	reply.PendingTxs = newMempoolTxs(service.vm.getPendingTxs())
+	reply.ProcessingTxs = newMempoolTxs(service.vm.getProcessingTxs())
+	return nil
+}
+
+// GetProcessingTxsReply defines the GetProcessingTxs replies returned from the
+// API
+type GetProcessingTxsReply struct {
+	Txs []MempoolTx `json:"txs"`
+}
+
+// GetProcessingTxs returns the txs issued into consensus that haven't been
+// decided yet
+func (service *Service) GetProcessingTxs(_ *http.Request, _ *struct{}, reply *GetProcessingTxsReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetProcessingTxs called")
+
+	reply.Txs = newMempoolTxs(service.vm.getProcessingTxs())
+	return nil
+}
+
+// VerifyTxReply defines the VerifyTx replies returned from the API
+type VerifyTxReply struct {
+	TxID ids.ID `json:"txID"`
+	// True iff the tx passed verification
+	Valid bool `json:"valid"`
+	// Reason the tx failed verification
+	Error string `json:"error,omitempty"`
+}
+
+// VerifyTx verifies a transaction against the current state without issuing
+// it
+func (service *Service) VerifyTx(_ *http.Request, args *api.FormattedTx, reply *VerifyTxReply) error {
+	service.vm.ctx.Log.Debug("AVM: VerifyTx called with %s", args.Tx)
+
+	if !service.vm.bootstrapped {
+		return errBootstrapping
+	}
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := service.vm.parsePrivateTx(txBytes)
+	if err != nil {
+		return fmt.Errorf("problem parsing transaction: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+	if err := service.vm.verifyTx(tx); err != nil {
+		reply.Error = err.Error()
+		return nil
+	}
+	reply.Valid = true
+	return nil
+}
+
 // GetTx returns the specified transaction
 func (service *Service) GetTx(r *http.Request, args *api.GetTxArgs, reply *api.GetTxReply) error {
 	service.vm.ctx.Log.Debug("AVM: GetTx called with %s", args.TxID)
@@ -327,8 +626,17 @@ type GetBalanceArgs struct {
 }
 
 // GetBalanceReply defines the GetBalance replies returned from the API
+//
+// [Unlocked] and [Locked] are independent totals of the UTXOs owned by the
+// address, split by locktime. Both count multisig UTXOs only if
+// [IncludePartial] was set. [Balance] is [Unlocked], plus [Locked] if
+// [IncludePartial] was set.
 type GetBalanceReply struct {
-	Balance json.Uint64   `json:"balance"`
+	Balance json.Uint64 `json:"balance"`
+	// Amount held by the owned UTXOs that can be spent now
+	Unlocked json.Uint64 `json:"unlocked"`
+	// Amount held by the owned UTXOs that can't be spent until their locktime
+	Locked  json.Uint64   `json:"locked"`
 	UTXOIDs []djtx.UTXOID `json:"utxoIDs"`
 }
 
@@ -337,6 +645,9 @@ type GetBalanceReply struct {
 // (1 out of 1 multisig) by the address and with a locktime in the past.
 // Otherwise, returned balance includes assets held only partially by the
 // address, and includes balances with locktime in the future.
+// In both cases, the balance is also reported split between its unlocked and
+// locked parts, and the locked part includes funds with a locktime in the
+// future.
 func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply *GetBalanceReply) error {
 	service.vm.ctx.Log.Debug("AVM: GetBalance called with address: %s assetID: %s", args.Address, args.AssetID)
 
@@ -370,9 +681,25 @@ func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply
 			continue
 		}
 		owners := transferable.OutputOwners
-		if !args.IncludePartial && (len(owners.Addrs) != 1 || owners.Locktime > now) {
+		if !args.IncludePartial && len(owners.Addrs) != 1 {
 			continue
 		}
+		if owners.Locktime > now {
+			locked, err := safemath.Add64(transferable.Amount(), uint64(reply.Locked))
+			if err != nil {
+				return err
+			}
+			reply.Locked = json.Uint64(locked)
+			if !args.IncludePartial {
+				continue
+			}
+		} else {
+			unlocked, err := safemath.Add64(transferable.Amount(), uint64(reply.Unlocked))
+			if err != nil {
+				return err
+			}
+			reply.Unlocked = json.Uint64(unlocked)
+		}
 		amt, err := safemath.Add64(transferable.Amount(), uint64(reply.Balance))
 		if err != nil {
 			return err
@@ -385,8 +712,10 @@ func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply
 }
 
 type Balance struct {
-	AssetID string      `json:"asset"`
-	Balance json.Uint64 `json:"balance"`
+	AssetID  string      `json:"asset"`
+	Balance  json.Uint64 `json:"balance"`
+	Unlocked json.Uint64 `json:"unlocked"`
+	Locked   json.Uint64 `json:"locked"`
 }
 
 type GetAllBalancesArgs struct {
@@ -405,6 +734,9 @@ type GetAllBalancesReply struct {
 // If ![args.IncludePartial], returns only unlocked balance/UTXOs with a 1-out-of-1 multisig.
 // Otherwise, returned balance/UTXOs includes assets held only partially by the
 // address, and includes balances with locktime in the future.
+// In both cases, each balance is also reported split between its unlocked and
+// locked parts, and the locked part includes funds with a locktime in the
+// future.
 func (service *Service) GetAllBalances(r *http.Request, args *GetAllBalancesArgs, reply *GetAllBalancesReply) error {
 	service.vm.ctx.Log.Debug("AVM: GetAllBalances called with address: %s", args.Address)
 
@@ -423,6 +755,16 @@ func (service *Service) GetAllBalances(r *http.Request, args *GetAllBalancesArgs
 	now := service.vm.clock.Unix()
 	assetIDs := ids.Set{}               // IDs of assets the address has a non-zero balance of
 	balances := make(map[ids.ID]uint64) // key: ID (as bytes). value: balance of that asset
+	unlocked := make(map[ids.ID]uint64) // key: ID (as bytes). value: unlocked balance of that asset
+	locked := make(map[ids.ID]uint64)   // key: ID (as bytes). value: locked balance of that asset
+	addBalance := func(balances map[ids.ID]uint64, assetID ids.ID, amount uint64) {
+		balance, err := safemath.Add64(amount, balances[assetID])
+		if err != nil {
+			balances[assetID] = math.MaxUint64
+		} else {
+			balances[assetID] = balance
+		}
+	}
 	for _, utxo := range utxos {
 		// TODO make this not specific to *secp256k1fx.TransferOutput
 		transferable, ok := utxo.Out.(*secp256k1fx.TransferOutput)
@@ -430,33 +772,33 @@ func (service *Service) GetAllBalances(r *http.Request, args *GetAllBalancesArgs
 			continue
 		}
 		owners := transferable.OutputOwners
-		if !args.IncludePartial && (len(owners.Addrs) != 1 || owners.Locktime > now) {
+		if !args.IncludePartial && len(owners.Addrs) != 1 {
 			continue
 		}
 		assetID := utxo.AssetID()
-		assetIDs.Add(assetID)
-		balance := balances[assetID] // 0 if key doesn't exist
-		balance, err := safemath.Add64(transferable.Amount(), balance)
-		if err != nil {
-			balances[assetID] = math.MaxUint64
+		if owners.Locktime > now {
+			addBalance(locked, assetID, transferable.Amount())
+			if !args.IncludePartial {
+				continue
+			}
 		} else {
-			balances[assetID] = balance
+			addBalance(unlocked, assetID, transferable.Amount())
 		}
+		assetIDs.Add(assetID)
+		addBalance(balances, assetID, transferable.Amount())
 	}
 
 	reply.Balances = make([]Balance, assetIDs.Len())
 	i := 0
 	for assetID := range assetIDs {
+		reply.Balances[i] = Balance{
+			AssetID:  assetID.String(),
+			Balance:  json.Uint64(balances[assetID]),
+			Unlocked: json.Uint64(unlocked[assetID]),
+			Locked:   json.Uint64(locked[assetID]),
+		}
 		if alias, err := service.vm.PrimaryAlias(assetID); err == nil {
-			reply.Balances[i] = Balance{
-				AssetID: alias,
-				Balance: json.Uint64(balances[assetID]),
-			}
-		} else {
-			reply.Balances[i] = Balance{
-				AssetID: assetID.String(),
-				Balance: json.Uint64(balances[assetID]),
-			}
+			reply.Balances[i].AssetID = alias
 		}
 		i++
 	}
@@ -484,6 +826,9 @@ type CreateAssetArgs struct {
 	Denomination        byte      `json:"denomination"`
 	InitialHolders      []*Holder `json:"initialHolders"`
 	MinterSets          []Owners  `json:"minterSets"`
+	// If provided, these owners can freeze, unfreeze and claw back the UTXOs
+	// of the asset. Requires the chain to support the compliance fx.
+	ComplianceAuthority *Owners `json:"complianceAuthority,omitempty"`
 }
 
 // AssetIDChangeAddr is an asset ID and a change address
@@ -587,6 +932,33 @@ func (service *Service) CreateAsset(r *http.Request, args *CreateAssetArgs, repl
 		initialState.Outs = append(initialState.Outs, minter)
 	}
 	initialState.Sort(service.vm.codec)
+	states := []*InitialState{initialState}
+
+	if owner := args.ComplianceAuthority; owner != nil {
+		fxIndex, err := service.vm.getComplianceFxIndex()
+		if err != nil {
+			return err
+		}
+		authority := &compliancefx.AuthorityOutput{
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: uint32(owner.Threshold),
+				Addrs:     make([]ids.ShortID, 0, len(owner.Minters)),
+			},
+		}
+		for _, address := range owner.Minters {
+			addr, err := service.vm.ParseLocalAddress(address)
+			if err != nil {
+				return err
+			}
+			authority.Addrs = append(authority.Addrs, addr)
+		}
+		ids.SortShortIDs(authority.Addrs)
+		states = append(states, &InitialState{
+			FxIndex: fxIndex,
+			Outs:    []verify.State{authority},
+		})
+		sortInitialStates(states)
+	}
 
 	tx := Tx{UnsignedTx: &CreateAssetTx{
 		BaseTx: BaseTx{BaseTx: djtx.BaseTx{
@@ -598,7 +970,7 @@ func (service *Service) CreateAsset(r *http.Request, args *CreateAssetArgs, repl
 		Name:         args.Name,
 		Symbol:       args.Symbol,
 		Denomination: args.Denomination,
-		States:       []*InitialState{initialState},
+		States:       states,
 	}}
 	if err := tx.SignSECP256K1Fx(service.vm.codec, keys); err != nil {
 		return err
@@ -911,6 +1283,34 @@ type SendOutput struct {
 
 	// Address of the recipient
 	To string `json:"to"`
+
+	// Unix time before which the funds can't be spent. Ignored if
+	// [UnlockTimes] is provided.
+	Locktime json.Uint64 `json:"locktime"`
+
+	// If provided, the funds vest over these increasing unix times: they are
+	// split evenly between outputs, each locked until one of these times.
+	UnlockTimes []json.Uint64 `json:"unlockTimes"`
+}
+
+// outputs returns the outputs of [assetID] sent to [to] by [output]
+func (output *SendOutput) outputs(assetID ids.ID, to ids.ShortID) ([]*djtx.TransferableOutput, error) {
+	unlockTimes := []uint64{uint64(output.Locktime)}
+	if len(output.UnlockTimes) > 0 {
+		unlockTimes = make([]uint64, len(output.UnlockTimes))
+		for i, unlockTime := range output.UnlockTimes {
+			unlockTimes[i] = uint64(unlockTime)
+		}
+	}
+	return NewVestingOutputs(
+		assetID,
+		uint64(output.Amount),
+		secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{to},
+		},
+		unlockTimes,
+	)
 }
 
 // SendArgs are arguments for passing into Send requests
@@ -1011,18 +1411,12 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 			return fmt.Errorf("problem parsing to address %q: %w", output.To, err)
 		}
 
-		// Create the Output
-		outs = append(outs, &djtx.TransferableOutput{
-			Asset: djtx.Asset{ID: assetID},
-			Out: &secp256k1fx.TransferOutput{
-				Amt: uint64(output.Amount),
-				OutputOwners: secp256k1fx.OutputOwners{
-					Locktime:  0,
-					Threshold: 1,
-					Addrs:     []ids.ShortID{to},
-				},
-			},
-		})
+		// Create the Outputs
+		sendOuts, err := output.outputs(assetID, to)
+		if err != nil {
+			return err
+		}
+		outs = append(outs, sendOuts...)
 	}
 
 	amountsWithFee := make(map[ids.ID]uint64, len(amounts)+1)
@@ -1427,6 +1821,77 @@ func (service *Service) MintNFT(r *http.Request, args *MintNFTArgs, reply *api.J
 	return err
 }
 
+// ComplianceArgs are arguments for passing into Freeze and Unfreeze requests
+type ComplianceArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// ID or alias of the asset to operate on
+	AssetID string `json:"assetID"`
+	// The operation applies to the UTXOs of the asset held by these addresses
+	Addresses []string `json:"addresses"`
+}
+
+// ClawbackArgs are arguments for passing into Clawback requests
+type ClawbackArgs struct {
+	ComplianceArgs
+	// Address receiving the clawed back funds
+	To string `json:"to"`
+}
+
+// Freeze freezes the UTXOs of an asset held by the provided addresses. The
+// user must control the asset's compliance authority.
+// Fails on chains created without compliancefx, such as the X-Chain.
+func (service *Service) Freeze(_ *http.Request, args *ComplianceArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("AVM: Freeze called with username: %s", args.Username)
+
+	return service.issueComplianceTx(args, reply, service.vm.Freeze)
+}
+
+// Unfreeze unfreezes the frozen UTXOs of an asset held by the provided
+// addresses. The user must control the asset's compliance authority.
+// Fails on chains created without compliancefx, such as the X-Chain.
+func (service *Service) Unfreeze(_ *http.Request, args *ComplianceArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("AVM: Unfreeze called with username: %s", args.Username)
+
+	return service.issueComplianceTx(args, reply, service.vm.Unfreeze)
+}
+
+// Clawback sends the frozen and transferable UTXOs of an asset held by the
+// provided addresses to [args.To]. The user must control the asset's
+// compliance authority.
+// Fails on chains created without compliancefx, such as the X-Chain.
+func (service *Service) Clawback(_ *http.Request, args *ClawbackArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("AVM: Clawback called with username: %s", args.Username)
+
+	buildOp, err := service.vm.clawbackBuilder(args.To)
+	if err != nil {
+		return err
+	}
+	return service.issueComplianceTx(&args.ComplianceArgs, reply, buildOp)
+}
+
+// issueComplianceTx issues a tx that performs the operation returned by
+// [buildOp]
+func (service *Service) issueComplianceTx(
+	args *ComplianceArgs,
+	reply *api.JSONTxIDChangeAddr,
+	buildOp complianceOperationBuilder,
+) error {
+	tx, changeAddr, err := service.vm.newComplianceTx(args, nil, buildOp)
+	if err != nil {
+		return err
+	}
+
+	txID, err := service.vm.IssueTx(tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+	return err
+}
+
 // ImportArgs are arguments for passing into Import requests
 type ImportArgs struct {
 	// User that controls To
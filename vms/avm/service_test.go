@@ -5,6 +5,7 @@ package avm
 
 import (
 	"bytes"
+	"container/list"
 	"fmt"
 	"math/rand"
 	"strings"
@@ -20,6 +21,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/api"
 	"github.com/lasthyphen/dijetsgo/chains/atomic"
 	"github.com/lasthyphen/dijetsgo/database/manager"
+	"github.com/lasthyphen/dijetsgo/database/prefixdb"
 	"github.com/lasthyphen/dijetsgo/ids"
 	"github.com/lasthyphen/dijetsgo/snow"
 	"github.com/lasthyphen/dijetsgo/snow/choices"
@@ -30,6 +32,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/utils/json"
 	"github.com/lasthyphen/dijetsgo/utils/sampler"
 	"github.com/lasthyphen/dijetsgo/version"
+	"github.com/lasthyphen/dijetsgo/vms/compliancefx"
 	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
 	"github.com/lasthyphen/dijetsgo/vms/components/index"
 	"github.com/lasthyphen/dijetsgo/vms/components/keystore"
@@ -2386,6 +2389,319 @@ func TestNFTWorkflow(t *testing.T) {
 	}
 }
 
+func TestServiceNFTIndex(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, _ := setupWithKeys(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	var err error
+	vm.nftIndexer, err = index.NewNFTIndexer(prefixdb.New([]byte("nfts"), vm.db), vm.ctx.Log, false, true)
+	assert.NoError(err)
+
+	owner := keys[0].PublicKey().Address()
+	ownerStr, err := vm.FormatLocalAddress(owner)
+	assert.NoError(err)
+	buyerStr, err := vm.FormatLocalAddress(ids.GenerateTestShortID())
+	assert.NoError(err)
+	spendHeader := api.JSONSpendHeader{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: ownerStr},
+	}
+
+	acceptTx := func(txID ids.ID) {
+		tx := UniqueTx{
+			vm:   vm,
+			txID: txID,
+		}
+		assert.Equal(choices.Processing, tx.Status())
+		assert.NoError(tx.Accept())
+	}
+
+	createReply := &AssetIDChangeAddr{}
+	assert.NoError(s.CreateNFTAsset(nil, &CreateNFTAssetArgs{
+		JSONSpendHeader: spendHeader,
+		Name:            "Collection",
+		Symbol:          "COLL",
+		MinterSets: []Owners{{
+			Threshold: 1,
+			Minters:   []string{ownerStr},
+		}},
+	}, createReply))
+	acceptTx(createReply.AssetID)
+	assetID := createReply.AssetID
+
+	payload, err := formatting.EncodeWithChecksum(formatting.Hex, []byte{1, 2, 3})
+	assert.NoError(err)
+	mintReply := &api.JSONTxIDChangeAddr{}
+	assert.NoError(s.MintNFT(nil, &MintNFTArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         assetID.String(),
+		Payload:         payload,
+		To:              ownerStr,
+		Encoding:        formatting.Hex,
+	}, mintReply))
+	acceptTx(mintReply.TxID)
+
+	ownedReply := &GetNFTsReply{}
+	assert.NoError(s.GetOwnedNFTs(nil, &GetOwnedNFTsArgs{
+		JSONAddress: api.JSONAddress{Address: ownerStr},
+	}, ownedReply))
+	assert.Len(ownedReply.NFTs, 1)
+	assert.Equal(assetID, ownedReply.NFTs[0].AssetID)
+	assert.EqualValues(0, ownedReply.NFTs[0].GroupID)
+	assert.Equal(ownerStr, ownedReply.NFTs[0].Owner)
+	assert.Equal([]byte{1, 2, 3}, []byte(ownedReply.NFTs[0].Payload))
+
+	// The next page starts after the last NFT
+	nextReply := &GetNFTsReply{}
+	assert.NoError(s.GetOwnedNFTs(nil, &GetOwnedNFTsArgs{
+		JSONAddress: api.JSONAddress{Address: ownerStr},
+		GetNFTsArgs: GetNFTsArgs{Cursor: ownedReply.Cursor},
+	}, nextReply))
+	assert.Len(nextReply.NFTs, 0)
+	assert.Equal(ownedReply.Cursor, nextReply.Cursor)
+
+	assert.Error(s.GetOwnedNFTs(nil, &GetOwnedNFTsArgs{
+		JSONAddress: api.JSONAddress{Address: ownerStr},
+		GetNFTsArgs: GetNFTsArgs{Cursor: "not a cursor"},
+	}, &GetNFTsReply{}))
+
+	sendReply := &api.JSONTxIDChangeAddr{}
+	assert.NoError(s.SendNFT(nil, &SendNFTArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         assetID.String(),
+		To:              buyerStr,
+	}, sendReply))
+	acceptTx(sendReply.TxID)
+
+	// The seller no longer holds the NFT
+	ownedReply = &GetNFTsReply{}
+	assert.NoError(s.GetOwnedNFTs(nil, &GetOwnedNFTsArgs{
+		JSONAddress: api.JSONAddress{Address: ownerStr},
+		GetNFTsArgs: GetNFTsArgs{AssetID: assetID.String()},
+	}, ownedReply))
+	assert.Len(ownedReply.NFTs, 0)
+
+	ownedReply = &GetNFTsReply{}
+	assert.NoError(s.GetOwnedNFTs(nil, &GetOwnedNFTsArgs{
+		JSONAddress: api.JSONAddress{Address: buyerStr},
+	}, ownedReply))
+	assert.Len(ownedReply.NFTs, 1)
+	assert.Equal(buyerStr, ownedReply.NFTs[0].Owner)
+
+	holdersReply := &GetNFTsReply{}
+	assert.NoError(s.GetNFTHolders(nil, &GetNFTsArgs{
+		AssetID: assetID.String(),
+	}, holdersReply))
+	assert.Len(holdersReply.NFTs, 1)
+	assert.Equal(buyerStr, holdersReply.NFTs[0].Owner)
+	assert.Equal(ownedReply.NFTs[0].UTXOID, holdersReply.NFTs[0].UTXOID)
+
+	historyReply := &GetNFTHistoryReply{}
+	assert.NoError(s.GetNFTHistory(nil, &GetNFTHistoryArgs{
+		AssetID: assetID.String(),
+	}, historyReply))
+	assert.Len(historyReply.Transfers, 2)
+	assert.Equal(mintReply.TxID, historyReply.Transfers[0].TxID)
+	assert.Equal([]string{ownerStr}, historyReply.Transfers[0].Owners)
+	assert.Equal(sendReply.TxID, historyReply.Transfers[1].TxID)
+	assert.Equal([]string{buyerStr}, historyReply.Transfers[1].Owners)
+	assert.Equal([]byte{1, 2, 3}, []byte(historyReply.Transfers[1].Payload))
+	assert.EqualValues(2, historyReply.Cursor)
+
+	// Page through the history
+	historyReply = &GetNFTHistoryReply{}
+	assert.NoError(s.GetNFTHistory(nil, &GetNFTHistoryArgs{
+		AssetID:  assetID.String(),
+		Cursor:   1,
+		PageSize: 1,
+	}, historyReply))
+	assert.Len(historyReply.Transfers, 1)
+	assert.Equal(sendReply.TxID, historyReply.Transfers[0].TxID)
+
+	// Other groups have no history
+	historyReply = &GetNFTHistoryReply{}
+	assert.NoError(s.GetNFTHistory(nil, &GetNFTHistoryArgs{
+		AssetID: assetID.String(),
+		GroupID: 1,
+	}, historyReply))
+	assert.Len(historyReply.Transfers, 0)
+}
+
+func TestComplianceWorkflow(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, vm, _ := GenesisVMWithArgs(
+		t,
+		[]*common.Fx{{
+			ID: compliancefx.ID,
+			Fx: &compliancefx.Fx{},
+		}},
+		nil,
+	)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+	s := &Service{vm: vm}
+
+	user, err := keystore.NewUserFromKeystore(vm.ctx.Keystore, username, password)
+	assert.NoError(err)
+	assert.NoError(user.PutKeys(keys...))
+	assert.NoError(user.Close())
+
+	authorityAddrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+	holderAddrStr, err := vm.FormatLocalAddress(ids.GenerateTestShortID())
+	assert.NoError(err)
+	spendHeader := api.JSONSpendHeader{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: authorityAddrStr},
+	}
+
+	acceptTx := func(txID ids.ID) {
+		tx := UniqueTx{
+			vm:   vm,
+			txID: txID,
+		}
+		assert.Equal(choices.Processing, tx.Status())
+		assert.NoError(tx.Accept())
+	}
+	balance := func(addrStr string, assetID ids.ID) uint64 {
+		reply := &GetBalanceReply{}
+		assert.NoError(s.GetBalance(nil, &GetBalanceArgs{
+			Address: addrStr,
+			AssetID: assetID.String(),
+		}, reply))
+		return uint64(reply.Balance)
+	}
+
+	createReply := &AssetIDChangeAddr{}
+	assert.NoError(s.CreateAsset(nil, &CreateAssetArgs{
+		JSONSpendHeader: spendHeader,
+		Name:            "REGULATED COIN",
+		Symbol:          "REG",
+		InitialHolders: []*Holder{{
+			Amount:  100,
+			Address: holderAddrStr,
+		}},
+		ComplianceAuthority: &Owners{
+			Threshold: 1,
+			Minters:   []string{authorityAddrStr},
+		},
+	}, createReply))
+	assetID := createReply.AssetID
+	acceptTx(assetID)
+	assert.EqualValues(100, balance(holderAddrStr, assetID))
+
+	complianceArgs := &ComplianceArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         assetID.String(),
+		Addresses:       []string{holderAddrStr},
+	}
+
+	// Frozen funds aren't part of the holder's balance
+	reply := &api.JSONTxIDChangeAddr{}
+	assert.NoError(s.Freeze(nil, complianceArgs, reply))
+	acceptTx(reply.TxID)
+	assert.Zero(balance(holderAddrStr, assetID))
+
+	err = s.Freeze(nil, complianceArgs, reply)
+	assert.ErrorIs(err, errNoComplianceUTXOs)
+
+	reply = &api.JSONTxIDChangeAddr{}
+	assert.NoError(s.Unfreeze(nil, complianceArgs, reply))
+	acceptTx(reply.TxID)
+	assert.EqualValues(100, balance(holderAddrStr, assetID))
+
+	// Frozen and transferable funds can be clawed back
+	reply = &api.JSONTxIDChangeAddr{}
+	assert.NoError(s.Freeze(nil, complianceArgs, reply))
+	acceptTx(reply.TxID)
+
+	reply = &api.JSONTxIDChangeAddr{}
+	assert.NoError(s.Clawback(nil, &ClawbackArgs{
+		ComplianceArgs: *complianceArgs,
+		To:             authorityAddrStr,
+	}, reply))
+	acceptTx(reply.TxID)
+	assert.Zero(balance(holderAddrStr, assetID))
+	assert.EqualValues(100, balance(authorityAddrStr, assetID))
+
+	// Only the authority can operate on the asset's UTXOs
+	authorityAddrs := ids.ShortSet{}
+	authorityAddrs.Add(keys[0].PublicKey().Address())
+	utxos, err := djtx.GetAllUTXOs(vm.state, authorityAddrs)
+	assert.NoError(err)
+	_, _, err = vm.Freeze(utxos, secp256k1fx.NewKeychain(keys[1]), assetID, utxos)
+	assert.ErrorIs(err, errAddressesNotComplianceAuthority)
+}
+
+func TestCreateComplianceAssetUnsupported(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, _ := setupWithKeys(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+	err = s.CreateAsset(nil, &CreateAssetArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+		},
+		Name:   "REGULATED COIN",
+		Symbol: "REG",
+		InitialHolders: []*Holder{{
+			Amount:  100,
+			Address: addrStr,
+		}},
+		ComplianceAuthority: &Owners{
+			Threshold: 1,
+			Minters:   []string{addrStr},
+		},
+	}, &AssetIDChangeAddr{})
+	assert.ErrorIs(err, errNoComplianceFx)
+
+	// The compliance operations are served but fail on chains without
+	// compliancefx
+	args := &ComplianceArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+		},
+		AssetID:   vm.ctx.DJTXAssetID.String(),
+		Addresses: []string{addrStr},
+	}
+	err = s.Freeze(nil, args, &api.JSONTxIDChangeAddr{})
+	assert.ErrorIs(err, errNoComplianceFx)
+	err = s.Unfreeze(nil, args, &api.JSONTxIDChangeAddr{})
+	assert.ErrorIs(err, errNoComplianceFx)
+	err = s.Clawback(nil, &ClawbackArgs{ComplianceArgs: *args, To: addrStr}, &api.JSONTxIDChangeAddr{})
+	assert.ErrorIs(err, errNoComplianceFx)
+
+	ws := &WalletService{vm: vm, pendingTxMap: make(map[ids.ID]*list.Element), pendingTxOrdering: list.New()}
+	err = ws.Freeze(nil, args, &api.JSONTxIDChangeAddr{})
+	assert.ErrorIs(err, errNoComplianceFx)
+}
+
 func TestImportExportKey(t *testing.T) {
 	_, vm, s, _, _ := setup(t, true)
 	defer func() {
@@ -2640,6 +2956,93 @@ func TestSendMultiple(t *testing.T) {
 	}
 }
 
+func TestSendMultipleVesting(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, genesisTx := setupWithKeys(t, false)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := genesisTx.ID()
+	to := ids.GenerateTestShortID()
+	toStr, err := vm.FormatLocalAddress(to)
+	assert.NoError(err)
+	changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+	assert.NoError(err)
+
+	now := uint64(vm.clock.Unix())
+	args := &SendMultipleArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+		},
+		Outputs: []SendOutput{
+			{
+				Amount:   100,
+				AssetID:  assetID.String(),
+				To:       toStr,
+				Locktime: json.Uint64(now + 3600),
+			},
+			{
+				Amount:      1000,
+				AssetID:     assetID.String(),
+				To:          toStr,
+				UnlockTimes: []json.Uint64{json.Uint64(now), json.Uint64(now + 60), json.Uint64(now + 120)},
+			},
+		},
+	}
+	reply := &api.JSONTxIDChangeAddr{}
+	vm.timer.Cancel()
+	assert.NoError(s.SendMultiple(nil, args, reply))
+	assert.Len(vm.txs, 1)
+
+	tx := UniqueTx{
+		vm:   vm,
+		txID: reply.TxID,
+	}
+	assert.Equal(choices.Processing, tx.Status())
+	assert.NoError(tx.Accept())
+
+	balanceArgs := &GetBalanceArgs{
+		Address: toStr,
+		AssetID: assetID.String(),
+	}
+	balanceReply := &GetBalanceReply{}
+	assert.NoError(s.GetBalance(nil, balanceArgs, balanceReply))
+	assert.EqualValues(333, balanceReply.Balance)
+	assert.EqualValues(333, balanceReply.Unlocked)
+	assert.EqualValues(100+333+334, balanceReply.Locked)
+	assert.Len(balanceReply.UTXOIDs, 1)
+
+	balanceArgs.IncludePartial = true
+	balanceReply = &GetBalanceReply{}
+	assert.NoError(s.GetBalance(nil, balanceArgs, balanceReply))
+	assert.EqualValues(1100, balanceReply.Balance)
+	assert.EqualValues(333, balanceReply.Unlocked)
+	assert.EqualValues(100+333+334, balanceReply.Locked)
+	assert.Len(balanceReply.UTXOIDs, 4)
+
+	// Once the vesting schedule has passed, everything is unlocked
+	vm.clock.Set(time.Unix(int64(now+3600), 0))
+	allBalancesReply := &GetAllBalancesReply{}
+	assert.NoError(s.GetAllBalances(nil, &GetAllBalancesArgs{
+		JSONAddress: api.JSONAddress{Address: toStr},
+	}, allBalancesReply))
+	assert.Len(allBalancesReply.Balances, 1)
+	assert.EqualValues(1100, allBalancesReply.Balances[0].Balance)
+	assert.EqualValues(1100, allBalancesReply.Balances[0].Unlocked)
+	assert.EqualValues(0, allBalancesReply.Balances[0].Locked)
+
+	// Unlock times must be increasing
+	args.Outputs[1].UnlockTimes = []json.Uint64{json.Uint64(now + 60), json.Uint64(now)}
+	assert.Error(s.SendMultiple(nil, args, &api.JSONTxIDChangeAddr{}))
+}
+
 func TestCreateAndListAddresses(t *testing.T) {
 	_, vm, s, _, _ := setup(t, true)
 	defer func() {
@@ -16,6 +16,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/ids"
 	"github.com/lasthyphen/dijetsgo/utils/formatting"
 	"github.com/lasthyphen/dijetsgo/utils/wrappers"
+	"github.com/lasthyphen/dijetsgo/vms/compliancefx"
 	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
 	"github.com/lasthyphen/dijetsgo/vms/components/verify"
 	"github.com/lasthyphen/dijetsgo/vms/nftfx"
@@ -45,6 +46,13 @@ var (
 	_ FxOperation       = &propertyfx.MintOperation{}
 	_ FxOperation       = &propertyfx.BurnOperation{}
 	_ verify.Verifiable = &propertyfx.Credential{}
+
+	_ verify.State      = &compliancefx.AuthorityOutput{}
+	_ verify.State      = &compliancefx.FrozenOutput{}
+	_ FxOperation       = &compliancefx.FreezeOperation{}
+	_ FxOperation       = &compliancefx.UnfreezeOperation{}
+	_ FxOperation       = &compliancefx.ClawbackOperation{}
+	_ verify.Verifiable = &compliancefx.Credential{}
 )
 
 // StaticService defines the base service for the asset vm
@@ -216,6 +224,12 @@ func staticCodec() (codec.Manager, error) {
 		c.RegisterType(&propertyfx.MintOperation{}),
 		c.RegisterType(&propertyfx.BurnOperation{}),
 		c.RegisterType(&propertyfx.Credential{}),
+		c.RegisterType(&compliancefx.AuthorityOutput{}),
+		c.RegisterType(&compliancefx.FrozenOutput{}),
+		c.RegisterType(&compliancefx.FreezeOperation{}),
+		c.RegisterType(&compliancefx.UnfreezeOperation{}),
+		c.RegisterType(&compliancefx.ClawbackOperation{}),
+		c.RegisterType(&compliancefx.Credential{}),
 		manager.RegisterCodec(codecVersion, c),
 	)
 	return manager, errs.Err
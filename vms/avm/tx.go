@@ -12,6 +12,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/snow"
 	"github.com/lasthyphen/dijetsgo/utils/crypto"
 	"github.com/lasthyphen/dijetsgo/utils/hashing"
+	"github.com/lasthyphen/dijetsgo/vms/compliancefx"
 	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
 	"github.com/lasthyphen/dijetsgo/vms/components/verify"
 	"github.com/lasthyphen/dijetsgo/vms/nftfx"
@@ -206,3 +207,32 @@ func (t *Tx) SignNFTFx(c codec.Manager, signers [][]*crypto.PrivateKeySECP256K1R
 	t.Initialize(unsignedBytes, signedBytes)
 	return nil
 }
+
+func (t *Tx) SignComplianceFx(c codec.Manager, signers [][]*crypto.PrivateKeySECP256K1R) error {
+	unsignedBytes, err := c.Marshal(codecVersion, &t.UnsignedTx)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+
+	hash := hashing.ComputeHash256(unsignedBytes)
+	for _, keys := range signers {
+		cred := &compliancefx.Credential{Credential: secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(keys)),
+		}}
+		for i, key := range keys {
+			sig, err := key.SignHash(hash)
+			if err != nil {
+				return fmt.Errorf("problem creating transaction: %w", err)
+			}
+			copy(cred.Sigs[i][:], sig)
+		}
+		t.Creds = append(t.Creds, &FxCredential{Verifiable: cred})
+	}
+
+	signedBytes, err := c.Marshal(codecVersion, t)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+	t.Initialize(unsignedBytes, signedBytes)
+	return nil
+}
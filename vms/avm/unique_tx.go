@@ -145,6 +145,9 @@ func (tx *UniqueTx) Accept() error {
 	if err := tx.vm.addressTxsIndexer.Accept(tx.ID(), inputUTXOs, outputUTXOs); err != nil {
 		return fmt.Errorf("error indexing tx: %w", err)
 	}
+	if err := tx.vm.nftIndexer.Accept(tx.ID(), inputUTXOs, outputUTXOs); err != nil {
+		return fmt.Errorf("error indexing NFTs: %w", err)
+	}
 
 	// Remove spent utxos
 	for _, utxo := range inputUTXOIDs {
@@ -180,6 +183,7 @@ func (tx *UniqueTx) Accept() error {
 
 	tx.vm.pubsub.Publish(NewPubSubFilterer(tx.Tx))
 	tx.vm.walletService.decided(txID)
+	delete(tx.vm.processingTxs, txID)
 
 	tx.deps = nil // Needed to prevent a memory leak
 
@@ -204,6 +208,7 @@ func (tx *UniqueTx) Reject() error {
 	}
 
 	tx.vm.walletService.decided(txID)
+	delete(tx.vm.processingTxs, txID)
 
 	tx.deps = nil // Needed to prevent a memory leak
 
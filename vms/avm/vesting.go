@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+var (
+	errNoUnlockTimes            = errors.New("no unlock times provided")
+	errUnlockTimesNotIncreasing = errors.New("unlock times must be strictly increasing")
+	errTooManyUnlockTimes       = errors.New("amount is too small to be split between the unlock times")
+)
+
+// NewVestingOutputs returns outputs of [assetID] owned by [owner] that unlock
+// [amount] over [unlockTimes]. The amount is split evenly between one output
+// per unlock time, with any remainder unlocking at the last time. The locktime
+// of [owner] is ignored.
+//
+// [unlockTimes] must be strictly increasing. Unlock times in the past, such as
+// 0, create outputs that can be spent immediately.
+func NewVestingOutputs(
+	assetID ids.ID,
+	amount uint64,
+	owner secp256k1fx.OutputOwners,
+	unlockTimes []uint64,
+) ([]*djtx.TransferableOutput, error) {
+	numOutputs := uint64(len(unlockTimes))
+	switch {
+	case numOutputs == 0:
+		return nil, errNoUnlockTimes
+	case amount < numOutputs:
+		return nil, errTooManyUnlockTimes
+	}
+	for i := 1; i < len(unlockTimes); i++ {
+		if unlockTimes[i-1] >= unlockTimes[i] {
+			return nil, errUnlockTimesNotIncreasing
+		}
+	}
+
+	amountPerOutput := amount / numOutputs
+	outs := make([]*djtx.TransferableOutput, len(unlockTimes))
+	for i, unlockTime := range unlockTimes {
+		amt := amountPerOutput
+		if i == len(unlockTimes)-1 {
+			amt += amount % numOutputs
+		}
+		outOwner := owner
+		outOwner.Locktime = unlockTime
+		outs[i] = &djtx.TransferableOutput{
+			Asset: djtx.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amt,
+				OutputOwners: outOwner,
+			},
+		}
+	}
+	return outs, nil
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+func TestNewVestingOutputs(t *testing.T) {
+	assert := assert.New(t)
+
+	assetID := ids.GenerateTestID()
+	owner := secp256k1fx.OutputOwners{
+		Locktime:  1,
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+
+	_, err := NewVestingOutputs(assetID, 10, owner, nil)
+	assert.ErrorIs(err, errNoUnlockTimes)
+
+	_, err = NewVestingOutputs(assetID, 1, owner, []uint64{1, 2})
+	assert.ErrorIs(err, errTooManyUnlockTimes)
+
+	_, err = NewVestingOutputs(assetID, 10, owner, []uint64{2, 2})
+	assert.ErrorIs(err, errUnlockTimesNotIncreasing)
+
+	_, err = NewVestingOutputs(assetID, 10, owner, []uint64{2, 1})
+	assert.ErrorIs(err, errUnlockTimesNotIncreasing)
+
+	outs, err := NewVestingOutputs(assetID, 10, owner, []uint64{0, 100, 200})
+	assert.NoError(err)
+	assert.Len(outs, 3)
+	for i, expected := range []struct {
+		amount   uint64
+		locktime uint64
+	}{
+		{amount: 3, locktime: 0},
+		{amount: 3, locktime: 100},
+		{amount: 4, locktime: 200}, // the remainder unlocks last
+	} {
+		assert.Equal(assetID, outs[i].AssetID())
+		out, ok := outs[i].Out.(*secp256k1fx.TransferOutput)
+		assert.True(ok)
+		assert.Equal(expected.amount, out.Amt)
+		assert.Equal(expected.locktime, out.Locktime)
+		assert.Equal(owner.Addrs, out.Addrs)
+		assert.NoError(out.Verify())
+	}
+
+	// The owner isn't modified
+	assert.EqualValues(1, owner.Locktime)
+}
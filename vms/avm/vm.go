@@ -19,6 +19,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/codec"
 	"github.com/lasthyphen/dijetsgo/database"
 	"github.com/lasthyphen/dijetsgo/database/manager"
+	"github.com/lasthyphen/dijetsgo/database/prefixdb"
 	"github.com/lasthyphen/dijetsgo/database/versiondb"
 	"github.com/lasthyphen/dijetsgo/ids"
 	"github.com/lasthyphen/dijetsgo/pubsub"
@@ -31,6 +32,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/utils/timer"
 	"github.com/lasthyphen/dijetsgo/utils/timer/mockable"
 	"github.com/lasthyphen/dijetsgo/version"
+	"github.com/lasthyphen/dijetsgo/vms/compliancefx"
 	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
 	"github.com/lasthyphen/dijetsgo/vms/components/index"
 	"github.com/lasthyphen/dijetsgo/vms/components/keystore"
@@ -51,11 +53,15 @@ const (
 var (
 	errIncompatibleFx            = errors.New("incompatible feature extension")
 	errUnknownFx                 = errors.New("unknown feature extension")
+	errNoComplianceFx            = errors.New("this chain doesn't support compliance operations because it wasn't created with compliancefx")
 	errGenesisAssetMustHaveState = errors.New("genesis asset must have non-empty state")
 	errBootstrapping             = errors.New("chain is currently bootstrapping")
 	errInsufficientFunds         = errors.New("insufficient funds")
 
-	_ vertex.DAGVM = &VM{}
+	nftIndexPrefix = []byte("nftIndex")
+
+	_ vertex.DAGVM           = &VM{}
+	_ vertex.IssuanceHandler = &VM{}
 )
 
 type VM struct {
@@ -94,6 +100,11 @@ type VM struct {
 	txs          []snowstorm.Tx
 	toEngine     chan<- common.Message
 
+	// txID -> time the tx was issued to this VM, for the txs in [txs]
+	txIssueTimes map[ids.ID]time.Time
+	// txID -> tx that was issued into consensus but hasn't been decided yet
+	processingTxs map[ids.ID]*processingTx
+
 	baseDB database.Database
 	db     *versiondb.Database
 
@@ -103,6 +114,7 @@ type VM struct {
 	walletService WalletService
 
 	addressTxsIndexer index.AddressTxsIndexer
+	nftIndexer        index.NFTIndexer
 }
 
 func (vm *VM) Connected(nodeID ids.ShortID, nodeVersion version.Application) error {
@@ -121,6 +133,7 @@ func (vm *VM) Disconnected(nodeID ids.ShortID) error {
 
 type Config struct {
 	IndexTransactions    bool `json:"index-transactions"`
+	IndexNFTs            bool `json:"index-nfts"`
 	IndexAllowIncomplete bool `json:"index-allow-incomplete"`
 }
 
@@ -199,6 +212,11 @@ func (vm *VM) Initialize(
 	}
 	vm.state = state
 
+	// Indices added to a chain that already accepted txs are incomplete
+	stateInitialized, err := vm.state.IsInitialized()
+	if err != nil {
+		return err
+	}
 	if err := vm.initGenesis(genesisBytes); err != nil {
 		return err
 	}
@@ -211,6 +229,8 @@ func (vm *VM) Initialize(
 	})
 	go ctx.Log.RecoverAndPanic(vm.timer.Dispatch)
 	vm.batchTimeout = batchTimeout
+	vm.txIssueTimes = make(map[ids.ID]time.Time)
+	vm.processingTxs = make(map[ids.ID]*processingTx)
 
 	vm.walletService.vm = vm
 	vm.walletService.pendingTxMap = make(map[ids.ID]*list.Element)
@@ -230,6 +250,21 @@ func (vm *VM) Initialize(
 			return fmt.Errorf("failed to initialize disabled indexer: %w", err)
 		}
 	}
+
+	nftIndexDB := prefixdb.New(nftIndexPrefix, vm.db)
+	if avmConfig.IndexNFTs {
+		vm.ctx.Log.Info("NFT indexing is enabled")
+		vm.nftIndexer, err = index.NewNFTIndexer(nftIndexDB, vm.ctx.Log, avmConfig.IndexAllowIncomplete, !stateInitialized)
+		if err != nil {
+			return fmt.Errorf("failed to initialize NFT indexer: %w", err)
+		}
+	} else {
+		vm.ctx.Log.Info("NFT indexing is disabled")
+		vm.nftIndexer, err = index.NewNoNFTIndexer(nftIndexDB, avmConfig.IndexAllowIncomplete, !stateInitialized)
+		if err != nil {
+			return fmt.Errorf("failed to initialize disabled NFT indexer: %w", err)
+		}
+	}
 	return vm.db.Commit()
 }
 
@@ -269,6 +304,8 @@ func (vm *VM) Shutdown() error {
 		return nil
 	}
 
+	vm.pubsub.Close()
+
 	// There is a potential deadlock if the timer is about to execute a timeout.
 	// So, the lock must be released before stopping the timer.
 	vm.ctx.Lock.Unlock()
@@ -328,6 +365,9 @@ func (vm *VM) PendingTxs() []snowstorm.Tx {
 
 	txs := vm.txs
 	vm.txs = nil
+	for _, tx := range txs {
+		delete(vm.txIssueTimes, tx.ID())
+	}
 	return txs
 }
 
@@ -508,6 +548,9 @@ func (vm *VM) parsePrivateTx(txBytes []byte) (*Tx, error) {
 
 func (vm *VM) issueTx(tx snowstorm.Tx) {
 	vm.txs = append(vm.txs, tx)
+	if txID := tx.ID(); vm.txIssueTimes[txID].IsZero() {
+		vm.txIssueTimes[txID] = vm.clock.Time()
+	}
 	switch {
 	case len(vm.txs) == batchSize:
 		vm.FlushTxs()
@@ -1020,6 +1063,408 @@ func (vm *VM) MintNFT(
 	return ops, keys, nil
 }
 
+// Freeze returns an operation, authorized by the compliance authority of
+// [assetID] held in [utxos], that freezes the transferable UTXOs of [assetID]
+// in [targets]
+func (vm *VM) Freeze(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	targets []*djtx.UTXO,
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	return vm.complianceOperation(
+		utxos,
+		kc,
+		assetID,
+		targets,
+		func(out verify.State) bool {
+			_, ok := out.(*secp256k1fx.TransferOutput)
+			return ok
+		},
+		func(in secp256k1fx.Input, authority compliancefx.AuthorityOutput, outs []verify.State) (FxOperation, error) {
+			op := &compliancefx.FreezeOperation{
+				Input:     in,
+				Authority: authority,
+				Frozen:    make([]*compliancefx.FrozenOutput, len(outs)),
+			}
+			for i, outIntf := range outs {
+				out := outIntf.(*secp256k1fx.TransferOutput)
+				op.Frozen[i] = &compliancefx.FrozenOutput{
+					Amt:          out.Amt,
+					OutputOwners: out.OutputOwners,
+				}
+			}
+			return op, nil
+		},
+	)
+}
+
+// Unfreeze returns an operation, authorized by the compliance authority of
+// [assetID] held in [utxos], that unfreezes the frozen UTXOs of [assetID] in
+// [targets]
+func (vm *VM) Unfreeze(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	targets []*djtx.UTXO,
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	return vm.complianceOperation(
+		utxos,
+		kc,
+		assetID,
+		targets,
+		func(out verify.State) bool {
+			_, ok := out.(*compliancefx.FrozenOutput)
+			return ok
+		},
+		func(in secp256k1fx.Input, authority compliancefx.AuthorityOutput, outs []verify.State) (FxOperation, error) {
+			op := &compliancefx.UnfreezeOperation{
+				Input:     in,
+				Authority: authority,
+				Unfrozen:  make([]*secp256k1fx.TransferOutput, len(outs)),
+			}
+			for i, outIntf := range outs {
+				out := outIntf.(*compliancefx.FrozenOutput)
+				op.Unfrozen[i] = &secp256k1fx.TransferOutput{
+					Amt:          out.Amt,
+					OutputOwners: out.OutputOwners,
+				}
+			}
+			return op, nil
+		},
+	)
+}
+
+// Clawback returns an operation, authorized by the compliance authority of
+// [assetID] held in [utxos], that sends the value of the frozen and
+// transferable UTXOs of [assetID] in [targets] to [to]
+func (vm *VM) Clawback(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	targets []*djtx.UTXO,
+	to ids.ShortID,
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	return vm.complianceOperation(
+		utxos,
+		kc,
+		assetID,
+		targets,
+		func(out verify.State) bool {
+			switch out.(type) {
+			case *secp256k1fx.TransferOutput, *compliancefx.FrozenOutput:
+				return true
+			default:
+				return false
+			}
+		},
+		func(in secp256k1fx.Input, authority compliancefx.AuthorityOutput, outs []verify.State) (FxOperation, error) {
+			amount := uint64(0)
+			for _, outIntf := range outs {
+				var amt uint64
+				switch out := outIntf.(type) {
+				case *secp256k1fx.TransferOutput:
+					amt = out.Amt
+				case *compliancefx.FrozenOutput:
+					amt = out.Amt
+				}
+				var err error
+				amount, err = safemath.Add64(amount, amt)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return &compliancefx.ClawbackOperation{
+				Input:     in,
+				Authority: authority,
+				Output: secp256k1fx.TransferOutput{
+					Amt: amount,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{to},
+					},
+				},
+			}, nil
+		},
+	)
+}
+
+// complianceOperationBuilder returns the operation, and the keys that sign it,
+// that the compliance authority of [assetID] held in [utxos] performs on
+// [targets]
+type complianceOperationBuilder func(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	targets []*djtx.UTXO,
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+)
+
+// clawbackBuilder returns a builder of operations that claw back UTXOs to [to]
+func (vm *VM) clawbackBuilder(to string) (complianceOperationBuilder, error) {
+	toAddr, err := vm.ParseLocalAddress(to)
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing to address %q: %w", to, err)
+	}
+	return func(
+		utxos []*djtx.UTXO,
+		kc *secp256k1fx.Keychain,
+		assetID ids.ID,
+		targets []*djtx.UTXO,
+	) (
+		[]*Operation,
+		[][]*crypto.PrivateKeySECP256K1R,
+		error,
+	) {
+		return vm.Clawback(utxos, kc, assetID, targets, toAddr)
+	}, nil
+}
+
+// newComplianceTx returns a tx, paid for by the user in [args], that performs
+// the operation returned by [buildOp] on the UTXOs held by [args.Addresses].
+// If non-nil, [update] is applied to the UTXOs of the user before they are
+// spent. Returns the tx and the address its change is sent to.
+func (vm *VM) newComplianceTx(
+	args *ComplianceArgs,
+	update func([]*djtx.UTXO) ([]*djtx.UTXO, error),
+	buildOp complianceOperationBuilder,
+) (*Tx, ids.ShortID, error) {
+	if _, err := vm.getComplianceFxIndex(); err != nil {
+		return nil, ids.ShortID{}, err
+	}
+
+	assetID, err := vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return nil, ids.ShortID{}, err
+	}
+
+	if len(args.Addresses) == 0 {
+		return nil, ids.ShortID{}, errNoAddresses
+	}
+	targetAddrs, err := djtx.ParseLocalAddresses(vm, args.Addresses)
+	if err != nil {
+		return nil, ids.ShortID{}, err
+	}
+	targets, err := djtx.GetAllUTXOs(vm.state, targetAddrs)
+	if err != nil {
+		return nil, ids.ShortID{}, fmt.Errorf("problem retrieving UTXOs: %w", err)
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := djtx.ParseLocalAddresses(vm, args.From)
+	if err != nil {
+		return nil, ids.ShortID{}, err
+	}
+
+	// Get the UTXOs/keys for the from addresses
+	feeUTXOs, feeKc, err := vm.LoadUser(args.Username, args.Password, fromAddrs)
+	if err != nil {
+		return nil, ids.ShortID{}, err
+	}
+	if update != nil {
+		feeUTXOs, err = update(feeUTXOs)
+		if err != nil {
+			return nil, ids.ShortID{}, err
+		}
+	}
+
+	// Parse the change address.
+	if len(feeKc.Keys) == 0 {
+		return nil, ids.ShortID{}, errNoKeys
+	}
+	changeAddr, err := vm.selectChangeAddr(feeKc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	if err != nil {
+		return nil, ids.ShortID{}, err
+	}
+
+	amountsSpent, ins, secpKeys, err := vm.Spend(
+		feeUTXOs,
+		feeKc,
+		map[ids.ID]uint64{
+			vm.feeAssetID: vm.TxFee,
+		},
+	)
+	if err != nil {
+		return nil, ids.ShortID{}, err
+	}
+
+	outs := []*djtx.TransferableOutput{}
+	if amountSpent := amountsSpent[vm.feeAssetID]; amountSpent > vm.TxFee {
+		outs = append(outs, &djtx.TransferableOutput{
+			Asset: djtx.Asset{ID: vm.feeAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amountSpent - vm.TxFee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  0,
+					Threshold: 1,
+					Addrs:     []ids.ShortID{changeAddr},
+				},
+			},
+		})
+	}
+
+	// Get all UTXOs/keys, as the authority may be held by any of them
+	utxos, kc, err := vm.LoadUser(args.Username, args.Password, nil)
+	if err != nil {
+		return nil, ids.ShortID{}, err
+	}
+	if update != nil {
+		utxos, err = update(utxos)
+		if err != nil {
+			return nil, ids.ShortID{}, err
+		}
+	}
+
+	ops, opKeys, err := buildOp(utxos, kc, assetID, targets)
+	if err != nil {
+		return nil, ids.ShortID{}, err
+	}
+
+	tx := &Tx{UnsignedTx: &OperationTx{
+		BaseTx: BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    vm.ctx.NetworkID,
+			BlockchainID: vm.ctx.ChainID,
+			Outs:         outs,
+			Ins:          ins,
+		}},
+		Ops: ops,
+	}}
+	if err := tx.SignSECP256K1Fx(vm.codec, secpKeys); err != nil {
+		return nil, ids.ShortID{}, err
+	}
+	if err := tx.SignComplianceFx(vm.codec, opKeys); err != nil {
+		return nil, ids.ShortID{}, err
+	}
+	return tx, changeAddr, nil
+}
+
+// complianceOperation returns an operation that consumes the compliance
+// authority of [assetID] held in [utxos], along with the UTXOs of [assetID] in
+// [targets] whose outputs match [isTarget]. [newOp] is given the outputs of the
+// matched UTXOs in the order they are consumed.
+func (vm *VM) complianceOperation(
+	utxos []*djtx.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	targets []*djtx.UTXO,
+	isTarget func(verify.State) bool,
+	newOp func(secp256k1fx.Input, compliancefx.AuthorityOutput, []verify.State) (FxOperation, error),
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	time := vm.clock.Unix()
+
+	var (
+		authorityUTXO *djtx.UTXO
+		authority     *compliancefx.AuthorityOutput
+		indices       []uint32
+		signers       []*crypto.PrivateKeySECP256K1R
+	)
+	for _, utxo := range utxos {
+		if utxo.AssetID() != assetID {
+			// wrong asset id
+			continue
+		}
+		out, ok := utxo.Out.(*compliancefx.AuthorityOutput)
+		if !ok {
+			// wrong output type
+			continue
+		}
+		indices, signers, ok = kc.Match(&out.OutputOwners, time)
+		if !ok {
+			// unable to spend the output
+			continue
+		}
+		authorityUTXO = utxo
+		authority = out
+		break
+	}
+	if authorityUTXO == nil {
+		return nil, nil, errAddressesNotComplianceAuthority
+	}
+
+	// Consumed UTXOs must be sorted, so the outputs passed to [newOp] follow
+	// that order
+	utxoIDs := []*djtx.UTXOID{&authorityUTXO.UTXOID}
+	targetOuts := make(map[ids.ID]verify.State)
+	for _, utxo := range targets {
+		if utxo.AssetID() != assetID || !isTarget(utxo.Out) {
+			continue
+		}
+		inputID := utxo.InputID()
+		if _, ok := targetOuts[inputID]; ok {
+			continue
+		}
+		targetOuts[inputID] = utxo.Out
+		utxoIDs = append(utxoIDs, &utxo.UTXOID)
+	}
+	if len(targetOuts) == 0 {
+		return nil, nil, errNoComplianceUTXOs
+	}
+	djtx.SortUTXOIDs(utxoIDs)
+
+	outs := make([]verify.State, 0, len(targetOuts))
+	for _, utxoID := range utxoIDs {
+		if out, ok := targetOuts[utxoID.InputID()]; ok {
+			outs = append(outs, out)
+		}
+	}
+
+	op, err := newOp(
+		secp256k1fx.Input{SigIndices: indices},
+		compliancefx.AuthorityOutput{OutputOwners: authority.OutputOwners},
+		outs,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []*Operation{{
+			Asset:   djtx.Asset{ID: assetID},
+			UTXOIDs: utxoIDs,
+			Op:      op,
+		}},
+		[][]*crypto.PrivateKeySECP256K1R{signers},
+		nil
+}
+
+// getComplianceFxIndex returns the index of compliancefx, or
+// [errNoComplianceFx] if this chain wasn't created with it. The primary
+// network's X-Chain doesn't include compliancefx.
+func (vm *VM) getComplianceFxIndex() (uint32, error) {
+	fxIndex, err := vm.getFxIndex(compliancefx.ID)
+	if errors.Is(err, errUnknownFx) {
+		return 0, errNoComplianceFx
+	}
+	return fxIndex, err
+}
+
+// getFxIndex returns the index of the fx with ID [fxID] in this chain
+func (vm *VM) getFxIndex(fxID ids.ID) (uint32, error) {
+	for i, fx := range vm.fxs {
+		if fx.ID == fxID {
+			return uint32(i), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", errUnknownFx, fxID)
+}
+
 // selectChangeAddr returns the change address to be used for [kc] when [changeAddr] is given
 // as the optional change address argument
 func (vm *VM) selectChangeAddr(defaultAddr ids.ShortID, changeAddr string) (ids.ShortID, error) {
@@ -42,6 +42,37 @@ type WalletClient interface {
 		outputs []SendOutput,
 		memo string,
 	) (ids.ID, error)
+	// Freeze the UTXOs of [assetID] held by [addrs] using the compliance
+	// authority of [user]
+	Freeze(
+		ctx context.Context,
+		user api.UserPass,
+		from []string,
+		changeAddr string,
+		assetID string,
+		addrs []string,
+	) (ids.ID, error)
+	// Unfreeze the frozen UTXOs of [assetID] held by [addrs] using the
+	// compliance authority of [user]
+	Unfreeze(
+		ctx context.Context,
+		user api.UserPass,
+		from []string,
+		changeAddr string,
+		assetID string,
+		addrs []string,
+	) (ids.ID, error)
+	// Clawback sends the UTXOs of [assetID] held by [addrs] to [to] using the
+	// compliance authority of [user]
+	Clawback(
+		ctx context.Context,
+		user api.UserPass,
+		from []string,
+		changeAddr string,
+		assetID string,
+		addrs []string,
+		to string,
+	) (ids.ID, error)
 }
 
 // implementation of an AVM wallet client for interacting with avm managed wallet on [chain]
@@ -116,3 +147,70 @@ func (c *walletClient) SendMultiple(
 	}, res)
 	return res.TxID, err
 }
+
+func (c *walletClient) Freeze(
+	ctx context.Context,
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetID string,
+	addrs []string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "freeze", &ComplianceArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		AssetID:   assetID,
+		Addresses: addrs,
+	}, res)
+	return res.TxID, err
+}
+
+func (c *walletClient) Unfreeze(
+	ctx context.Context,
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetID string,
+	addrs []string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "unfreeze", &ComplianceArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		AssetID:   assetID,
+		Addresses: addrs,
+	}, res)
+	return res.TxID, err
+}
+
+func (c *walletClient) Clawback(
+	ctx context.Context,
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetID string,
+	addrs []string,
+	to string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "clawback", &ClawbackArgs{
+		ComplianceArgs: ComplianceArgs{
+			JSONSpendHeader: api.JSONSpendHeader{
+				UserPass:       user,
+				JSONFromAddrs:  api.JSONFromAddrs{From: from},
+				JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+			},
+			AssetID:   assetID,
+			Addresses: addrs,
+		},
+		To: to,
+	}, res)
+	return res.TxID, err
+}
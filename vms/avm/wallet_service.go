@@ -183,18 +183,12 @@ func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 			return fmt.Errorf("problem parsing to address %q: %w", output.To, err)
 		}
 
-		// Create the Output
-		outs = append(outs, &djtx.TransferableOutput{
-			Asset: djtx.Asset{ID: assetID},
-			Out: &secp256k1fx.TransferOutput{
-				Amt: uint64(output.Amount),
-				OutputOwners: secp256k1fx.OutputOwners{
-					Locktime:  0,
-					Threshold: 1,
-					Addrs:     []ids.ShortID{to},
-				},
-			},
-		})
+		// Create the Outputs
+		sendOuts, err := output.outputs(assetID, to)
+		if err != nil {
+			return err
+		}
+		outs = append(outs, sendOuts...)
 	}
 
 	amountsWithFee := make(map[ids.ID]uint64, len(amounts)+1)
@@ -257,3 +251,54 @@ func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 	reply.ChangeAddr, err = w.vm.FormatLocalAddress(changeAddr)
 	return err
 }
+
+// Freeze freezes the UTXOs of an asset held by the provided addresses. The
+// user must control the asset's compliance authority.
+func (w *WalletService) Freeze(_ *http.Request, args *ComplianceArgs, reply *api.JSONTxIDChangeAddr) error {
+	w.vm.ctx.Log.Debug("AVM Wallet: Freeze called with username: %s", args.Username)
+
+	return w.issueComplianceTx(args, reply, w.vm.Freeze)
+}
+
+// Unfreeze unfreezes the frozen UTXOs of an asset held by the provided
+// addresses. The user must control the asset's compliance authority.
+func (w *WalletService) Unfreeze(_ *http.Request, args *ComplianceArgs, reply *api.JSONTxIDChangeAddr) error {
+	w.vm.ctx.Log.Debug("AVM Wallet: Unfreeze called with username: %s", args.Username)
+
+	return w.issueComplianceTx(args, reply, w.vm.Unfreeze)
+}
+
+// Clawback sends the frozen and transferable UTXOs of an asset held by the
+// provided addresses to [args.To]. The user must control the asset's
+// compliance authority.
+func (w *WalletService) Clawback(_ *http.Request, args *ClawbackArgs, reply *api.JSONTxIDChangeAddr) error {
+	w.vm.ctx.Log.Debug("AVM Wallet: Clawback called with username: %s", args.Username)
+
+	buildOp, err := w.vm.clawbackBuilder(args.To)
+	if err != nil {
+		return err
+	}
+	return w.issueComplianceTx(&args.ComplianceArgs, reply, buildOp)
+}
+
+// issueComplianceTx issues a tx that performs the operation returned by
+// [buildOp], taking into account the txs this wallet has already issued
+func (w *WalletService) issueComplianceTx(
+	args *ComplianceArgs,
+	reply *api.JSONTxIDChangeAddr,
+	buildOp complianceOperationBuilder,
+) error {
+	tx, changeAddr, err := w.vm.newComplianceTx(args, w.update, buildOp)
+	if err != nil {
+		return err
+	}
+
+	txID, err := w.issue(tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	reply.ChangeAddr, err = w.vm.FormatLocalAddress(changeAddr)
+	return err
+}
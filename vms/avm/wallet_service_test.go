@@ -7,9 +7,13 @@ import (
 	"container/list"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/lasthyphen/dijetsgo/api"
 	"github.com/lasthyphen/dijetsgo/chains/atomic"
 	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/snow/engine/common"
+	"github.com/lasthyphen/dijetsgo/vms/compliancefx"
 	"github.com/lasthyphen/dijetsgo/vms/components/keystore"
 )
 
@@ -128,3 +132,70 @@ func TestWalletService_SendMultiple(t *testing.T) {
 		})
 	}
 }
+
+func TestWalletService_Freeze(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, vm, _ := GenesisVMWithArgs(
+		t,
+		[]*common.Fx{{
+			ID: compliancefx.ID,
+			Fx: &compliancefx.Fx{},
+		}},
+		nil,
+	)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+	ws := &WalletService{vm: vm, pendingTxMap: make(map[ids.ID]*list.Element), pendingTxOrdering: list.New()}
+
+	user, err := keystore.NewUserFromKeystore(vm.ctx.Keystore, username, password)
+	assert.NoError(err)
+	assert.NoError(user.PutKeys(keys...))
+	assert.NoError(user.Close())
+
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+	spendHeader := api.JSONSpendHeader{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
+	}
+
+	vm.timer.Cancel()
+	s := &Service{vm: vm}
+	createReply := &AssetIDChangeAddr{}
+	assert.NoError(s.CreateAsset(nil, &CreateAssetArgs{
+		JSONSpendHeader: spendHeader,
+		Name:            "REGULATED COIN",
+		Symbol:          "REG",
+		InitialHolders: []*Holder{{
+			Amount:  100,
+			Address: addrStr,
+		}},
+		ComplianceAuthority: &Owners{
+			Threshold: 1,
+			Minters:   []string{addrStr},
+		},
+	}, createReply))
+	createTx := UniqueTx{
+		vm:   vm,
+		txID: createReply.AssetID,
+	}
+	assert.NoError(createTx.Accept())
+
+	reply := &api.JSONTxIDChangeAddr{}
+	assert.NoError(ws.Freeze(nil, &ComplianceArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         createReply.AssetID.String(),
+		Addresses:       []string{addrStr},
+	}, reply))
+
+	// The freeze is issued after the asset creation
+	pendingTxs := vm.txs
+	assert.Len(pendingTxs, 2)
+	assert.Equal(reply.TxID, pendingTxs[1].ID())
+	assert.Contains(ws.pendingTxMap, reply.TxID)
+}
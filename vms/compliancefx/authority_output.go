@@ -0,0 +1,14 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compliancefx
+
+import (
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+// AuthorityOutput is owned by the issuer of an asset, and authorizes the
+// freezing, unfreezing and clawback of the asset's UTXOs
+type AuthorityOutput struct {
+	secp256k1fx.OutputOwners `serialize:"true"`
+}
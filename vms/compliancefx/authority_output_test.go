@@ -0,0 +1,17 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compliancefx
+
+import (
+	"testing"
+
+	"github.com/lasthyphen/dijetsgo/vms/components/verify"
+)
+
+func TestAuthorityOutputState(t *testing.T) {
+	intf := interface{}(&AuthorityOutput{})
+	if _, ok := intf.(verify.State); !ok {
+		t.Fatalf("should be marked as state")
+	}
+}
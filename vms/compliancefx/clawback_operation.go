@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compliancefx
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsgo/snow"
+	"github.com/lasthyphen/dijetsgo/vms/components/verify"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+var errNilClawbackOperation = errors.New("nil clawback operation")
+
+// ClawbackOperation moves the value of frozen or transferable UTXOs of an asset
+// into a single output chosen by the asset's authority.
+type ClawbackOperation struct {
+	Input     secp256k1fx.Input          `serialize:"true" json:"input"`
+	Authority AuthorityOutput            `serialize:"true" json:"authority"`
+	Output    secp256k1fx.TransferOutput `serialize:"true" json:"output"`
+}
+
+func (op *ClawbackOperation) InitCtx(ctx *snow.Context) {
+	op.Authority.OutputOwners.InitCtx(ctx)
+	op.Output.OutputOwners.InitCtx(ctx)
+}
+
+func (op *ClawbackOperation) Cost() (uint64, error) {
+	return op.Input.Cost()
+}
+
+func (op *ClawbackOperation) Outs() []verify.State {
+	return []verify.State{
+		&op.Authority,
+		&op.Output,
+	}
+}
+
+func (op *ClawbackOperation) Verify() error {
+	switch {
+	case op == nil:
+		return errNilClawbackOperation
+	default:
+		return verify.All(&op.Input, &op.Authority, &op.Output)
+	}
+}
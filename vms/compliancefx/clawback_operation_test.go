@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compliancefx
+
+import (
+	"testing"
+
+	"github.com/lasthyphen/dijetsgo/vms/components/verify"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+func TestClawbackOperationVerifyNil(t *testing.T) {
+	op := (*ClawbackOperation)(nil)
+	if err := op.Verify(); err == nil {
+		t.Fatalf("nil operation should have failed verification")
+	}
+}
+
+func TestClawbackOperationVerifyInvalidOutput(t *testing.T) {
+	op := ClawbackOperation{
+		Output: secp256k1fx.TransferOutput{
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+			},
+		},
+	}
+	if err := op.Verify(); err == nil {
+		t.Fatalf("operation should have failed verification")
+	}
+}
+
+func TestClawbackOperationOuts(t *testing.T) {
+	op := ClawbackOperation{}
+	if outs := op.Outs(); len(outs) != 2 {
+		t.Fatalf("Wrong number of outputs returned")
+	}
+}
+
+func TestClawbackOperationState(t *testing.T) {
+	intf := interface{}(&ClawbackOperation{})
+	if _, ok := intf.(verify.State); ok {
+		t.Fatalf("shouldn't be marked as state")
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compliancefx
+
+import (
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/snow"
+)
+
+// ID that this Fx uses when labeled
+var (
+	ID = ids.ID{'c', 'o', 'm', 'p', 'l', 'i', 'a', 'n', 'c', 'e', 'f', 'x'}
+)
+
+type Factory struct{}
+
+func (f *Factory) New(*snow.Context) (interface{}, error) { return &Fx{}, nil }
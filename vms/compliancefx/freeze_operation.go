@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compliancefx
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsgo/snow"
+	"github.com/lasthyphen/dijetsgo/vms/components/verify"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+var (
+	errNilFreezeOperation = errors.New("nil freeze operation")
+	errNoOperationOutputs = errors.New("operation has no outputs")
+)
+
+// FreezeOperation freezes transferable UTXOs of an asset. The i-th frozen
+// output replaces the i-th consumed UTXO that isn't the authority.
+type FreezeOperation struct {
+	Input     secp256k1fx.Input `serialize:"true" json:"input"`
+	Authority AuthorityOutput   `serialize:"true" json:"authority"`
+	Frozen    []*FrozenOutput   `serialize:"true" json:"frozen"`
+}
+
+func (op *FreezeOperation) InitCtx(ctx *snow.Context) {
+	op.Authority.OutputOwners.InitCtx(ctx)
+	for _, out := range op.Frozen {
+		out.OutputOwners.InitCtx(ctx)
+	}
+}
+
+func (op *FreezeOperation) Cost() (uint64, error) {
+	return op.Input.Cost()
+}
+
+func (op *FreezeOperation) Outs() []verify.State {
+	outs := make([]verify.State, 0, len(op.Frozen)+1)
+	outs = append(outs, &op.Authority)
+	for _, out := range op.Frozen {
+		outs = append(outs, out)
+	}
+	return outs
+}
+
+func (op *FreezeOperation) Verify() error {
+	switch {
+	case op == nil:
+		return errNilFreezeOperation
+	case len(op.Frozen) == 0:
+		return errNoOperationOutputs
+	}
+
+	if err := verify.All(&op.Input, &op.Authority); err != nil {
+		return err
+	}
+	for _, out := range op.Frozen {
+		if err := out.Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compliancefx
+
+import (
+	"testing"
+
+	"github.com/lasthyphen/dijetsgo/vms/components/verify"
+)
+
+func TestFreezeOperationVerifyNil(t *testing.T) {
+	op := (*FreezeOperation)(nil)
+	if err := op.Verify(); err == nil {
+		t.Fatalf("nil operation should have failed verification")
+	}
+}
+
+func TestFreezeOperationVerifyNoOutputs(t *testing.T) {
+	op := FreezeOperation{}
+	if err := op.Verify(); err == nil {
+		t.Fatalf("operation without outputs should have failed verification")
+	}
+}
+
+func TestFreezeOperationVerifyInvalidOutput(t *testing.T) {
+	op := FreezeOperation{
+		Frozen: []*FrozenOutput{{}},
+	}
+	if err := op.Verify(); err == nil {
+		t.Fatalf("operation should have failed verification")
+	}
+}
+
+func TestFreezeOperationOuts(t *testing.T) {
+	op := FreezeOperation{
+		Frozen: []*FrozenOutput{{}, {}},
+	}
+	if outs := op.Outs(); len(outs) != 3 {
+		t.Fatalf("Wrong number of outputs returned")
+	}
+}
+
+func TestFreezeOperationState(t *testing.T) {
+	intf := interface{}(&FreezeOperation{})
+	if _, ok := intf.(verify.State); ok {
+		t.Fatalf("shouldn't be marked as state")
+	}
+}
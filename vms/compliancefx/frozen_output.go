@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compliancefx
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/lasthyphen/dijetsgo/vms/components/verify"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+var (
+	errNilOutput     = errors.New("nil output")
+	errNoValueOutput = errors.New("output has no value")
+
+	_ verify.State = &FrozenOutput{}
+)
+
+// FrozenOutput holds an amount of an asset that can't be transferred until the
+// asset's authority unfreezes it.
+//
+// FrozenOutput intentionally doesn't expose an Amount method, so it can't be
+// consumed or produced as a transferable output.
+type FrozenOutput struct {
+	Amt uint64 `serialize:"true" json:"amount"`
+
+	secp256k1fx.OutputOwners `serialize:"true"`
+}
+
+// MarshalJSON marshals Amt and the embedded OutputOwners struct
+// into a JSON readable format
+func (out *FrozenOutput) MarshalJSON() ([]byte, error) {
+	result, err := out.OutputOwners.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	result["amount"] = out.Amt
+	return json.Marshal(result)
+}
+
+func (out *FrozenOutput) Verify() error {
+	switch {
+	case out == nil:
+		return errNilOutput
+	case out.Amt == 0:
+		return errNoValueOutput
+	default:
+		return out.OutputOwners.Verify()
+	}
+}
+
+func (out *FrozenOutput) VerifyState() error { return out.Verify() }
@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compliancefx
+
+import (
+	"testing"
+
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
+	"github.com/lasthyphen/dijetsgo/vms/components/verify"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+func TestFrozenOutputVerify(t *testing.T) {
+	out := (*FrozenOutput)(nil)
+	if err := out.Verify(); err == nil {
+		t.Fatalf("nil output should have failed verification")
+	}
+
+	out = &FrozenOutput{
+		OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		},
+	}
+	if err := out.Verify(); err == nil {
+		t.Fatalf("output without value should have failed verification")
+	}
+
+	out.Amt = 1
+	if err := out.Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFrozenOutputState(t *testing.T) {
+	intf := interface{}(&FrozenOutput{})
+	if _, ok := intf.(verify.State); !ok {
+		t.Fatalf("should be marked as state")
+	}
+	if _, ok := intf.(djtx.TransferableOut); ok {
+		t.Fatalf("shouldn't be transferable")
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compliancefx
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsgo/utils/wrappers"
+	"github.com/lasthyphen/dijetsgo/vms/components/verify"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+
+	safemath "github.com/lasthyphen/dijetsgo/utils/math"
+)
+
+var (
+	errWrongTxType          = errors.New("wrong tx type")
+	errWrongUTXOType        = errors.New("wrong utxo type")
+	errWrongOperationType   = errors.New("wrong operation type")
+	errWrongCredentialType  = errors.New("wrong credential type")
+	errWrongNumberOfUTXOs   = errors.New("wrong number of UTXOs for the operation")
+	errWrongNumberOfAuths   = errors.New("operation must consume exactly one authority UTXO")
+	errWrongAuthorityOutput = errors.New("wrong authority output provided")
+	errWrongFrozenOutput    = errors.New("frozen output doesn't match the UTXO it freezes")
+	errWrongUnfrozenOutput  = errors.New("unfrozen output doesn't match the UTXO it unfreezes")
+	errWrongClawbackAmount  = errors.New("clawback output doesn't match the value of the UTXOs it consumes")
+	errCantTransfer         = errors.New("cant transfer with this fx")
+)
+
+type Fx struct{ secp256k1fx.Fx }
+
+func (fx *Fx) Initialize(vmIntf interface{}) error {
+	if err := fx.InitializeVM(vmIntf); err != nil {
+		return err
+	}
+
+	log := fx.VM.Logger()
+	log.Debug("initializing compliance fx")
+
+	c := fx.VM.CodecRegistry()
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&AuthorityOutput{}),
+		c.RegisterType(&FrozenOutput{}),
+		c.RegisterType(&FreezeOperation{}),
+		c.RegisterType(&UnfreezeOperation{}),
+		c.RegisterType(&ClawbackOperation{}),
+		c.RegisterType(&Credential{}),
+	)
+	return errs.Err
+}
+
+func (fx *Fx) VerifyOperation(txIntf, opIntf, credIntf interface{}, utxosIntf []interface{}) error {
+	tx, ok := txIntf.(secp256k1fx.Tx)
+	if !ok {
+		return errWrongTxType
+	}
+
+	cred, ok := credIntf.(*Credential)
+	if !ok {
+		return errWrongCredentialType
+	}
+
+	// The UTXOs are ordered by their IDs, so the authority can be anywhere in
+	// the list
+	authority, utxos, err := splitAuthority(utxosIntf)
+	if err != nil {
+		return err
+	}
+
+	switch op := opIntf.(type) {
+	case *FreezeOperation:
+		return fx.VerifyFreezeOperation(tx, op, cred, authority, utxos)
+	case *UnfreezeOperation:
+		return fx.VerifyUnfreezeOperation(tx, op, cred, authority, utxos)
+	case *ClawbackOperation:
+		return fx.VerifyClawbackOperation(tx, op, cred, authority, utxos)
+	default:
+		return errWrongOperationType
+	}
+}
+
+func (fx *Fx) VerifyFreezeOperation(
+	tx secp256k1fx.Tx,
+	op *FreezeOperation,
+	cred *Credential,
+	authority *AuthorityOutput,
+	utxosIntf []interface{},
+) error {
+	if err := fx.verifyAuthority(op, &op.Authority, cred, authority); err != nil {
+		return err
+	}
+	if len(utxosIntf) != len(op.Frozen) {
+		return errWrongNumberOfUTXOs
+	}
+	for i, utxoIntf := range utxosIntf {
+		out, ok := utxoIntf.(*secp256k1fx.TransferOutput)
+		if !ok {
+			return errWrongUTXOType
+		}
+		frozen := op.Frozen[i]
+		if out.Amt != frozen.Amt || !out.OutputOwners.Equals(&frozen.OutputOwners) {
+			return errWrongFrozenOutput
+		}
+	}
+	return fx.VerifyCredentials(tx, &op.Input, &cred.Credential, &authority.OutputOwners)
+}
+
+func (fx *Fx) VerifyUnfreezeOperation(
+	tx secp256k1fx.Tx,
+	op *UnfreezeOperation,
+	cred *Credential,
+	authority *AuthorityOutput,
+	utxosIntf []interface{},
+) error {
+	if err := fx.verifyAuthority(op, &op.Authority, cred, authority); err != nil {
+		return err
+	}
+	if len(utxosIntf) != len(op.Unfrozen) {
+		return errWrongNumberOfUTXOs
+	}
+	for i, utxoIntf := range utxosIntf {
+		out, ok := utxoIntf.(*FrozenOutput)
+		if !ok {
+			return errWrongUTXOType
+		}
+		unfrozen := op.Unfrozen[i]
+		if out.Amt != unfrozen.Amt || !out.OutputOwners.Equals(&unfrozen.OutputOwners) {
+			return errWrongUnfrozenOutput
+		}
+	}
+	return fx.VerifyCredentials(tx, &op.Input, &cred.Credential, &authority.OutputOwners)
+}
+
+func (fx *Fx) VerifyClawbackOperation(
+	tx secp256k1fx.Tx,
+	op *ClawbackOperation,
+	cred *Credential,
+	authority *AuthorityOutput,
+	utxosIntf []interface{},
+) error {
+	if err := fx.verifyAuthority(op, &op.Authority, cred, authority); err != nil {
+		return err
+	}
+	if len(utxosIntf) == 0 {
+		return errWrongNumberOfUTXOs
+	}
+	amount := uint64(0)
+	for _, utxoIntf := range utxosIntf {
+		var amt uint64
+		switch out := utxoIntf.(type) {
+		case *secp256k1fx.TransferOutput:
+			amt = out.Amt
+		case *FrozenOutput:
+			amt = out.Amt
+		default:
+			return errWrongUTXOType
+		}
+		var err error
+		amount, err = safemath.Add64(amount, amt)
+		if err != nil {
+			return err
+		}
+	}
+	if amount != op.Output.Amt {
+		return errWrongClawbackAmount
+	}
+	return fx.VerifyCredentials(tx, &op.Input, &cred.Credential, &authority.OutputOwners)
+}
+
+// verifyAuthority verifies that [op] re-creates the consumed [authority]
+func (fx *Fx) verifyAuthority(
+	op verify.Verifiable,
+	opAuthority *AuthorityOutput,
+	cred *Credential,
+	authority *AuthorityOutput,
+) error {
+	if err := verify.All(op, cred, authority); err != nil {
+		return err
+	}
+	if !authority.OutputOwners.Equals(&opAuthority.OutputOwners) {
+		return errWrongAuthorityOutput
+	}
+	return nil
+}
+
+func (fx *Fx) VerifyTransfer(_, _, _, _ interface{}) error { return errCantTransfer }
+
+// splitAuthority returns the only authority in [utxosIntf], along with the
+// other UTXOs in their original order
+func splitAuthority(utxosIntf []interface{}) (*AuthorityOutput, []interface{}, error) {
+	var authority *AuthorityOutput
+	utxos := make([]interface{}, 0, len(utxosIntf))
+	for _, utxoIntf := range utxosIntf {
+		out, ok := utxoIntf.(*AuthorityOutput)
+		if !ok {
+			utxos = append(utxos, utxoIntf)
+			continue
+		}
+		if authority != nil {
+			return nil, nil, errWrongNumberOfAuths
+		}
+		authority = out
+	}
+	if authority == nil {
+		return nil, nil, errWrongNumberOfAuths
+	}
+	return authority, utxos, nil
+}
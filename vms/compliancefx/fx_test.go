@@ -0,0 +1,358 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compliancefx
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lasthyphen/dijetsgo/codec/linearcodec"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils/crypto"
+	"github.com/lasthyphen/dijetsgo/utils/hashing"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+var (
+	txBytes  = []byte{0, 1, 2, 3, 4, 5}
+	sigBytes = [crypto.SECP256K1RSigLen]byte{
+		0x0e, 0x33, 0x4e, 0xbc, 0x67, 0xa7, 0x3f, 0xe8,
+		0x24, 0x33, 0xac, 0xa3, 0x47, 0x88, 0xa6, 0x3d,
+		0x58, 0xe5, 0x8e, 0xf0, 0x3a, 0xd5, 0x84, 0xf1,
+		0xbc, 0xa3, 0xb2, 0xd2, 0x5d, 0x51, 0xd6, 0x9b,
+		0x0f, 0x28, 0x5d, 0xcd, 0x3f, 0x71, 0x17, 0x0a,
+		0xf9, 0xbf, 0x2d, 0xb1, 0x10, 0x26, 0x5c, 0xe9,
+		0xdc, 0xc3, 0x9d, 0x7a, 0x01, 0x50, 0x9d, 0xe8,
+		0x35, 0xbd, 0xcb, 0x29, 0x3a, 0xd1, 0x49, 0x32,
+		0x00,
+	}
+	addr = [hashing.AddrLen]byte{
+		0x01, 0x5c, 0xce, 0x6c, 0x55, 0xd6, 0xb5, 0x09,
+		0x84, 0x5c, 0x8c, 0x4e, 0x30, 0xbe, 0xd9, 0x8d,
+		0x39, 0x1a, 0xe7, 0xf0,
+	}
+)
+
+func newTestFx(t *testing.T) *Fx {
+	vm := secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	date := time.Date(2019, time.January, 19, 16, 25, 17, 3, time.UTC)
+	vm.CLK.Set(date)
+
+	fx := &Fx{}
+	if err := fx.Initialize(&vm); err != nil {
+		t.Fatal(err)
+	}
+	return fx
+}
+
+func newTestCredential() *Credential {
+	return &Credential{Credential: secp256k1fx.Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{
+			sigBytes,
+		},
+	}}
+}
+
+func newTestAuthority() AuthorityOutput {
+	return AuthorityOutput{OutputOwners: secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs: []ids.ShortID{
+			addr,
+		},
+	}}
+}
+
+func newTestHolder() secp256k1fx.OutputOwners {
+	return secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs: []ids.ShortID{
+			ids.GenerateTestShortID(),
+		},
+	}
+}
+
+func TestFxInitialize(t *testing.T) {
+	vm := secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := Fx{}
+	err := fx.Initialize(&vm)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFxInitializeInvalid(t *testing.T) {
+	fx := Fx{}
+	err := fx.Initialize(nil)
+	if err == nil {
+		t.Fatalf("Should have returned an error")
+	}
+}
+
+func TestFxVerifyFreezeOperation(t *testing.T) {
+	fx := newTestFx(t)
+	tx := &secp256k1fx.TestTx{
+		Bytes: txBytes,
+	}
+	authority := newTestAuthority()
+	holder := newTestHolder()
+	utxo := &secp256k1fx.TransferOutput{
+		Amt:          5,
+		OutputOwners: holder,
+	}
+	op := &FreezeOperation{
+		Input: secp256k1fx.Input{
+			SigIndices: []uint32{0},
+		},
+		Authority: authority,
+		Frozen: []*FrozenOutput{{
+			Amt:          5,
+			OutputOwners: holder,
+		}},
+	}
+
+	// The authority can be consumed after the frozen UTXOs
+	utxos := []interface{}{utxo, &authority}
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != nil {
+		t.Fatal(err)
+	}
+
+	op.Frozen[0].Amt = 4
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != errWrongFrozenOutput {
+		t.Fatalf("VerifyOperation should have errored due to a wrong frozen amount but returned %v", err)
+	}
+
+	op.Frozen[0].Amt = 5
+	op.Frozen[0].OutputOwners = newTestHolder()
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != errWrongFrozenOutput {
+		t.Fatalf("VerifyOperation should have errored due to wrong frozen owners but returned %v", err)
+	}
+}
+
+func TestFxVerifyFreezeOperationWrongUTXO(t *testing.T) {
+	fx := newTestFx(t)
+	tx := &secp256k1fx.TestTx{
+		Bytes: txBytes,
+	}
+	authority := newTestAuthority()
+	holder := newTestHolder()
+	op := &FreezeOperation{
+		Input: secp256k1fx.Input{
+			SigIndices: []uint32{0},
+		},
+		Authority: authority,
+		Frozen: []*FrozenOutput{{
+			Amt:          5,
+			OutputOwners: holder,
+		}},
+	}
+
+	// Frozen UTXOs can't be frozen again
+	utxos := []interface{}{&authority, &FrozenOutput{Amt: 5, OutputOwners: holder}}
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != errWrongUTXOType {
+		t.Fatalf("VerifyOperation should have errored due to a wrong utxo type but returned %v", err)
+	}
+
+	// Every consumed UTXO must be frozen
+	utxos = []interface{}{&authority}
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != errWrongNumberOfUTXOs {
+		t.Fatalf("VerifyOperation should have errored due to a wrong number of utxos but returned %v", err)
+	}
+}
+
+func TestFxVerifyOperationWrongAuthority(t *testing.T) {
+	fx := newTestFx(t)
+	tx := &secp256k1fx.TestTx{
+		Bytes: txBytes,
+	}
+	authority := newTestAuthority()
+	holder := newTestHolder()
+	utxo := &secp256k1fx.TransferOutput{
+		Amt:          5,
+		OutputOwners: holder,
+	}
+	op := &FreezeOperation{
+		Input: secp256k1fx.Input{
+			SigIndices: []uint32{0},
+		},
+		Authority: authority,
+		Frozen: []*FrozenOutput{{
+			Amt:          5,
+			OutputOwners: holder,
+		}},
+	}
+
+	utxos := []interface{}{utxo}
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != errWrongNumberOfAuths {
+		t.Fatalf("VerifyOperation should have errored due to a missing authority but returned %v", err)
+	}
+
+	utxos = []interface{}{&authority, utxo, &authority}
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != errWrongNumberOfAuths {
+		t.Fatalf("VerifyOperation should have errored due to duplicated authorities but returned %v", err)
+	}
+
+	otherAuthority := AuthorityOutput{OutputOwners: newTestHolder()}
+	utxos = []interface{}{&otherAuthority, utxo}
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != errWrongAuthorityOutput {
+		t.Fatalf("VerifyOperation should have errored due to a changed authority but returned %v", err)
+	}
+
+	// The authority can't be transferred to someone else
+	op.Authority = otherAuthority
+	utxos = []interface{}{&authority, utxo}
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != errWrongAuthorityOutput {
+		t.Fatalf("VerifyOperation should have errored due to a changed authority but returned %v", err)
+	}
+
+	// The authority must sign the operation
+	op.Authority = authority
+	if err := fx.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+	cred := newTestCredential()
+	cred.Sigs[0][0]++
+	if err := fx.VerifyOperation(tx, op, cred, utxos); err == nil {
+		t.Fatalf("VerifyOperation should have errored due to an invalid signature")
+	}
+}
+
+func TestFxVerifyUnfreezeOperation(t *testing.T) {
+	fx := newTestFx(t)
+	tx := &secp256k1fx.TestTx{
+		Bytes: txBytes,
+	}
+	authority := newTestAuthority()
+	holder := newTestHolder()
+	utxo := &FrozenOutput{
+		Amt:          5,
+		OutputOwners: holder,
+	}
+	op := &UnfreezeOperation{
+		Input: secp256k1fx.Input{
+			SigIndices: []uint32{0},
+		},
+		Authority: authority,
+		Unfrozen: []*secp256k1fx.TransferOutput{{
+			Amt:          5,
+			OutputOwners: holder,
+		}},
+	}
+
+	utxos := []interface{}{&authority, utxo}
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != nil {
+		t.Fatal(err)
+	}
+
+	op.Unfrozen[0].OutputOwners = newTestHolder()
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != errWrongUnfrozenOutput {
+		t.Fatalf("VerifyOperation should have errored due to wrong unfrozen owners but returned %v", err)
+	}
+
+	// Only frozen UTXOs can be unfrozen
+	op.Unfrozen[0].OutputOwners = holder
+	utxos = []interface{}{&authority, &secp256k1fx.TransferOutput{Amt: 5, OutputOwners: holder}}
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != errWrongUTXOType {
+		t.Fatalf("VerifyOperation should have errored due to a wrong utxo type but returned %v", err)
+	}
+}
+
+func TestFxVerifyClawbackOperation(t *testing.T) {
+	fx := newTestFx(t)
+	tx := &secp256k1fx.TestTx{
+		Bytes: txBytes,
+	}
+	authority := newTestAuthority()
+	op := &ClawbackOperation{
+		Input: secp256k1fx.Input{
+			SigIndices: []uint32{0},
+		},
+		Authority: authority,
+		Output: secp256k1fx.TransferOutput{
+			Amt:          8,
+			OutputOwners: authority.OutputOwners,
+		},
+	}
+
+	// Both frozen and transferable UTXOs can be clawed back
+	utxos := []interface{}{
+		&FrozenOutput{Amt: 5, OutputOwners: newTestHolder()},
+		&authority,
+		&secp256k1fx.TransferOutput{Amt: 3, OutputOwners: newTestHolder()},
+	}
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != nil {
+		t.Fatal(err)
+	}
+
+	op.Output.Amt = 9
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != errWrongClawbackAmount {
+		t.Fatalf("VerifyOperation should have errored due to a wrong clawback amount but returned %v", err)
+	}
+
+	utxos = []interface{}{
+		&authority,
+		&secp256k1fx.TransferOutput{Amt: math.MaxUint64, OutputOwners: newTestHolder()},
+		&secp256k1fx.TransferOutput{Amt: 1, OutputOwners: newTestHolder()},
+	}
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err == nil {
+		t.Fatalf("VerifyOperation should have errored due to an overflow")
+	}
+
+	utxos = []interface{}{&authority}
+	if err := fx.VerifyOperation(tx, op, newTestCredential(), utxos); err != errWrongNumberOfUTXOs {
+		t.Fatalf("VerifyOperation should have errored due to a wrong number of utxos but returned %v", err)
+	}
+}
+
+func TestFxVerifyOperationWrongTx(t *testing.T) {
+	fx := newTestFx(t)
+	authority := newTestAuthority()
+	op := &ClawbackOperation{}
+
+	utxos := []interface{}{&authority}
+	if err := fx.VerifyOperation(nil, op, newTestCredential(), utxos); err != errWrongTxType {
+		t.Fatalf("VerifyOperation should have errored due to an invalid tx but returned %v", err)
+	}
+}
+
+func TestFxVerifyOperationWrongCredential(t *testing.T) {
+	fx := newTestFx(t)
+	tx := &secp256k1fx.TestTx{
+		Bytes: txBytes,
+	}
+	authority := newTestAuthority()
+	op := &ClawbackOperation{}
+
+	utxos := []interface{}{&authority}
+	if err := fx.VerifyOperation(tx, op, &secp256k1fx.Credential{}, utxos); err != errWrongCredentialType {
+		t.Fatalf("VerifyOperation should have errored due to an invalid credential but returned %v", err)
+	}
+}
+
+func TestFxVerifyOperationUnknownOperation(t *testing.T) {
+	fx := newTestFx(t)
+	tx := &secp256k1fx.TestTx{
+		Bytes: txBytes,
+	}
+	authority := newTestAuthority()
+
+	utxos := []interface{}{&authority}
+	if err := fx.VerifyOperation(tx, nil, newTestCredential(), utxos); err != errWrongOperationType {
+		t.Fatalf("VerifyOperation should have errored due to an unknown operation but returned %v", err)
+	}
+}
+
+func TestFxVerifyTransfer(t *testing.T) {
+	fx := newTestFx(t)
+	if err := fx.VerifyTransfer(nil, nil, nil, nil); err == nil {
+		t.Fatalf("this Fx doesn't support transfers")
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compliancefx
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsgo/snow"
+	"github.com/lasthyphen/dijetsgo/vms/components/verify"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+var errNilUnfreezeOperation = errors.New("nil unfreeze operation")
+
+// UnfreezeOperation makes frozen UTXOs of an asset transferable again. The i-th
+// unfrozen output replaces the i-th consumed UTXO that isn't the authority.
+type UnfreezeOperation struct {
+	Input     secp256k1fx.Input             `serialize:"true" json:"input"`
+	Authority AuthorityOutput               `serialize:"true" json:"authority"`
+	Unfrozen  []*secp256k1fx.TransferOutput `serialize:"true" json:"unfrozen"`
+}
+
+func (op *UnfreezeOperation) InitCtx(ctx *snow.Context) {
+	op.Authority.OutputOwners.InitCtx(ctx)
+	for _, out := range op.Unfrozen {
+		out.OutputOwners.InitCtx(ctx)
+	}
+}
+
+func (op *UnfreezeOperation) Cost() (uint64, error) {
+	return op.Input.Cost()
+}
+
+func (op *UnfreezeOperation) Outs() []verify.State {
+	outs := make([]verify.State, 0, len(op.Unfrozen)+1)
+	outs = append(outs, &op.Authority)
+	for _, out := range op.Unfrozen {
+		outs = append(outs, out)
+	}
+	return outs
+}
+
+func (op *UnfreezeOperation) Verify() error {
+	switch {
+	case op == nil:
+		return errNilUnfreezeOperation
+	case len(op.Unfrozen) == 0:
+		return errNoOperationOutputs
+	}
+
+	if err := verify.All(&op.Input, &op.Authority); err != nil {
+		return err
+	}
+	for _, out := range op.Unfrozen {
+		if err := out.Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compliancefx
+
+import (
+	"testing"
+
+	"github.com/lasthyphen/dijetsgo/vms/components/verify"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+func TestUnfreezeOperationVerifyNil(t *testing.T) {
+	op := (*UnfreezeOperation)(nil)
+	if err := op.Verify(); err == nil {
+		t.Fatalf("nil operation should have failed verification")
+	}
+}
+
+func TestUnfreezeOperationVerifyNoOutputs(t *testing.T) {
+	op := UnfreezeOperation{}
+	if err := op.Verify(); err == nil {
+		t.Fatalf("operation without outputs should have failed verification")
+	}
+}
+
+func TestUnfreezeOperationVerifyInvalidOutput(t *testing.T) {
+	op := UnfreezeOperation{
+		Unfrozen: []*secp256k1fx.TransferOutput{{}},
+	}
+	if err := op.Verify(); err == nil {
+		t.Fatalf("operation should have failed verification")
+	}
+}
+
+func TestUnfreezeOperationOuts(t *testing.T) {
+	op := UnfreezeOperation{
+		Unfrozen: []*secp256k1fx.TransferOutput{{}, {}},
+	}
+	if outs := op.Outs(); len(outs) != 3 {
+		t.Fatalf("Wrong number of outputs returned")
+	}
+}
+
+func TestUnfreezeOperationState(t *testing.T) {
+	intf := interface{}(&UnfreezeOperation{})
+	if _, ok := intf.(verify.State); ok {
+		t.Fatalf("shouldn't be marked as state")
+	}
+}
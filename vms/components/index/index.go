@@ -236,6 +236,23 @@ func checkIndexStatus(db database.KeyValueReaderWriter, enableIndexing, allowInc
 	return nil
 }
 
+// checkAddedIndexStatus is checkIndexStatus for an index that may be added to
+// a chain that already accepted txs. If the index has no status yet, it's
+// only complete if the chain is [newChain], as otherwise it misses the txs
+// accepted before it was added.
+func checkAddedIndexStatus(db database.KeyValueReaderWriter, enableIndexing, allowIncomplete, newChain bool) error {
+	hasStatus, err := db.Has(idxCompleteKey)
+	if err != nil {
+		return err
+	}
+	if !hasStatus && !newChain {
+		if err := database.PutBool(db, idxCompleteKey, false); err != nil {
+			return err
+		}
+	}
+	return checkIndexStatus(db, enableIndexing, allowIncomplete)
+}
+
 type noIndexer struct{}
 
 func NewNoIndexer(db database.Database, allowIncomplete bool) (AddressTxsIndexer, error) {
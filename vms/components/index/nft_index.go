@@ -0,0 +1,395 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsgo/database"
+	"github.com/lasthyphen/dijetsgo/database/prefixdb"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils/hashing"
+	"github.com/lasthyphen/dijetsgo/utils/logging"
+	"github.com/lasthyphen/dijetsgo/utils/wrappers"
+	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
+	"github.com/lasthyphen/dijetsgo/vms/nftfx"
+)
+
+const (
+	// groupIDLen is the length of a serialized group ID
+	groupIDLen = wrappers.IntLen
+	// ownedKeyLen is the length of a key in an owner's database:
+	// [assetID] + [groupID] + [utxoID]
+	ownedKeyLen = hashing.HashLen + groupIDLen + hashing.HashLen
+	// holderKeyLen is the length of a key in a collection's database:
+	// [groupID] + [address] + [utxoID]
+	holderKeyLen = groupIDLen + hashing.AddrLen + hashing.HashLen
+)
+
+var (
+	ownerPrefix      = []byte("owner")
+	collectionPrefix = []byte("collection")
+	historyPrefix    = []byte("history")
+
+	_ NFTIndexer = &nftIndexer{}
+	_ NFTIndexer = &noNFTIndexer{}
+)
+
+// NFT is an unspent nftfx.TransferOutput as seen by one of its owners
+type NFT struct {
+	AssetID ids.ID
+	GroupID uint32
+	// UTXOID is the input ID of the UTXO holding the NFT
+	UTXOID  ids.ID
+	Owner   ids.ShortID
+	Payload []byte
+}
+
+// NFTTransfer describes an nftfx.TransferOutput created by an accepted
+// transaction
+type NFTTransfer struct {
+	TxID      ids.ID
+	Payload   []byte
+	Locktime  uint64
+	Threshold uint32
+	Owners    []ids.ShortID
+}
+
+// NFTIndexer maintains which NFTs are currently held by which addresses, and
+// the payloads every group of an NFT collection has been transferred with.
+// Only UTXOs of type nftfx.TransferOutput are indexed.
+type NFTIndexer interface {
+	// Accept is called when [txID] is accepted.
+	// [inputUTXOs] are the UTXOs [txID] consumes.
+	// [outputUTXOs] are the UTXOs [txID] creates.
+	// If the error is non-nil, do not persist [txID] to disk as accepted in the VM
+	Accept(
+		txID ids.ID,
+		inputUTXOs []*djtx.UTXO,
+		outputUTXOs []*djtx.UTXO,
+	) error
+
+	// ReadOwned returns the NFTs [address] currently owns, ordered by asset
+	// ID, group ID and UTXO ID. If [assetID] is not empty, only NFTs of that
+	// collection are returned.
+	// [cursor] is the cursor returned by the previous page, or nil to read
+	// from the first NFT. The returned cursor is where the next page starts.
+	// The length of the returned slice <= [pageSize].
+	ReadOwned(address ids.ShortID, assetID ids.ID, cursor []byte, pageSize uint64) ([]NFT, []byte, error)
+
+	// ReadHolders returns the NFTs of collection [assetID] and who holds them,
+	// ordered by group ID, address and UTXO ID.
+	// [cursor] is the cursor returned by the previous page, or nil to read
+	// from the first NFT. The returned cursor is where the next page starts.
+	// The length of the returned slice <= [pageSize].
+	ReadHolders(assetID ids.ID, cursor []byte, pageSize uint64) ([]NFT, []byte, error)
+
+	// ReadHistory returns the outputs group [groupID] of collection [assetID]
+	// was minted and transferred with, in order of acceptance.
+	// [cursor] is the offset to start reading from.
+	// The length of the returned slice <= [pageSize].
+	ReadHistory(assetID ids.ID, groupID uint32, cursor, pageSize uint64) ([]NFTTransfer, error)
+}
+
+type nftIndexer struct {
+	log          logging.Logger
+	ownerDB      database.Database
+	collectionDB database.Database
+	historyDB    database.Database
+}
+
+// NewNFTIndexer returns a new NFTIndexer that stores its index in [db].
+// [newChain] is true if the chain's state was initialized in this run, so
+// that the index sees every accepted tx.
+func NewNFTIndexer(
+	db database.Database,
+	log logging.Logger,
+	allowIncompleteIndices bool,
+	newChain bool,
+) (NFTIndexer, error) {
+	if err := checkAddedIndexStatus(db, true, allowIncompleteIndices, newChain); err != nil {
+		return nil, err
+	}
+	return &nftIndexer{
+		log:          log,
+		ownerDB:      prefixdb.New(ownerPrefix, db),
+		collectionDB: prefixdb.New(collectionPrefix, db),
+		historyDB:    prefixdb.New(historyPrefix, db),
+	}, nil
+}
+
+// Accept removes the NFTs spent by [txID] and adds the NFTs it creates.
+// The database structure is:
+// "owner"
+// |  [address]
+// |  |  [assetID] + [groupID] + [utxoID] => payload
+// "collection"
+// |  [assetID]
+// |  |  [groupID] + [address] + [utxoID] => payload
+// "history"
+// |  [assetID] + [groupID]
+// |  |  "idx" => 2 		Running transfer index key, represents the next index
+// |  |  "0"   => transfer1
+// |  |  "1"   => transfer2
+// See interface documentation NFTIndexer.Accept
+func (i *nftIndexer) Accept(txID ids.ID, inputUTXOs []*djtx.UTXO, outputUTXOs []*djtx.UTXO) error {
+	for _, utxo := range inputUTXOs {
+		out, ok := utxo.Out.(*nftfx.TransferOutput)
+		if !ok {
+			continue
+		}
+
+		assetID := utxo.AssetID()
+		utxoID := utxo.InputID()
+		for _, addr := range out.Addrs {
+			i.log.Verbo("removing NFT address/assetID/groupID/utxoID %s/%s/%d/%s", addr, assetID, out.GroupID, utxoID)
+			ownerDB := prefixdb.New(addr[:], i.ownerDB)
+			if err := ownerDB.Delete(ownedKey(assetID, out.GroupID, utxoID)); err != nil {
+				return fmt.Errorf("failed to remove owned NFT while indexing %s: %w", txID, err)
+			}
+			holdersDB := prefixdb.New(assetID[:], i.collectionDB)
+			if err := holdersDB.Delete(holderKey(out.GroupID, addr, utxoID)); err != nil {
+				return fmt.Errorf("failed to remove NFT holder while indexing %s: %w", txID, err)
+			}
+		}
+	}
+
+	for _, utxo := range outputUTXOs {
+		out, ok := utxo.Out.(*nftfx.TransferOutput)
+		if !ok {
+			continue
+		}
+
+		assetID := utxo.AssetID()
+		utxoID := utxo.InputID()
+		for _, addr := range out.Addrs {
+			i.log.Verbo("writing NFT address/assetID/groupID/utxoID %s/%s/%d/%s", addr, assetID, out.GroupID, utxoID)
+			ownerDB := prefixdb.New(addr[:], i.ownerDB)
+			if err := ownerDB.Put(ownedKey(assetID, out.GroupID, utxoID), out.Payload); err != nil {
+				return fmt.Errorf("failed to write owned NFT while indexing %s: %w", txID, err)
+			}
+			holdersDB := prefixdb.New(assetID[:], i.collectionDB)
+			if err := holdersDB.Put(holderKey(out.GroupID, addr, utxoID), out.Payload); err != nil {
+				return fmt.Errorf("failed to write NFT holder while indexing %s: %w", txID, err)
+			}
+		}
+
+		if err := i.appendHistory(txID, assetID, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *nftIndexer) appendHistory(txID ids.ID, assetID ids.ID, out *nftfx.TransferOutput) error {
+	groupDB := prefixdb.New(groupPrefix(assetID, out.GroupID), i.historyDB)
+
+	var idx uint64
+	idxBytes, err := groupDB.Get(idxKey)
+	switch err {
+	case nil:
+		// index is found, parse stored [idxBytes]
+		idx = binary.BigEndian.Uint64(idxBytes)
+	case database.ErrNotFound:
+		// idx not found; this must be the first entry.
+		idxBytes = make([]byte, wrappers.LongLen)
+	default:
+		// Unexpected error
+		return fmt.Errorf("unexpected error when indexing txID %s: %w", txID, err)
+	}
+
+	transferBytes, err := marshalNFTTransfer(txID, out)
+	if err != nil {
+		return fmt.Errorf("failed to marshal NFT transfer while indexing %s: %w", txID, err)
+	}
+	if err := groupDB.Put(idxBytes, transferBytes); err != nil {
+		return fmt.Errorf("failed to write NFT transfer while indexing %s: %w", txID, err)
+	}
+
+	// increment and store the index for next use
+	idx++
+	binary.BigEndian.PutUint64(idxBytes, idx)
+
+	if err := groupDB.Put(idxKey, idxBytes); err != nil {
+		return fmt.Errorf("failed to write NFT transfer index while indexing %s: %w", txID, err)
+	}
+	return nil
+}
+
+// ReadOwned implements the NFTIndexer interface
+func (i *nftIndexer) ReadOwned(address ids.ShortID, assetID ids.ID, cursor []byte, pageSize uint64) ([]NFT, []byte, error) {
+	ownerDB := prefixdb.New(address[:], i.ownerDB)
+
+	var prefix []byte
+	if assetID != ids.Empty {
+		prefix = assetID[:]
+	}
+	iter := ownerDB.NewIteratorWithStartAndPrefix(cursor, prefix)
+	defer iter.Release()
+
+	var nfts []NFT
+	for uint64(len(nfts)) < pageSize && iter.Next() {
+		key := iter.Key()
+		if len(key) != ownedKeyLen {
+			return nil, nil, fmt.Errorf("unexpected owned NFT key length %d", len(key))
+		}
+		nft := NFT{
+			GroupID: binary.BigEndian.Uint32(key[hashing.HashLen:]),
+			Owner:   address,
+			Payload: copyBytes(iter.Value()),
+		}
+		copy(nft.AssetID[:], key)
+		copy(nft.UTXOID[:], key[hashing.HashLen+groupIDLen:])
+		nfts = append(nfts, nft)
+		cursor = nextCursor(key)
+	}
+	return nfts, cursor, iter.Error()
+}
+
+// ReadHolders implements the NFTIndexer interface
+func (i *nftIndexer) ReadHolders(assetID ids.ID, cursor []byte, pageSize uint64) ([]NFT, []byte, error) {
+	holdersDB := prefixdb.New(assetID[:], i.collectionDB)
+
+	iter := holdersDB.NewIteratorWithStart(cursor)
+	defer iter.Release()
+
+	var nfts []NFT
+	for uint64(len(nfts)) < pageSize && iter.Next() {
+		key := iter.Key()
+		if len(key) != holderKeyLen {
+			return nil, nil, fmt.Errorf("unexpected NFT holder key length %d", len(key))
+		}
+		nft := NFT{
+			AssetID: assetID,
+			GroupID: binary.BigEndian.Uint32(key),
+			Payload: copyBytes(iter.Value()),
+		}
+		copy(nft.Owner[:], key[groupIDLen:])
+		copy(nft.UTXOID[:], key[groupIDLen+hashing.AddrLen:])
+		nfts = append(nfts, nft)
+		cursor = nextCursor(key)
+	}
+	return nfts, cursor, iter.Error()
+}
+
+// ReadHistory implements the NFTIndexer interface
+func (i *nftIndexer) ReadHistory(assetID ids.ID, groupID uint32, cursor, pageSize uint64) ([]NFTTransfer, error) {
+	groupDB := prefixdb.New(groupPrefix(assetID, groupID), i.historyDB)
+
+	// get cursor in bytes
+	cursorBytes := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(cursorBytes, cursor)
+
+	// start reading from the cursor bytes, numeric keys maintain the order
+	iter := groupDB.NewIteratorWithStart(cursorBytes)
+	defer iter.Release()
+
+	var transfers []NFTTransfer
+	for uint64(len(transfers)) < pageSize && iter.Next() {
+		if len(iter.Key()) != wrappers.LongLen {
+			// This key has the next index to use, not a transfer
+			continue
+		}
+
+		transfer, err := unmarshalNFTTransfer(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers, iter.Error()
+}
+
+func ownedKey(assetID ids.ID, groupID uint32, utxoID ids.ID) []byte {
+	key := make([]byte, ownedKeyLen)
+	copy(key, assetID[:])
+	binary.BigEndian.PutUint32(key[hashing.HashLen:], groupID)
+	copy(key[hashing.HashLen+groupIDLen:], utxoID[:])
+	return key
+}
+
+func holderKey(groupID uint32, addr ids.ShortID, utxoID ids.ID) []byte {
+	key := make([]byte, holderKeyLen)
+	binary.BigEndian.PutUint32(key, groupID)
+	copy(key[groupIDLen:], addr[:])
+	copy(key[groupIDLen+hashing.AddrLen:], utxoID[:])
+	return key
+}
+
+func groupPrefix(assetID ids.ID, groupID uint32) []byte {
+	prefix := make([]byte, hashing.HashLen+groupIDLen)
+	copy(prefix, assetID[:])
+	binary.BigEndian.PutUint32(prefix[hashing.HashLen:], groupID)
+	return prefix
+}
+
+func marshalNFTTransfer(txID ids.ID, out *nftfx.TransferOutput) ([]byte, error) {
+	p := wrappers.Packer{
+		MaxSize: hashing.HashLen + wrappers.IntLen + len(out.Payload) + wrappers.LongLen +
+			2*wrappers.IntLen + len(out.Addrs)*hashing.AddrLen,
+	}
+	p.PackFixedBytes(txID[:])
+	p.PackBytes(out.Payload)
+	p.PackLong(out.Locktime)
+	p.PackInt(out.Threshold)
+	p.PackInt(uint32(len(out.Addrs)))
+	for _, addr := range out.Addrs {
+		p.PackFixedBytes(addr[:])
+	}
+	return p.Bytes, p.Err
+}
+
+func unmarshalNFTTransfer(b []byte) (NFTTransfer, error) {
+	p := wrappers.Packer{Bytes: b}
+	transfer := NFTTransfer{}
+	copy(transfer.TxID[:], p.UnpackFixedBytes(hashing.HashLen))
+	transfer.Payload = copyBytes(p.UnpackBytes())
+	transfer.Locktime = p.UnpackLong()
+	transfer.Threshold = p.UnpackInt()
+	numOwners := p.UnpackInt()
+	if p.Err == nil && uint64(numOwners)*hashing.AddrLen > uint64(len(b)) {
+		return transfer, fmt.Errorf("invalid NFT transfer with %d owners", numOwners)
+	}
+	transfer.Owners = make([]ids.ShortID, numOwners)
+	for j := range transfer.Owners {
+		copy(transfer.Owners[j][:], p.UnpackFixedBytes(hashing.AddrLen))
+	}
+	return transfer, p.Err
+}
+
+// nextCursor returns the smallest key that is greater than [key]
+func nextCursor(key []byte) []byte {
+	cursor := make([]byte, len(key)+1)
+	copy(cursor, key)
+	return cursor
+}
+
+func copyBytes(b []byte) []byte {
+	cb := make([]byte, len(b))
+	copy(cb, b)
+	return cb
+}
+
+type noNFTIndexer struct{}
+
+func NewNoNFTIndexer(db database.Database, allowIncomplete bool, newChain bool) (NFTIndexer, error) {
+	return &noNFTIndexer{}, checkAddedIndexStatus(db, false, allowIncomplete, newChain)
+}
+
+func (i *noNFTIndexer) Accept(ids.ID, []*djtx.UTXO, []*djtx.UTXO) error {
+	return nil
+}
+
+func (i *noNFTIndexer) ReadOwned(ids.ShortID, ids.ID, []byte, uint64) ([]NFT, []byte, error) {
+	return nil, nil, nil
+}
+
+func (i *noNFTIndexer) ReadHolders(ids.ID, []byte, uint64) ([]NFT, []byte, error) {
+	return nil, nil, nil
+}
+
+func (i *noNFTIndexer) ReadHistory(ids.ID, uint32, uint64, uint64) ([]NFTTransfer, error) {
+	return nil, nil
+}
@@ -17,8 +17,9 @@ import (
 )
 
 var (
-	_ vertex.DAGVM = &vertexVM{}
-	_ snowstorm.Tx = &meterTx{}
+	_ vertex.DAGVM           = &vertexVM{}
+	_ vertex.IssuanceHandler = &vertexVM{}
+	_ snowstorm.Tx           = &meterTx{}
 )
 
 func NewVertexVM(vm vertex.DAGVM) vertex.DAGVM {
@@ -104,6 +105,12 @@ func (vm *vertexVM) GetTx(txID ids.ID) (snowstorm.Tx, error) {
 	}, nil
 }
 
+func (vm *vertexVM) TxsIssued(vtxID ids.ID, txIDs []ids.ID) {
+	if handler, ok := vm.DAGVM.(vertex.IssuanceHandler); ok {
+		handler.TxsIssued(vtxID, txIDs)
+	}
+}
+
 type meterTx struct {
 	snowstorm.Tx
 
@@ -110,13 +110,19 @@ func (tx *UnsignedAddDelegatorTx) SyntacticVerify(ctx *snow.Context) error {
 
 // Attempts to verify this transaction with the provided state.
 func (tx *UnsignedAddDelegatorTx) SemanticVerify(vm *VM, parentState MutableState, stx *Tx) error {
+	return tx.semanticVerify(vm, parentState, stx, vm.fx)
+}
+
+// semanticVerify verifies this transaction with the provided state, using
+// [fx] to verify its credentials.
+func (tx *UnsignedAddDelegatorTx) semanticVerify(vm *VM, parentState MutableState, stx *Tx, fx Fx) error {
 	startTime := tx.StartTime()
 	maxLocalStartTime := vm.clock.Time().Add(maxFutureStartTime)
 	if startTime.After(maxLocalStartTime) {
 		return errFutureStakeTime
 	}
 
-	_, _, err := tx.Execute(vm, parentState, stx)
+	_, _, err := tx.execute(vm, parentState, stx, fx)
 	// We ignore [errFutureStakeTime] here because an advanceTimeTx will be
 	// issued before this transaction is issued.
 	if errors.Is(err, errFutureStakeTime) {
@@ -134,6 +140,20 @@ func (tx *UnsignedAddDelegatorTx) Execute(
 	VersionedState,
 	VersionedState,
 	error,
+) {
+	return tx.execute(vm, parentState, stx, vm.fx)
+}
+
+// execute this transaction, using [fx] to verify its credentials.
+func (tx *UnsignedAddDelegatorTx) execute(
+	vm *VM,
+	parentState MutableState,
+	stx *Tx,
+	fx Fx,
+) (
+	VersionedState,
+	VersionedState,
+	error,
 ) {
 	// Verify the tx is well-formed
 	if err := tx.SyntacticVerify(vm.ctx); err != nil {
@@ -164,7 +184,8 @@ func (tx *UnsignedAddDelegatorTx) Execute(
 		validatorStartTime := tx.StartTime()
 		if !currentTimestamp.Before(validatorStartTime) {
 			return nil, nil, fmt.Errorf(
-				"chain timestamp (%s) not before validator's start time (%s)",
+				"%w: chain timestamp (%s) not before validator's start time (%s)",
+				errStartTimeTooEarly,
 				currentTimestamp,
 				validatorStartTime,
 			)
@@ -246,9 +267,17 @@ func (tx *UnsignedAddDelegatorTx) Execute(
 			return nil, nil, errOverDelegated
 		}
 
+		// Ensure that the validator's delegation policy, if it set one, allows
+		// this delegator. Delegation policies are enforced from AP6 on.
+		if !currentTimestamp.Before(vm.ApricotPhase6Time) {
+			if err := verifyDelegationPolicy(parentState, vdrTx, currentDelegators, pendingDelegators, tx); err != nil {
+				return nil, nil, err
+			}
+		}
+
 		// Verify the flowcheck
-		if err := vm.semanticVerifySpend(parentState, tx, tx.Ins, outs, stx.Creds, vm.AddStakerTxFee, vm.ctx.DJTXAssetID); err != nil {
-			return nil, nil, fmt.Errorf("failed semanticVerifySpend: %w", err)
+		if err := vm.semanticVerifySpend(fx, parentState, tx, tx.Ins, outs, stx.Creds, vm.AddStakerTxFee, vm.ctx.DJTXAssetID); err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", errFlowCheckFailed, err)
 		}
 
 		// Make sure the tx doesn't start too far in the future. This is done
@@ -352,6 +381,69 @@ func CanDelegate(
 	return newMaxStake <= maximumStake, nil
 }
 
+// verifyDelegationPolicy returns nil iff the delegation policy of the
+// validator added by [vdrTx] allows the [new] delegator to be added next to the
+// validator's [current] and [pending] delegators. Validators that never set a
+// policy allow any delegator.
+func verifyDelegationPolicy(
+	state MutableState,
+	vdrTx *UnsignedAddValidatorTx,
+	current,
+	pending []*UnsignedAddDelegatorTx, // sorted by next start time first
+	new *UnsignedAddDelegatorTx,
+) error {
+	policy, err := state.GetDelegationPolicy(vdrTx.ID())
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !policy.allows(new) {
+		return errDelegatorNotAllowed
+	}
+	if policy.MaxDelegatorStake != 0 && new.Validator.Wght > policy.MaxDelegatorStake {
+		return fmt.Errorf(
+			"%w: %d > %d",
+			errDelegatorStakeAbovePolicy,
+			new.Validator.Wght,
+			policy.MaxDelegatorStake,
+		)
+	}
+	if policy.MaxDelegators == 0 {
+		return nil
+	}
+
+	// The number of delegators is tracked over time just like their stake, by
+	// giving every delegator a weight of 1.
+	maxDelegators, err := maxStakeAmount(
+		unitDelegators(current),
+		unitDelegators(pending),
+		new.StartTime(),
+		new.EndTime(),
+		uint64(len(current)),
+	)
+	if err != nil {
+		return err
+	}
+	if maxDelegators >= uint64(policy.MaxDelegators) {
+		return errTooManyDelegators
+	}
+	return nil
+}
+
+// unitDelegators returns copies of [delegators] that each have a weight of 1
+func unitDelegators(delegators []*UnsignedAddDelegatorTx) []*UnsignedAddDelegatorTx {
+	units := make([]*UnsignedAddDelegatorTx, len(delegators))
+	for i, delegator := range delegators {
+		unit := &UnsignedAddDelegatorTx{Validator: delegator.Validator}
+		unit.Validator.Wght = 1
+		units[i] = unit
+	}
+	return units
+}
+
 // Return the maximum amount of stake on a node (including delegations) at any
 // given time between [startTime] and [endTime] given that:
 // * The amount of stake on the node right now is [currentStake]
@@ -72,13 +72,19 @@ func (tx *UnsignedAddSubnetValidatorTx) SyntacticVerify(ctx *snow.Context) error
 
 // Attempts to verify this transaction with the provided state.
 func (tx *UnsignedAddSubnetValidatorTx) SemanticVerify(vm *VM, parentState MutableState, stx *Tx) error {
+	return tx.semanticVerify(vm, parentState, stx, vm.fx)
+}
+
+// semanticVerify verifies this transaction with the provided state, using
+// [fx] to verify its credentials.
+func (tx *UnsignedAddSubnetValidatorTx) semanticVerify(vm *VM, parentState MutableState, stx *Tx, fx Fx) error {
 	startTime := tx.StartTime()
 	maxLocalStartTime := vm.clock.Time().Add(maxFutureStartTime)
 	if startTime.After(maxLocalStartTime) {
 		return errFutureStakeTime
 	}
 
-	_, _, err := tx.Execute(vm, parentState, stx)
+	_, _, err := tx.execute(vm, parentState, stx, fx)
 	// We ignore [errFutureStakeTime] here because an advanceTimeTx will be
 	// issued before this transaction is issued.
 	if errors.Is(err, errFutureStakeTime) {
@@ -96,6 +102,20 @@ func (tx *UnsignedAddSubnetValidatorTx) Execute(
 	VersionedState,
 	VersionedState,
 	error,
+) {
+	return tx.execute(vm, parentState, stx, vm.fx)
+}
+
+// execute this transaction, using [fx] to verify its credentials.
+func (tx *UnsignedAddSubnetValidatorTx) execute(
+	vm *VM,
+	parentState MutableState,
+	stx *Tx,
+	fx Fx,
+) (
+	VersionedState,
+	VersionedState,
+	error,
 ) {
 	// Verify the tx is well-formed
 	if err := tx.SyntacticVerify(vm.ctx); err != nil {
@@ -121,9 +141,10 @@ func (tx *UnsignedAddSubnetValidatorTx) Execute(
 		validatorStartTime := tx.StartTime()
 		if !currentTimestamp.Before(validatorStartTime) {
 			return nil, nil, fmt.Errorf(
-				"validator's start time (%s) is at or after current chain timestamp (%s)",
-				currentTimestamp,
+				"%w: validator's start time (%s) at or before current timestamp (%s)",
+				errStartTimeTooEarly,
 				validatorStartTime,
+				currentTimestamp,
 			)
 		}
 
@@ -186,11 +207,17 @@ func (tx *UnsignedAddSubnetValidatorTx) Execute(
 		baseTxCreds := stx.Creds[:baseTxCredsLen]
 		subnetCred := stx.Creds[baseTxCredsLen]
 
-		subnetIntf, _, err := parentState.GetTx(tx.Validator.Subnet)
+		subnetOwner, err := parentState.GetSubnetOwner(tx.Validator.Subnet)
 		if err != nil {
 			if err == database.ErrNotFound {
 				return nil, nil, errDSValidatorSubset
 			}
+			if errors.Is(err, errNotASubnet) {
+				return nil, nil, fmt.Errorf(
+					"%s is not a subnet",
+					tx.Validator.Subnet,
+				)
+			}
 			return nil, nil, fmt.Errorf(
 				"couldn't find subnet %s with %w",
 				tx.Validator.Subnet,
@@ -198,21 +225,13 @@ func (tx *UnsignedAddSubnetValidatorTx) Execute(
 			)
 		}
 
-		subnet, ok := subnetIntf.UnsignedTx.(*UnsignedCreateSubnetTx)
-		if !ok {
-			return nil, nil, fmt.Errorf(
-				"%s is not a subnet",
-				tx.Validator.Subnet,
-			)
-		}
-
-		if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnet.Owner); err != nil {
+		if err := fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnetOwner); err != nil {
 			return nil, nil, err
 		}
 
 		// Verify the flowcheck
-		if err := vm.semanticVerifySpend(parentState, tx, tx.Ins, tx.Outs, baseTxCreds, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
-			return nil, nil, err
+		if err := vm.semanticVerifySpend(fx, parentState, tx, tx.Ins, tx.Outs, baseTxCreds, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", errFlowCheckFailed, err)
 		}
 
 		// Make sure the tx doesn't start too far in the future. This is done
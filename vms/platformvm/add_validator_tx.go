@@ -28,6 +28,7 @@ var (
 	errStakeTooShort             = errors.New("staking period is too short")
 	errStakeTooLong              = errors.New("staking period is too long")
 	errInsufficientDelegationFee = errors.New("staker charges an insufficient delegation fee")
+	errFlowCheckFailed           = errors.New("failed semanticVerifySpend")
 	errFutureStakeTime           = fmt.Errorf("staker is attempting to start staking more than %s ahead of the current chain time", maxFutureStartTime)
 	errTooManyShares             = fmt.Errorf("a staker can only require at most %d shares from delegators", reward.PercentDenominator)
 
@@ -121,13 +122,19 @@ func (tx *UnsignedAddValidatorTx) SyntacticVerify(ctx *snow.Context) error {
 
 // Attempts to verify this transaction with the provided state.
 func (tx *UnsignedAddValidatorTx) SemanticVerify(vm *VM, parentState MutableState, stx *Tx) error {
+	return tx.semanticVerify(vm, parentState, stx, vm.fx)
+}
+
+// semanticVerify verifies this transaction with the provided state, using
+// [fx] to verify its credentials.
+func (tx *UnsignedAddValidatorTx) semanticVerify(vm *VM, parentState MutableState, stx *Tx, fx Fx) error {
 	startTime := tx.StartTime()
 	maxLocalStartTime := vm.clock.Time().Add(maxFutureStartTime)
 	if startTime.After(maxLocalStartTime) {
 		return errFutureStakeTime
 	}
 
-	_, _, err := tx.Execute(vm, parentState, stx)
+	_, _, err := tx.execute(vm, parentState, stx, fx)
 	// We ignore [errFutureStakeTime] here because an advanceTimeTx will be
 	// issued before this transaction is issued.
 	if errors.Is(err, errFutureStakeTime) {
@@ -145,6 +152,20 @@ func (tx *UnsignedAddValidatorTx) Execute(
 	VersionedState,
 	VersionedState,
 	error,
+) {
+	return tx.execute(vm, parentState, stx, vm.fx)
+}
+
+// execute this transaction, using [fx] to verify its credentials.
+func (tx *UnsignedAddValidatorTx) execute(
+	vm *VM,
+	parentState MutableState,
+	stx *Tx,
+	fx Fx,
+) (
+	VersionedState,
+	VersionedState,
+	error,
 ) {
 	// Verify the tx is well-formed
 	if err := tx.SyntacticVerify(vm.ctx); err != nil {
@@ -181,7 +202,8 @@ func (tx *UnsignedAddValidatorTx) Execute(
 		startTime := tx.StartTime()
 		if !currentTimestamp.Before(startTime) {
 			return nil, nil, fmt.Errorf(
-				"validator's start time (%s) at or before current timestamp (%s)",
+				"%w: validator's start time (%s) at or before current timestamp (%s)",
+				errStartTimeTooEarly,
 				startTime,
 				currentTimestamp,
 			)
@@ -220,8 +242,8 @@ func (tx *UnsignedAddValidatorTx) Execute(
 		}
 
 		// Verify the flowcheck
-		if err := vm.semanticVerifySpend(parentState, tx, tx.Ins, outs, stx.Creds, vm.AddStakerTxFee, vm.ctx.DJTXAssetID); err != nil {
-			return nil, nil, fmt.Errorf("failed semanticVerifySpend: %w", err)
+		if err := vm.semanticVerifySpend(fx, parentState, tx, tx.Ins, outs, stx.Creds, vm.AddStakerTxFee, vm.ctx.DJTXAssetID); err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", errFlowCheckFailed, err)
 		}
 
 		// Make sure the tx doesn't start too far in the future. This is done
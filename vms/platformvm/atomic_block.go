@@ -184,6 +184,7 @@ func (ab *AtomicBlock) Accept() error {
 			)
 		}
 	}
+	ab.vm.publishTx(&ab.Tx, false)
 
 	ab.free()
 	return nil
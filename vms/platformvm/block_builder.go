@@ -60,7 +60,7 @@ func (m *blockBuilder) Initialize(
 	m.toEngine = toEngine
 
 	m.vm.ctx.Log.Verbo("initializing platformVM mempool")
-	mempool, err := NewMempool("mempool", registerer)
+	mempool, err := NewMempool("mempool", registerer, vm.getTxFee)
 	if err != nil {
 		return err
 	}
@@ -103,6 +103,9 @@ func (m *blockBuilder) AddUnverifiedTx(tx *Tx) error {
 	}
 
 	preferredState := preferredDecision.onAccept()
+	if err := m.vm.verifyApricotPhase6(tx.UnsignedTx, preferredState.GetTimestamp()); err != nil {
+		return err
+	}
 	if err := tx.UnsignedTx.SemanticVerify(m.vm, preferredState, tx); err != nil {
 		m.MarkDropped(txID)
 		return err
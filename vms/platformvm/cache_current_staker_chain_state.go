@@ -40,6 +40,9 @@ type currentStakerChainState interface {
 		numTxsToRemove int,
 	) (currentStakerChainState, error)
 	DeleteNextStaker() (currentStakerChainState, error)
+	// DeleteSubnetValidator removes [addSubnetValidatorTx] from the current
+	// stakers before its end time.
+	DeleteSubnetValidator(addSubnetValidatorTx *Tx) (currentStakerChainState, error)
 
 	// Stakers returns the current stakers on the network sorted in order of the
 	// order of their future removal from the validator set.
@@ -267,6 +270,53 @@ func (cs *currentStakerChainStateImpl) DeleteNextStaker() (currentStakerChainSta
 	return newCS, nil
 }
 
+func (cs *currentStakerChainStateImpl) DeleteSubnetValidator(removedTx *Tx) (currentStakerChainState, error) {
+	tx, ok := removedTx.UnsignedTx.(*UnsignedAddSubnetValidatorTx)
+	if !ok {
+		return nil, errWrongTxType
+	}
+	removedTxID := removedTx.ID()
+	if _, exists := cs.validatorsByTxID[removedTxID]; !exists {
+		return nil, database.ErrNotFound
+	}
+
+	newCS := &currentStakerChainStateImpl{
+		validatorsByNodeID: make(map[ids.ShortID]*currentValidatorImpl, len(cs.validatorsByNodeID)),
+		validatorsByTxID:   make(map[ids.ID]*validatorReward, len(cs.validatorsByTxID)-1),
+		validators:         make([]*Tx, 0, len(cs.validators)-1), // sorted in order of removal
+
+		deletedStakers: []*Tx{removedTx},
+	}
+
+	for _, vdr := range cs.validators {
+		if vdr.ID() != removedTxID {
+			newCS.validators = append(newCS.validators, vdr)
+		}
+	}
+
+	for nodeID, vdr := range cs.validatorsByNodeID {
+		newCS.validatorsByNodeID[nodeID] = vdr
+	}
+	oldVdr := cs.validatorsByNodeID[tx.Validator.NodeID]
+	newVdr := *oldVdr
+	newVdr.subnets = make(map[ids.ID]*UnsignedAddSubnetValidatorTx, len(oldVdr.subnets)-1)
+	for subnetID, addTx := range oldVdr.subnets {
+		if subnetID != tx.Validator.Subnet {
+			newVdr.subnets[subnetID] = addTx
+		}
+	}
+	newCS.validatorsByNodeID[tx.Validator.NodeID] = &newVdr
+
+	for txID, vdr := range cs.validatorsByTxID {
+		if txID != removedTxID {
+			newCS.validatorsByTxID[txID] = vdr
+		}
+	}
+
+	newCS.setNextStaker()
+	return newCS, nil
+}
+
 func (cs *currentStakerChainStateImpl) Stakers() []*Tx {
 	return cs.validators
 }
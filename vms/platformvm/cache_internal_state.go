@@ -29,27 +29,32 @@ import (
 )
 
 var (
-	validatorsPrefix      = []byte("validators")
-	currentPrefix         = []byte("current")
-	pendingPrefix         = []byte("pending")
-	validatorPrefix       = []byte("validator")
-	delegatorPrefix       = []byte("delegator")
-	subnetValidatorPrefix = []byte("subnetValidator")
-	validatorDiffsPrefix  = []byte("validatorDiffs")
-	blockPrefix           = []byte("block")
-	txPrefix              = []byte("tx")
-	rewardUTXOsPrefix     = []byte("rewardUTXOs")
-	utxoPrefix            = []byte("utxo")
-	subnetPrefix          = []byte("subnet")
-	chainPrefix           = []byte("chain")
-	singletonPrefix       = []byte("singleton")
+	validatorsPrefix       = []byte("validators")
+	currentPrefix          = []byte("current")
+	pendingPrefix          = []byte("pending")
+	validatorPrefix        = []byte("validator")
+	delegatorPrefix        = []byte("delegator")
+	subnetValidatorPrefix  = []byte("subnetValidator")
+	validatorDiffsPrefix   = []byte("validatorDiffs")
+	stakerDiffsPrefix      = []byte("stakerDiffs")
+	blockPrefix            = []byte("block")
+	txPrefix               = []byte("tx")
+	rewardUTXOsPrefix      = []byte("rewardUTXOs")
+	utxoPrefix             = []byte("utxo")
+	subnetPrefix           = []byte("subnet")
+	subnetOwnerPrefix      = []byte("subnetOwner")
+	delegationPolicyPrefix = []byte("delegationPolicy")
+	chainPrefix            = []byte("chain")
+	singletonPrefix        = []byte("singleton")
 
 	timestampKey     = []byte("timestamp")
 	currentSupplyKey = []byte("current supply")
 	lastAcceptedKey  = []byte("last accepted")
 	initializedKey   = []byte("initialized")
+	stakerDiffsKey   = []byte("staker diffs")
 
 	errWrongNetworkID = errors.New("tx has wrong network ID")
+	errNotASubnet     = errors.New("not a subnet")
 
 	_ InternalState = &internalStateImpl{}
 )
@@ -62,12 +67,21 @@ const (
 	mediumPriority
 	topPriority
 
-	validatorDiffsCacheSize = 2048
-	blockCacheSize          = 2048
-	txCacheSize             = 2048
-	rewardUTXOsCacheSize    = 2048
-	chainCacheSize          = 2048
-	chainDBCacheSize        = 2048
+	validatorDiffsCacheSize   = 2048
+	blockCacheSize            = 2048
+	txCacheSize               = 2048
+	rewardUTXOsCacheSize      = 2048
+	chainCacheSize            = 2048
+	chainDBCacheSize          = 2048
+	subnetOwnerCacheSize      = 2048
+	delegationPolicyCacheSize = 2048
+
+	// Number of heights that the current stakers can be reconstructed at
+	// below the last accepted height. Older staker diffs are pruned.
+	defaultStakerDiffsRetention = 64 * 1024
+	// Maximum number of staker diffs pruned by a single commit, so that a
+	// database that kept a longer history is pruned gradually.
+	maxPrunedStakerDiffs = 64
 )
 
 type InternalState interface {
@@ -80,6 +94,12 @@ type InternalState interface {
 	AddCurrentStaker(tx *Tx, potentialReward uint64)
 	DeleteCurrentStaker(tx *Tx)
 	GetValidatorWeightDiffs(height uint64, subnetID ids.ID) (map[ids.ShortID]*ValidatorWeightDiff, error)
+	// GetStakerDiff returns the changes made to the current stakers at
+	// [height]
+	GetStakerDiff(height uint64) (*StakerDiff, error)
+	// GetStakerDiffsHeight returns the lowest height that the current stakers
+	// can be reconstructed at with the staker diffs
+	GetStakerDiffsHeight() uint64
 
 	AddPendingStaker(tx *Tx)
 	DeletePendingStaker(tx *Tx)
@@ -122,10 +142,13 @@ type InternalState interface {
  * | | '-. subnetValidator
  * | |   '-. list
  * | |     '-- txID -> nil
- * | '-. diffs
- * |   '-. height+subnet
- * |     '-. list
- * |       '-- nodeID -> weightChange
+ * | |-. diffs
+ * | | '-. height+subnet
+ * | |   '-. list
+ * | |     '-- nodeID -> weightChange
+ * | '-. stakerDiffs
+ * |   '-- height -> added and removed current stakers, for the last
+ * |       [stakerDiffsRetention] heights
  * |-. blocks
  * | '-- blockID -> block bytes
  * |-. txs
@@ -139,6 +162,10 @@ type InternalState interface {
  * |-. subnets
  * | '-. list
  * |   '-- txID -> nil
+ * |-. subnetOwners
+ * | '-- subnetID -> owner bytes
+ * |-. delegationPolicies
+ * | '-- validatorTxID -> delegation policy bytes
  * |-. chains
  * | '-. subnetID
  * |   '-. list
@@ -147,7 +174,8 @@ type InternalState interface {
  *   |-- initializedKey -> nil
  *   |-- timestampKey -> timestamp
  *   |-- currentSupplyKey -> currentSupply
- *   '-- lastAcceptedKey -> lastAccepted
+ *   |-- lastAcceptedKey -> lastAccepted
+ *   '-- stakerDiffsKey -> stakerDiffsHeight
  */
 type internalStateImpl struct {
 	vm *VM
@@ -184,6 +212,14 @@ type internalStateImpl struct {
 	validatorDiffsCache cache.Cacher // cache of heightWithSubnet -> map[ids.ShortID]*ValidatorWeightDiff
 	validatorDiffsDB    database.Database
 
+	stakerDiffsDB database.Database
+	// stakerDiffsHeight is the lowest height that the current stakers can be
+	// reconstructed at. It is persisted on the next commit if
+	// [stakerDiffsHeightModified] is true.
+	stakerDiffsHeight         uint64
+	stakerDiffsHeightModified bool
+	stakerDiffsRetention      uint64
+
 	addedBlocks map[ids.ID]Block // map of blockID -> Block
 	blockCache  cache.Cacher     // cache of blockID -> Block, if the entry is nil, it is not in the database
 	blockDB     database.Database
@@ -205,6 +241,14 @@ type internalStateImpl struct {
 	subnetBaseDB  database.Database
 	subnetDB      linkeddb.LinkedDB
 
+	modifiedSubnetOwners map[ids.ID]Owner // map of subnetID -> the owner the subnet was transferred to
+	subnetOwnerCache     cache.Cacher     // cache of subnetID -> Owner
+	subnetOwnerDB        database.Database
+
+	modifiedDelegationPolicies map[ids.ID]*DelegationPolicy // map of validatorTxID -> the policy the validator set
+	delegationPolicyCache      cache.Cacher                 // cache of validatorTxID -> *DelegationPolicy, if the entry is nil, it is not in the database
+	delegationPolicyDB         database.Database
+
 	addedChains  map[ids.ID][]*Tx // maps subnetID -> the newly added chains to the subnet
 	chainCache   cache.Cacher     // cache of subnetID -> the chains after all local modifications []*Tx
 	chainDBCache cache.Cacher     // cache of subnetID -> linkedDB
@@ -221,6 +265,19 @@ type ValidatorWeightDiff struct {
 	Amount   uint64 `serialize:"true"`
 }
 
+// StakerDiff is the set of stakers that were added to and removed from the
+// current stakers at a height
+type StakerDiff struct {
+	Added   []ids.ID        `serialize:"true"`
+	Removed []RemovedStaker `serialize:"true"`
+}
+
+// RemovedStaker is a staker that was removed from the current stakers
+type RemovedStaker struct {
+	TxID            ids.ID `serialize:"true"`
+	PotentialReward uint64 `serialize:"true"`
+}
+
 type heightWithSubnet struct {
 	Height   uint64 `serialize:"true"`
 	SubnetID ids.ID `serialize:"true"`
@@ -252,6 +309,7 @@ func newInternalStateDatabases(vm *VM, db database.Database) *internalStateImpl
 	pendingSubnetValidatorBaseDB := prefixdb.New(subnetValidatorPrefix, pendingValidatorsDB)
 
 	validatorDiffsDB := prefixdb.New(validatorDiffsPrefix, validatorsDB)
+	stakerDiffsDB := prefixdb.New(stakerDiffsPrefix, validatorsDB)
 
 	rewardUTXODB := prefixdb.New(rewardUTXOsPrefix, baseDB)
 	utxoDB := prefixdb.New(utxoPrefix, baseDB)
@@ -280,6 +338,8 @@ func newInternalStateDatabases(vm *VM, db database.Database) *internalStateImpl
 		pendingSubnetValidatorBaseDB: pendingSubnetValidatorBaseDB,
 		pendingSubnetValidatorList:   linkeddb.NewDefault(pendingSubnetValidatorBaseDB),
 		validatorDiffsDB:             validatorDiffsDB,
+		stakerDiffsDB:                stakerDiffsDB,
+		stakerDiffsRetention:         defaultStakerDiffsRetention,
 
 		addedBlocks: make(map[ids.ID]Block),
 		blockDB:     prefixdb.New(blockPrefix, baseDB),
@@ -296,6 +356,12 @@ func newInternalStateDatabases(vm *VM, db database.Database) *internalStateImpl
 		subnetBaseDB: subnetBaseDB,
 		subnetDB:     linkeddb.NewDefault(subnetBaseDB),
 
+		modifiedSubnetOwners: make(map[ids.ID]Owner),
+		subnetOwnerDB:        prefixdb.New(subnetOwnerPrefix, baseDB),
+
+		modifiedDelegationPolicies: make(map[ids.ID]*DelegationPolicy),
+		delegationPolicyDB:         prefixdb.New(delegationPolicyPrefix, baseDB),
+
 		addedChains: make(map[ids.ID][]*Tx),
 		chainDB:     prefixdb.New(chainPrefix, baseDB),
 
@@ -311,6 +377,8 @@ func (st *internalStateImpl) initCaches() {
 	st.utxoState = djtx.NewUTXOState(st.utxoDB, GenesisCodec)
 	st.chainCache = &cache.LRU{Size: chainCacheSize}
 	st.chainDBCache = &cache.LRU{Size: chainDBCacheSize}
+	st.subnetOwnerCache = &cache.LRU{Size: subnetOwnerCacheSize}
+	st.delegationPolicyCache = &cache.LRU{Size: delegationPolicyCacheSize}
 }
 
 func (st *internalStateImpl) initMeteredCaches(metrics prometheus.Registerer) error {
@@ -369,6 +437,24 @@ func (st *internalStateImpl) initMeteredCaches(metrics prometheus.Registerer) er
 		metrics,
 		&cache.LRU{Size: chainDBCacheSize},
 	)
+	if err != nil {
+		return err
+	}
+
+	subnetOwnerCache, err := metercacher.New(
+		"subnet_owner_cache",
+		metrics,
+		&cache.LRU{Size: subnetOwnerCacheSize},
+	)
+	if err != nil {
+		return err
+	}
+
+	delegationPolicyCache, err := metercacher.New(
+		"delegation_policy_cache",
+		metrics,
+		&cache.LRU{Size: delegationPolicyCacheSize},
+	)
 	st.validatorDiffsCache = validatorDiffsCache
 	st.blockCache = blockCache
 	st.txCache = txCache
@@ -376,6 +462,8 @@ func (st *internalStateImpl) initMeteredCaches(metrics prometheus.Registerer) er
 	st.utxoState = utxoState
 	st.chainCache = chainCache
 	st.chainDBCache = chainDBCache
+	st.subnetOwnerCache = subnetOwnerCache
+	st.delegationPolicyCache = delegationPolicyCache
 	return err
 }
 
@@ -484,6 +572,77 @@ func (st *internalStateImpl) AddSubnet(createSubnetTx *Tx) {
 	}
 }
 
+func (st *internalStateImpl) GetSubnetOwner(subnetID ids.ID) (Owner, error) {
+	if owner, exists := st.modifiedSubnetOwners[subnetID]; exists {
+		return owner, nil
+	}
+	if ownerIntf, cached := st.subnetOwnerCache.Get(subnetID); cached {
+		return ownerIntf.(Owner), nil
+	}
+
+	ownerBytes, err := st.subnetOwnerDB.Get(subnetID[:])
+	switch err {
+	case nil:
+		var owner Owner
+		if _, err := GenesisCodec.Unmarshal(ownerBytes, &owner); err != nil {
+			return nil, err
+		}
+		st.subnetOwnerCache.Put(subnetID, owner)
+		return owner, nil
+	case database.ErrNotFound:
+		// The ownership of the subnet was never transferred, so it's owned by
+		// the owner it was created with
+		subnetTx, _, err := st.GetTx(subnetID)
+		if err != nil {
+			return nil, err
+		}
+		subnet, ok := subnetTx.UnsignedTx.(*UnsignedCreateSubnetTx)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errNotASubnet, subnetID)
+		}
+		st.subnetOwnerCache.Put(subnetID, subnet.Owner)
+		return subnet.Owner, nil
+	default:
+		return nil, err
+	}
+}
+
+func (st *internalStateImpl) SetSubnetOwner(subnetID ids.ID, owner Owner) {
+	st.modifiedSubnetOwners[subnetID] = owner
+}
+
+func (st *internalStateImpl) GetDelegationPolicy(validatorTxID ids.ID) (*DelegationPolicy, error) {
+	if policy, exists := st.modifiedDelegationPolicies[validatorTxID]; exists {
+		return policy, nil
+	}
+	if policyIntf, cached := st.delegationPolicyCache.Get(validatorTxID); cached {
+		if policyIntf == nil {
+			return nil, database.ErrNotFound
+		}
+		return policyIntf.(*DelegationPolicy), nil
+	}
+
+	policyBytes, err := st.delegationPolicyDB.Get(validatorTxID[:])
+	if err == database.ErrNotFound {
+		st.delegationPolicyCache.Put(validatorTxID, nil)
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &DelegationPolicy{}
+	if _, err := GenesisCodec.Unmarshal(policyBytes, policy); err != nil {
+		return nil, err
+	}
+	st.delegationPolicyCache.Put(validatorTxID, policy)
+	return policy, nil
+}
+
+func (st *internalStateImpl) SetDelegationPolicy(validatorTxID ids.ID, policy *DelegationPolicy) {
+	st.modifiedDelegationPolicies[validatorTxID] = policy
+}
+
 func (st *internalStateImpl) GetChains(subnetID ids.ID) ([]*Tx, error) {
 	if chainsIntf, cached := st.chainCache.Get(subnetID); cached {
 		return chainsIntf.([]*Tx), nil
@@ -782,6 +941,40 @@ func (st *internalStateImpl) GetValidatorWeightDiffs(height uint64, subnetID ids
 	return weightDiffs, nil
 }
 
+func (st *internalStateImpl) GetStakerDiff(height uint64) (*StakerDiff, error) {
+	diffBytes, err := st.stakerDiffsDB.Get(database.PackUInt64(height))
+	if err == database.ErrNotFound {
+		// The current stakers weren't modified at this height
+		return &StakerDiff{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &StakerDiff{}
+	if _, err := GenesisCodec.Unmarshal(diffBytes, diff); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+func (st *internalStateImpl) GetStakerDiffsHeight() uint64 { return st.stakerDiffsHeight }
+
+// pruneStakerDiffs removes up to [maxPrunedStakerDiffs] of the staker diffs
+// that are older than [stakerDiffsRetention] heights below the current height
+func (st *internalStateImpl) pruneStakerDiffs() error {
+	for pruned := 0; pruned < maxPrunedStakerDiffs && st.stakerDiffsHeight+st.stakerDiffsRetention < st.currentHeight; pruned++ {
+		// The diff at [stakerDiffsHeight] isn't needed to reconstruct the
+		// current stakers at [stakerDiffsHeight] or above
+		if err := st.stakerDiffsDB.Delete(database.PackUInt64(st.stakerDiffsHeight)); err != nil {
+			return err
+		}
+		st.stakerDiffsHeight++
+		st.stakerDiffsHeightModified = true
+	}
+	return nil
+}
+
 func (st *internalStateImpl) Abort() {
 	st.baseDB.Abort()
 }
@@ -820,6 +1013,12 @@ func (st *internalStateImpl) CommitBatch() (database.Batch, error) {
 	if err := st.writeSubnets(); err != nil {
 		return nil, fmt.Errorf("failed to write current subnets with: %w", err)
 	}
+	if err := st.writeSubnetOwners(); err != nil {
+		return nil, fmt.Errorf("failed to write subnet owners with: %w", err)
+	}
+	if err := st.writeDelegationPolicies(); err != nil {
+		return nil, fmt.Errorf("failed to write delegation policies with: %w", err)
+	}
 	if err := st.writeChains(); err != nil {
 		return nil, fmt.Errorf("failed to write chains with: %w", err)
 	}
@@ -846,6 +1045,8 @@ func (st *internalStateImpl) Close() error {
 		st.rewardUTXODB.Close(),
 		st.utxoDB.Close(),
 		st.subnetBaseDB.Close(),
+		st.subnetOwnerDB.Close(),
+		st.delegationPolicyDB.Close(),
 		st.chainDB.Close(),
 		st.singletonDB.Close(),
 		st.baseDB.Close(),
@@ -864,9 +1065,11 @@ type currentValidatorState struct {
 
 func (st *internalStateImpl) writeCurrentStakers() error {
 	weightDiffs := make(map[ids.ID]map[ids.ShortID]*ValidatorWeightDiff) // subnetID -> nodeID -> weightDiff
+	stakerDiff := StakerDiff{}
 	for _, currentStaker := range st.addedCurrentStakers {
 		txID := currentStaker.addStakerTx.ID()
 		potentialReward := currentStaker.potentialReward
+		stakerDiff.Added = append(stakerDiff.Added, txID)
 
 		var (
 			subnetID ids.ID
@@ -939,16 +1142,21 @@ func (st *internalStateImpl) writeCurrentStakers() error {
 	st.addedCurrentStakers = nil
 
 	for _, tx := range st.deletedCurrentStakers {
+		txID := tx.ID()
 		var (
-			db       database.KeyValueDeleter
-			subnetID ids.ID
-			nodeID   ids.ShortID
-			weight   uint64
+			db              database.KeyValueDeleter
+			subnetID        ids.ID
+			nodeID          ids.ShortID
+			weight          uint64
+			potentialReward uint64
 		)
 		switch tx := tx.UnsignedTx.(type) {
 		case *UnsignedAddValidatorTx:
 			db = st.currentValidatorList
 
+			if vdr, exists := st.uptimes[tx.Validator.NodeID]; exists {
+				potentialReward = vdr.PotentialReward
+			}
 			delete(st.uptimes, tx.Validator.NodeID)
 			delete(st.updatedUptimes, tx.Validator.NodeID)
 
@@ -958,6 +1166,12 @@ func (st *internalStateImpl) writeCurrentStakers() error {
 		case *UnsignedAddDelegatorTx:
 			db = st.currentDelegatorList
 
+			reward, err := database.GetUInt64(st.currentDelegatorList, txID[:])
+			if err != nil {
+				return err
+			}
+			potentialReward = reward
+
 			subnetID = constants.PrimaryNetworkID
 			nodeID = tx.Validator.NodeID
 			weight = tx.Validator.Wght
@@ -971,10 +1185,13 @@ func (st *internalStateImpl) writeCurrentStakers() error {
 			return errWrongTxType
 		}
 
-		txID := tx.ID()
 		if err := db.Delete(txID[:]); err != nil {
 			return err
 		}
+		stakerDiff.Removed = append(stakerDiff.Removed, RemovedStaker{
+			TxID:            txID,
+			PotentialReward: potentialReward,
+		})
 
 		subnetDiffs, ok := weightDiffs[subnetID]
 		if !ok {
@@ -1001,6 +1218,19 @@ func (st *internalStateImpl) writeCurrentStakers() error {
 	}
 	st.deletedCurrentStakers = nil
 
+	if len(stakerDiff.Added) > 0 || len(stakerDiff.Removed) > 0 {
+		stakerDiffBytes, err := GenesisCodec.Marshal(CodecVersion, &stakerDiff)
+		if err != nil {
+			return err
+		}
+		if err := st.stakerDiffsDB.Put(database.PackUInt64(st.currentHeight), stakerDiffBytes); err != nil {
+			return err
+		}
+	}
+	if err := st.pruneStakerDiffs(); err != nil {
+		return err
+	}
+
 	for subnetID, nodeUpdates := range weightDiffs {
 		prefixStruct := heightWithSubnet{
 			Height:   st.currentHeight,
@@ -1213,6 +1443,42 @@ func (st *internalStateImpl) writeSubnets() error {
 	return nil
 }
 
+func (st *internalStateImpl) writeSubnetOwners() error {
+	for subnetID, owner := range st.modifiedSubnetOwners {
+		ownerBytes, err := GenesisCodec.Marshal(CodecVersion, &owner)
+		if err != nil {
+			return err
+		}
+
+		// Copy so value passed into [Put] doesn't get overwritten next iteration
+		subnetID := subnetID
+		if err := st.subnetOwnerDB.Put(subnetID[:], ownerBytes); err != nil {
+			return err
+		}
+		st.subnetOwnerCache.Put(subnetID, owner)
+		delete(st.modifiedSubnetOwners, subnetID)
+	}
+	return nil
+}
+
+func (st *internalStateImpl) writeDelegationPolicies() error {
+	for validatorTxID, policy := range st.modifiedDelegationPolicies {
+		policyBytes, err := GenesisCodec.Marshal(CodecVersion, policy)
+		if err != nil {
+			return err
+		}
+
+		// Copy so value passed into [Put] doesn't get overwritten next iteration
+		validatorTxID := validatorTxID
+		if err := st.delegationPolicyDB.Put(validatorTxID[:], policyBytes); err != nil {
+			return err
+		}
+		st.delegationPolicyCache.Put(validatorTxID, policy)
+		delete(st.modifiedDelegationPolicies, validatorTxID)
+	}
+	return nil
+}
+
 func (st *internalStateImpl) writeChains() error {
 	for subnetID, chains := range st.addedChains {
 		for _, chain := range chains {
@@ -1247,6 +1513,12 @@ func (st *internalStateImpl) writeSingletons() error {
 		}
 		st.originalLastAccepted = st.lastAccepted
 	}
+	if st.stakerDiffsHeightModified {
+		if err := database.PutUInt64(st.singletonDB, stakerDiffsKey, st.stakerDiffsHeight); err != nil {
+			return err
+		}
+		st.stakerDiffsHeightModified = false
+	}
 	return nil
 }
 
@@ -1282,6 +1554,21 @@ func (st *internalStateImpl) loadSingletons() error {
 	st.originalLastAccepted = lastAccepted
 	st.lastAccepted = lastAccepted
 
+	stakerDiffsHeight, err := database.GetUInt64(st.singletonDB, stakerDiffsKey)
+	if err == database.ErrNotFound {
+		// The staker diffs weren't tracked before the last accepted block, so
+		// the current stakers can't be reconstructed at earlier heights.
+		lastAcceptedBlk, err := st.GetBlock(lastAccepted)
+		if err != nil {
+			return err
+		}
+		stakerDiffsHeight = lastAcceptedBlk.Height()
+		st.stakerDiffsHeightModified = true
+	} else if err != nil {
+		return err
+	}
+	st.stakerDiffsHeight = stakerDiffsHeight
+
 	return nil
 }
 
@@ -1606,6 +1893,9 @@ func (st *internalStateImpl) init(genesisBytes []byte) error {
 	if err := st.singletonDB.Put(initializedKey, nil); err != nil {
 		return err
 	}
+	// The staker diffs are tracked since genesis
+	st.stakerDiffsHeight = 0
+	st.stakerDiffsHeightModified = true
 
 	return st.Commit()
 }
@@ -23,6 +23,9 @@ type pendingStakerChainState interface {
 
 	AddStaker(addStakerTx *Tx) pendingStakerChainState
 	DeleteStakers(numToRemove int) pendingStakerChainState
+	// DeleteSubnetValidator removes [addSubnetValidatorTx] from the pending
+	// stakers before its start time.
+	DeleteSubnetValidator(addSubnetValidatorTx *Tx) (pendingStakerChainState, error)
 
 	// Stakers returns the list of pending validators in order of their removal
 	// from the pending staker set
@@ -194,6 +197,52 @@ func (ps *pendingStakerChainStateImpl) DeleteStakers(numToRemove int) pendingSta
 	return newPS
 }
 
+func (ps *pendingStakerChainStateImpl) DeleteSubnetValidator(removedTx *Tx) (pendingStakerChainState, error) {
+	tx, ok := removedTx.UnsignedTx.(*UnsignedAddSubnetValidatorTx)
+	if !ok {
+		return nil, errWrongTxType
+	}
+	removedTxID := removedTx.ID()
+
+	newPS := &pendingStakerChainStateImpl{
+		validatorsByNodeID:      ps.validatorsByNodeID,
+		validatorExtrasByNodeID: make(map[ids.ShortID]*validatorImpl, len(ps.validatorExtrasByNodeID)),
+		validators:              make([]*Tx, 0, len(ps.validators)),
+
+		deletedStakers: []*Tx{removedTx},
+	}
+
+	for _, vdr := range ps.validators {
+		if vdr.ID() != removedTxID {
+			newPS.validators = append(newPS.validators, vdr)
+		}
+	}
+	if len(newPS.validators) == len(ps.validators) {
+		return nil, database.ErrNotFound
+	}
+
+	for nodeID, vdr := range ps.validatorExtrasByNodeID {
+		if nodeID != tx.Validator.NodeID {
+			newPS.validatorExtrasByNodeID[nodeID] = vdr
+		}
+	}
+	vdr := ps.validatorExtrasByNodeID[tx.Validator.NodeID]
+	if len(vdr.delegators) == 0 && len(vdr.subnets) == 1 {
+		return newPS, nil
+	}
+	newSubnets := make(map[ids.ID]*UnsignedAddSubnetValidatorTx, len(vdr.subnets)-1)
+	for subnetID, subnetTx := range vdr.subnets {
+		if subnetID != tx.Validator.Subnet {
+			newSubnets[subnetID] = subnetTx
+		}
+	}
+	newPS.validatorExtrasByNodeID[tx.Validator.NodeID] = &validatorImpl{
+		delegators: vdr.delegators,
+		subnets:    newSubnets,
+	}
+	return newPS, nil
+}
+
 func (ps *pendingStakerChainStateImpl) Stakers() []*Tx {
 	return ps.validators
 }
@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/lasthyphen/dijetsgo/database"
@@ -48,6 +49,17 @@ type MutableState interface {
 	GetSubnets() ([]*Tx, error)
 	AddSubnet(createSubnetTx *Tx)
 
+	// GetSubnetOwner returns the owner that is currently authorized to manage
+	// [subnetID]
+	GetSubnetOwner(subnetID ids.ID) (Owner, error)
+	SetSubnetOwner(subnetID ids.ID, owner Owner)
+
+	// GetDelegationPolicy returns the delegation policy of the validator added
+	// by [validatorTxID]. Returns database.ErrNotFound if the validator never
+	// set a policy.
+	GetDelegationPolicy(validatorTxID ids.ID) (*DelegationPolicy, error)
+	SetDelegationPolicy(validatorTxID ids.ID, policy *DelegationPolicy)
+
 	GetChains(subnetID ids.ID) ([]*Tx, error)
 	AddChain(createChainTx *Tx)
 
@@ -58,6 +70,12 @@ type MutableState interface {
 type VersionedState interface {
 	MutableState
 
+	// SetCurrentStakerChainState and SetPendingStakerChainState replace the
+	// staker sets of this state. The changes of each staker set that was set
+	// are applied in order.
+	SetCurrentStakerChainState(currentStakerChainState)
+	SetPendingStakerChainState(pendingStakerChainState)
+
 	SetBase(MutableState)
 	Apply(InternalState)
 }
@@ -68,6 +86,12 @@ type versionedStateImpl struct {
 	currentStakerChainState currentStakerChainState
 	pendingStakerChainState pendingStakerChainState
 
+	// staker sets that were replaced by SetCurrentStakerChainState and
+	// SetPendingStakerChainState, whose changes must be applied before the
+	// changes of the current ones
+	replacedCurrentStakerChainStates []currentStakerChainState
+	replacedPendingStakerChainStates []pendingStakerChainState
+
 	timestamp time.Time
 
 	currentSupply uint64
@@ -75,6 +99,12 @@ type versionedStateImpl struct {
 	addedSubnets  []*Tx
 	cachedSubnets []*Tx
 
+	// map of subnetID -> the owner the subnet was transferred to
+	modifiedSubnetOwners map[ids.ID]Owner
+
+	// map of validatorTxID -> the policy the validator set
+	modifiedDelegationPolicies map[ids.ID]*DelegationPolicy
+
 	addedChains  map[ids.ID][]*Tx
 	cachedChains map[ids.ID][]*Tx
 
@@ -155,6 +185,50 @@ func (vs *versionedStateImpl) AddSubnet(createSubnetTx *Tx) {
 	}
 }
 
+func (vs *versionedStateImpl) GetSubnetOwner(subnetID ids.ID) (Owner, error) {
+	if owner, modified := vs.modifiedSubnetOwners[subnetID]; modified {
+		return owner, nil
+	}
+	for _, subnetTx := range vs.addedSubnets {
+		if subnetTx.ID() != subnetID {
+			continue
+		}
+		subnet, ok := subnetTx.UnsignedTx.(*UnsignedCreateSubnetTx)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errNotASubnet, subnetID)
+		}
+		return subnet.Owner, nil
+	}
+	return vs.parentState.GetSubnetOwner(subnetID)
+}
+
+func (vs *versionedStateImpl) SetSubnetOwner(subnetID ids.ID, owner Owner) {
+	if vs.modifiedSubnetOwners == nil {
+		vs.modifiedSubnetOwners = map[ids.ID]Owner{
+			subnetID: owner,
+		}
+	} else {
+		vs.modifiedSubnetOwners[subnetID] = owner
+	}
+}
+
+func (vs *versionedStateImpl) GetDelegationPolicy(validatorTxID ids.ID) (*DelegationPolicy, error) {
+	if policy, modified := vs.modifiedDelegationPolicies[validatorTxID]; modified {
+		return policy, nil
+	}
+	return vs.parentState.GetDelegationPolicy(validatorTxID)
+}
+
+func (vs *versionedStateImpl) SetDelegationPolicy(validatorTxID ids.ID, policy *DelegationPolicy) {
+	if vs.modifiedDelegationPolicies == nil {
+		vs.modifiedDelegationPolicies = map[ids.ID]*DelegationPolicy{
+			validatorTxID: policy,
+		}
+	} else {
+		vs.modifiedDelegationPolicies[validatorTxID] = policy
+	}
+}
+
 func (vs *versionedStateImpl) GetChains(subnetID ids.ID) ([]*Tx, error) {
 	if len(vs.addedChains) == 0 {
 		// No chains have been added
@@ -293,6 +367,16 @@ func (vs *versionedStateImpl) PendingStakerChainState() pendingStakerChainState
 	return vs.pendingStakerChainState
 }
 
+func (vs *versionedStateImpl) SetCurrentStakerChainState(cs currentStakerChainState) {
+	vs.replacedCurrentStakerChainStates = append(vs.replacedCurrentStakerChainStates, vs.currentStakerChainState)
+	vs.currentStakerChainState = cs
+}
+
+func (vs *versionedStateImpl) SetPendingStakerChainState(ps pendingStakerChainState) {
+	vs.replacedPendingStakerChainStates = append(vs.replacedPendingStakerChainStates, vs.pendingStakerChainState)
+	vs.pendingStakerChainState = ps
+}
+
 func (vs *versionedStateImpl) SetBase(parentState MutableState) {
 	vs.parentState = parentState
 }
@@ -303,6 +387,12 @@ func (vs *versionedStateImpl) Apply(is InternalState) {
 	for _, subnet := range vs.addedSubnets {
 		is.AddSubnet(subnet)
 	}
+	for subnetID, owner := range vs.modifiedSubnetOwners {
+		is.SetSubnetOwner(subnetID, owner)
+	}
+	for validatorTxID, policy := range vs.modifiedDelegationPolicies {
+		is.SetDelegationPolicy(validatorTxID, policy)
+	}
 	for _, chains := range vs.addedChains {
 		for _, chain := range chains {
 			is.AddChain(chain)
@@ -323,6 +413,12 @@ func (vs *versionedStateImpl) Apply(is InternalState) {
 			is.DeleteUTXO(utxo.utxoID)
 		}
 	}
+	for _, cs := range vs.replacedCurrentStakerChainStates {
+		cs.Apply(is)
+	}
 	vs.currentStakerChainState.Apply(is)
+	for _, ps := range vs.replacedPendingStakerChainStates {
+		ps.Apply(is)
+	}
 	vs.pendingStakerChainState.Apply(is)
 }
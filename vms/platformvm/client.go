@@ -58,10 +58,18 @@ type Client interface {
 	GetStakingAssetID(context.Context, ids.ID) (ids.ID, error)
 	// GetCurrentValidators returns the list of current validators for subnet with ID [subnetID]
 	GetCurrentValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.ShortID) ([]interface{}, error)
+	// GetCurrentValidatorsAt returns the list of validators for subnet with ID
+	// [subnetID] as of the block at [height]
+	GetCurrentValidatorsAt(ctx context.Context, subnetID ids.ID, nodeIDs []ids.ShortID, height uint64) ([]interface{}, error)
 	// GetPendingValidators returns the list of pending validators for subnet with ID [subnetID]
 	GetPendingValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.ShortID) ([]interface{}, []interface{}, error)
 	// GetCurrentSupply returns an upper bound on the supply of DJTX in the system
 	GetCurrentSupply(ctx context.Context) (uint64, error)
+	// EstimateReward returns the reward for staking [amount] for [duration] if
+	// the staker started staking now
+	EstimateReward(ctx context.Context, amount uint64, duration time.Duration, delegationFeeRate float32) (*EstimateRewardReply, error)
+	// GetSupplyProjection projects the supply of DJTX until [endTime]
+	GetSupplyProjection(ctx context.Context, endTime uint64, restake bool) ([]SupplyPoint, error)
 	// SampleValidators returns the nodeIDs of a sample of [sampleSize] validators from the current validator set for subnet with ID [subnetID]
 	SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16) ([]string, error)
 	// AddValidator issues a transaction to add a validator to the primary network
@@ -113,6 +121,41 @@ type Client interface {
 		controlKeys []string,
 		threshold uint32,
 	) (ids.ID, error)
+	// TransferSubnetOwnership issues a transaction to transfer the ownership
+	// of subnet [subnetID] to [controlKeys] and returns the txID
+	TransferSubnetOwnership(
+		ctx context.Context,
+		user api.UserPass,
+		from []string,
+		changeAddr string,
+		subnetID string,
+		controlKeys []string,
+		threshold uint32,
+	) (ids.ID, error)
+	// RemoveSubnetValidator issues a transaction to remove validator [nodeID]
+	// from subnet with ID [subnetID] and returns the txID
+	RemoveSubnetValidator(
+		ctx context.Context,
+		user api.UserPass,
+		from []string,
+		changeAddr string,
+		subnetID,
+		nodeID string,
+	) (ids.ID, error)
+	// SetDelegationPolicy issues a transaction to restrict who can delegate to
+	// the validator added by [validatorTxID] and returns the txID. An empty
+	// [allowedAddrs] allows anyone to delegate, and a 0 [maxDelegators] or
+	// [maxDelegatorStake] means there is no limit.
+	SetDelegationPolicy(
+		ctx context.Context,
+		user api.UserPass,
+		from []string,
+		changeAddr string,
+		validatorTxID ids.ID,
+		allowedAddrs []string,
+		maxDelegators uint32,
+		maxDelegatorStake uint64,
+	) (ids.ID, error)
 	// ExportDJTX issues an ExportTx transaction and returns the txID
 	ExportDJTX(
 		ctx context.Context,
@@ -175,6 +218,11 @@ type Client interface {
 	GetRewardUTXOs(context.Context, *api.GetTxArgs) ([][]byte, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context) (time.Time, error)
+	// GetMempool returns the txs in the mempool along with the fees they pay
+	GetMempool(ctx context.Context) (*GetMempoolReply, error)
+	// SimulateTx verifies the signed or unsigned staker tx [txBytes] against
+	// the current chain state without issuing it
+	SimulateTx(ctx context.Context, txBytes []byte) (*SimulateTxReply, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided subnet
 	// at the specified height.
 	GetValidatorsAt(ctx context.Context, subnetID ids.ID, height uint64) (map[string]uint64, error)
@@ -298,6 +346,21 @@ func (c *client) GetCurrentValidators(ctx context.Context, subnetID ids.ID, node
 	return res.Validators, err
 }
 
+func (c *client) GetCurrentValidatorsAt(ctx context.Context, subnetID ids.ID, nodeIDs []ids.ShortID, height uint64) ([]interface{}, error) {
+	nodeIDsStr := []string{}
+	for _, nodeID := range nodeIDs {
+		nodeIDsStr = append(nodeIDsStr, nodeID.PrefixedString(constants.NodeIDPrefix))
+	}
+	jsonHeight := json.Uint64(height)
+	res := &GetCurrentValidatorsReply{}
+	err := c.requester.SendRequest(ctx, "getCurrentValidators", &GetCurrentValidatorsArgs{
+		SubnetID: subnetID,
+		NodeIDs:  nodeIDsStr,
+		Height:   &jsonHeight,
+	}, res)
+	return res.Validators, err
+}
+
 func (c *client) GetPendingValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.ShortID) ([]interface{}, []interface{}, error) {
 	nodeIDsStr := []string{}
 	for _, nodeID := range nodeIDs {
@@ -317,6 +380,25 @@ func (c *client) GetCurrentSupply(ctx context.Context) (uint64, error) {
 	return uint64(res.Supply), err
 }
 
+func (c *client) EstimateReward(ctx context.Context, amount uint64, duration time.Duration, delegationFeeRate float32) (*EstimateRewardReply, error) {
+	res := &EstimateRewardReply{}
+	err := c.requester.SendRequest(ctx, "estimateReward", &EstimateRewardArgs{
+		Amount:            json.Uint64(amount),
+		Duration:          json.Uint64(duration / time.Second),
+		DelegationFeeRate: json.Float32(delegationFeeRate),
+	}, res)
+	return res, err
+}
+
+func (c *client) GetSupplyProjection(ctx context.Context, endTime uint64, restake bool) ([]SupplyPoint, error) {
+	res := &GetSupplyProjectionReply{}
+	err := c.requester.SendRequest(ctx, "getSupplyProjection", &GetSupplyProjectionArgs{
+		EndTime: json.Uint64(endTime),
+		Restake: restake,
+	}, res)
+	return res.Supply, err
+}
+
 func (c *client) SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16) ([]string, error) {
 	res := &SampleValidatorsReply{}
 	err := c.requester.SendRequest(ctx, "sampleValidators", &SampleValidatorsArgs{
@@ -439,6 +521,77 @@ func (c *client) CreateSubnet(
 	return res.TxID, err
 }
 
+func (c *client) TransferSubnetOwnership(
+	ctx context.Context,
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	subnetID string,
+	controlKeys []string,
+	threshold uint32,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "transferSubnetOwnership", &TransferSubnetOwnershipArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		SubnetID:    subnetID,
+		ControlKeys: controlKeys,
+		Threshold:   json.Uint32(threshold),
+	}, res)
+	return res.TxID, err
+}
+
+func (c *client) RemoveSubnetValidator(
+	ctx context.Context,
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	subnetID,
+	nodeID string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "removeSubnetValidator", &RemoveSubnetValidatorArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		NodeID:   nodeID,
+		SubnetID: subnetID,
+	}, res)
+	return res.TxID, err
+}
+
+func (c *client) SetDelegationPolicy(
+	ctx context.Context,
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	validatorTxID ids.ID,
+	allowedAddrs []string,
+	maxDelegators uint32,
+	maxDelegatorStake uint64,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "setDelegationPolicy", &SetDelegationPolicyArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		ValidatorTxID: validatorTxID,
+		APIDelegationPolicy: APIDelegationPolicy{
+			AllowedAddresses:  allowedAddrs,
+			MaxDelegators:     json.Uint32(maxDelegators),
+			MaxDelegatorStake: json.Uint64(maxDelegatorStake),
+		},
+	}, res)
+	return res.TxID, err
+}
+
 func (c *client) ExportDJTX(
 	ctx context.Context,
 	user api.UserPass,
@@ -654,6 +807,26 @@ func (c *client) GetTimestamp(ctx context.Context) (time.Time, error) {
 	return res.Timestamp, err
 }
 
+func (c *client) GetMempool(ctx context.Context) (*GetMempoolReply, error) {
+	res := &GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "getMempool", struct{}{}, res)
+	return res, err
+}
+
+func (c *client) SimulateTx(ctx context.Context, txBytes []byte) (*SimulateTxReply, error) {
+	txStr, err := formatting.EncodeWithChecksum(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SimulateTxReply{}
+	err = c.requester.SendRequest(ctx, "simulateTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res)
+	return res, err
+}
+
 func (c *client) GetValidatorsAt(ctx context.Context, subnetID ids.ID, height uint64) (map[string]uint64, error) {
 	res := &GetValidatorsAtReply{}
 	err := c.requester.SendRequest(ctx, "getValidatorsAt", &GetValidatorsAtArgs{
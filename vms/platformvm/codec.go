@@ -9,7 +9,9 @@ import (
 	"github.com/lasthyphen/dijetsgo/codec"
 	"github.com/lasthyphen/dijetsgo/codec/linearcodec"
 	"github.com/lasthyphen/dijetsgo/codec/reflectcodec"
+	"github.com/lasthyphen/dijetsgo/codec/schema"
 	"github.com/lasthyphen/dijetsgo/utils/wrappers"
+	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
 	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
 )
 
@@ -22,10 +24,14 @@ const (
 var (
 	Codec        codec.Manager
 	GenesisCodec codec.Manager
+
+	// codecTypes reports the types registered in Codec
+	codecTypes codec.Introspector
 )
 
 func init() {
 	c := linearcodec.NewDefault()
+	codecTypes = c
 	Codec = codec.NewDefaultManager()
 	gc := linearcodec.New(reflectcodec.DefaultTagName, math.MaxInt32)
 	GenesisCodec = codec.NewManager(math.MaxInt32)
@@ -65,6 +71,11 @@ func init() {
 
 			c.RegisterType(&StakeableLockIn{}),
 			c.RegisterType(&StakeableLockOut{}),
+
+			c.RegisterType(&UnsignedTransferSubnetOwnershipTx{}),
+			c.RegisterType(&UnsignedRemoveSubnetValidatorTx{}),
+
+			c.RegisterType(&UnsignedSetDelegationPolicyTx{}),
 		)
 	}
 	errs.Add(
@@ -75,3 +86,8 @@ func init() {
 		panic(errs.Err)
 	}
 }
+
+// CodecSchema returns the schema of Codec
+func CodecSchema() (*schema.Schema, error) {
+	return schema.New(codecTypes, reflectcodec.DefaultTagName, &Tx{}, &djtx.UTXO{})
+}
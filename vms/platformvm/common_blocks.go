@@ -319,6 +319,10 @@ func (ddb *DoubleDecisionBlock) Accept() error {
 		}
 	}
 
+	// The parent's proposal was rejected if this is an abort block
+	_, aborted := ddb.self.(*AbortBlock)
+	ddb.vm.publishTx(&parent.Tx, aborted)
+
 	// remove this block and its parent from memory
 	parent.free()
 	ddb.free()
@@ -131,25 +131,23 @@ func (tx *UnsignedCreateChainTx) Execute(
 	// Verify the flowcheck
 	timestamp := vs.GetTimestamp()
 	createBlockchainTxFee := vm.getCreateBlockchainTxFee(timestamp)
-	if err := vm.semanticVerifySpend(vs, tx, tx.Ins, tx.Outs, baseTxCreds, createBlockchainTxFee, vm.ctx.DJTXAssetID); err != nil {
+	if err := vm.semanticVerifySpend(vm.fx, vs, tx, tx.Ins, tx.Outs, baseTxCreds, createBlockchainTxFee, vm.ctx.DJTXAssetID); err != nil {
 		return nil, err
 	}
 
-	subnetIntf, _, err := vs.GetTx(tx.SubnetID)
+	subnetOwner, err := vs.GetSubnetOwner(tx.SubnetID)
 	if err == database.ErrNotFound {
 		return nil, fmt.Errorf("%s isn't a known subnet", tx.SubnetID)
 	}
+	if errors.Is(err, errNotASubnet) {
+		return nil, fmt.Errorf("%s isn't a subnet", tx.SubnetID)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	subnet, ok := subnetIntf.UnsignedTx.(*UnsignedCreateSubnetTx)
-	if !ok {
-		return nil, fmt.Errorf("%s isn't a subnet", tx.SubnetID)
-	}
-
 	// Verify that this chain is authorized by the subnet
-	if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnet.Owner); err != nil {
+	if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnetOwner); err != nil {
 		return nil, err
 	}
 
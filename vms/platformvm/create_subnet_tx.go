@@ -88,7 +88,7 @@ func (tx *UnsignedCreateSubnetTx) Execute(
 	// Verify the flowcheck
 	timestamp := vs.GetTimestamp()
 	createSubnetTxFee := vm.getCreateSubnetTxFee(timestamp)
-	if err := vm.semanticVerifySpend(vs, tx, tx.Ins, tx.Outs, stx.Creds, createSubnetTxFee, vm.ctx.DJTXAssetID); err != nil {
+	if err := vm.semanticVerifySpend(vm.fx, vs, tx, tx.Ins, tx.Outs, stx.Creds, createSubnetTxFee, vm.ctx.DJTXAssetID); err != nil {
 		return nil, err
 	}
 
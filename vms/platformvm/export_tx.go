@@ -109,7 +109,7 @@ func (tx *UnsignedExportTx) Execute(
 	}
 
 	// Verify the flowcheck
-	if err := vm.semanticVerifySpend(vs, tx, tx.Ins, outs, stx.Creds, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
+	if err := vm.semanticVerifySpend(vm.fx, vs, tx, tx.Ins, outs, stx.Creds, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
 		return nil, fmt.Errorf("failed semanticVerifySpend: %w", err)
 	}
 
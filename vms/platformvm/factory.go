@@ -25,6 +25,9 @@ type Factory struct {
 	// Provides access to the uptime manager as a thread safe data structure
 	UptimeLockedCalculator uptime.LockedCalculator
 
+	// If non-nil, serves the platform API over gRPC once the chain is running
+	GRPCService *GRPCService
+
 	// True if the node is being run with staking enabled
 	StakingEnabled bool
 
@@ -78,6 +81,9 @@ type Factory struct {
 
 	// Time of the AP5 network upgrade
 	ApricotPhase5Time time.Time
+
+	// Time of the AP6 network upgrade
+	ApricotPhase6Time time.Time
 }
 
 // New returns a new instance of the Platform Chain
@@ -0,0 +1,505 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/lasthyphen/dijetsgo/api/proto/platformproto"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+)
+
+var (
+	errGRPCNotReady = status.Error(codes.Unavailable, "API call rejected because chain is not done bootstrapping")
+
+	_ platformproto.PlatformServer = &GRPCService{}
+)
+
+// GRPCService serves the read-only platform API over gRPC. It is created
+// before the platform chain exists, and rejects calls until the chain's VM has
+// been set and has finished bootstrapping.
+type GRPCService struct {
+	platformproto.UnimplementedPlatformServer
+
+	lock           sync.RWMutex
+	isBootstrapped *utils.AtomicBool
+	vmLock         sync.Locker
+	service        *Service
+}
+
+func NewGRPCService() *GRPCService {
+	return &GRPCService{}
+}
+
+func (s *GRPCService) setVM(isBootstrapped *utils.AtomicBool, lock sync.Locker, vm *VM) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.isBootstrapped = isBootstrapped
+	s.vmLock = lock
+	s.service = &Service{vm: vm}
+}
+
+// acquire returns the platform API service with the VM's lock held. The
+// returned function releases the lock.
+func (s *GRPCService) acquire() (*Service, func(), error) {
+	s.lock.RLock()
+	isBootstrapped := s.isBootstrapped
+	vmLock := s.vmLock
+	service := s.service
+	s.lock.RUnlock()
+
+	if service == nil || !isBootstrapped.GetValue() {
+		return nil, nil, errGRPCNotReady
+	}
+	vmLock.Lock()
+	return service, vmLock.Unlock, nil
+}
+
+func (s *GRPCService) GetHeight(context.Context, *emptypb.Empty) (*platformproto.GetHeightResponse, error) {
+	service, unlock, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	reply := GetHeightResponse{}
+	if err := service.GetHeight(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &platformproto.GetHeightResponse{
+		Height: uint64(reply.Height),
+	}, nil
+}
+
+func (s *GRPCService) GetTimestamp(context.Context, *emptypb.Empty) (*platformproto.GetTimestampResponse, error) {
+	service, unlock, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	reply := GetTimestampReply{}
+	if err := service.GetTimestamp(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &platformproto.GetTimestampResponse{
+		Timestamp: timestamppb.New(reply.Timestamp),
+	}, nil
+}
+
+func (s *GRPCService) GetCurrentSupply(context.Context, *emptypb.Empty) (*platformproto.GetCurrentSupplyResponse, error) {
+	service, unlock, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	reply := GetCurrentSupplyReply{}
+	if err := service.GetCurrentSupply(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &platformproto.GetCurrentSupplyResponse{
+		Supply: uint64(reply.Supply),
+	}, nil
+}
+
+func (s *GRPCService) GetMinStake(context.Context, *emptypb.Empty) (*platformproto.GetMinStakeResponse, error) {
+	service, unlock, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	reply := GetMinStakeReply{}
+	if err := service.GetMinStake(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &platformproto.GetMinStakeResponse{
+		MinValidatorStake: uint64(reply.MinValidatorStake),
+		MinDelegatorStake: uint64(reply.MinDelegatorStake),
+	}, nil
+}
+
+func (s *GRPCService) GetTotalStake(context.Context, *emptypb.Empty) (*platformproto.GetTotalStakeResponse, error) {
+	service, unlock, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	reply := GetTotalStakeReply{}
+	if err := service.GetTotalStake(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &platformproto.GetTotalStakeResponse{
+		Stake: uint64(reply.Stake),
+	}, nil
+}
+
+func (s *GRPCService) GetStakingAssetID(_ context.Context, req *platformproto.GetStakingAssetIDRequest) (*platformproto.GetStakingAssetIDResponse, error) {
+	subnetID, err := parseSubnetID(req.SubnetId)
+	if err != nil {
+		return nil, err
+	}
+
+	service, unlock, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	reply := GetStakingAssetIDResponse{}
+	if err := service.GetStakingAssetID(nil, &GetStakingAssetIDArgs{SubnetID: subnetID}, &reply); err != nil {
+		return nil, err
+	}
+	return &platformproto.GetStakingAssetIDResponse{
+		AssetId: reply.AssetID[:],
+	}, nil
+}
+
+func (s *GRPCService) GetSubnets(_ context.Context, req *platformproto.GetSubnetsRequest) (*platformproto.GetSubnetsResponse, error) {
+	args := GetSubnetsArgs{
+		IDs: make([]ids.ID, len(req.Ids)),
+	}
+	for i, subnetIDBytes := range req.Ids {
+		subnetID, err := ids.ToID(subnetIDBytes)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		args.IDs[i] = subnetID
+	}
+
+	service, unlock, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	reply := GetSubnetsResponse{}
+	if err := service.GetSubnets(nil, &args, &reply); err != nil {
+		return nil, err
+	}
+
+	subnets := make([]*platformproto.Subnet, len(reply.Subnets))
+	for i, subnet := range reply.Subnets {
+		subnets[i] = &platformproto.Subnet{
+			Id:          idBytes(subnet.ID),
+			ControlKeys: subnet.ControlKeys,
+			Threshold:   uint32(subnet.Threshold),
+		}
+	}
+	return &platformproto.GetSubnetsResponse{
+		Subnets: subnets,
+	}, nil
+}
+
+func (s *GRPCService) GetBlockchains(context.Context, *emptypb.Empty) (*platformproto.GetBlockchainsResponse, error) {
+	service, unlock, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	reply := GetBlockchainsResponse{}
+	if err := service.GetBlockchains(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+
+	blockchains := make([]*platformproto.Blockchain, len(reply.Blockchains))
+	for i, blockchain := range reply.Blockchains {
+		blockchains[i] = &platformproto.Blockchain{
+			Id:       idBytes(blockchain.ID),
+			Name:     blockchain.Name,
+			SubnetId: idBytes(blockchain.SubnetID),
+			VmId:     idBytes(blockchain.VMID),
+		}
+	}
+	return &platformproto.GetBlockchainsResponse{
+		Blockchains: blockchains,
+	}, nil
+}
+
+func (s *GRPCService) GetBlockchainStatus(_ context.Context, req *platformproto.GetBlockchainStatusRequest) (*platformproto.GetBlockchainStatusResponse, error) {
+	service, unlock, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	reply := GetBlockchainStatusReply{}
+	if err := service.GetBlockchainStatus(nil, &GetBlockchainStatusArgs{BlockchainID: req.BlockchainId}, &reply); err != nil {
+		return nil, err
+	}
+	return &platformproto.GetBlockchainStatusResponse{
+		Status: reply.Status.String(),
+	}, nil
+}
+
+func (s *GRPCService) GetCurrentValidators(_ context.Context, req *platformproto.GetCurrentValidatorsRequest) (*platformproto.GetCurrentValidatorsResponse, error) {
+	subnetID, err := parseSubnetID(req.SubnetId)
+	if err != nil {
+		return nil, err
+	}
+	nodeIDs, err := parseNodeIDs(req.NodeIds)
+	if err != nil {
+		return nil, err
+	}
+
+	service, unlock, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	reply := GetCurrentValidatorsReply{}
+	args := GetCurrentValidatorsArgs{
+		SubnetID: subnetID,
+		NodeIDs:  nodeIDs,
+	}
+	if err := service.GetCurrentValidators(nil, &args, &reply); err != nil {
+		return nil, err
+	}
+
+	validators, err := stakersToProto(reply.Validators)
+	return &platformproto.GetCurrentValidatorsResponse{
+		Validators: validators,
+	}, err
+}
+
+func (s *GRPCService) GetPendingValidators(_ context.Context, req *platformproto.GetPendingValidatorsRequest) (*platformproto.GetPendingValidatorsResponse, error) {
+	subnetID, err := parseSubnetID(req.SubnetId)
+	if err != nil {
+		return nil, err
+	}
+	nodeIDs, err := parseNodeIDs(req.NodeIds)
+	if err != nil {
+		return nil, err
+	}
+
+	service, unlock, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	reply := GetPendingValidatorsReply{}
+	args := GetPendingValidatorsArgs{
+		SubnetID: subnetID,
+		NodeIDs:  nodeIDs,
+	}
+	if err := service.GetPendingValidators(nil, &args, &reply); err != nil {
+		return nil, err
+	}
+
+	validators, err := stakersToProto(reply.Validators)
+	if err != nil {
+		return nil, err
+	}
+	delegators, err := stakersToProto(reply.Delegators)
+	return &platformproto.GetPendingValidatorsResponse{
+		Validators: validators,
+		Delegators: delegators,
+	}, err
+}
+
+func (s *GRPCService) GetValidatorsAt(_ context.Context, req *platformproto.GetValidatorsAtRequest) (*platformproto.GetValidatorsAtResponse, error) {
+	subnetID, err := parseSubnetID(req.SubnetId)
+	if err != nil {
+		return nil, err
+	}
+
+	service, unlock, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	validators, err := service.vm.GetValidatorSet(req.Height, subnetID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get validator set: %w", err)
+	}
+
+	weights := make([]*platformproto.ValidatorWeight, 0, len(validators))
+	for nodeID, weight := range validators {
+		weights = append(weights, &platformproto.ValidatorWeight{
+			NodeId: nodeID.Bytes(),
+			Weight: weight,
+		})
+	}
+	return &platformproto.GetValidatorsAtResponse{
+		Validators: weights,
+	}, nil
+}
+
+func (s *GRPCService) GetTx(_ context.Context, req *platformproto.GetTxRequest) (*platformproto.GetTxResponse, error) {
+	txID, err := ids.ToID(req.TxId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	service, unlock, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	tx, _, err := service.vm.internalState.GetTx(txID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get tx: %w", err)
+	}
+	return &platformproto.GetTxResponse{
+		Tx: tx.Bytes(),
+	}, nil
+}
+
+func (s *GRPCService) GetTxStatus(_ context.Context, req *platformproto.GetTxStatusRequest) (*platformproto.GetTxStatusResponse, error) {
+	txID, err := ids.ToID(req.TxId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	service, unlock, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	reply := GetTxStatusResponse{}
+	args := GetTxStatusArgs{
+		TxID:          txID,
+		IncludeReason: true,
+	}
+	if err := service.GetTxStatus(nil, &args, &reply); err != nil {
+		return nil, err
+	}
+	return &platformproto.GetTxStatusResponse{
+		Status: reply.Status.String(),
+		Reason: reply.Reason,
+	}, nil
+}
+
+// parseSubnetID parses [subnetIDBytes] as a subnet ID. If no bytes are
+// provided, the primary network is assumed.
+func parseSubnetID(subnetIDBytes []byte) (ids.ID, error) {
+	if len(subnetIDBytes) == 0 {
+		return constants.PrimaryNetworkID, nil
+	}
+	subnetID, err := ids.ToID(subnetIDBytes)
+	if err != nil {
+		return ids.Empty, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return subnetID, nil
+}
+
+// parseNodeIDs returns the string representations of the node IDs in
+// [nodeIDBytes], as expected by the JSON API.
+func parseNodeIDs(nodeIDBytes [][]byte) ([]string, error) {
+	nodeIDs := make([]string, len(nodeIDBytes))
+	for i, b := range nodeIDBytes {
+		nodeID, err := ids.ToShortID(b)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		nodeIDs[i] = nodeID.PrefixedString(constants.NodeIDPrefix)
+	}
+	return nodeIDs, nil
+}
+
+func idBytes(id ids.ID) []byte {
+	return id[:]
+}
+
+func stakersToProto(stakers []interface{}) ([]*platformproto.Staker, error) {
+	protoStakers := make([]*platformproto.Staker, len(stakers))
+	for i, stakerIntf := range stakers {
+		var err error
+		switch staker := stakerIntf.(type) {
+		case APIPrimaryValidator:
+			protoStakers[i], err = primaryValidatorToProto(staker)
+		case APIPrimaryDelegator:
+			protoStakers[i], err = primaryDelegatorToProto(staker)
+		case APIStaker:
+			protoStakers[i], err = stakerToProto(staker)
+		default:
+			err = fmt.Errorf("unexpected staker type %T", stakerIntf)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return protoStakers, nil
+}
+
+func primaryValidatorToProto(validator APIPrimaryValidator) (*platformproto.Staker, error) {
+	protoValidator, err := stakerToProto(validator.APIStaker)
+	if err != nil {
+		return nil, err
+	}
+	protoValidator.RewardOwner = ownerToProto(validator.RewardOwner)
+	if validator.PotentialReward != nil {
+		protoValidator.PotentialReward = uint64(*validator.PotentialReward)
+	}
+	protoValidator.DelegationFee = float32(validator.DelegationFee)
+	if validator.Uptime != nil {
+		protoValidator.Uptime = float32(*validator.Uptime)
+	}
+	if validator.Connected != nil {
+		protoValidator.Connected = *validator.Connected
+	}
+	protoValidator.Delegators = make([]*platformproto.Staker, len(validator.Delegators))
+	for i, delegator := range validator.Delegators {
+		protoValidator.Delegators[i], err = primaryDelegatorToProto(delegator)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return protoValidator, nil
+}
+
+func primaryDelegatorToProto(delegator APIPrimaryDelegator) (*platformproto.Staker, error) {
+	protoDelegator, err := stakerToProto(delegator.APIStaker)
+	if err != nil {
+		return nil, err
+	}
+	protoDelegator.RewardOwner = ownerToProto(delegator.RewardOwner)
+	if delegator.PotentialReward != nil {
+		protoDelegator.PotentialReward = uint64(*delegator.PotentialReward)
+	}
+	return protoDelegator, nil
+}
+
+func stakerToProto(staker APIStaker) (*platformproto.Staker, error) {
+	nodeID, err := ids.ShortFromPrefixedString(staker.NodeID, constants.NodeIDPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return &platformproto.Staker{
+		TxId:      idBytes(staker.TxID),
+		NodeId:    nodeID.Bytes(),
+		StartTime: uint64(staker.StartTime),
+		EndTime:   uint64(staker.EndTime),
+		Weight:    staker.weight(),
+	}, nil
+}
+
+func ownerToProto(owner *APIOwner) *platformproto.Owner {
+	if owner == nil {
+		return nil
+	}
+	return &platformproto.Owner{
+		Locktime:  uint64(owner.Locktime),
+		Threshold: uint32(owner.Threshold),
+		Addresses: owner.Addresses,
+	}
+}
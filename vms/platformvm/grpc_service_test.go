@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/lasthyphen/dijetsgo/api/proto/platformproto"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+)
+
+func TestGRPCServiceRejectsCallsBeforeBootstrapped(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewGRPCService()
+	_, err := s.GetHeight(context.Background(), &emptypb.Empty{})
+	assert.Equal(codes.Unavailable, status.Code(err))
+
+	vm, _, _ := defaultVM()
+	defer func() {
+		vm.ctx.Lock.Lock()
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	vm.bootstrapped.SetValue(false)
+	s.setVM(&vm.bootstrapped, &vm.ctx.Lock, vm)
+
+	_, err = s.GetHeight(context.Background(), &emptypb.Empty{})
+	assert.Equal(codes.Unavailable, status.Code(err))
+
+	vm.bootstrapped.SetValue(true)
+	reply, err := s.GetHeight(context.Background(), &emptypb.Empty{})
+	assert.NoError(err)
+	// The default VM accepts a block that creates a subnet
+	assert.EqualValues(1, reply.Height)
+}
+
+func TestGRPCServiceGetCurrentValidators(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	s := NewGRPCService()
+	s.setVM(&vm.bootstrapped, &vm.ctx.Lock, vm)
+	defer func() {
+		vm.ctx.Lock.Lock()
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	genesis, _ := defaultGenesis()
+
+	reply, err := s.GetCurrentValidators(context.Background(), &platformproto.GetCurrentValidatorsRequest{})
+	assert.NoError(err)
+	assert.Len(reply.Validators, len(genesis.Validators))
+
+	expectedNodeIDs := ids.ShortSet{}
+	for _, vdr := range genesis.Validators {
+		nodeID, err := ids.ShortFromPrefixedString(vdr.NodeID, constants.NodeIDPrefix)
+		assert.NoError(err)
+		expectedNodeIDs.Add(nodeID)
+	}
+	for _, vdr := range reply.Validators {
+		nodeID, err := ids.ToShortID(vdr.NodeId)
+		assert.NoError(err)
+		assert.True(expectedNodeIDs.Contains(nodeID))
+		assert.NotZero(vdr.Weight)
+		assert.NotNil(vdr.RewardOwner)
+	}
+
+	// Only the requested validator is returned
+	requestedNodeID := keys[0].PublicKey().Address()
+	reply, err = s.GetCurrentValidators(context.Background(), &platformproto.GetCurrentValidatorsRequest{
+		NodeIds: [][]byte{requestedNodeID.Bytes()},
+	})
+	assert.NoError(err)
+	assert.Len(reply.Validators, 1)
+	assert.Equal(requestedNodeID.Bytes(), reply.Validators[0].NodeId)
+
+	_, err = s.GetCurrentValidators(context.Background(), &platformproto.GetCurrentValidatorsRequest{
+		SubnetId: []byte{1, 2, 3},
+	})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+}
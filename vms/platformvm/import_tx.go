@@ -144,7 +144,7 @@ func (tx *UnsignedImportTx) Execute(
 		copy(ins, tx.Ins)
 		copy(ins[len(tx.Ins):], tx.ImportedInputs)
 
-		if err := vm.semanticVerifySpendUTXOs(tx, utxos, ins, tx.Outs, stx.Creds, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
+		if err := vm.semanticVerifySpendUTXOs(vm.fx, tx, utxos, ins, tx.Outs, stx.Creds, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
 			return nil, err
 		}
 	}
@@ -6,6 +6,7 @@ package platformvm
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -52,6 +53,16 @@ type Mempool interface {
 
 	MarkDropped(txID ids.ID)
 	WasDropped(txID ids.ID) bool
+
+	// BytesAvailable returns the number of bytes of space left in the mempool
+	BytesAvailable() int
+	// GetFee returns the fee paid by the mempool tx [txID]
+	GetFee(txID ids.ID) (TxFee, bool)
+	// DecisionTxs returns the decision txs in the mempool in the order they
+	// will be issued
+	DecisionTxs() []*Tx
+	// ProposalTxs returns the proposal txs in the mempool ordered by start time
+	ProposalTxs() []*Tx
 }
 
 // Transactions from clients that have not yet been put into blocks and added to
@@ -60,16 +71,31 @@ type mempool struct {
 	bytesAvailableMetric prometheus.Gauge
 	bytesAvailable       int
 
-	unissuedDecisionTxs TxHeap
-	unissuedProposalTxs TxHeap
-	unknownTxs          prometheus.Counter
+	// Decision txs are issued highest fee rate first and evicted lowest fee
+	// rate first
+	unissuedDecisionTxs  TxHeap
+	evictableDecisionTxs TxHeap
+	unissuedProposalTxs  TxHeap
+	unknownTxs           prometheus.Counter
+	evictedTxs           prometheus.Counter
+
+	// getTxFee returns the fee paid by a tx
+	getTxFee func(tx *Tx) TxFee
+	// fees paid by the txs in the mempool
+	fees map[ids.ID]*feeEntry
+	// the age of the next tx added to the mempool
+	currentAge uint64
 
 	droppedTxIDs *cache.LRU
 
 	consumedUTXOs ids.Set
 }
 
-func NewMempool(namespace string, registerer prometheus.Registerer) (Mempool, error) {
+func NewMempool(
+	namespace string,
+	registerer prometheus.Registerer,
+	getTxFee func(tx *Tx) TxFee,
+) (Mempool, error) {
 	bytesAvailableMetric := prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Name:      "bytes_available",
@@ -79,8 +105,9 @@ func NewMempool(namespace string, registerer prometheus.Registerer) (Mempool, er
 		return nil, err
 	}
 
+	fees := make(map[ids.ID]*feeEntry)
 	unissuedDecisionTxs, err := NewTxHeapWithMetrics(
-		NewTxHeapByAge(),
+		newTxHeapByFee(fees),
 		fmt.Sprintf("%s_decision_txs", namespace),
 		registerer,
 	)
@@ -106,13 +133,26 @@ func NewMempool(namespace string, registerer prometheus.Registerer) (Mempool, er
 		return nil, err
 	}
 
+	evictedTxs := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "evicted_txs",
+		Help:      "Number of decision txs evicted from the mempool to make room for txs paying a higher fee",
+	})
+	if err := registerer.Register(evictedTxs); err != nil {
+		return nil, err
+	}
+
 	bytesAvailableMetric.Set(maxMempoolSize)
 	return &mempool{
 		bytesAvailableMetric: bytesAvailableMetric,
 		bytesAvailable:       maxMempoolSize,
 		unissuedDecisionTxs:  unissuedDecisionTxs,
+		evictableDecisionTxs: newTxHeapByLowestFee(fees),
 		unissuedProposalTxs:  unissuedProposalTxs,
 		unknownTxs:           unknownTxs,
+		evictedTxs:           evictedTxs,
+		getTxFee:             getTxFee,
+		fees:                 fees,
 		droppedTxIDs:         &cache.LRU{Size: droppedTxIDsCacheSize},
 		consumedUTXOs:        ids.NewSet(initialConsumedUTXOsSize),
 	}, nil
@@ -124,25 +164,29 @@ func (m *mempool) Add(tx *Tx) error {
 	if m.Has(txID) {
 		return errDuplicatedTx
 	}
-	if txBytes := tx.Bytes(); len(txBytes) > m.bytesAvailable {
-		return errMempoolFull
-	}
 
 	inputs := tx.InputIDs()
 	if m.consumedUTXOs.Overlaps(inputs) {
 		return errConflictingTx
 	}
 
-	switch tx.UnsignedTx.(type) {
-	case TimedTx:
-		m.AddProposalTx(tx)
-	case UnsignedDecisionTx:
-		m.AddDecisionTx(tx)
-	default:
+	_, isProposalTx := tx.UnsignedTx.(TimedTx)
+	_, isDecisionTx := tx.UnsignedTx.(UnsignedDecisionTx)
+	if !isProposalTx && !isDecisionTx {
 		m.unknownTxs.Inc()
 		return fmt.Errorf("%w: %T", errUnknownTxType, tx.UnsignedTx)
 	}
 
+	if err := m.makeRoom(tx); err != nil {
+		return err
+	}
+
+	if isProposalTx {
+		m.AddProposalTx(tx)
+	} else {
+		m.AddDecisionTx(tx)
+	}
+
 	// Mark these UTXOs as consumed in the mempool
 	m.consumedUTXOs.Union(inputs)
 
@@ -163,13 +207,14 @@ func (m *mempool) Get(txID ids.ID) *Tx {
 }
 
 func (m *mempool) AddDecisionTx(tx *Tx) {
-	m.unissuedDecisionTxs.Add(tx)
 	m.register(tx)
+	m.unissuedDecisionTxs.Add(tx)
+	m.evictableDecisionTxs.Add(tx)
 }
 
 func (m *mempool) AddProposalTx(tx *Tx) {
-	m.unissuedProposalTxs.Add(tx)
 	m.register(tx)
+	m.unissuedProposalTxs.Add(tx)
 }
 
 func (m *mempool) HasDecisionTxs() bool { return m.unissuedDecisionTxs.Len() > 0 }
@@ -180,6 +225,7 @@ func (m *mempool) RemoveDecisionTxs(txs []*Tx) {
 	for _, tx := range txs {
 		txID := tx.ID()
 		if m.unissuedDecisionTxs.Remove(txID) != nil {
+			m.evictableDecisionTxs.Remove(txID)
 			m.deregister(tx)
 		}
 	}
@@ -200,6 +246,7 @@ func (m *mempool) PopDecisionTxs(numTxs int) []*Tx {
 	txs := make([]*Tx, numTxs)
 	for i := range txs {
 		tx := m.unissuedDecisionTxs.RemoveTop()
+		m.evictableDecisionTxs.Remove(tx.ID())
 		m.deregister(tx)
 		txs[i] = tx
 	}
@@ -221,7 +268,95 @@ func (m *mempool) WasDropped(txID ids.ID) bool {
 	return exist
 }
 
+func (m *mempool) BytesAvailable() int { return m.bytesAvailable }
+
+func (m *mempool) GetFee(txID ids.ID) (TxFee, bool) {
+	entry, exists := m.fees[txID]
+	if !exists {
+		return TxFee{}, false
+	}
+	return entry.fee, true
+}
+
+func (m *mempool) DecisionTxs() []*Tx {
+	txs := make([]*Tx, 0, m.unissuedDecisionTxs.Len())
+	for _, entry := range m.fees {
+		if _, ok := entry.tx.UnsignedTx.(TimedTx); !ok {
+			txs = append(txs, entry.tx)
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		return m.fees[txs[i].ID()].hasPriority(m.fees[txs[j].ID()])
+	})
+	return txs
+}
+
+func (m *mempool) ProposalTxs() []*Tx {
+	txs := make([]*Tx, 0, m.unissuedProposalTxs.Len())
+	for _, entry := range m.fees {
+		if _, ok := entry.tx.UnsignedTx.(TimedTx); ok {
+			txs = append(txs, entry.tx)
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		iStartTime := txs[i].UnsignedTx.(TimedTx).StartTime()
+		jStartTime := txs[j].UnsignedTx.(TimedTx).StartTime()
+		if !iStartTime.Equal(jStartTime) {
+			return iStartTime.Before(jStartTime)
+		}
+		return m.fees[txs[i].ID()].age < m.fees[txs[j].ID()].age
+	})
+	return txs
+}
+
+// makeRoom evicts the decision txs paying a lower fee rate than [tx], lowest
+// fee rate first, until there is enough space in the mempool for [tx]. If
+// enough space can't be made, no txs are evicted and errMempoolFull is
+// returned.
+func (m *mempool) makeRoom(tx *Tx) error {
+	txSize := len(tx.Bytes())
+	if txSize <= m.bytesAvailable {
+		return nil
+	}
+
+	fee := m.getTxFee(tx)
+	bytesAvailable := m.bytesAvailable
+	evicted := []*Tx(nil)
+	for bytesAvailable < txSize && m.evictableDecisionTxs.Len() > 0 {
+		lowestTx := m.evictableDecisionTxs.Peek()
+		if !fee.HasHigherRate(m.fees[lowestTx.ID()].fee) {
+			break
+		}
+		m.evictableDecisionTxs.RemoveTop()
+		evicted = append(evicted, lowestTx)
+		bytesAvailable += len(lowestTx.Bytes())
+	}
+
+	if bytesAvailable < txSize {
+		for _, evictedTx := range evicted {
+			m.evictableDecisionTxs.Add(evictedTx)
+		}
+		return errMempoolFull
+	}
+
+	for _, evictedTx := range evicted {
+		txID := evictedTx.ID()
+		m.unissuedDecisionTxs.Remove(txID)
+		m.deregister(evictedTx)
+		m.MarkDropped(txID)
+		m.evictedTxs.Inc()
+	}
+	return nil
+}
+
 func (m *mempool) register(tx *Tx) {
+	m.fees[tx.ID()] = &feeEntry{
+		tx:  tx,
+		fee: m.getTxFee(tx),
+		age: m.currentAge,
+	}
+	m.currentAge++
+
 	txBytes := tx.Bytes()
 	m.bytesAvailable -= len(txBytes)
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
@@ -234,4 +369,6 @@ func (m *mempool) deregister(tx *Tx) {
 
 	inputs := tx.InputIDs()
 	m.consumedUTXOs.Difference(inputs)
+
+	delete(m.fees, tx.ID())
 }
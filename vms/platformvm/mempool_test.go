@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/ids"
+)
+
+// newTestDecisionTx returns a unique decision tx
+func newTestDecisionTx(assert *assert.Assertions) *Tx {
+	tx := &Tx{UnsignedTx: &UnsignedImportTx{
+		SourceChain: ids.GenerateTestID(),
+	}}
+	assert.NoError(tx.Sign(Codec, nil))
+	return tx
+}
+
+func TestTxHeapByFee(t *testing.T) {
+	assert := assert.New(t)
+
+	low := newTestDecisionTx(assert)
+	mid := newTestDecisionTx(assert)
+	midNewer := newTestDecisionTx(assert)
+	high := newTestDecisionTx(assert)
+
+	fees := map[ids.ID]*feeEntry{
+		low.ID():      {tx: low, fee: TxFee{Burned: 1, Size: 1}, age: 0},
+		mid.ID():      {tx: mid, fee: TxFee{Burned: 10, Size: 2}, age: 1},
+		midNewer.ID(): {tx: midNewer, fee: TxFee{Burned: 5, Size: 1}, age: 2},
+		high.ID():     {tx: high, fee: TxFee{Burned: 110, Required: 10, Size: 1}, age: 3},
+	}
+
+	highest := newTxHeapByFee(fees)
+	lowest := newTxHeapByLowestFee(fees)
+	for _, tx := range []*Tx{midNewer, low, high, mid} {
+		highest.Add(tx)
+		lowest.Add(tx)
+	}
+
+	for _, expected := range []*Tx{high, mid, midNewer, low} {
+		assert.Equal(expected.ID(), highest.RemoveTop().ID())
+	}
+	for _, expected := range []*Tx{low, midNewer, mid, high} {
+		assert.Equal(expected.ID(), lowest.RemoveTop().ID())
+	}
+}
+
+func TestMempoolEvictsLowestFeeTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	low := newTestDecisionTx(assert)
+	mid := newTestDecisionTx(assert)
+	high := newTestDecisionTx(assert)
+	lowest := newTestDecisionTx(assert)
+	burned := map[ids.ID]uint64{
+		low.ID():    10,
+		mid.ID():    20,
+		high.ID():   30,
+		lowest.ID(): 5,
+	}
+	getTxFee := func(tx *Tx) TxFee {
+		return TxFee{
+			Burned:   burned[tx.ID()],
+			Required: 5,
+			Size:     len(tx.Bytes()),
+		}
+	}
+
+	mempoolIntf, err := NewMempool("mempool", prometheus.NewRegistry(), getTxFee)
+	assert.NoError(err)
+	mempool := mempoolIntf.(*mempool)
+
+	// Only leave room for two txs
+	mempool.bytesAvailable = len(low.Bytes()) + len(mid.Bytes())
+
+	assert.NoError(mempool.Add(low))
+	assert.NoError(mempool.Add(mid))
+
+	// [high] pays more than [low], so [low] is evicted
+	assert.NoError(mempool.Add(high))
+	assert.False(mempool.Has(low.ID()))
+	assert.True(mempool.WasDropped(low.ID()))
+	_, exists := mempool.GetFee(low.ID())
+	assert.False(exists)
+
+	// [lowest] doesn't pay more than any tx in the mempool
+	assert.ErrorIs(mempool.Add(lowest), errMempoolFull)
+	assert.True(mempool.Has(mid.ID()))
+	assert.True(mempool.Has(high.ID()))
+
+	fee, exists := mempool.GetFee(high.ID())
+	assert.True(exists)
+	assert.Equal(uint64(25), fee.Effective())
+
+	decisionTxs := mempool.DecisionTxs()
+	assert.Len(decisionTxs, 2)
+	assert.Equal(high.ID(), decisionTxs[0].ID())
+	assert.Equal(mid.ID(), decisionTxs[1].ID())
+
+	txs := mempool.PopDecisionTxs(BatchSize)
+	assert.Len(txs, 2)
+	assert.Equal(high.ID(), txs[0].ID())
+	assert.Equal(mid.ID(), txs[1].ID())
+	assert.Empty(mempool.fees)
+}
@@ -37,7 +37,10 @@ type metrics struct {
 	numCreateSubnetTxs,
 	numExportTxs,
 	numImportTxs,
-	numRewardValidatorTxs prometheus.Counter
+	numRemoveSubnetValidatorTxs,
+	numRewardValidatorTxs,
+	numSetDelegationPolicyTxs,
+	numTransferSubnetOwnershipTxs prometheus.Counter
 
 	validatorSetsCached     prometheus.Counter
 	validatorSetsCreated    prometheus.Counter
@@ -109,7 +112,10 @@ func (m *metrics) Initialize(
 	m.numCreateSubnetTxs = newTxMetrics(namespace, "create_subnet")
 	m.numExportTxs = newTxMetrics(namespace, "export")
 	m.numImportTxs = newTxMetrics(namespace, "import")
+	m.numRemoveSubnetValidatorTxs = newTxMetrics(namespace, "remove_subnet_validator")
 	m.numRewardValidatorTxs = newTxMetrics(namespace, "reward_validator")
+	m.numSetDelegationPolicyTxs = newTxMetrics(namespace, "set_delegation_policy")
+	m.numTransferSubnetOwnershipTxs = newTxMetrics(namespace, "transfer_subnet_ownership")
 
 	m.validatorSetsCached = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: namespace,
@@ -159,7 +165,10 @@ func (m *metrics) Initialize(
 		registerer.Register(m.numCreateSubnetTxs),
 		registerer.Register(m.numExportTxs),
 		registerer.Register(m.numImportTxs),
+		registerer.Register(m.numRemoveSubnetValidatorTxs),
 		registerer.Register(m.numRewardValidatorTxs),
+		registerer.Register(m.numSetDelegationPolicyTxs),
+		registerer.Register(m.numTransferSubnetOwnershipTxs),
 
 		registerer.Register(m.validatorSetsCreated),
 		registerer.Register(m.validatorSetsCached),
@@ -212,8 +221,14 @@ func (m *metrics) AcceptTx(tx *Tx) error {
 		m.numImportTxs.Inc()
 	case *UnsignedExportTx:
 		m.numExportTxs.Inc()
+	case *UnsignedRemoveSubnetValidatorTx:
+		m.numRemoveSubnetValidatorTxs.Inc()
 	case *UnsignedRewardValidatorTx:
 		m.numRewardValidatorTxs.Inc()
+	case *UnsignedSetDelegationPolicyTx:
+		m.numSetDelegationPolicyTxs.Inc()
+	case *UnsignedTransferSubnetOwnershipTx:
+		m.numTransferSubnetOwnershipTxs.Inc()
 	default:
 		return fmt.Errorf("%w: %T", errUnknownTxType, tx.UnsignedTx)
 	}
@@ -283,6 +283,21 @@ func (mr *MockInternalStateMockRecorder) GetCurrentSupply() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSupply", reflect.TypeOf((*MockInternalState)(nil).GetCurrentSupply))
 }
 
+// GetDelegationPolicy mocks base method.
+func (m *MockInternalState) GetDelegationPolicy(validatorTxID ids.ID) (*DelegationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegationPolicy", validatorTxID)
+	ret0, _ := ret[0].(*DelegationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegationPolicy indicates an expected call of GetDelegationPolicy.
+func (mr *MockInternalStateMockRecorder) GetDelegationPolicy(validatorTxID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegationPolicy", reflect.TypeOf((*MockInternalState)(nil).GetDelegationPolicy), validatorTxID)
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockInternalState) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -327,6 +342,50 @@ func (mr *MockInternalStateMockRecorder) GetStartTime(nodeID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStartTime", reflect.TypeOf((*MockInternalState)(nil).GetStartTime), nodeID)
 }
 
+// GetStakerDiff mocks base method.
+func (m *MockInternalState) GetStakerDiff(height uint64) (*StakerDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStakerDiff", height)
+	ret0, _ := ret[0].(*StakerDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStakerDiff indicates an expected call of GetStakerDiff.
+func (mr *MockInternalStateMockRecorder) GetStakerDiff(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakerDiff", reflect.TypeOf((*MockInternalState)(nil).GetStakerDiff), height)
+}
+
+// GetStakerDiffsHeight mocks base method.
+func (m *MockInternalState) GetStakerDiffsHeight() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStakerDiffsHeight")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetStakerDiffsHeight indicates an expected call of GetStakerDiffsHeight.
+func (mr *MockInternalStateMockRecorder) GetStakerDiffsHeight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakerDiffsHeight", reflect.TypeOf((*MockInternalState)(nil).GetStakerDiffsHeight))
+}
+
+// GetSubnetOwner mocks base method.
+func (m *MockInternalState) GetSubnetOwner(subnetID ids.ID) (Owner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetOwner", subnetID)
+	ret0, _ := ret[0].(Owner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetOwner indicates an expected call of GetSubnetOwner.
+func (mr *MockInternalStateMockRecorder) GetSubnetOwner(subnetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwner", reflect.TypeOf((*MockInternalState)(nil).GetSubnetOwner), subnetID)
+}
+
 // GetSubnets mocks base method.
 func (m *MockInternalState) GetSubnets() ([]*Tx, error) {
 	m.ctrl.T.Helper()
@@ -456,6 +515,18 @@ func (mr *MockInternalStateMockRecorder) SetCurrentSupply(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurrentSupply", reflect.TypeOf((*MockInternalState)(nil).SetCurrentSupply), arg0)
 }
 
+// SetDelegationPolicy mocks base method.
+func (m *MockInternalState) SetDelegationPolicy(validatorTxID ids.ID, policy *DelegationPolicy) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDelegationPolicy", validatorTxID, policy)
+}
+
+// SetDelegationPolicy indicates an expected call of SetDelegationPolicy.
+func (mr *MockInternalStateMockRecorder) SetDelegationPolicy(validatorTxID, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegationPolicy", reflect.TypeOf((*MockInternalState)(nil).SetDelegationPolicy), validatorTxID, policy)
+}
+
 // SetHeight mocks base method.
 func (m *MockInternalState) SetHeight(height uint64) {
 	m.ctrl.T.Helper()
@@ -492,6 +563,18 @@ func (mr *MockInternalStateMockRecorder) SetPendingStakerChainState(arg0 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPendingStakerChainState", reflect.TypeOf((*MockInternalState)(nil).SetPendingStakerChainState), arg0)
 }
 
+// SetSubnetOwner mocks base method.
+func (m *MockInternalState) SetSubnetOwner(subnetID ids.ID, owner Owner) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetOwner", subnetID, owner)
+}
+
+// SetSubnetOwner indicates an expected call of SetSubnetOwner.
+func (mr *MockInternalStateMockRecorder) SetSubnetOwner(subnetID, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockInternalState)(nil).SetSubnetOwner), subnetID, owner)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockInternalState) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
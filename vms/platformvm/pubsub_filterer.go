@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/pubsub"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
+)
+
+var _ pubsub.Filterer = &filterer{}
+
+// PubSubTx is the message sent to pubsub subscribers when a tx is accepted
+type PubSubTx struct {
+	TxID ids.ID `json:"txID"`
+	Type string `json:"type"`
+	// True if the tx is a proposal tx whose proposal was rejected
+	Aborted bool `json:"aborted,omitempty"`
+}
+
+type filterer struct {
+	tx *Tx
+	// The tx that added the staker rewarded by [tx], if [tx] is a
+	// RewardValidatorTx. May be nil.
+	stakerTx    *Tx
+	aborted     bool
+	djtxAssetID ids.ID
+}
+
+// NewPubSubFilterer returns a filterer for the accepted [tx]. [stakerTx] is the
+// tx that added the staker rewarded by [tx], if [tx] is a RewardValidatorTx.
+// [aborted] is true if [tx] is a proposal tx whose proposal was rejected.
+func NewPubSubFilterer(tx, stakerTx *Tx, aborted bool, djtxAssetID ids.ID) pubsub.Filterer {
+	return &filterer{
+		tx:          tx,
+		stakerTx:    stakerTx,
+		aborted:     aborted,
+		djtxAssetID: djtxAssetID,
+	}
+}
+
+// Apply the filters on the type, the outputs and the nodes and subnets the tx
+// refers to.
+func (f *filterer) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
+	tx := pubsub.Tx{
+		Type: txType(f.tx.UnsignedTx),
+	}
+	f.addFields(&tx, f.tx.UnsignedTx)
+	// A RewardValidatorTx refers to the staker it rewards
+	if _, ok := f.tx.UnsignedTx.(*UnsignedRewardValidatorTx); ok && f.stakerTx != nil {
+		f.addFields(&tx, f.stakerTx.UnsignedTx)
+	}
+
+	resp := make([]bool, len(filters))
+	for i, c := range filters {
+		resp[i] = c.CheckTx(&tx)
+	}
+	return resp, PubSubTx{
+		TxID:    f.tx.ID(),
+		Type:    tx.Type,
+		Aborted: f.aborted,
+	}
+}
+
+// addFields adds the outputs, nodes and subnets of [utx] to [tx]
+func (f *filterer) addFields(tx *pubsub.Tx, utx UnsignedTx) {
+	switch utx := utx.(type) {
+	case *UnsignedAddValidatorTx:
+		f.addOutputs(tx, utx.Outs)
+		f.addOutputs(tx, utx.Stake)
+		f.addOwner(tx, utx.RewardsOwner)
+		tx.NodeIDs = append(tx.NodeIDs, utx.Validator.NodeID)
+		tx.SubnetIDs = append(tx.SubnetIDs, constants.PrimaryNetworkID)
+	case *UnsignedAddDelegatorTx:
+		f.addOutputs(tx, utx.Outs)
+		f.addOutputs(tx, utx.Stake)
+		f.addOwner(tx, utx.RewardsOwner)
+		tx.NodeIDs = append(tx.NodeIDs, utx.Validator.NodeID)
+		tx.SubnetIDs = append(tx.SubnetIDs, constants.PrimaryNetworkID)
+	case *UnsignedAddSubnetValidatorTx:
+		f.addOutputs(tx, utx.Outs)
+		tx.NodeIDs = append(tx.NodeIDs, utx.Validator.NodeID)
+		tx.SubnetIDs = append(tx.SubnetIDs, utx.Validator.Subnet)
+	case *UnsignedCreateChainTx:
+		f.addOutputs(tx, utx.Outs)
+		tx.SubnetIDs = append(tx.SubnetIDs, utx.SubnetID)
+	case *UnsignedCreateSubnetTx:
+		f.addOutputs(tx, utx.Outs)
+		f.addOwner(tx, utx.Owner)
+	case *UnsignedTransferSubnetOwnershipTx:
+		f.addOutputs(tx, utx.Outs)
+		f.addOwner(tx, utx.Owner)
+		tx.SubnetIDs = append(tx.SubnetIDs, utx.Subnet)
+	case *UnsignedRemoveSubnetValidatorTx:
+		f.addOutputs(tx, utx.Outs)
+		tx.NodeIDs = append(tx.NodeIDs, utx.NodeID)
+		tx.SubnetIDs = append(tx.SubnetIDs, utx.Subnet)
+	case *UnsignedSetDelegationPolicyTx:
+		f.addOutputs(tx, utx.Outs)
+		tx.SubnetIDs = append(tx.SubnetIDs, constants.PrimaryNetworkID)
+	case *UnsignedImportTx:
+		f.addOutputs(tx, utx.Outs)
+	case *UnsignedExportTx:
+		f.addOutputs(tx, utx.Outs)
+		// Exported outputs don't produce UTXOs on this chain, so they are only
+		// matched by the asset ID and minimum amount filters.
+		f.addExportedOutputs(tx, utx.ExportedOutputs)
+	}
+}
+
+func (f *filterer) addOutputs(tx *pubsub.Tx, outs []*djtx.TransferableOutput) {
+	for _, out := range outs {
+		tx.Outputs = append(tx.Outputs, pubsubOutput(out))
+	}
+}
+
+func (f *filterer) addExportedOutputs(tx *pubsub.Tx, outs []*djtx.TransferableOutput) {
+	for _, out := range outs {
+		output := pubsubOutput(out)
+		output.Exported = true
+		tx.Outputs = append(tx.Outputs, output)
+	}
+}
+
+// pubsubOutput returns the pubsub representation of [out]
+func pubsubOutput(out *djtx.TransferableOutput) pubsub.Output {
+	output := pubsub.Output{
+		AssetID: out.AssetID(),
+		Amount:  out.Output().Amount(),
+	}
+	if addressable, ok := out.Out.(djtx.Addressable); ok {
+		output.Addresses = addressable.Addresses()
+	}
+	return output
+}
+
+// addOwner adds [owner] to [tx] as an output that holds no funds, so that
+// subscribers can filter by reward and subnet owner addresses.
+func (f *filterer) addOwner(tx *pubsub.Tx, owner Owner) {
+	addressable, ok := owner.(djtx.Addressable)
+	if !ok {
+		return
+	}
+	tx.Outputs = append(tx.Outputs, pubsub.Output{
+		AssetID:   f.djtxAssetID,
+		Addresses: addressable.Addresses(),
+	})
+}
+
+// txType returns the name that pubsub subscribers use to filter by the type of
+// [tx]
+func txType(tx UnsignedTx) string {
+	switch tx.(type) {
+	case *UnsignedAddValidatorTx:
+		return "AddValidatorTx"
+	case *UnsignedAddDelegatorTx:
+		return "AddDelegatorTx"
+	case *UnsignedAddSubnetValidatorTx:
+		return "AddSubnetValidatorTx"
+	case *UnsignedRewardValidatorTx:
+		return "RewardValidatorTx"
+	case *UnsignedAdvanceTimeTx:
+		return "AdvanceTimeTx"
+	case *UnsignedCreateChainTx:
+		return "CreateChainTx"
+	case *UnsignedCreateSubnetTx:
+		return "CreateSubnetTx"
+	case *UnsignedTransferSubnetOwnershipTx:
+		return "TransferSubnetOwnershipTx"
+	case *UnsignedRemoveSubnetValidatorTx:
+		return "RemoveSubnetValidatorTx"
+	case *UnsignedSetDelegationPolicyTx:
+		return "SetDelegationPolicyTx"
+	case *UnsignedImportTx:
+		return "ImportTx"
+	case *UnsignedExportTx:
+		return "ExportTx"
+	default:
+		return "unknown"
+	}
+}
+
+// publishTx notifies pubsub subscribers that [tx] was accepted
+func (vm *VM) publishTx(tx *Tx, aborted bool) {
+	var stakerTx *Tx
+	if utx, ok := tx.UnsignedTx.(*UnsignedRewardValidatorTx); ok {
+		var err error
+		stakerTx, _, err = vm.internalState.GetTx(utx.TxID)
+		if err != nil {
+			vm.ctx.Log.Debug("couldn't get staker tx %s rewarded by %s: %s", utx.TxID, tx.ID(), err)
+		}
+	}
+	vm.pubsub.Publish(NewPubSubFilterer(tx, stakerTx, aborted, vm.ctx.DJTXAssetID))
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/pubsub"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+func TestPubSubFiltererRewardValidatorTx(t *testing.T) {
+	assert := assert.New(t)
+
+	djtxAssetID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestShortID()
+	rewardAddr := ids.GenerateTestShortID()
+	stakerTx := &Tx{UnsignedTx: &UnsignedAddValidatorTx{
+		Validator: Validator{NodeID: nodeID},
+		Stake: []*djtx.TransferableOutput{{
+			Asset: djtx.Asset{ID: djtxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 2000,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Addrs: []ids.ShortID{ids.GenerateTestShortID()},
+				},
+			},
+		}},
+		RewardsOwner: &secp256k1fx.OutputOwners{
+			Addrs: []ids.ShortID{rewardAddr},
+		},
+	}}
+	tx := &Tx{UnsignedTx: &UnsignedRewardValidatorTx{TxID: ids.GenerateTestID()}}
+
+	byType := pubsub.NewFilterParam()
+	assert.NoError(byType.AddTxTypes("RewardValidatorTx"))
+
+	byRewardAddr := pubsub.NewFilterParam()
+	assert.NoError(byRewardAddr.Add(rewardAddr[:]))
+
+	byNode := pubsub.NewFilterParam()
+	assert.NoError(byNode.AddNodeIDs(nodeID))
+
+	byPrimaryNetwork := pubsub.NewFilterParam()
+	assert.NoError(byPrimaryNetwork.AddSubnetIDs(constants.PrimaryNetworkID))
+
+	otherNode := pubsub.NewFilterParam()
+	assert.NoError(otherNode.AddNodeIDs(ids.GenerateTestShortID()))
+
+	otherType := pubsub.NewFilterParam()
+	assert.NoError(otherType.AddTxTypes("AddValidatorTx"))
+
+	filterer := NewPubSubFilterer(tx, stakerTx, true, djtxAssetID)
+	fr, msg := filterer.Filter([]pubsub.Filter{byType, byRewardAddr, byNode, byPrimaryNetwork, otherNode, otherType})
+	assert.Equal([]bool{true, true, true, true, false, false}, fr)
+	assert.Equal(PubSubTx{
+		TxID:    tx.ID(),
+		Type:    "RewardValidatorTx",
+		Aborted: true,
+	}, msg)
+}
+
+func TestPubSubFiltererCreateChainTx(t *testing.T) {
+	assert := assert.New(t)
+
+	subnetID := ids.GenerateTestID()
+	tx := &Tx{UnsignedTx: &UnsignedCreateChainTx{SubnetID: subnetID}}
+
+	bySubnet := pubsub.NewFilterParam()
+	assert.NoError(bySubnet.AddSubnetIDs(subnetID))
+	assert.NoError(bySubnet.AddTxTypes("CreateChainTx"))
+
+	otherSubnet := pubsub.NewFilterParam()
+	assert.NoError(otherSubnet.AddSubnetIDs(constants.PrimaryNetworkID))
+
+	filterer := NewPubSubFilterer(tx, nil, false, ids.GenerateTestID())
+	fr, _ := filterer.Filter([]pubsub.Filter{bySubnet, otherSubnet})
+	assert.Equal([]bool{true, false}, fr)
+}
+
+func TestPubSubFiltererExportTx(t *testing.T) {
+	assert := assert.New(t)
+
+	djtxAssetID := ids.GenerateTestID()
+	exportAddr := ids.GenerateTestShortID()
+	tx := &Tx{UnsignedTx: &UnsignedExportTx{
+		ExportedOutputs: []*djtx.TransferableOutput{{
+			Asset: djtx.Asset{ID: djtxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1000,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Addrs: []ids.ShortID{exportAddr},
+				},
+			},
+		}},
+	}}
+
+	byExportAddr := pubsub.NewFilterParam()
+	assert.NoError(byExportAddr.Add(exportAddr[:]))
+
+	byAsset := pubsub.NewFilterParam()
+	assert.NoError(byAsset.AddAssetIDs(djtxAssetID))
+
+	byMinAmount := pubsub.NewFilterParam()
+	byMinAmount.SetMinAmount(1000)
+
+	filterer := NewPubSubFilterer(tx, nil, false, djtxAssetID)
+	fr, _ := filterer.Filter([]pubsub.Filter{byExportAddr, byAsset, byMinAmount})
+	assert.Equal([]bool{false, true, true}, fr, "address filters shouldn't match exported outputs")
+}
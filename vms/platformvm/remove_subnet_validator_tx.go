@@ -0,0 +1,221 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsgo/chains/atomic"
+	"github.com/lasthyphen/dijetsgo/database"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/snow"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+	"github.com/lasthyphen/dijetsgo/utils/crypto"
+	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
+	"github.com/lasthyphen/dijetsgo/vms/components/verify"
+)
+
+var (
+	errRemovePrimaryNetworkValidator = errors.New("can't remove a primary network validator with a removeSubnetValidatorTx")
+	errNotSubnetValidator            = errors.New("node isn't a validator of the subnet")
+
+	_ UnsignedDecisionTx = &UnsignedRemoveSubnetValidatorTx{}
+)
+
+// UnsignedRemoveSubnetValidatorTx is an unsigned removeSubnetValidatorTx
+type UnsignedRemoveSubnetValidatorTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// The node to remove from the subnet
+	NodeID ids.ShortID `serialize:"true" json:"nodeID"`
+	// The subnet to remove the node from
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// Proves that the issuer has the right to remove the node from the subnet
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+// InputUTXOs for [DecisionTxs] will return an empty set to diffrentiate from the [AtomicTxs] input UTXOs
+func (tx *UnsignedRemoveSubnetValidatorTx) InputUTXOs() ids.Set { return nil }
+
+func (tx *UnsignedRemoveSubnetValidatorTx) AtomicOperations() (ids.ID, *atomic.Requests, error) {
+	return ids.ID{}, nil, nil
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *UnsignedRemoveSubnetValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case tx.syntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return errRemovePrimaryNetworkValidator
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.syntacticallyVerified = true
+	return nil
+}
+
+// Attempts to verify this transaction with the provided state.
+func (tx *UnsignedRemoveSubnetValidatorTx) SemanticVerify(vm *VM, parentState MutableState, stx *Tx) error {
+	vs := newVersionedState(
+		parentState,
+		parentState.CurrentStakerChainState(),
+		parentState.PendingStakerChainState(),
+	)
+	_, err := tx.Execute(vm, vs, stx)
+	return err
+}
+
+// Execute this transaction.
+func (tx *UnsignedRemoveSubnetValidatorTx) Execute(
+	vm *VM,
+	vs VersionedState,
+	stx *Tx,
+) (
+	func() error,
+	error,
+) {
+	// Make sure this transaction is well formed.
+	if len(stx.Creds) == 0 {
+		return nil, errWrongNumberOfCredentials
+	}
+	if err := tx.SyntacticVerify(vm.ctx); err != nil {
+		return nil, err
+	}
+	if err := vm.verifyApricotPhase6(tx, vs.GetTimestamp()); err != nil {
+		return nil, err
+	}
+
+	// Select the credentials for each purpose
+	baseTxCredsLen := len(stx.Creds) - 1
+	baseTxCreds := stx.Creds[:baseTxCredsLen]
+	subnetCred := stx.Creds[baseTxCredsLen]
+
+	subnetOwner, err := vs.GetSubnetOwner(tx.Subnet)
+	if err == database.ErrNotFound {
+		return nil, fmt.Errorf("%s isn't a known subnet", tx.Subnet)
+	}
+	if errors.Is(err, errNotASubnet) {
+		return nil, fmt.Errorf("%s isn't a subnet", tx.Subnet)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that the removal is authorized by the subnet
+	if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnetOwner); err != nil {
+		return nil, err
+	}
+
+	// Verify the flowcheck
+	if err := vm.semanticVerifySpend(vm.fx, vs, tx, tx.Ins, tx.Outs, baseTxCreds, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
+		return nil, err
+	}
+
+	// Remove the validator from the current or the pending stakers
+	currentStakers := vs.CurrentStakerChainState()
+	currentValidator, err := currentStakers.GetValidator(tx.NodeID)
+	if err != nil && err != database.ErrNotFound {
+		return nil, fmt.Errorf(
+			"failed to find whether %s is a validator: %w",
+			tx.NodeID.PrefixedString(constants.NodeIDPrefix),
+			err,
+		)
+	}
+	if err == nil {
+		if addTx, validates := currentValidator.SubnetValidators()[tx.Subnet]; validates {
+			addStakerTx, _, err := currentStakers.GetStaker(addTx.ID())
+			if err != nil {
+				return nil, err
+			}
+			newlyCurrentStakers, err := currentStakers.DeleteSubnetValidator(addStakerTx)
+			if err != nil {
+				return nil, err
+			}
+			vs.SetCurrentStakerChainState(newlyCurrentStakers)
+			tx.consumeAndProduce(vm, vs)
+			return nil, nil
+		}
+	}
+
+	pendingStakers := vs.PendingStakerChainState()
+	addTx, validates := pendingStakers.GetValidator(tx.NodeID).SubnetValidators()[tx.Subnet]
+	if !validates {
+		return nil, fmt.Errorf(
+			"%w: %s isn't validating %s",
+			errNotSubnetValidator,
+			tx.NodeID.PrefixedString(constants.NodeIDPrefix),
+			tx.Subnet,
+		)
+	}
+	addTxID := addTx.ID()
+	for _, addStakerTx := range pendingStakers.Stakers() {
+		if addStakerTx.ID() != addTxID {
+			continue
+		}
+		newlyPendingStakers, err := pendingStakers.DeleteSubnetValidator(addStakerTx)
+		if err != nil {
+			return nil, err
+		}
+		vs.SetPendingStakerChainState(newlyPendingStakers)
+		tx.consumeAndProduce(vm, vs)
+		return nil, nil
+	}
+	return nil, fmt.Errorf("couldn't find pending staker %s", addTxID)
+}
+
+// consumeAndProduce the UTXOs of this transaction in [vs]
+func (tx *UnsignedRemoveSubnetValidatorTx) consumeAndProduce(vm *VM, vs VersionedState) {
+	// Consume the UTXOS
+	consumeInputs(vs, tx.Ins)
+	// Produce the UTXOS
+	txID := tx.ID()
+	produceOutputs(vs, txID, vm.ctx.DJTXAssetID, tx.Outs)
+}
+
+// Create a new transaction
+func (vm *VM) newRemoveSubnetValidatorTx(
+	nodeID ids.ShortID, // ID of the node to remove
+	subnetID ids.ID, // ID of the subnet to remove the node from
+	keys []*crypto.PrivateKeySECP256K1R, // pay the fee and prove ownership of the subnet
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, outs, _, signers, err := vm.stake(keys, 0, vm.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := vm.authorize(vm.internalState, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Create the tx
+	utx := &UnsignedRemoveSubnetValidatorTx{
+		BaseTx: BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    vm.ctx.NetworkID,
+			BlockchainID: vm.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		NodeID:     nodeID,
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+	}
+	tx := &Tx{UnsignedTx: utx}
+	if err := tx.Sign(Codec, signers); err != nil {
+		return nil, err
+	}
+	return tx, utx.SyntacticVerify(vm.ctx)
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/snow/consensus/snowman"
+	"github.com/lasthyphen/dijetsgo/utils/crypto"
+	"github.com/lasthyphen/dijetsgo/vms/platformvm/status"
+)
+
+// acceptCommit accepts [blk] and, if it's a proposal block, its commit option
+func acceptCommit(assert *assert.Assertions, blk snowman.Block) {
+	assert.NoError(blk.Verify())
+	assert.NoError(blk.Accept())
+
+	proposalBlk, ok := blk.(*ProposalBlock)
+	if !ok {
+		return
+	}
+	options, err := proposalBlk.Options()
+	assert.NoError(err)
+	commit, ok := options[0].(*CommitBlock)
+	assert.True(ok)
+	assert.NoError(commit.Verify())
+	assert.NoError(commit.Accept())
+}
+
+// addTestSubnetValidator adds [nodeID] as a pending validator of testSubnet1
+// that starts at [startTime]
+func addTestSubnetValidator(assert *assert.Assertions, vm *VM, nodeID ids.ShortID, startTime time.Time) {
+	endTime := startTime.Add(defaultMinStakingDuration)
+	tx, err := vm.newAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		testSubnet1ControlKeys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(tx))
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	acceptCommit(assert, blk)
+}
+
+func removeTestSubnetValidator(assert *assert.Assertions, vm *VM, nodeID ids.ShortID) {
+	tx, err := vm.newRemoveSubnetValidatorTx(
+		nodeID,
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		testSubnet1ControlKeys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(tx))
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	_, ok := blk.(*StandardBlock)
+	assert.True(ok)
+	acceptCommit(assert, blk)
+
+	_, txStatus, err := vm.internalState.GetTx(tx.ID())
+	assert.NoError(err)
+	assert.Equal(status.Committed, txStatus)
+}
+
+func TestRemoveSubnetValidatorTxPending(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	nodeID := keys[0].PublicKey().Address()
+	startTime := defaultValidateStartTime.Add(syncBound).Add(1 * time.Second)
+	addTestSubnetValidator(assert, vm, nodeID, startTime)
+
+	pendingStakers := vm.internalState.PendingStakerChainState()
+	_, exists := pendingStakers.GetValidator(nodeID).SubnetValidators()[testSubnet1.ID()]
+	assert.True(exists)
+	numPendingStakers := len(pendingStakers.Stakers())
+
+	removeTestSubnetValidator(assert, vm, nodeID)
+
+	pendingStakers = vm.internalState.PendingStakerChainState()
+	_, exists = pendingStakers.GetValidator(nodeID).SubnetValidators()[testSubnet1.ID()]
+	assert.False(exists)
+	assert.Len(pendingStakers.Stakers(), numPendingStakers-1)
+
+	// The validator can be added again
+	addTestSubnetValidator(assert, vm, nodeID, startTime)
+}
+
+func TestRemoveSubnetValidatorTxCurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	nodeID := keys[0].PublicKey().Address()
+	startTime := defaultValidateStartTime.Add(syncBound).Add(1 * time.Second)
+	addTestSubnetValidator(assert, vm, nodeID, startTime)
+
+	// Advance time so that the validator starts validating
+	vm.clock.Set(startTime)
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	acceptCommit(assert, blk)
+
+	currentStakers := vm.internalState.CurrentStakerChainState()
+	vdrs, err := currentStakers.ValidatorSet(testSubnet1.ID())
+	assert.NoError(err)
+	assert.True(vdrs.Contains(nodeID))
+
+	removeTestSubnetValidator(assert, vm, nodeID)
+
+	currentStakers = vm.internalState.CurrentStakerChainState()
+	vdrs, err = currentStakers.ValidatorSet(testSubnet1.ID())
+	assert.NoError(err)
+	assert.False(vdrs.Contains(nodeID))
+	currentValidator, err := currentStakers.GetValidator(nodeID)
+	assert.NoError(err)
+	_, exists := currentValidator.SubnetValidators()[testSubnet1.ID()]
+	assert.False(exists)
+
+	// The removed validator isn't removed again at its end time
+	for _, stakerTx := range currentStakers.Stakers() {
+		_, ok := stakerTx.UnsignedTx.(*UnsignedAddSubnetValidatorTx)
+		assert.False(ok)
+	}
+}
+
+func TestRemoveSubnetValidatorTxNotValidator(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	tx, err := vm.newRemoveSubnetValidatorTx(
+		keys[0].PublicKey().Address(),
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	vs := newVersionedState(
+		vm.internalState,
+		vm.internalState.CurrentStakerChainState(),
+		vm.internalState.PendingStakerChainState(),
+	)
+	_, err = tx.UnsignedTx.(UnsignedDecisionTx).Execute(vm, vs, tx)
+	assert.ErrorIs(err, errNotSubnetValidator)
+}
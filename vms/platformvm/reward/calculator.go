@@ -6,6 +6,8 @@ package reward
 import (
 	"math/big"
 	"time"
+
+	"github.com/lasthyphen/dijetsgo/utils/math"
 )
 
 var _ Calculator = &calculator{}
@@ -57,3 +59,15 @@ func (c *calculator) Calculate(stakedDuration time.Duration, stakedAmount, curre
 
 	return reward.Uint64()
 }
+
+// Split divides [amount] between a staker charging [shares] and the staker
+// paying them. Returns the amount taken by the shares and the remaining amount.
+func Split(amount uint64, shares uint32) (uint64, uint64) {
+	remainderShares := PercentDenominator - uint64(shares)             // shares <= PercentDenominator so no underflow
+	remainderAmount := remainderShares * (amount / PercentDenominator) // remainderShares <= PercentDenominator so no overflow
+	// Delay rounding as long as possible for small numbers
+	if optimisticReward, err := math.Mul64(remainderShares, amount); err == nil {
+		remainderAmount = optimisticReward / PercentDenominator
+	}
+	return amount - remainderAmount, remainderAmount // remainderAmount <= amount so no underflow
+}
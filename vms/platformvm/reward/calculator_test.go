@@ -5,6 +5,7 @@ package reward
 
 import (
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -134,3 +135,51 @@ func TestRewards(t *testing.T) {
 		})
 	}
 }
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		amount          uint64
+		shares          uint32
+		expectedSplit   uint64
+		expectedRemains uint64
+	}{
+		{
+			amount:          1000,
+			shares:          PercentDenominator / 10,
+			expectedSplit:   100,
+			expectedRemains: 900,
+		},
+		{ // Rounding favors the shares
+			amount:          9,
+			shares:          PercentDenominator / 2,
+			expectedSplit:   5,
+			expectedRemains: 4,
+		},
+		{
+			amount:          1000,
+			shares:          PercentDenominator,
+			expectedSplit:   1000,
+			expectedRemains: 0,
+		},
+		{ // Doesn't overflow for large amounts
+			amount:          math.MaxUint64,
+			shares:          0,
+			expectedSplit:   math.MaxUint64 % PercentDenominator,
+			expectedRemains: math.MaxUint64 - math.MaxUint64%PercentDenominator,
+		},
+	}
+	for _, test := range tests {
+		name := fmt.Sprintf("split(%d,%d)==(%d,%d)",
+			test.amount,
+			test.shares,
+			test.expectedSplit,
+			test.expectedRemains,
+		)
+		t.Run(name, func(t *testing.T) {
+			split, remains := Split(test.amount, test.shares)
+			if split != test.expectedSplit || remains != test.expectedRemains {
+				t.Fatalf("expected (%d,%d); got (%d,%d)", test.expectedSplit, test.expectedRemains, split, remains)
+			}
+		})
+	}
+}
@@ -522,8 +522,14 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 
 		response.Subnets = make([]APISubnet, len(subnets)+1)
 		for i, subnet := range subnets {
-			unsignedTx := subnet.UnsignedTx.(*UnsignedCreateSubnetTx)
-			owner := unsignedTx.Owner.(*secp256k1fx.OutputOwners)
+			subnetOwner, err := service.vm.internalState.GetSubnetOwner(subnet.ID())
+			if err != nil {
+				return fmt.Errorf("error getting owner of subnet %s: %w", subnet.ID(), err)
+			}
+			owner, ok := subnetOwner.(*secp256k1fx.OutputOwners)
+			if !ok {
+				return errUnknownOwners
+			}
 			controlAddrs := []string{}
 			for _, controlKeyID := range owner.Addrs {
 				addr, err := service.vm.FormatLocalAddress(controlKeyID)
@@ -565,19 +571,17 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 			continue
 		}
 
-		subnetTx, _, err := service.vm.internalState.GetTx(subnetID)
+		subnetOwner, err := service.vm.internalState.GetSubnetOwner(subnetID)
 		if err == database.ErrNotFound {
 			continue
 		}
+		if errors.Is(err, errNotASubnet) {
+			return errWrongTxType
+		}
 		if err != nil {
 			return err
 		}
-
-		subnet, ok := subnetTx.UnsignedTx.(*UnsignedCreateSubnetTx)
-		if !ok {
-			return errWrongTxType
-		}
-		owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+		owner, ok := subnetOwner.(*secp256k1fx.OutputOwners)
 		if !ok {
 			return errUnknownOwners
 		}
@@ -593,7 +597,7 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 
 		response.Subnets = append(response.Subnets,
 			APISubnet{
-				ID:          subnet.ID(),
+				ID:          subnetID,
 				ControlKeys: controlAddrs,
 				Threshold:   json.Uint32(owner.Threshold),
 			},
@@ -642,6 +646,11 @@ type GetCurrentValidatorsArgs struct {
 	// some nodeIDs are not currently validators, they
 	// will be omitted from the response.
 	NodeIDs []string `json:"nodeIDs"`
+	// If provided, the validators are returned as of the block at [Height]
+	// rather than as of the last accepted block. The uptimes and connectivity
+	// of historical validators aren't reported. Only recent heights are
+	// retained.
+	Height *json.Uint64 `json:"height,omitempty"`
 }
 
 // GetCurrentValidatorsReply are the results from calling GetCurrentValidators.
@@ -670,13 +679,32 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 	}
 	includeAllNodes := nodeIDs.Len() == 0
 
-	currentValidators := service.vm.internalState.CurrentStakerChainState()
-
-	for _, tx := range currentValidators.Stakers() { // Iterates in order of increasing stop time
-		_, rewardAmount, err := currentValidators.GetStaker(tx.ID())
+	var (
+		stakers          []*Tx
+		potentialRewards map[ids.ID]uint64
+	)
+	if args.Height == nil {
+		currentValidators := service.vm.internalState.CurrentStakerChainState()
+		stakers = currentValidators.Stakers()
+		potentialRewards = make(map[ids.ID]uint64, len(stakers))
+		for _, tx := range stakers {
+			txID := tx.ID()
+			_, rewardAmount, err := currentValidators.GetStaker(txID)
+			if err != nil {
+				return err
+			}
+			potentialRewards[txID] = rewardAmount
+		}
+	} else {
+		var err error
+		stakers, potentialRewards, err = service.vm.getCurrentStakersAt(uint64(*args.Height))
 		if err != nil {
-			return err
+			return fmt.Errorf("couldn't get validators at height %d: %w", *args.Height, err)
 		}
+	}
+
+	for _, tx := range stakers { // Iterates in order of increasing stop time
+		rewardAmount := potentialRewards[tx.ID()]
 		switch staker := tx.UnsignedTx.(type) {
 		case *UnsignedAddDelegatorTx:
 			if args.SubnetID != constants.PrimaryNetworkID {
@@ -730,13 +758,22 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 			weight := json.Uint64(staker.Validator.Weight())
 			potentialReward := json.Uint64(rewardAmount)
 			delegationFee := json.Float32(100 * float32(staker.Shares) / float32(reward.PercentDenominator))
-			rawUptime, err := service.vm.uptimeManager.CalculateUptimePercentFrom(nodeID, startTime)
-			if err != nil {
-				return err
-			}
-			uptime := json.Float32(rawUptime)
 
-			connected := service.vm.uptimeManager.IsConnected(nodeID)
+			var (
+				uptime    *json.Float32
+				connected *bool
+			)
+			if args.Height == nil {
+				rawUptime, err := service.vm.uptimeManager.CalculateUptimePercentFrom(nodeID, startTime)
+				if err != nil {
+					return err
+				}
+				currentUptime := json.Float32(rawUptime)
+				uptime = &currentUptime
+
+				isConnected := service.vm.uptimeManager.IsConnected(nodeID)
+				connected = &isConnected
+			}
 
 			var rewardOwner *APIOwner
 			owner, ok := staker.RewardsOwner.(*secp256k1fx.OutputOwners)
@@ -754,6 +791,15 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 				}
 			}
 
+			var delegationPolicy *APIDelegationPolicy
+			if args.Height == nil {
+				policy, err := service.getDelegationPolicy(tx.ID())
+				if err != nil {
+					return err
+				}
+				delegationPolicy = policy
+			}
+
 			reply.Validators = append(reply.Validators, APIPrimaryValidator{
 				APIStaker: APIStaker{
 					TxID:        tx.ID(),
@@ -762,11 +808,12 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 					EndTime:     json.Uint64(staker.EndTime().Unix()),
 					StakeAmount: &weight,
 				},
-				Uptime:          &uptime,
-				Connected:       &connected,
-				PotentialReward: &potentialReward,
-				RewardOwner:     rewardOwner,
-				DelegationFee:   delegationFee,
+				Uptime:           uptime,
+				Connected:        connected,
+				PotentialReward:  &potentialReward,
+				RewardOwner:      rewardOwner,
+				DelegationFee:    delegationFee,
+				DelegationPolicy: delegationPolicy,
 			})
 		case *UnsignedAddSubnetValidatorTx:
 			if args.SubnetID != staker.Validator.Subnet {
@@ -872,6 +919,11 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 			weight := json.Uint64(staker.Validator.Weight())
 			delegationFee := json.Float32(100 * float32(staker.Shares) / float32(reward.PercentDenominator))
 
+			delegationPolicy, err := service.getDelegationPolicy(tx.ID())
+			if err != nil {
+				return err
+			}
+
 			connected := service.vm.uptimeManager.IsConnected(nodeID)
 			reply.Validators = append(reply.Validators, APIPrimaryValidator{
 				APIStaker: APIStaker{
@@ -881,8 +933,9 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 					EndTime:     json.Uint64(staker.EndTime().Unix()),
 					StakeAmount: &weight,
 				},
-				DelegationFee: delegationFee,
-				Connected:     &connected,
+				DelegationFee:    delegationFee,
+				Connected:        &connected,
+				DelegationPolicy: delegationPolicy,
 			})
 		case *UnsignedAddSubnetValidatorTx:
 			if args.SubnetID != staker.Validator.Subnet {
@@ -920,6 +973,100 @@ func (service *Service) GetCurrentSupply(_ *http.Request, _ *struct{}, reply *Ge
 	return nil
 }
 
+// EstimateRewardArgs are the arguments for calling EstimateReward
+type EstimateRewardArgs struct {
+	// Amount of nDJTX to stake
+	Amount json.Uint64 `json:"amount"`
+	// Number of seconds to stake for
+	Duration json.Uint64 `json:"duration"`
+	// Delegation fee rate, between 0 and 100, charged by the validator if
+	// [Amount] is delegated
+	DelegationFeeRate json.Float32 `json:"delegationFeeRate"`
+}
+
+// EstimateRewardReply are the results from calling EstimateReward
+type EstimateRewardReply struct {
+	// Supply the estimate is based on
+	CurrentSupply json.Uint64 `json:"currentSupply"`
+	// Reward for staking [Amount] for [Duration] as a validator
+	PotentialReward json.Uint64 `json:"potentialReward"`
+	// Part of [PotentialReward] the delegator receives if [Amount] is delegated
+	DelegatorReward json.Uint64 `json:"delegatorReward"`
+	// Part of [PotentialReward] the validator receives if [Amount] is delegated
+	DelegationFee json.Uint64 `json:"delegationFee"`
+}
+
+// EstimateReward returns the reward for staking an amount for a duration if
+// the staker started staking now
+func (service *Service) EstimateReward(_ *http.Request, args *EstimateRewardArgs, reply *EstimateRewardReply) error {
+	service.vm.ctx.Log.Debug("Platform: EstimateReward called")
+
+	duration := time.Duration(args.Duration) * time.Second
+	switch {
+	case args.Amount == 0:
+		return errWeightTooSmall
+	case duration < service.vm.MinStakeDuration:
+		return errStakeTooShort
+	case duration > service.vm.MaxStakeDuration:
+		return errStakeTooLong
+	case args.DelegationFeeRate < 0 || args.DelegationFeeRate > 100:
+		return errInvalidDelegationRate
+	}
+
+	currentSupply := service.vm.internalState.GetCurrentSupply()
+	potentialReward := service.vm.rewards.Calculate(duration, uint64(args.Amount), currentSupply)
+	delegationFee, delegatorReward := reward.Split(potentialReward, uint32(10000*args.DelegationFeeRate))
+
+	reply.CurrentSupply = json.Uint64(currentSupply)
+	reply.PotentialReward = json.Uint64(potentialReward)
+	reply.DelegatorReward = json.Uint64(delegatorReward)
+	reply.DelegationFee = json.Uint64(delegationFee)
+	return nil
+}
+
+// GetSupplyProjectionArgs are the arguments for calling GetSupplyProjection
+type GetSupplyProjectionArgs struct {
+	// Unix time to project the supply until
+	EndTime json.Uint64 `json:"endTime"`
+	// If true, stakers are assumed to stake again for the same amount and
+	// duration as soon as they stop staking
+	Restake bool `json:"restake"`
+}
+
+// SupplyPoint is the projected supply at a point in time
+type SupplyPoint struct {
+	// Unix time of the projection
+	Time   json.Uint64 `json:"time"`
+	Supply json.Uint64 `json:"supply"`
+}
+
+// GetSupplyProjectionReply are the results from calling GetSupplyProjection
+type GetSupplyProjectionReply struct {
+	// Projected supply after every change, starting with the current supply
+	Supply []SupplyPoint `json:"supply"`
+}
+
+// GetSupplyProjection projects the supply of DJTX from the current chain time
+// until the provided end time, given the current and pending stakers and
+// assuming all of them are rewarded
+func (service *Service) GetSupplyProjection(_ *http.Request, args *GetSupplyProjectionArgs, reply *GetSupplyProjectionReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetSupplyProjection called")
+
+	points, err := service.vm.projectSupply(time.Unix(int64(args.EndTime), 0), args.Restake)
+	if err != nil {
+		return fmt.Errorf("couldn't project supply: %w", err)
+	}
+
+	reply.Supply = make([]SupplyPoint, len(points))
+	for i, point := range points {
+		reply.Supply[i] = SupplyPoint{
+			Time:   json.Uint64(point.time.Unix()),
+			Supply: json.Uint64(point.supply),
+		}
+	}
+	return nil
+}
+
 // SampleValidatorsArgs are the arguments for calling SampleValidators
 type SampleValidatorsArgs struct {
 	// Number of validators in the sample
@@ -1363,6 +1510,283 @@ func (service *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, re
 	return errs.Err
 }
 
+// TransferSubnetOwnershipArgs are the arguments to TransferSubnetOwnership
+type TransferSubnetOwnershipArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// ID of the subnet to transfer
+	SubnetID string `json:"subnetID"`
+	// Each element in ControlKeys is the address of a new control key of the
+	// subnet
+	ControlKeys []string `json:"controlKeys"`
+	// Threshold of ControlKeys needed to manage the subnet
+	Threshold json.Uint32 `json:"threshold"`
+}
+
+// TransferSubnetOwnership creates and signs and issues a transaction to
+// replace the control keys of a subnet
+func (service *Service) TransferSubnetOwnership(_ *http.Request, args *TransferSubnetOwnershipArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: TransferSubnetOwnership called")
+
+	if args.SubnetID == "" {
+		return errNoSubnetID
+	}
+
+	// Parse the subnet ID
+	subnetID, err := ids.FromString(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("problem parsing subnetID %q: %w", args.SubnetID, err)
+	}
+	if subnetID == constants.PrimaryNetworkID {
+		return errNamedSubnetCantBePrimary
+	}
+
+	// Parse the control keys
+	controlKeys, err := djtx.ParseLocalAddresses(service.vm, args.ControlKeys)
+	if err != nil {
+		return err
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := djtx.ParseLocalAddresses(service.vm, args.From)
+	if err != nil {
+		return err
+	}
+
+	user, err := keystore.NewUserFromKeystore(service.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	keys, err := keystore.GetKeychain(user, fromAddrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address.
+	if len(keys.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := keys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.newTransferSubnetOwnershipTx(
+		subnetID,               // Subnet ID
+		uint32(args.Threshold), // Threshold
+		controlKeys.List(),     // Control Addresses
+		keys.Keys,              // Keys
+		changeAddr,             // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.blockBuilder.AddUnverifiedTx(tx),
+		user.Close(),
+	)
+	return errs.Err
+}
+
+// RemoveSubnetValidatorArgs are the arguments to RemoveSubnetValidator
+type RemoveSubnetValidatorArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// ID of the node to remove
+	NodeID string `json:"nodeID"`
+	// ID of the subnet to remove the node from
+	SubnetID string `json:"subnetID"`
+}
+
+// RemoveSubnetValidator creates and signs and issues a transaction to remove a
+// current or pending validator from a subnet other than the primary network
+func (service *Service) RemoveSubnetValidator(_ *http.Request, args *RemoveSubnetValidatorArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: RemoveSubnetValidator called")
+
+	if args.SubnetID == "" {
+		return errNoSubnetID
+	}
+
+	// Parse the node ID
+	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+	if err != nil {
+		return fmt.Errorf("error parsing nodeID: %q: %w", args.NodeID, err)
+	}
+
+	// Parse the subnet ID
+	subnetID, err := ids.FromString(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("problem parsing subnetID %q: %w", args.SubnetID, err)
+	}
+	if subnetID == constants.PrimaryNetworkID {
+		return errNamedSubnetCantBePrimary
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := djtx.ParseLocalAddresses(service.vm, args.From)
+	if err != nil {
+		return err
+	}
+
+	user, err := keystore.NewUserFromKeystore(service.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	keys, err := keystore.GetKeychain(user, fromAddrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address.
+	if len(keys.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := keys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.newRemoveSubnetValidatorTx(
+		nodeID,     // Node ID
+		subnetID,   // Subnet ID
+		keys.Keys,  // Keys
+		changeAddr, // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.blockBuilder.AddUnverifiedTx(tx),
+		user.Close(),
+	)
+	return errs.Err
+}
+
+// SetDelegationPolicyArgs are the arguments to SetDelegationPolicy
+type SetDelegationPolicyArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// ID of the tx that added the validator
+	ValidatorTxID ids.ID `json:"validatorTxID"`
+	// Policy that replaces the validator's current policy
+	APIDelegationPolicy
+}
+
+// SetDelegationPolicy creates and signs and issues a transaction to restrict
+// who can delegate to a current or pending primary network validator. The
+// issuer must control the validator's reward address.
+func (service *Service) SetDelegationPolicy(_ *http.Request, args *SetDelegationPolicyArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: SetDelegationPolicy called")
+
+	// Parse the allowed addresses
+	allowedAddrs, err := djtx.ParseLocalAddresses(service.vm, args.AllowedAddresses)
+	if err != nil {
+		return err
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := djtx.ParseLocalAddresses(service.vm, args.From)
+	if err != nil {
+		return err
+	}
+
+	user, err := keystore.NewUserFromKeystore(service.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	keys, err := keystore.GetKeychain(user, fromAddrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address.
+	if len(keys.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := keys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.newSetDelegationPolicyTx(
+		args.ValidatorTxID,             // Validator tx ID
+		allowedAddrs.List(),            // Allowed addresses
+		uint32(args.MaxDelegators),     // Max delegators
+		uint64(args.MaxDelegatorStake), // Max delegator stake
+		keys.Keys,                      // Keys
+		changeAddr,                     // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.blockBuilder.AddUnverifiedTx(tx),
+		user.Close(),
+	)
+	return errs.Err
+}
+
+// getDelegationPolicy returns the delegation policy set by the validator added
+// by [validatorTxID], or nil if it never set one
+func (service *Service) getDelegationPolicy(validatorTxID ids.ID) (*APIDelegationPolicy, error) {
+	policy, err := service.vm.internalState.GetDelegationPolicy(validatorTxID)
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get delegation policy of %s: %w", validatorTxID, err)
+	}
+
+	apiPolicy := &APIDelegationPolicy{
+		AllowedAddresses:  make([]string, len(policy.AllowedAddrs)),
+		MaxDelegators:     json.Uint32(policy.MaxDelegators),
+		MaxDelegatorStake: json.Uint64(policy.MaxDelegatorStake),
+	}
+	for i, addr := range policy.AllowedAddrs {
+		apiPolicy.AllowedAddresses[i], err = service.vm.FormatLocalAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return apiPolicy, nil
+}
+
 // ExportDJTXArgs are the arguments to ExportDJTX
 type ExportDJTXArgs struct {
 	// User, password, from addrs, change addr
@@ -2304,6 +2728,105 @@ func (service *Service) GetTimestamp(_ *http.Request, args *struct{}, reply *Get
 	return nil
 }
 
+// MempoolTx is a tx in the mempool along with the fee it pays
+type MempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// Type of the tx
+	Type string `json:"type"`
+	// Size of the tx in bytes
+	Size json.Uint64 `json:"size"`
+	// Amount of nDJTX the tx burns
+	Burned json.Uint64 `json:"burned"`
+	// Amount of nDJTX the tx is required to burn
+	RequiredFee json.Uint64 `json:"requiredFee"`
+	// Amount of nDJTX the tx burns above the required fee
+	EffectiveFee json.Uint64 `json:"effectiveFee"`
+	// Effective fee paid per byte of the tx, in nDJTX
+	FeeRate json.Uint64 `json:"feeRate"`
+}
+
+// GetMempoolReply is the response from GetMempool
+type GetMempoolReply struct {
+	// Number of bytes of space available in the mempool
+	BytesAvailable json.Uint64 `json:"bytesAvailable"`
+	// Decision txs in the order they will be issued
+	DecisionTxs []MempoolTx `json:"decisionTxs"`
+	// Proposal txs ordered by start time
+	ProposalTxs []MempoolTx `json:"proposalTxs"`
+}
+
+// GetMempool returns the txs in the mempool along with the fees they pay
+func (service *Service) GetMempool(_ *http.Request, _ *struct{}, reply *GetMempoolReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetMempool called")
+
+	mempool := service.vm.blockBuilder.Mempool
+	reply.BytesAvailable = json.Uint64(mempool.BytesAvailable())
+	reply.DecisionTxs = service.mempoolTxs(mempool, mempool.DecisionTxs())
+	reply.ProposalTxs = service.mempoolTxs(mempool, mempool.ProposalTxs())
+	return nil
+}
+
+// mempoolTxs returns the API representation of [txs]
+func (service *Service) mempoolTxs(mempool Mempool, txs []*Tx) []MempoolTx {
+	mempoolTxs := make([]MempoolTx, len(txs))
+	for i, tx := range txs {
+		fee, _ := mempool.GetFee(tx.ID())
+		mempoolTxs[i] = MempoolTx{
+			TxID:         tx.ID(),
+			Type:         txType(tx.UnsignedTx),
+			Size:         json.Uint64(fee.Size),
+			Burned:       json.Uint64(fee.Burned),
+			RequiredFee:  json.Uint64(fee.Required),
+			EffectiveFee: json.Uint64(fee.Effective()),
+			FeeRate:      json.Uint64(fee.Rate()),
+		}
+	}
+	return mempoolTxs
+}
+
+// SimulateTxReply is the response from SimulateTx
+type SimulateTxReply struct {
+	// ID of the simulated tx, or nil if it wasn't signed
+	TxID   *ids.ID `json:"txID,omitempty"`
+	Signed bool    `json:"signed"`
+	// True if the tx would pass verification and be added to a block
+	Valid bool `json:"valid"`
+	// Reasons the tx would fail verification
+	Failures []SimulationFailure `json:"failures"`
+	// Reward the staker would receive if the tx was accepted now, before any
+	// delegation fee is paid
+	PotentialReward json.Uint64 `json:"potentialReward"`
+}
+
+// SimulateTx runs a signed or unsigned staker tx through the same
+// verification it would go through if it was issued, without issuing it.
+// Unsigned txs are verified as if they were correctly signed.
+func (service *Service) SimulateTx(_ *http.Request, args *api.FormattedTx, reply *SimulateTxReply) error {
+	service.vm.ctx.Log.Debug("Platform: SimulateTx called")
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	result, err := service.vm.simulateTx(txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't simulate tx: %w", err)
+	}
+
+	if result.signed {
+		txID := result.tx.ID()
+		reply.TxID = &txID
+	}
+	reply.Signed = result.signed
+	reply.Valid = len(result.failures) == 0
+	reply.Failures = result.failures
+	if reply.Failures == nil {
+		reply.Failures = []SimulationFailure{}
+	}
+	reply.PotentialReward = json.Uint64(result.potentialReward)
+	return nil
+}
+
 // GetValidatorsAtArgs is the response from GetValidatorsAt
 type GetValidatorsAtArgs struct {
 	Height   json.Uint64 `json:"height"`
@@ -19,6 +19,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/api"
 	"github.com/lasthyphen/dijetsgo/api/keystore"
 	"github.com/lasthyphen/dijetsgo/chains/atomic"
+	"github.com/lasthyphen/dijetsgo/database"
 	"github.com/lasthyphen/dijetsgo/database/manager"
 	"github.com/lasthyphen/dijetsgo/database/prefixdb"
 	"github.com/lasthyphen/dijetsgo/ids"
@@ -26,8 +27,10 @@ import (
 	"github.com/lasthyphen/dijetsgo/utils/crypto"
 	"github.com/lasthyphen/dijetsgo/utils/formatting"
 	"github.com/lasthyphen/dijetsgo/utils/logging"
+	"github.com/lasthyphen/dijetsgo/utils/units"
 	"github.com/lasthyphen/dijetsgo/version"
 	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
+	"github.com/lasthyphen/dijetsgo/vms/platformvm/reward"
 	"github.com/lasthyphen/dijetsgo/vms/platformvm/status"
 	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
 
@@ -767,6 +770,297 @@ func TestGetTimestamp(t *testing.T) {
 	assert.Equal(newTimestamp, reply.Timestamp)
 }
 
+func TestGetMempool(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	tx := getValidTx(service.vm, t)
+	assert.NoError(service.vm.blockBuilder.AddUnverifiedTx(tx))
+
+	reply := GetMempoolReply{}
+	assert.NoError(service.GetMempool(nil, nil, &reply))
+	assert.Empty(reply.ProposalTxs)
+	assert.Len(reply.DecisionTxs, 1)
+	assert.Equal(uint64(maxMempoolSize-len(tx.Bytes())), uint64(reply.BytesAvailable))
+
+	mempoolTx := reply.DecisionTxs[0]
+	createBlockchainTxFee := service.vm.getCreateBlockchainTxFee(service.vm.internalState.GetTimestamp())
+	assert.Equal(tx.ID(), mempoolTx.TxID)
+	assert.Equal("CreateChainTx", mempoolTx.Type)
+	assert.Equal(uint64(len(tx.Bytes())), uint64(mempoolTx.Size))
+	assert.Equal(createBlockchainTxFee, uint64(mempoolTx.Burned))
+	assert.Equal(createBlockchainTxFee, uint64(mempoolTx.RequiredFee))
+	assert.Zero(uint64(mempoolTx.EffectiveFee))
+	assert.Zero(uint64(mempoolTx.FeeRate))
+}
+
+func TestEstimateReward(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	args := EstimateRewardArgs{
+		Amount:            cjson.Uint64(units.KiloDjtx),
+		Duration:          cjson.Uint64(defaultMinStakingDuration / time.Second),
+		DelegationFeeRate: 10,
+	}
+	reply := EstimateRewardReply{}
+	assert.NoError(service.EstimateReward(nil, &args, &reply))
+
+	currentSupply := service.vm.internalState.GetCurrentSupply()
+	expectedReward := service.vm.rewards.Calculate(defaultMinStakingDuration, units.KiloDjtx, currentSupply)
+	assert.NotZero(expectedReward)
+	assert.Equal(currentSupply, uint64(reply.CurrentSupply))
+	assert.Equal(expectedReward, uint64(reply.PotentialReward))
+	expectedFee, expectedDelegatorReward := reward.Split(expectedReward, reward.PercentDenominator/10)
+	assert.Equal(expectedDelegatorReward, uint64(reply.DelegatorReward))
+	assert.Equal(expectedFee, uint64(reply.DelegationFee))
+
+	args.Duration = cjson.Uint64((defaultMinStakingDuration - time.Second) / time.Second)
+	assert.ErrorIs(service.EstimateReward(nil, &args, &reply), errStakeTooShort)
+
+	args.Duration = cjson.Uint64(defaultMinStakingDuration / time.Second)
+	args.DelegationFeeRate = 101
+	assert.ErrorIs(service.EstimateReward(nil, &args, &reply), errInvalidDelegationRate)
+}
+
+func TestGetSupplyProjection(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+	vm := service.vm
+
+	currentSupply := vm.internalState.GetCurrentSupply()
+	endTime := defaultValidateEndTime.Add(time.Second)
+	args := GetSupplyProjectionArgs{
+		EndTime: cjson.Uint64(endTime.Unix()),
+	}
+
+	// The genesis validators are already staking, so their rewards were
+	// already minted
+	reply := GetSupplyProjectionReply{}
+	assert.NoError(service.GetSupplyProjection(nil, &args, &reply))
+	assert.Equal([]SupplyPoint{
+		{Time: cjson.Uint64(defaultGenesisTime.Unix()), Supply: cjson.Uint64(currentSupply)},
+		{Time: cjson.Uint64(endTime.Unix()), Supply: cjson.Uint64(currentSupply)},
+	}, reply.Supply)
+
+	// If the genesis validators restake, their new rewards are minted when
+	// they stop staking
+	expectedSupply := currentSupply
+	for range keys {
+		expectedSupply += vm.rewards.Calculate(
+			defaultValidateEndTime.Sub(defaultValidateStartTime),
+			defaultWeight,
+			expectedSupply,
+		)
+	}
+	assert.Greater(expectedSupply, currentSupply)
+
+	args.Restake = true
+	assert.NoError(service.GetSupplyProjection(nil, &args, &reply))
+	assert.Equal([]SupplyPoint{
+		{Time: cjson.Uint64(defaultGenesisTime.Unix()), Supply: cjson.Uint64(currentSupply)},
+		{Time: cjson.Uint64(defaultValidateEndTime.Unix()), Supply: cjson.Uint64(expectedSupply)},
+		{Time: cjson.Uint64(endTime.Unix()), Supply: cjson.Uint64(expectedSupply)},
+	}, reply.Supply)
+
+	args.EndTime = cjson.Uint64(defaultGenesisTime.Unix())
+	assert.ErrorIs(service.GetSupplyProjection(nil, &args, &reply), errProjectionEndTooEarly)
+}
+
+func TestGetCurrentValidatorsAtHeight(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+	vm := service.vm
+
+	// acceptProposal builds a proposal block and accepts its preferred option
+	acceptProposal := func() {
+		blk, err := vm.BuildBlock()
+		assert.NoError(err)
+		assert.NoError(blk.Verify())
+		assert.NoError(blk.Accept())
+		options, err := blk.(*ProposalBlock).Options()
+		assert.NoError(err)
+		assert.NoError(options[0].Verify())
+		assert.NoError(options[0].Accept())
+		assert.NoError(vm.SetPreference(options[0].ID()))
+	}
+	getValidators := func(height *cjson.Uint64) []interface{} {
+		reply := GetCurrentValidatorsReply{}
+		assert.NoError(service.GetCurrentValidators(nil, &GetCurrentValidatorsArgs{
+			SubnetID: constants.PrimaryNetworkID,
+			Height:   height,
+		}, &reply))
+		return reply.Validators
+	}
+	heightPtr := func(height uint64) *cjson.Uint64 {
+		h := cjson.Uint64(height)
+		return &h
+	}
+
+	startTime := defaultGenesisTime.Add(syncBound).Add(time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	nodeID := ids.GenerateTestShortID()
+	tx, err := vm.newAddValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		nodeID,
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(tx))
+	acceptProposal() // heights 1 and 2
+
+	// The validator starts validating at height 4
+	vm.clock.Set(startTime)
+	acceptProposal() // heights 3 and 4
+	_, potentialReward, err := vm.internalState.CurrentStakerChainState().GetStaker(tx.ID())
+	assert.NoError(err)
+
+	// The validator stops validating at height 8
+	vm.clock.Set(endTime)
+	acceptProposal() // heights 5 and 6
+	acceptProposal() // heights 7 and 8
+	height, err := vm.GetCurrentHeight()
+	assert.NoError(err)
+	assert.Equal(uint64(8), height)
+
+	assert.Len(getValidators(heightPtr(0)), len(keys))
+	assert.Len(getValidators(heightPtr(3)), len(keys))
+	assert.Len(getValidators(heightPtr(7)), len(keys)+1)
+	assert.Len(getValidators(heightPtr(8)), len(keys))
+	assert.Len(getValidators(nil), len(keys))
+
+	validators := getValidators(heightPtr(7))
+	assert.Len(validators, len(keys)+1)
+	var found bool
+	for _, vdrIntf := range validators {
+		vdr, ok := vdrIntf.(APIPrimaryValidator)
+		assert.True(ok)
+		// Uptimes aren't reported for past heights
+		assert.Nil(vdr.Uptime)
+		assert.Nil(vdr.Connected)
+		if vdr.TxID != tx.ID() {
+			continue
+		}
+		found = true
+		assert.Equal(nodeID.PrefixedString(constants.NodeIDPrefix), vdr.NodeID)
+		assert.Equal(startTime.Unix(), int64(vdr.StartTime))
+		assert.Equal(endTime.Unix(), int64(vdr.EndTime))
+		assert.Equal(potentialReward, uint64(*vdr.PotentialReward))
+	}
+	assert.True(found)
+
+	// Future heights aren't known
+	err = service.GetCurrentValidators(nil, &GetCurrentValidatorsArgs{
+		Height: heightPtr(9),
+	}, &GetCurrentValidatorsReply{})
+	assert.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestGetCurrentValidatorsAtPrunedHeight(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+	vm := service.vm
+	is := vm.internalState.(*internalStateImpl)
+	is.stakerDiffsRetention = 2
+
+	acceptProposal := func() {
+		blk, err := vm.BuildBlock()
+		assert.NoError(err)
+		assert.NoError(blk.Verify())
+		assert.NoError(blk.Accept())
+		options, err := blk.(*ProposalBlock).Options()
+		assert.NoError(err)
+		assert.NoError(options[0].Verify())
+		assert.NoError(options[0].Accept())
+		assert.NoError(vm.SetPreference(options[0].ID()))
+	}
+
+	startTime := defaultGenesisTime.Add(syncBound).Add(time.Second)
+	nodeID := ids.GenerateTestShortID()
+	tx, err := vm.newAddValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+		nodeID,
+		nodeID,
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(tx))
+	acceptProposal() // heights 1 and 2
+
+	// The validator starts validating at height 4
+	vm.clock.Set(startTime)
+	acceptProposal() // heights 3 and 4
+	_, err = is.stakerDiffsDB.Get(database.PackUInt64(4))
+	assert.NoError(err)
+
+	// The validator stops validating at height 6
+	vm.clock.Set(startTime.Add(defaultMinStakingDuration))
+	acceptProposal() // heights 5 and 6
+	height, err := vm.GetCurrentHeight()
+	assert.NoError(err)
+	assert.Equal(uint64(6), height)
+
+	// Only the last 2 heights below the current height can be reconstructed
+	assert.Equal(uint64(4), vm.internalState.GetStakerDiffsHeight())
+	reply := GetCurrentValidatorsReply{}
+	h := cjson.Uint64(4)
+	assert.NoError(service.GetCurrentValidators(nil, &GetCurrentValidatorsArgs{Height: &h}, &reply))
+	h = 3
+	err = service.GetCurrentValidators(nil, &GetCurrentValidatorsArgs{Height: &h}, &GetCurrentValidatorsReply{})
+	assert.ErrorIs(err, errNoStakerHistory)
+
+	// The diffs below the retained heights were removed, and the lowest
+	// retained height was persisted by the last commit
+	_, err = is.stakerDiffsDB.Get(database.PackUInt64(3))
+	assert.ErrorIs(err, database.ErrNotFound)
+	_, err = is.stakerDiffsDB.Get(database.PackUInt64(4))
+	assert.NoError(err)
+	stakerDiffsHeight, err := database.GetUInt64(is.singletonDB, stakerDiffsKey)
+	assert.NoError(err)
+	assert.Equal(uint64(4), stakerDiffsHeight)
+}
+
 func TestGetBlock(t *testing.T) {
 	tests := []struct {
 		name     string
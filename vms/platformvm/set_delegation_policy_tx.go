@@ -0,0 +1,288 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsgo/chains/atomic"
+	"github.com/lasthyphen/dijetsgo/database"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/snow"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+	"github.com/lasthyphen/dijetsgo/utils/crypto"
+	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
+	"github.com/lasthyphen/dijetsgo/vms/components/verify"
+)
+
+// maxDelegationPolicyAddrs is the maximum number of addresses a delegation
+// policy can allow to delegate
+const maxDelegationPolicyAddrs = 1024
+
+var (
+	errTooManyAllowedAddrs       = fmt.Errorf("delegation policy can't allow more than %d addresses", maxDelegationPolicyAddrs)
+	errAllowedAddrsNotSorted     = errors.New("allowed addresses not sorted and unique")
+	errNotAValidator             = errors.New("not a primary network validator")
+	errDelegatorNotAllowed       = errors.New("delegator isn't allowed by the validator's delegation policy")
+	errTooManyDelegators         = errors.New("validator would have more delegators than its delegation policy allows")
+	errDelegatorStakeAbovePolicy = errors.New("delegator stake is larger than the validator's delegation policy allows")
+
+	_ UnsignedDecisionTx = &UnsignedSetDelegationPolicyTx{}
+)
+
+// DelegationPolicy restricts who can delegate to a validator, and how much.
+// The zero value allows anyone to delegate up to the delegation cap.
+type DelegationPolicy struct {
+	// If non-empty, the only addresses allowed to own a delegator's stake and
+	// rewards. Must be sorted and unique.
+	AllowedAddrs []ids.ShortID `serialize:"true" json:"allowedAddresses"`
+	// Maximum number of delegators that can delegate at the same time. 0 means
+	// there is no limit.
+	MaxDelegators uint32 `serialize:"true" json:"maxDelegators"`
+	// Maximum amount a single delegator can stake. 0 means there is no limit.
+	MaxDelegatorStake uint64 `serialize:"true" json:"maxDelegatorStake"`
+}
+
+// Verify returns nil iff [p] is well-formed
+func (p *DelegationPolicy) Verify() error {
+	switch {
+	case len(p.AllowedAddrs) > maxDelegationPolicyAddrs:
+		return errTooManyAllowedAddrs
+	case !ids.IsSortedAndUniqueShortIDs(p.AllowedAddrs):
+		return errAllowedAddrsNotSorted
+	default:
+		return nil
+	}
+}
+
+// allows returns true if every address that owns the stake or rewards of
+// [tx] is allowed to delegate by [p]
+func (p *DelegationPolicy) allows(tx *UnsignedAddDelegatorTx) bool {
+	if len(p.AllowedAddrs) == 0 {
+		return true
+	}
+
+	allowed := ids.ShortSet{}
+	allowed.Add(p.AllowedAddrs...)
+
+	owners := make([]interface{}, 0, len(tx.Stake)+1)
+	for _, out := range tx.Stake {
+		owners = append(owners, out.Output())
+	}
+	owners = append(owners, tx.RewardsOwner)
+	for _, ownerIntf := range owners {
+		if lockedOut, ok := ownerIntf.(*StakeableLockOut); ok {
+			ownerIntf = lockedOut.TransferableOut
+		}
+		owner, ok := ownerIntf.(djtx.Addressable)
+		if !ok {
+			return false
+		}
+		for _, addrBytes := range owner.Addresses() {
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil || !allowed.Contains(addr) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// UnsignedSetDelegationPolicyTx is an unsigned setDelegationPolicyTx
+type UnsignedSetDelegationPolicyTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the tx that added the validator this policy applies to
+	ValidatorTxID ids.ID `serialize:"true" json:"validatorTxID"`
+	// Proves that the issuer owns the validator's rewards
+	ValidatorAuth verify.Verifiable `serialize:"true" json:"validatorAuthorization"`
+	// Policy that replaces the validator's current policy
+	Policy DelegationPolicy `serialize:"true" json:"policy"`
+}
+
+// InputUTXOs for [DecisionTxs] will return an empty set to diffrentiate from the [AtomicTxs] input UTXOs
+func (tx *UnsignedSetDelegationPolicyTx) InputUTXOs() ids.Set { return nil }
+
+func (tx *UnsignedSetDelegationPolicyTx) AtomicOperations() (ids.ID, *atomic.Requests, error) {
+	return ids.ID{}, nil, nil
+}
+
+// SyntacticVerify verifies that this transaction is well-formed
+func (tx *UnsignedSetDelegationPolicyTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case tx.syntacticallyVerified: // already passed syntactic verification
+		return nil
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := verify.All(tx.ValidatorAuth, &tx.Policy); err != nil {
+		return err
+	}
+
+	tx.syntacticallyVerified = true
+	return nil
+}
+
+// Attempts to verify this transaction with the provided state.
+func (tx *UnsignedSetDelegationPolicyTx) SemanticVerify(vm *VM, parentState MutableState, stx *Tx) error {
+	vs := newVersionedState(
+		parentState,
+		parentState.CurrentStakerChainState(),
+		parentState.PendingStakerChainState(),
+	)
+	_, err := tx.Execute(vm, vs, stx)
+	return err
+}
+
+// Execute this transaction.
+func (tx *UnsignedSetDelegationPolicyTx) Execute(
+	vm *VM,
+	vs VersionedState,
+	stx *Tx,
+) (
+	func() error,
+	error,
+) {
+	// Make sure this transaction is well formed.
+	if len(stx.Creds) == 0 {
+		return nil, errWrongNumberOfCredentials
+	}
+	if err := tx.SyntacticVerify(vm.ctx); err != nil {
+		return nil, err
+	}
+	if err := vm.verifyApricotPhase6(tx, vs.GetTimestamp()); err != nil {
+		return nil, err
+	}
+
+	// Select the credentials for each purpose
+	baseTxCredsLen := len(stx.Creds) - 1
+	baseTxCreds := stx.Creds[:baseTxCredsLen]
+	validatorCred := stx.Creds[baseTxCredsLen]
+
+	vdrTx, err := getStakingValidatorTx(vs, tx.ValidatorTxID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that the policy is set by the owner of the validator's rewards
+	if err := vm.fx.VerifyPermission(tx, tx.ValidatorAuth, validatorCred, vdrTx.RewardsOwner); err != nil {
+		return nil, err
+	}
+
+	// Verify the flowcheck
+	if err := vm.semanticVerifySpend(vm.fx, vs, tx, tx.Ins, tx.Outs, baseTxCreds, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
+		return nil, err
+	}
+
+	// Consume the UTXOS
+	consumeInputs(vs, tx.Ins)
+	// Produce the UTXOS
+	txID := tx.ID()
+	produceOutputs(vs, txID, vm.ctx.DJTXAssetID, tx.Outs)
+	// Replace the policy of the validator
+	policy := tx.Policy
+	vs.SetDelegationPolicy(tx.ValidatorTxID, &policy)
+
+	return nil, nil
+}
+
+// getStakingValidatorTx returns the tx with ID [validatorTxID] if it added a
+// current or pending primary network validator
+func getStakingValidatorTx(vs MutableState, validatorTxID ids.ID) (*UnsignedAddValidatorTx, error) {
+	tx, _, err := vs.GetTx(validatorTxID)
+	if err == database.ErrNotFound {
+		return nil, fmt.Errorf("%w: %s isn't a known tx", errNotAValidator, validatorTxID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	vdrTx, ok := tx.UnsignedTx.(*UnsignedAddValidatorTx)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s isn't an addValidatorTx", errNotAValidator, validatorTxID)
+	}
+
+	nodeID := vdrTx.Validator.NodeID
+	currentValidator, err := vs.CurrentStakerChainState().GetValidator(nodeID)
+	switch err {
+	case nil:
+		if currentValidator.AddValidatorTx().ID() == validatorTxID {
+			return vdrTx, nil
+		}
+	case database.ErrNotFound:
+	default:
+		return nil, err
+	}
+
+	pendingTx, err := vs.PendingStakerChainState().GetValidatorTx(nodeID)
+	switch err {
+	case nil:
+		if pendingTx.ID() == validatorTxID {
+			return vdrTx, nil
+		}
+	case database.ErrNotFound:
+	default:
+		return nil, err
+	}
+	return nil, fmt.Errorf(
+		"%w: %s added %s, which isn't current or pending",
+		errNotAValidator,
+		validatorTxID,
+		nodeID.PrefixedString(constants.NodeIDPrefix),
+	)
+}
+
+// [allowedAddrs] must be unique. They will be sorted by this method.
+func (vm *VM) newSetDelegationPolicyTx(
+	validatorTxID ids.ID, // ID of the tx that added the validator
+	allowedAddrs []ids.ShortID, // addresses allowed to delegate, empty for anyone
+	maxDelegators uint32, // maximum number of concurrent delegators, 0 for no limit
+	maxDelegatorStake uint64, // maximum stake of a delegator, 0 for no limit
+	keys []*crypto.PrivateKeySECP256K1R, // pay the fee and prove ownership of the validator's rewards
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, outs, _, signers, err := vm.stake(keys, 0, vm.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	vdrTx, err := getStakingValidatorTx(vm.internalState, validatorTxID)
+	if err != nil {
+		return nil, err
+	}
+	validatorAuth, validatorSigners, err := vm.authorizeOwner(vdrTx.RewardsOwner, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's validator: %w", err)
+	}
+	signers = append(signers, validatorSigners)
+
+	// Sort allowed addresses
+	ids.SortShortIDs(allowedAddrs)
+
+	// Create the tx
+	utx := &UnsignedSetDelegationPolicyTx{
+		BaseTx: BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    vm.ctx.NetworkID,
+			BlockchainID: vm.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		ValidatorTxID: validatorTxID,
+		ValidatorAuth: validatorAuth,
+		Policy: DelegationPolicy{
+			AllowedAddrs:      allowedAddrs,
+			MaxDelegators:     maxDelegators,
+			MaxDelegatorStake: maxDelegatorStake,
+		},
+	}
+	tx := &Tx{UnsignedTx: utx}
+	if err := tx.Sign(Codec, signers); err != nil {
+		return nil, err
+	}
+	return tx, utx.SyntacticVerify(vm.ctx)
+}
@@ -0,0 +1,345 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+	"github.com/lasthyphen/dijetsgo/utils/crypto"
+	"github.com/lasthyphen/dijetsgo/utils/json"
+	"github.com/lasthyphen/dijetsgo/vms/platformvm/reward"
+	"github.com/lasthyphen/dijetsgo/vms/platformvm/status"
+)
+
+// setTestDelegationPolicy issues and accepts a tx setting the delegation policy
+// of the validator added by [validatorTxID]
+func setTestDelegationPolicy(
+	assert *assert.Assertions,
+	vm *VM,
+	validatorTxID ids.ID,
+	policy DelegationPolicy,
+	txKeys []*crypto.PrivateKeySECP256K1R,
+) {
+	tx, err := vm.newSetDelegationPolicyTx(
+		validatorTxID,
+		policy.AllowedAddrs,
+		policy.MaxDelegators,
+		policy.MaxDelegatorStake,
+		txKeys,
+		txKeys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(tx))
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	_, ok := blk.(*StandardBlock)
+	assert.True(ok)
+	acceptCommit(assert, blk)
+
+	_, txStatus, err := vm.internalState.GetTx(tx.ID())
+	assert.NoError(err)
+	assert.Equal(status.Committed, txStatus)
+}
+
+func TestDelegationPolicyVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	addrs := []ids.ShortID{ids.GenerateTestShortID(), ids.GenerateTestShortID()}
+	ids.SortShortIDs(addrs)
+
+	policy := DelegationPolicy{}
+	assert.NoError(policy.Verify())
+
+	policy.AllowedAddrs = addrs
+	assert.NoError(policy.Verify())
+
+	policy.AllowedAddrs = []ids.ShortID{addrs[1], addrs[0]}
+	assert.ErrorIs(policy.Verify(), errAllowedAddrsNotSorted)
+
+	policy.AllowedAddrs = []ids.ShortID{addrs[0], addrs[0]}
+	assert.ErrorIs(policy.Verify(), errAllowedAddrsNotSorted)
+
+	policy.AllowedAddrs = make([]ids.ShortID, maxDelegationPolicyAddrs+1)
+	assert.ErrorIs(policy.Verify(), errTooManyAllowedAddrs)
+}
+
+func TestSetDelegationPolicyTxNotValidator(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	_, err := vm.newSetDelegationPolicyTx(
+		ids.GenerateTestID(),
+		nil,
+		1,
+		0,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.ErrorIs(err, errNotAValidator)
+
+	_, err = vm.newSetDelegationPolicyTx(
+		testSubnet1.ID(),
+		nil,
+		1,
+		0,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.ErrorIs(err, errNotAValidator)
+}
+
+func TestSetDelegationPolicyTxCurrentValidator(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	nodeID := keys[0].PublicKey().Address()
+	currentValidator, err := vm.internalState.CurrentStakerChainState().GetValidator(nodeID)
+	assert.NoError(err)
+	validatorTxID := currentValidator.AddValidatorTx().ID()
+
+	// Only the owner of the validator's rewards can set its policy
+	_, err = vm.newSetDelegationPolicyTx(
+		validatorTxID,
+		nil,
+		1,
+		0,
+		[]*crypto.PrivateKeySECP256K1R{keys[1]},
+		keys[1].PublicKey().Address(), // change addr
+	)
+	assert.ErrorIs(err, errCantSign)
+
+	allowedAddr := keys[1].PublicKey().Address()
+	setTestDelegationPolicy(
+		assert,
+		vm,
+		validatorTxID,
+		DelegationPolicy{
+			AllowedAddrs:      []ids.ShortID{allowedAddr},
+			MaxDelegators:     3,
+			MaxDelegatorStake: vm.MinDelegatorStake,
+		},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+	)
+
+	// The policy is persisted
+	_, err = vm.internalState.(*internalStateImpl).delegationPolicyDB.Get(validatorTxID[:])
+	assert.NoError(err)
+
+	// The policy is reported by the API
+	allowedAddrStr, err := vm.FormatLocalAddress(allowedAddr)
+	assert.NoError(err)
+	service := Service{vm: vm}
+	reply := GetCurrentValidatorsReply{}
+	assert.NoError(service.GetCurrentValidators(nil, &GetCurrentValidatorsArgs{
+		NodeIDs: []string{nodeID.PrefixedString(constants.NodeIDPrefix)},
+	}, &reply))
+	assert.Len(reply.Validators, 1)
+	vdr, ok := reply.Validators[0].(APIPrimaryValidator)
+	assert.True(ok)
+	assert.Equal(&APIDelegationPolicy{
+		AllowedAddresses:  []string{allowedAddrStr},
+		MaxDelegators:     3,
+		MaxDelegatorStake: json.Uint64(vm.MinDelegatorStake),
+	}, vdr.DelegationPolicy)
+
+	// Validators that never set a policy don't report one
+	reply = GetCurrentValidatorsReply{}
+	assert.NoError(service.GetCurrentValidators(nil, &GetCurrentValidatorsArgs{
+		NodeIDs: []string{keys[1].PublicKey().Address().PrefixedString(constants.NodeIDPrefix)},
+	}, &reply))
+	assert.Len(reply.Validators, 1)
+	vdr, ok = reply.Validators[0].(APIPrimaryValidator)
+	assert.True(ok)
+	assert.Nil(vdr.DelegationPolicy)
+}
+
+func TestSetDelegationPolicyTxDelegators(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Add a pending validator that can accept delegations
+	nodeID := ids.GenerateTestShortID()
+	rewardsKey := keys[1]
+	vdrStartTime := defaultValidateStartTime.Add(syncBound).Add(1 * time.Second)
+	vdrEndTime := vdrStartTime.Add(3 * defaultMinStakingDuration)
+	vdrTx, err := vm.newAddValidatorTx(
+		vm.MinValidatorStake,
+		uint64(vdrStartTime.Unix()),
+		uint64(vdrEndTime.Unix()),
+		nodeID,
+		rewardsKey.PublicKey().Address(),
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(vdrTx))
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	acceptCommit(assert, blk)
+
+	// The policy is authorized by the rewards owner, not the staker
+	_, err = vm.newSetDelegationPolicyTx(
+		vdrTx.ID(),
+		nil,
+		1,
+		0,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.ErrorIs(err, errCantSign)
+
+	allowedKey := keys[2]
+	allowedAddr := allowedKey.PublicKey().Address()
+	setTestDelegationPolicy(
+		assert,
+		vm,
+		vdrTx.ID(),
+		DelegationPolicy{
+			AllowedAddrs:      []ids.ShortID{allowedAddr},
+			MaxDelegators:     1,
+			MaxDelegatorStake: 2 * vm.MinDelegatorStake,
+		},
+		[]*crypto.PrivateKeySECP256K1R{keys[0], rewardsKey},
+	)
+
+	// The policy of the pending validator is reported by the API
+	service := Service{vm: vm}
+	reply := GetPendingValidatorsReply{}
+	assert.NoError(service.GetPendingValidators(nil, &GetPendingValidatorsArgs{
+		NodeIDs: []string{nodeID.PrefixedString(constants.NodeIDPrefix)},
+	}, &reply))
+	assert.Len(reply.Validators, 1)
+	vdr, ok := reply.Validators[0].(APIPrimaryValidator)
+	assert.True(ok)
+	assert.NotNil(vdr.DelegationPolicy)
+	assert.EqualValues(1, vdr.DelegationPolicy.MaxDelegators)
+
+	newDelegatorTx := func(weight uint64, startTime time.Time, owner ids.ShortID) *Tx {
+		tx, err := vm.newAddDelegatorTx(
+			weight,
+			uint64(startTime.Unix()),
+			uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+			nodeID,
+			owner, // reward address
+			[]*crypto.PrivateKeySECP256K1R{keys[0]},
+			owner, // change addr, which also owns the stake
+		)
+		assert.NoError(err)
+		return tx
+	}
+	verifyDelegator := func(tx *Tx) error {
+		return tx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.internalState, tx)
+	}
+
+	delegatorStartTime := vdrStartTime.Add(time.Second)
+
+	// Delegators must be owned by allowed addresses
+	err = verifyDelegator(newDelegatorTx(vm.MinDelegatorStake, delegatorStartTime, keys[0].PublicKey().Address()))
+	assert.ErrorIs(err, errDelegatorNotAllowed)
+
+	// Delegators can't stake more than the policy allows
+	err = verifyDelegator(newDelegatorTx(3*vm.MinDelegatorStake, delegatorStartTime, allowedAddr))
+	assert.ErrorIs(err, errDelegatorStakeAbovePolicy)
+
+	firstDelegatorTx := newDelegatorTx(vm.MinDelegatorStake, delegatorStartTime, allowedAddr)
+	assert.NoError(verifyDelegator(firstDelegatorTx))
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(firstDelegatorTx))
+	blk, err = vm.BuildBlock()
+	assert.NoError(err)
+	acceptCommit(assert, blk)
+
+	// The validator can only have one delegator at a time
+	err = verifyDelegator(newDelegatorTx(vm.MinDelegatorStake, delegatorStartTime.Add(time.Second), allowedAddr))
+	assert.ErrorIs(err, errTooManyDelegators)
+
+	// A delegator can start once the previous one stops
+	laterStartTime := delegatorStartTime.Add(defaultMinStakingDuration)
+	assert.NoError(verifyDelegator(newDelegatorTx(vm.MinDelegatorStake, laterStartTime, allowedAddr)))
+}
+
+func TestDelegationPolicyApricotPhase6(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Add a pending validator that can accept delegations
+	nodeID := ids.GenerateTestShortID()
+	rewardsKey := keys[1]
+	vdrStartTime := defaultValidateStartTime.Add(syncBound).Add(1 * time.Second)
+	vdrTx, err := vm.newAddValidatorTx(
+		vm.MinValidatorStake,
+		uint64(vdrStartTime.Unix()),
+		uint64(vdrStartTime.Add(2*defaultMinStakingDuration).Unix()),
+		nodeID,
+		rewardsKey.PublicKey().Address(),
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(vdrTx))
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	acceptCommit(assert, blk)
+
+	allowedAddr := keys[2].PublicKey().Address()
+	setTestDelegationPolicy(
+		assert,
+		vm,
+		vdrTx.ID(),
+		DelegationPolicy{AllowedAddrs: []ids.ShortID{allowedAddr}},
+		[]*crypto.PrivateKeySECP256K1R{keys[0], rewardsKey},
+	)
+
+	delegatorStartTime := vdrStartTime.Add(time.Second)
+	notAllowedAddr := keys[3].PublicKey().Address()
+	delegatorTx, err := vm.newAddDelegatorTx(
+		vm.MinDelegatorStake,
+		uint64(delegatorStartTime.Unix()),
+		uint64(delegatorStartTime.Add(defaultMinStakingDuration).Unix()),
+		nodeID,
+		notAllowedAddr, // reward address
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		notAllowedAddr, // change addr, which also owns the stake
+	)
+	assert.NoError(err)
+	verifyDelegator := func() error {
+		return delegatorTx.UnsignedTx.(UnsignedProposalTx).SemanticVerify(vm, vm.internalState, delegatorTx)
+	}
+
+	// Delegation policies are only enforced once the upgrade is activated
+	err = verifyDelegator()
+	assert.ErrorIs(err, errDelegatorNotAllowed)
+	vm.ApricotPhase6Time = vm.internalState.GetTimestamp().Add(time.Second)
+	assert.NoError(verifyDelegator())
+}
@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
+	"github.com/lasthyphen/dijetsgo/vms/components/verify"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+// Codes describing why a simulated tx would fail verification
+const (
+	SimulationStartTimeTooEarly        = "startTimeTooEarly"
+	SimulationStartTimeTooSoon         = "startTimeTooSoon"
+	SimulationStartTimeTooLate         = "startTimeTooLate"
+	SimulationStakeTooSmall            = "stakeTooSmall"
+	SimulationStakeTooLarge            = "stakeTooLarge"
+	SimulationStakeTooShort            = "stakeDurationTooShort"
+	SimulationStakeTooLong             = "stakeDurationTooLong"
+	SimulationDelegationFeeTooLow      = "delegationFeeTooLow"
+	SimulationOverDelegated            = "overDelegated"
+	SimulationDelegationPolicy         = "delegationPolicyViolated"
+	SimulationDelegatorNotSubset       = "delegatorPeriodNotSubset"
+	SimulationSubnetValidatorNotSubset = "subnetValidatorPeriodNotSubset"
+	SimulationInsufficientFunds        = "invalidSpend"
+	SimulationInvalid                  = "invalid"
+)
+
+var (
+	errNotSimulatable  = errors.New("only staker txs can be simulated")
+	errNotBootstrapped = errors.New("chain is not bootstrapped")
+	errWrongInputType  = errors.New("wrong input type")
+	errWrongUTXOType   = errors.New("wrong utxo type")
+
+	_ Fx = &unsignedTxFx{}
+
+	_ simulatableTx = &UnsignedAddValidatorTx{}
+	_ simulatableTx = &UnsignedAddDelegatorTx{}
+	_ simulatableTx = &UnsignedAddSubnetValidatorTx{}
+)
+
+// simulatableTx is a staker tx that can be verified with the credentials of an
+// unsigned tx
+type simulatableTx interface {
+	TimedTx
+
+	// semanticVerify verifies the tx like SemanticVerify, using [fx] to verify
+	// its credentials
+	semanticVerify(vm *VM, parentState MutableState, stx *Tx, fx Fx) error
+}
+
+// SimulationFailure is a reason a simulated tx would fail verification
+type SimulationFailure struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// simulation is the outcome of verifying a staker tx without issuing it
+type simulation struct {
+	tx *Tx
+	// True if the simulated tx carried its credentials
+	signed   bool
+	failures []SimulationFailure
+	// Reward the staker would receive if the tx was accepted now, before any
+	// delegation fee is paid. Always 0 for subnet validators.
+	potentialReward uint64
+}
+
+// simulateTx runs [txBytes], which is either a signed tx or an unsigned staker
+// tx, through semantic verification against the preferred state. Neither the
+// tx nor its effects are persisted. Unsigned txs are verified as if they were
+// signed by the owners of the UTXOs and subnets they spend and authorize.
+func (vm *VM) simulateTx(txBytes []byte) (*simulation, error) {
+	if !vm.bootstrapped.GetValue() {
+		return nil, errNotBootstrapped
+	}
+
+	tx, signed, err := parseSimulatedTx(txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var numCreds int
+	switch utx := tx.UnsignedTx.(type) {
+	case *UnsignedAddValidatorTx:
+		numCreds = len(utx.Ins)
+	case *UnsignedAddDelegatorTx:
+		numCreds = len(utx.Ins)
+	case *UnsignedAddSubnetValidatorTx:
+		// The last credential authorizes the subnet validator
+		numCreds = len(utx.Ins) + 1
+	default:
+		return nil, errNotSimulatable
+	}
+
+	fx := vm.fx
+	if !signed {
+		tx.Creds = make([]verify.Verifiable, numCreds)
+		for i := range tx.Creds {
+			tx.Creds[i] = &secp256k1fx.Credential{}
+		}
+		fx = &unsignedTxFx{Fx: vm.fx}
+	}
+
+	preferred, err := vm.Preferred()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get preferred block: %w", err)
+	}
+	preferredDecision, ok := preferred.(decision)
+	if !ok {
+		// The preferred block should always be a decision block
+		return nil, errInvalidBlockType
+	}
+	preferredState := preferredDecision.onAccept()
+
+	result := &simulation{
+		tx:     tx,
+		signed: signed,
+	}
+	staker := tx.UnsignedTx.(simulatableTx)
+	if err := staker.semanticVerify(vm, preferredState, tx, fx); err != nil {
+		result.failures = append(result.failures, SimulationFailure{
+			Code:    simulationFailureCode(err),
+			Message: err.Error(),
+		})
+	}
+
+	// Proposal txs that start within the synchrony bound are dropped from the
+	// mempool before a block is built with them.
+	syncTime := vm.clock.Time().Add(syncBound)
+	if startTime := staker.StartTime(); startTime.Before(syncTime) {
+		result.failures = append(result.failures, SimulationFailure{
+			Code: SimulationStartTimeTooSoon,
+			Message: fmt.Sprintf(
+				"synchrony bound (%s) is later than staker start time (%s)",
+				syncTime,
+				startTime,
+			),
+		})
+	}
+
+	switch utx := tx.UnsignedTx.(type) {
+	case *UnsignedAddValidatorTx:
+		result.potentialReward = vm.rewards.Calculate(
+			utx.Validator.Duration(),
+			utx.Validator.Wght,
+			preferredState.GetCurrentSupply(),
+		)
+	case *UnsignedAddDelegatorTx:
+		result.potentialReward = vm.rewards.Calculate(
+			utx.Validator.Duration(),
+			utx.Validator.Wght,
+			preferredState.GetCurrentSupply(),
+		)
+	}
+	return result, nil
+}
+
+// parseSimulatedTx parses [txBytes] as a signed tx, or as an unsigned tx if
+// they aren't a signed tx. Returns true if the tx is signed.
+func parseSimulatedTx(txBytes []byte) (*Tx, bool, error) {
+	tx := &Tx{}
+	if _, err := Codec.Unmarshal(txBytes, tx); err == nil {
+		return tx, true, tx.Sign(Codec, nil)
+	}
+
+	var utx UnsignedTx
+	if _, err := Codec.Unmarshal(txBytes, &utx); err != nil {
+		return nil, false, fmt.Errorf("couldn't parse tx: %w", err)
+	}
+	tx = &Tx{UnsignedTx: utx}
+	return tx, false, tx.Sign(Codec, nil)
+}
+
+// simulationFailureCode returns the code describing why verification failed
+// with [err]
+func simulationFailureCode(err error) string {
+	switch {
+	case errors.Is(err, errStartTimeTooEarly):
+		return SimulationStartTimeTooEarly
+	case errors.Is(err, errFutureStakeTime):
+		return SimulationStartTimeTooLate
+	case errors.Is(err, errWeightTooSmall):
+		return SimulationStakeTooSmall
+	case errors.Is(err, errWeightTooLarge):
+		return SimulationStakeTooLarge
+	case errors.Is(err, errStakeTooShort):
+		return SimulationStakeTooShort
+	case errors.Is(err, errStakeTooLong):
+		return SimulationStakeTooLong
+	case errors.Is(err, errInsufficientDelegationFee):
+		return SimulationDelegationFeeTooLow
+	case errors.Is(err, errOverDelegated):
+		return SimulationOverDelegated
+	case errors.Is(err, errDelegatorNotAllowed),
+		errors.Is(err, errTooManyDelegators),
+		errors.Is(err, errDelegatorStakeAbovePolicy):
+		return SimulationDelegationPolicy
+	case errors.Is(err, errDelegatorSubset):
+		return SimulationDelegatorNotSubset
+	case errors.Is(err, errDSValidatorSubset):
+		return SimulationSubnetValidatorNotSubset
+	case errors.Is(err, errFlowCheckFailed):
+		return SimulationInsufficientFunds
+	default:
+		return SimulationInvalid
+	}
+}
+
+// unsignedTxFx is the Fx used to simulate unsigned txs. It doesn't verify
+// credentials, but still requires inputs to consume their UTXOs' full amount.
+type unsignedTxFx struct {
+	Fx
+}
+
+func (*unsignedTxFx) VerifyTransfer(_, inIntf, _, utxoIntf interface{}) error {
+	in, ok := inIntf.(djtx.TransferableIn)
+	if !ok {
+		return errWrongInputType
+	}
+	out, ok := utxoIntf.(djtx.TransferableOut)
+	if !ok {
+		return errWrongUTXOType
+	}
+	if in.Amount() != out.Amount() {
+		return fmt.Errorf(
+			"utxo amount and input amount should be same but are %d and %d",
+			out.Amount(),
+			in.Amount(),
+		)
+	}
+	return nil
+}
+
+func (*unsignedTxFx) VerifyPermission(_, _, _, _ interface{}) error { return nil }
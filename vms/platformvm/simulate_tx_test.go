@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/api"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils/crypto"
+	"github.com/lasthyphen/dijetsgo/utils/formatting"
+	"github.com/lasthyphen/dijetsgo/vms/platformvm/reward"
+)
+
+func TestServiceSimulateTx(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+	vm := service.vm
+	changeAddr := keys[0].PublicKey().Address()
+
+	simulate := func(txBytes []byte) *SimulateTxReply {
+		txStr, err := formatting.EncodeWithChecksum(formatting.Hex, txBytes)
+		assert.NoError(err)
+		reply := &SimulateTxReply{}
+		assert.NoError(service.SimulateTx(nil, &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}, reply))
+		return reply
+	}
+	unsignedBytes := func(utx UnsignedTx) []byte {
+		b, err := Codec.Marshal(CodecVersion, &utx)
+		assert.NoError(err)
+		return b
+	}
+	failureCodes := func(reply *SimulateTxReply) []string {
+		codes := make([]string, len(reply.Failures))
+		for i, failure := range reply.Failures {
+			codes[i] = failure.Code
+		}
+		return codes
+	}
+
+	startTime := defaultGenesisTime.Add(syncBound).Add(time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	nodeID := ids.GenerateTestShortID()
+	tx, err := vm.newAddValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		nodeID,
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		changeAddr,
+	)
+	assert.NoError(err)
+
+	// A valid signed tx
+	reply := simulate(tx.Bytes())
+	assert.True(reply.Valid)
+	assert.True(reply.Signed)
+	assert.Equal(tx.ID(), *reply.TxID)
+	assert.Empty(reply.Failures)
+	expectedReward := vm.rewards.Calculate(
+		defaultMinStakingDuration,
+		vm.MinValidatorStake,
+		vm.internalState.GetCurrentSupply(),
+	)
+	assert.NotZero(expectedReward)
+	assert.EqualValues(expectedReward, reply.PotentialReward)
+
+	// The same tx without credentials
+	utx := tx.UnsignedTx.(*UnsignedAddValidatorTx)
+	reply = simulate(unsignedBytes(utx))
+	assert.True(reply.Valid)
+	assert.False(reply.Signed)
+	assert.Nil(reply.TxID)
+	assert.EqualValues(expectedReward, reply.PotentialReward)
+
+	// Simulating the tx doesn't issue it
+	assert.False(vm.blockBuilder.Has(tx.ID()))
+
+	// Staking more than the maximum validator stake
+	maxValidatorStake := vm.MaxValidatorStake
+	vm.MaxValidatorStake = vm.MinValidatorStake - 1
+	reply = simulate(tx.Bytes())
+	assert.False(reply.Valid)
+	assert.Equal([]string{SimulationStakeTooLarge}, failureCodes(reply))
+	vm.MaxValidatorStake = maxValidatorStake
+
+	// Starting at the current chain time
+	utx.Validator.Start = uint64(defaultGenesisTime.Unix())
+	utx.Validator.End = uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix())
+	reply = simulate(unsignedBytes(utx))
+	assert.False(reply.Valid)
+	assert.Equal(
+		[]string{SimulationStartTimeTooEarly, SimulationStartTimeTooSoon},
+		failureCodes(reply),
+	)
+
+	// Delegating more than the validator can accept
+	delegatorTx, err := vm.newAddDelegatorTx(
+		10*vm.MinDelegatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		keys[1].PublicKey().Address(),
+		changeAddr,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		changeAddr,
+	)
+	assert.NoError(err)
+	reply = simulate(delegatorTx.Bytes())
+	assert.False(reply.Valid)
+	assert.Equal([]string{SimulationOverDelegated}, failureCodes(reply))
+	assert.NotZero(reply.PotentialReward)
+
+	// Validating a subnet past the end of the primary network validation
+	subnetTx, err := vm.newAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(startTime.Unix()),
+		uint64(defaultValidateEndTime.Add(time.Second).Unix()),
+		keys[0].PublicKey().Address(),
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		changeAddr,
+	)
+	assert.NoError(err)
+	reply = simulate(subnetTx.Bytes())
+	assert.False(reply.Valid)
+	assert.Equal([]string{SimulationSubnetValidatorNotSubset}, failureCodes(reply))
+	assert.Zero(reply.PotentialReward)
+
+	// Only staker txs can be simulated
+	createChainTx := getValidTx(vm, t)
+	txStr, err := formatting.EncodeWithChecksum(formatting.Hex, createChainTx.Bytes())
+	assert.NoError(err)
+	err = service.SimulateTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &SimulateTxReply{})
+	assert.ErrorIs(err, errNotSimulatable)
+}
@@ -285,7 +285,10 @@ func (vm *VM) authorize(
 	[]*crypto.PrivateKeySECP256K1R, // Keys that prove ownership
 	error,
 ) {
-	subnetTx, _, err := vs.GetTx(subnetID)
+	subnetOwner, err := vs.GetSubnetOwner(subnetID)
+	if errors.Is(err, errNotASubnet) {
+		return nil, nil, errWrongTxType
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf(
 			"failed to fetch subnet %s: %w",
@@ -293,13 +296,22 @@ func (vm *VM) authorize(
 			err,
 		)
 	}
-	subnet, ok := subnetTx.UnsignedTx.(*UnsignedCreateSubnetTx)
-	if !ok {
-		return nil, nil, errWrongTxType
-	}
 
-	// Make sure the owners of the subnet match the provided keys
-	owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+	return vm.authorizeOwner(subnetOwner, keys)
+}
+
+// authorizeOwner returns an input that proves ownership of [ownerIntf] with
+// [keys], along with the keys that must sign it
+func (vm *VM) authorizeOwner(
+	ownerIntf Owner,
+	keys []*crypto.PrivateKeySECP256K1R,
+) (
+	verify.Verifiable, // Input that names owners
+	[]*crypto.PrivateKeySECP256K1R, // Keys that prove ownership
+	error,
+) {
+	// Make sure the owners match the provided keys
+	owner, ok := ownerIntf.(*secp256k1fx.OutputOwners)
 	if !ok {
 		return nil, nil, errUnknownOwners
 	}
@@ -310,7 +322,7 @@ func (vm *VM) authorize(
 	// Make sure that the operation is valid after a minimum time
 	now := uint64(vm.clock.Time().Unix())
 
-	// Attempt to prove ownership of the subnet
+	// Attempt to prove ownership
 	indices, signers, matches := kc.Match(owner, now)
 	if !matches {
 		return nil, nil, errCantSign
@@ -323,8 +335,10 @@ func (vm *VM) authorize(
 // [db] should not be committed if an error is returned
 // [ins] and [outs] are the inputs and outputs of [tx].
 // [creds] are the credentials of [tx], which allow [ins] to be spent.
+// [fx] verifies [creds].
 // Precondition: [tx] has already been syntactically verified
 func (vm *VM) semanticVerifySpend(
+	fx Fx,
 	utxoDB UTXOGetter,
 	tx UnsignedTx,
 	ins []*djtx.TransferableInput,
@@ -346,16 +360,18 @@ func (vm *VM) semanticVerifySpend(
 		utxos[index] = utxo
 	}
 
-	return vm.semanticVerifySpendUTXOs(tx, utxos, ins, outs, creds, feeAmount, feeAssetID)
+	return vm.semanticVerifySpendUTXOs(fx, tx, utxos, ins, outs, creds, feeAmount, feeAssetID)
 }
 
 // Verify that [tx] is semantically valid.
 // [db] should not be committed if an error is returned
 // [ins] and [outs] are the inputs and outputs of [tx].
 // [creds] are the credentials of [tx], which allow [ins] to be spent.
+// [fx] verifies [creds].
 // [utxos[i]] is the UTXO being consumed by [ins[i]]
 // Precondition: [tx] has already been syntactically verified
 func (vm *VM) semanticVerifySpendUTXOs(
+	fx Fx,
 	tx UnsignedTx,
 	utxos []*djtx.UTXO,
 	ins []*djtx.TransferableInput,
@@ -429,7 +445,7 @@ func (vm *VM) semanticVerifySpendUTXOs(
 		}
 
 		// Verify that this tx's credentials allow [in] to be spent
-		if err := vm.fx.VerifyTransfer(tx, in, creds[index], out); err != nil {
+		if err := fx.VerifyTransfer(tx, in, creds[index], out); err != nil {
 			return fmt.Errorf("failed to verify transfer: %w", err)
 		}
 
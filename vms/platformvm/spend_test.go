@@ -393,6 +393,7 @@ func TestSemanticVerifySpendUTXOs(t *testing.T) {
 
 		t.Run(test.description, func(t *testing.T) {
 			err := vm.semanticVerifySpendUTXOs(
+				vm.fx,
 				&unsignedTx,
 				test.utxos,
 				test.ins,
@@ -76,10 +76,24 @@ type APIPrimaryValidator struct {
 	Uptime             *json.Float32 `json:"uptime,omitempty"`
 	Connected          *bool         `json:"connected,omitempty"`
 	Staked             []APIUTXO     `json:"staked,omitempty"`
+	// The restrictions on who can delegate to this validator, if it set any
+	DelegationPolicy *APIDelegationPolicy `json:"delegationPolicy,omitempty"`
 	// The delegators delegating to this validator
 	Delegators []APIPrimaryDelegator `json:"delegators"`
 }
 
+// APIDelegationPolicy is the repr. of a validator's delegation policy sent over
+// APIs.
+type APIDelegationPolicy struct {
+	// If non-empty, the only addresses that can own a delegator's stake and
+	// rewards
+	AllowedAddresses []string `json:"allowedAddresses"`
+	// Maximum number of concurrent delegators, 0 if there is no limit
+	MaxDelegators json.Uint32 `json:"maxDelegators"`
+	// Maximum stake of a single delegator, 0 if there is no limit
+	MaxDelegatorStake json.Uint64 `json:"maxDelegatorStake"`
+}
+
 // APIPrimaryDelegator is the repr. of a primary network delegator sent over APIs.
 type APIPrimaryDelegator struct {
 	APIStaker
@@ -0,0 +1,165 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"time"
+
+	safemath "github.com/lasthyphen/dijetsgo/utils/math"
+)
+
+// maxSupplyProjectionPeriod is the furthest past the current chain time that
+// the supply can be projected
+const maxSupplyProjectionPeriod = 5 * 365 * 24 * time.Hour
+
+var (
+	errProjectionEndTooEarly = errors.New("projection must end after the current chain time")
+	errProjectionEndTooLate  = fmt.Errorf("projection can't end more than %s after the current chain time", maxSupplyProjectionPeriod)
+
+	_ heap.Interface = &stakePeriodHeap{}
+)
+
+// supplyPoint is the projected supply at a point in time
+type supplyPoint struct {
+	time   time.Time
+	supply uint64
+}
+
+// stakePeriod is a period that is projected to be rewarded once it starts
+type stakePeriod struct {
+	start    time.Time
+	duration time.Duration
+	weight   uint64
+	// Order the period was added in, used to break ties between periods
+	// starting at the same time
+	seq int
+}
+
+type stakePeriodHeap struct {
+	periods []stakePeriod
+	nextSeq int
+}
+
+func (h *stakePeriodHeap) Len() int { return len(h.periods) }
+
+func (h *stakePeriodHeap) Less(i, j int) bool {
+	iPeriod, jPeriod := h.periods[i], h.periods[j]
+	if !iPeriod.start.Equal(jPeriod.start) {
+		return iPeriod.start.Before(jPeriod.start)
+	}
+	return iPeriod.seq < jPeriod.seq
+}
+
+func (h *stakePeriodHeap) Swap(i, j int) { h.periods[i], h.periods[j] = h.periods[j], h.periods[i] }
+
+func (h *stakePeriodHeap) Push(x interface{}) {
+	period := x.(stakePeriod)
+	period.seq = h.nextSeq
+	h.nextSeq++
+	h.periods = append(h.periods, period)
+}
+
+func (h *stakePeriodHeap) Pop() interface{} {
+	newLen := len(h.periods) - 1
+	period := h.periods[newLen]
+	h.periods = h.periods[:newLen]
+	return period
+}
+
+// projectSupply projects the supply from the current chain time until
+// [endTime] given the current and pending stakers. Just like when the chain
+// time advances, the potential reward of each staker is minted when it starts
+// staking, using the supply at that time. All stakers are assumed to be
+// rewarded. If [restake] is true, each staker is assumed to stake the same
+// amount for the same duration again as soon as it stops staking.
+//
+// Returns the supply after every change, starting with the current supply.
+func (vm *VM) projectSupply(endTime time.Time, restake bool) ([]supplyPoint, error) {
+	currentTime := vm.internalState.GetTimestamp()
+	switch {
+	case !endTime.After(currentTime):
+		return nil, errProjectionEndTooEarly
+	case endTime.Sub(currentTime) > maxSupplyProjectionPeriod:
+		return nil, errProjectionEndTooLate
+	}
+
+	periods := &stakePeriodHeap{}
+	if restake {
+		for _, tx := range vm.internalState.CurrentStakerChainState().Stakers() {
+			if staker := rewardedValidator(tx.UnsignedTx); staker != nil {
+				heap.Push(periods, stakePeriod{
+					start:    staker.EndTime(),
+					duration: staker.Duration(),
+					weight:   staker.Wght,
+				})
+			}
+		}
+	}
+	for _, tx := range vm.internalState.PendingStakerChainState().Stakers() {
+		if staker := rewardedValidator(tx.UnsignedTx); staker != nil {
+			heap.Push(periods, stakePeriod{
+				start:    staker.StartTime(),
+				duration: staker.Duration(),
+				weight:   staker.Wght,
+			})
+		}
+	}
+
+	supply := vm.internalState.GetCurrentSupply()
+	points := []supplyPoint{{
+		time:   currentTime,
+		supply: supply,
+	}}
+	for periods.Len() > 0 {
+		period := heap.Pop(periods).(stakePeriod)
+		if period.start.After(endTime) {
+			break
+		}
+
+		reward := vm.rewards.Calculate(period.duration, period.weight, supply)
+		newSupply, err := safemath.Add64(supply, reward)
+		if err != nil {
+			return nil, err
+		}
+		supply = newSupply
+
+		if lastPoint := &points[len(points)-1]; lastPoint.time.Equal(period.start) {
+			lastPoint.supply = supply
+		} else {
+			points = append(points, supplyPoint{
+				time:   period.start,
+				supply: supply,
+			})
+		}
+
+		if restake {
+			period.start = period.start.Add(period.duration)
+			heap.Push(periods, period)
+		}
+	}
+
+	if lastPoint := points[len(points)-1]; lastPoint.time.Before(endTime) {
+		points = append(points, supplyPoint{
+			time:   endTime,
+			supply: supply,
+		})
+	}
+	return points, nil
+}
+
+// rewardedValidator returns the validator of [tx] if [tx] adds a primary
+// network staker, which is rewarded for staking. Returns nil otherwise.
+func rewardedValidator(tx UnsignedTx) *Validator {
+	switch staker := tx.(type) {
+	case *UnsignedAddValidatorTx:
+		return &staker.Validator
+	case *UnsignedAddDelegatorTx:
+		return &staker.Validator
+	default:
+		return nil
+	}
+}
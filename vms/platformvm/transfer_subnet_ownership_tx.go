@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsgo/chains/atomic"
+	"github.com/lasthyphen/dijetsgo/database"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/snow"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+	"github.com/lasthyphen/dijetsgo/utils/crypto"
+	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
+	"github.com/lasthyphen/dijetsgo/vms/components/verify"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+var (
+	errTransferPrimaryNetwork = errors.New("can't transfer ownership of the primary network")
+
+	_ UnsignedDecisionTx = &UnsignedTransferSubnetOwnershipTx{}
+)
+
+// UnsignedTransferSubnetOwnershipTx is an unsigned transferSubnetOwnershipTx
+type UnsignedTransferSubnetOwnershipTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the subnet this tx is modifying
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// Proves that the issuer has the right to transfer the ownership of the
+	// subnet
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+	// Who is now authorized to manage this subnet
+	Owner Owner `serialize:"true" json:"newOwner"`
+}
+
+// InputUTXOs for [DecisionTxs] will return an empty set to diffrentiate from the [AtomicTxs] input UTXOs
+func (tx *UnsignedTransferSubnetOwnershipTx) InputUTXOs() ids.Set { return nil }
+
+func (tx *UnsignedTransferSubnetOwnershipTx) AtomicOperations() (ids.ID, *atomic.Requests, error) {
+	return ids.ID{}, nil, nil
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [UnsignedTransferSubnetOwnershipTx]. Also sets the [ctx] to the given
+// [vm.ctx] so that the addresses can be json marshalled into human readable
+// format
+func (tx *UnsignedTransferSubnetOwnershipTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+	tx.Owner.InitCtx(ctx)
+}
+
+// SyntacticVerify verifies that this transaction is well-formed
+func (tx *UnsignedTransferSubnetOwnershipTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case tx.syntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return errTransferPrimaryNetwork
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := verify.All(tx.SubnetAuth, tx.Owner); err != nil {
+		return err
+	}
+
+	tx.syntacticallyVerified = true
+	return nil
+}
+
+// Attempts to verify this transaction with the provided state.
+func (tx *UnsignedTransferSubnetOwnershipTx) SemanticVerify(vm *VM, parentState MutableState, stx *Tx) error {
+	vs := newVersionedState(
+		parentState,
+		parentState.CurrentStakerChainState(),
+		parentState.PendingStakerChainState(),
+	)
+	_, err := tx.Execute(vm, vs, stx)
+	return err
+}
+
+// Execute this transaction.
+func (tx *UnsignedTransferSubnetOwnershipTx) Execute(
+	vm *VM,
+	vs VersionedState,
+	stx *Tx,
+) (
+	func() error,
+	error,
+) {
+	// Make sure this transaction is well formed.
+	if len(stx.Creds) == 0 {
+		return nil, errWrongNumberOfCredentials
+	}
+	if err := tx.SyntacticVerify(vm.ctx); err != nil {
+		return nil, err
+	}
+	if err := vm.verifyApricotPhase6(tx, vs.GetTimestamp()); err != nil {
+		return nil, err
+	}
+
+	// Select the credentials for each purpose
+	baseTxCredsLen := len(stx.Creds) - 1
+	baseTxCreds := stx.Creds[:baseTxCredsLen]
+	subnetCred := stx.Creds[baseTxCredsLen]
+
+	subnetOwner, err := vs.GetSubnetOwner(tx.Subnet)
+	if err == database.ErrNotFound {
+		return nil, fmt.Errorf("%s isn't a known subnet", tx.Subnet)
+	}
+	if errors.Is(err, errNotASubnet) {
+		return nil, fmt.Errorf("%s isn't a subnet", tx.Subnet)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that the transfer is authorized by the current owner
+	if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnetOwner); err != nil {
+		return nil, err
+	}
+
+	// Verify the flowcheck
+	if err := vm.semanticVerifySpend(vm.fx, vs, tx, tx.Ins, tx.Outs, baseTxCreds, vm.TxFee, vm.ctx.DJTXAssetID); err != nil {
+		return nil, err
+	}
+
+	// Consume the UTXOS
+	consumeInputs(vs, tx.Ins)
+	// Produce the UTXOS
+	txID := tx.ID()
+	produceOutputs(vs, txID, vm.ctx.DJTXAssetID, tx.Outs)
+	// Transfer the ownership of the subnet
+	vs.SetSubnetOwner(tx.Subnet, tx.Owner)
+
+	return nil, nil
+}
+
+// [ownerAddrs] must be unique. They will be sorted by this method.
+func (vm *VM) newTransferSubnetOwnershipTx(
+	subnetID ids.ID, // ID of the subnet to transfer
+	threshold uint32, // [threshold] of [ownerAddrs] needed to manage the subnet
+	ownerAddrs []ids.ShortID, // new control addresses of the subnet
+	keys []*crypto.PrivateKeySECP256K1R, // pay the fee and prove ownership of the subnet
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, outs, _, signers, err := vm.stake(keys, 0, vm.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := vm.authorize(vm.internalState, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Sort control addresses
+	ids.SortShortIDs(ownerAddrs)
+
+	// Create the tx
+	utx := &UnsignedTransferSubnetOwnershipTx{
+		BaseTx: BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    vm.ctx.NetworkID,
+			BlockchainID: vm.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+		Owner: &secp256k1fx.OutputOwners{
+			Threshold: threshold,
+			Addrs:     ownerAddrs,
+		},
+	}
+	tx := &Tx{UnsignedTx: utx}
+	if err := tx.Sign(Codec, signers); err != nil {
+		return nil, err
+	}
+	return tx, utx.SyntacticVerify(vm.ctx)
+}
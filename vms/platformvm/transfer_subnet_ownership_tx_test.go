@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lasthyphen/dijetsgo/api"
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/utils/constants"
+	"github.com/lasthyphen/dijetsgo/utils/crypto"
+	"github.com/lasthyphen/dijetsgo/vms/platformvm/status"
+	"github.com/lasthyphen/dijetsgo/vms/secp256k1fx"
+)
+
+func TestTransferSubnetOwnershipTxSyntacticVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	var unsignedTx *UnsignedTransferSubnetOwnershipTx
+	assert.ErrorIs(unsignedTx.SyntacticVerify(vm.ctx), errNilTx)
+
+	tx, err := vm.newTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		1,
+		[]ids.ShortID{keys[3].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	unsignedTx = tx.UnsignedTx.(*UnsignedTransferSubnetOwnershipTx)
+	unsignedTx.syntacticallyVerified = false
+	unsignedTx.Subnet = constants.PrimaryNetworkID
+	assert.ErrorIs(unsignedTx.SyntacticVerify(vm.ctx), errTransferPrimaryNetwork)
+
+	// The subnet can only be transferred by its owner
+	_, err = vm.newTransferSubnetOwnershipTx(
+		testSubnet1.ID(),
+		1,
+		[]ids.ShortID{keys[3].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[3]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.ErrorIs(err, errCantSign)
+}
+
+func TestTransferSubnetOwnershipTx(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	subnetID := testSubnet1.ID()
+	newOwnerKey := keys[3]
+	tx, err := vm.newTransferSubnetOwnershipTx(
+		subnetID,
+		1,
+		[]ids.ShortID{newOwnerKey.PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(tx))
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	assert.NoError(blk.Accept())
+
+	_, txStatus, err := vm.internalState.GetTx(tx.ID())
+	assert.NoError(err)
+	assert.Equal(status.Committed, txStatus)
+
+	owner, err := vm.internalState.GetSubnetOwner(subnetID)
+	assert.NoError(err)
+	outputOwners, ok := owner.(*secp256k1fx.OutputOwners)
+	assert.True(ok)
+	assert.Equal(uint32(1), outputOwners.Threshold)
+	assert.Equal([]ids.ShortID{newOwnerKey.PublicKey().Address()}, outputOwners.Addrs)
+
+	// The previous owner can no longer manage the subnet
+	_, _, err = vm.authorize(vm.internalState, subnetID, testSubnet1ControlKeys)
+	assert.ErrorIs(err, errCantSign)
+	_, _, err = vm.authorize(vm.internalState, subnetID, []*crypto.PrivateKeySECP256K1R{newOwnerKey})
+	assert.NoError(err)
+
+	// The transferred ownership is reported by the API
+	newOwnerAddr, err := vm.FormatLocalAddress(newOwnerKey.PublicKey().Address())
+	assert.NoError(err)
+	service := Service{vm: vm}
+	reply := GetSubnetsResponse{}
+	assert.NoError(service.GetSubnets(nil, &GetSubnetsArgs{IDs: []ids.ID{subnetID}}, &reply))
+	assert.Len(reply.Subnets, 1)
+	assert.Equal([]string{newOwnerAddr}, reply.Subnets[0].ControlKeys)
+
+	// The transferred ownership is persisted
+	_, err = vm.internalState.(*internalStateImpl).subnetOwnerDB.Get(subnetID[:])
+	assert.NoError(err)
+}
+
+func TestServiceTransferSubnetOwnershipPrimaryNetwork(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	service := Service{vm: vm}
+	err := service.TransferSubnetOwnership(nil, &TransferSubnetOwnershipArgs{
+		SubnetID: constants.PrimaryNetworkID.String(),
+	}, &api.JSONTxIDChangeAddr{})
+	assert.ErrorIs(err, errNamedSubnetCantBePrimary)
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"math/bits"
+
+	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/vms/components/djtx"
+
+	safemath "github.com/lasthyphen/dijetsgo/utils/math"
+)
+
+// TxFee is the amount of DJTX burned by a transaction
+type TxFee struct {
+	// Amount of DJTX the tx burns
+	Burned uint64
+	// Amount of DJTX the tx is required to burn
+	Required uint64
+	// Size of the tx in bytes
+	Size int
+}
+
+// Effective returns the amount of DJTX burned above the required fee
+func (f TxFee) Effective() uint64 {
+	if f.Burned < f.Required {
+		return 0
+	}
+	return f.Burned - f.Required
+}
+
+// Rate returns the effective fee paid per byte of the tx
+func (f TxFee) Rate() uint64 {
+	if f.Size <= 0 {
+		return 0
+	}
+	return f.Effective() / uint64(f.Size)
+}
+
+// HasHigherRate returns true if [f] pays a higher effective fee per byte than
+// [other]
+func (f TxFee) HasHigherRate(other TxFee) bool {
+	// f.Effective() / f.Size > other.Effective() / other.Size is compared as
+	// f.Effective() * other.Size > other.Effective() * f.Size to avoid losing
+	// precision.
+	fHi, fLo := bits.Mul64(f.Effective(), uint64(other.Size))
+	otherHi, otherLo := bits.Mul64(other.Effective(), uint64(f.Size))
+	return fHi > otherHi || (fHi == otherHi && fLo > otherLo)
+}
+
+// getTxFee returns the fee that [tx] pays at the current chain time
+func (vm *VM) getTxFee(tx *Tx) TxFee {
+	var (
+		ins      []*djtx.TransferableInput
+		outs     []*djtx.TransferableOutput
+		required uint64
+	)
+	timestamp := vm.internalState.GetTimestamp()
+	switch utx := tx.UnsignedTx.(type) {
+	case *UnsignedAddValidatorTx:
+		ins = utx.Ins
+		outs = append(utx.Outs[:len(utx.Outs):len(utx.Outs)], utx.Stake...)
+		required = vm.AddStakerTxFee
+	case *UnsignedAddDelegatorTx:
+		ins = utx.Ins
+		outs = append(utx.Outs[:len(utx.Outs):len(utx.Outs)], utx.Stake...)
+		required = vm.AddStakerTxFee
+	case *UnsignedAddSubnetValidatorTx:
+		ins = utx.Ins
+		outs = utx.Outs
+		required = vm.TxFee
+	case *UnsignedCreateChainTx:
+		ins = utx.Ins
+		outs = utx.Outs
+		required = vm.getCreateBlockchainTxFee(timestamp)
+	case *UnsignedCreateSubnetTx:
+		ins = utx.Ins
+		outs = utx.Outs
+		required = vm.getCreateSubnetTxFee(timestamp)
+	case *UnsignedImportTx:
+		ins = append(utx.Ins[:len(utx.Ins):len(utx.Ins)], utx.ImportedInputs...)
+		outs = utx.Outs
+		required = vm.TxFee
+	case *UnsignedExportTx:
+		ins = utx.Ins
+		outs = append(utx.Outs[:len(utx.Outs):len(utx.Outs)], utx.ExportedOutputs...)
+		required = vm.TxFee
+	case *UnsignedTransferSubnetOwnershipTx:
+		ins = utx.Ins
+		outs = utx.Outs
+		required = vm.TxFee
+	case *UnsignedRemoveSubnetValidatorTx:
+		ins = utx.Ins
+		outs = utx.Outs
+		required = vm.TxFee
+	case *UnsignedSetDelegationPolicyTx:
+		ins = utx.Ins
+		outs = utx.Outs
+		required = vm.TxFee
+	}
+	return TxFee{
+		Burned:   burnedAmount(vm.ctx.DJTXAssetID, ins, outs),
+		Required: required,
+		Size:     len(tx.Bytes()),
+	}
+}
+
+// burnedAmount returns the amount of [assetID] consumed by [ins] that isn't
+// produced by [outs]
+func burnedAmount(assetID ids.ID, ins []*djtx.TransferableInput, outs []*djtx.TransferableOutput) uint64 {
+	var (
+		consumed uint64
+		produced uint64
+		err      error
+	)
+	for _, in := range ins {
+		if in.AssetID() != assetID {
+			continue
+		}
+		consumed, err = safemath.Add64(consumed, in.Input().Amount())
+		if err != nil {
+			return 0
+		}
+	}
+	for _, out := range outs {
+		if out.AssetID() != assetID {
+			continue
+		}
+		produced, err = safemath.Add64(produced, out.Output().Amount())
+		if err != nil {
+			return 0
+		}
+	}
+	if consumed < produced {
+		return 0
+	}
+	return consumed - produced
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"github.com/lasthyphen/dijetsgo/ids"
+)
+
+var _ TxHeap = &txHeapByFee{}
+
+// feeEntry is the fee paid by a mempool tx and the order it was added in
+type feeEntry struct {
+	tx  *Tx
+	fee TxFee
+	age uint64
+}
+
+// hasPriority returns true if [e] should be issued before [other]
+func (e *feeEntry) hasPriority(other *feeEntry) bool {
+	if e.fee.HasHigherRate(other.fee) {
+		return true
+	}
+	if other.fee.HasHigherRate(e.fee) {
+		return false
+	}
+	return e.age < other.age
+}
+
+type txHeapByFee struct {
+	txHeap
+
+	// fees of the txs in the heap. Must contain every tx added to the heap.
+	fees map[ids.ID]*feeEntry
+	// if true, the tx with the lowest priority is at the top of the heap
+	lowestFirst bool
+}
+
+// newTxHeapByFee returns a heap whose top is the tx paying the highest
+// effective fee rate, with older txs breaking ties
+func newTxHeapByFee(fees map[ids.ID]*feeEntry) TxHeap {
+	h := &txHeapByFee{fees: fees}
+	h.initialize(h)
+	return h
+}
+
+// newTxHeapByLowestFee returns a heap whose top is the tx paying the lowest
+// effective fee rate, with newer txs breaking ties
+func newTxHeapByLowestFee(fees map[ids.ID]*feeEntry) TxHeap {
+	h := &txHeapByFee{
+		fees:        fees,
+		lowestFirst: true,
+	}
+	h.initialize(h)
+	return h
+}
+
+func (h *txHeapByFee) Less(i, j int) bool {
+	iEntry := h.fees[h.txs[i].tx.ID()]
+	jEntry := h.fees[h.txs[j].tx.ID()]
+	if h.lowestFirst {
+		return jEntry.hasPriority(iEntry)
+	}
+	return iEntry.hasPriority(jEntry)
+}
@@ -4,8 +4,10 @@
 package platformvm
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/gorilla/rpc/v2"
@@ -19,6 +21,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/database"
 	"github.com/lasthyphen/dijetsgo/database/manager"
 	"github.com/lasthyphen/dijetsgo/ids"
+	"github.com/lasthyphen/dijetsgo/pubsub"
 	"github.com/lasthyphen/dijetsgo/snow"
 	"github.com/lasthyphen/dijetsgo/snow/choices"
 	"github.com/lasthyphen/dijetsgo/snow/consensus/snowman"
@@ -59,6 +62,8 @@ var (
 	errStartTimeTooEarly = errors.New("start time is before the current chain time")
 	errStartAfterEndTime = errors.New("start time is after the end time")
 	errWrongCacheType    = errors.New("unexpectedly cached type")
+	errNoStakerHistory   = errors.New("current stakers aren't known at this height")
+	errPreApricotPhase6  = errors.New("tx isn't allowed before the apricot phase 6 upgrade")
 
 	_ block.ChainVM        = &VM{}
 	_ validators.Connector = &VM{}
@@ -119,6 +124,9 @@ type VM struct {
 	// Key: block ID
 	// Value: the block
 	currentBlocks map[ids.ID]Block
+
+	// Notifies subscribers of accepted txs
+	pubsub *pubsub.Server
 }
 
 // Initialize this blockchain.
@@ -155,6 +163,7 @@ func (vm *VM) Initialize(
 
 	vm.ctx = ctx
 	vm.dbManager = dbManager
+	vm.pubsub = pubsub.New(ctx.NetworkID, ctx.Log)
 
 	vm.codecRegistry = linearcodec.NewDefault()
 	if err := vm.fx.Initialize(vm); err != nil {
@@ -183,6 +192,9 @@ func (vm *VM) Initialize(
 	// Initialize the utility to track validator uptimes
 	vm.uptimeManager = uptime.NewManager(is)
 	vm.UptimeLockedCalculator.SetCalculator(&vm.bootstrapped, &ctx.Lock, vm.uptimeManager)
+	if vm.GRPCService != nil {
+		vm.GRPCService.setVM(&vm.bootstrapped, &ctx.Lock, vm)
+	}
 
 	if err := vm.updateValidators(); err != nil {
 		return fmt.Errorf(
@@ -326,6 +338,7 @@ func (vm *VM) Shutdown() error {
 	}
 
 	vm.blockBuilder.Shutdown()
+	vm.pubsub.Close()
 
 	if vm.bootstrapped.GetValue() {
 		primaryValidatorSet, exist := vm.Validators.GetValidators(constants.PrimaryNetworkID)
@@ -427,6 +440,10 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 		"": {
 			Handler: server,
 		},
+		"/events": {
+			LockOptions: common.NoLock,
+			Handler:     vm.pubsub,
+		},
 	}, nil
 }
 
@@ -543,6 +560,72 @@ func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.ShortID]u
 	return vdrSet, nil
 }
 
+// getCurrentStakersAt returns the current stakers, ordered by end time, and
+// their potential rewards as of [height]. The stakers are reconstructed by
+// undoing the staker diffs of the blocks accepted after [height].
+func (vm *VM) getCurrentStakersAt(height uint64) ([]*Tx, map[ids.ID]uint64, error) {
+	lastAcceptedHeight, err := vm.GetCurrentHeight()
+	if err != nil {
+		return nil, nil, err
+	}
+	switch {
+	case lastAcceptedHeight < height:
+		return nil, nil, database.ErrNotFound
+	case height < vm.internalState.GetStakerDiffsHeight():
+		return nil, nil, fmt.Errorf("%w: %d", errNoStakerHistory, height)
+	}
+
+	currentStakers := vm.internalState.CurrentStakerChainState()
+	stakers := make(map[ids.ID]*Tx)
+	potentialRewards := make(map[ids.ID]uint64)
+	for _, tx := range currentStakers.Stakers() {
+		txID := tx.ID()
+		_, potentialReward, err := currentStakers.GetStaker(txID)
+		if err != nil {
+			return nil, nil, err
+		}
+		stakers[txID] = tx
+		potentialRewards[txID] = potentialReward
+	}
+
+	for i := lastAcceptedHeight; i > height; i-- {
+		diff, err := vm.internalState.GetStakerDiff(i)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// The stakers added at this block weren't staking in the prior block
+		for _, txID := range diff.Added {
+			delete(stakers, txID)
+			delete(potentialRewards, txID)
+		}
+		// The stakers removed at this block were staking in the prior block
+		for _, removed := range diff.Removed {
+			tx, _, err := vm.internalState.GetTx(removed.TxID)
+			if err != nil {
+				return nil, nil, err
+			}
+			stakers[removed.TxID] = tx
+			potentialRewards[removed.TxID] = removed.PotentialReward
+		}
+	}
+
+	stakerList := make([]*Tx, 0, len(stakers))
+	for _, tx := range stakers {
+		stakerList = append(stakerList, tx)
+	}
+	sort.Slice(stakerList, func(i, j int) bool {
+		iEndTime := stakerList[i].UnsignedTx.(TimedTx).EndTime()
+		jEndTime := stakerList[j].UnsignedTx.(TimedTx).EndTime()
+		if !iEndTime.Equal(jEndTime) {
+			return iEndTime.Before(jEndTime)
+		}
+		iTxID, jTxID := stakerList[i].ID(), stakerList[j].ID()
+		return bytes.Compare(iTxID[:], jTxID[:]) == -1
+	})
+	return stakerList, potentialRewards, nil
+}
+
 // GetCurrentHeight returns the height of the last accepted block
 func (vm *VM) GetCurrentHeight() (uint64, error) {
 	lastAccepted, err := vm.getBlock(vm.lastAcceptedID)
@@ -578,6 +661,20 @@ func (vm *VM) updateValidators() error {
 	return nil
 }
 
+// verifyApricotPhase6 returns an error if [tx] was introduced by the AP6
+// network upgrade, which isn't activated at [timestamp]
+func (vm *VM) verifyApricotPhase6(tx UnsignedTx, timestamp time.Time) error {
+	if !timestamp.Before(vm.ApricotPhase6Time) {
+		return nil
+	}
+	switch tx.(type) {
+	case *UnsignedTransferSubnetOwnershipTx, *UnsignedRemoveSubnetValidatorTx, *UnsignedSetDelegationPolicyTx:
+		return errPreApricotPhase6
+	default:
+		return nil
+	}
+}
+
 func (vm *VM) CodecRegistry() codec.Registry { return vm.codecRegistry }
 
 func (vm *VM) Clock() *mockable.Clock { return &vm.clock }
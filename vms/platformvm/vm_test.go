@@ -3049,3 +3049,90 @@ func TestRejectedStateRegressionInvalidValidatorReward(t *testing.T) {
 		assert.Equal(newValidatorStartTime1.Unix(), currentTimestamp.Unix())
 	}
 }
+
+// Ensure that the txs introduced by the AP6 upgrade are neither executed nor
+// added to the mempool before the upgrade is activated
+func TestApricotPhase6Txs(t *testing.T) {
+	tests := []struct {
+		name  string
+		newTx func(*assert.Assertions, *VM) *Tx
+	}{
+		{
+			name: "TransferSubnetOwnershipTx",
+			newTx: func(assert *assert.Assertions, vm *VM) *Tx {
+				tx, err := vm.newTransferSubnetOwnershipTx(
+					testSubnet1.ID(),
+					1,
+					[]ids.ShortID{keys[3].PublicKey().Address()},
+					[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+					ids.ShortEmpty, // change addr
+				)
+				assert.NoError(err)
+				return tx
+			},
+		},
+		{
+			name: "RemoveSubnetValidatorTx",
+			newTx: func(assert *assert.Assertions, vm *VM) *Tx {
+				nodeID := keys[0].PublicKey().Address()
+				startTime := defaultValidateStartTime.Add(syncBound).Add(1 * time.Second)
+				addTestSubnetValidator(assert, vm, nodeID, startTime)
+
+				tx, err := vm.newRemoveSubnetValidatorTx(
+					nodeID,
+					testSubnet1.ID(),
+					[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+					testSubnet1ControlKeys[0].PublicKey().Address(), // change addr
+				)
+				assert.NoError(err)
+				return tx
+			},
+		},
+		{
+			name: "SetDelegationPolicyTx",
+			newTx: func(assert *assert.Assertions, vm *VM) *Tx {
+				nodeID := keys[0].PublicKey().Address()
+				validator, err := vm.internalState.CurrentStakerChainState().GetValidator(nodeID)
+				assert.NoError(err)
+
+				tx, err := vm.newSetDelegationPolicyTx(
+					validator.AddValidatorTx().ID(),
+					[]ids.ShortID{keys[1].PublicKey().Address()},
+					0,
+					0,
+					[]*crypto.PrivateKeySECP256K1R{keys[0]},
+					keys[0].PublicKey().Address(), // change addr
+				)
+				assert.NoError(err)
+				return tx
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			vm, _, _ := defaultVM()
+			vm.ctx.Lock.Lock()
+			defer func() {
+				assert.NoError(vm.Shutdown())
+				vm.ctx.Lock.Unlock()
+			}()
+
+			tx := test.newTx(assert, vm)
+
+			chainTime := vm.internalState.GetTimestamp()
+			vm.ApricotPhase6Time = chainTime.Add(time.Second)
+			err := tx.UnsignedTx.SemanticVerify(vm, vm.internalState, tx)
+			assert.ErrorIs(err, errPreApricotPhase6)
+			err = vm.blockBuilder.AddUnverifiedTx(tx)
+			assert.ErrorIs(err, errPreApricotPhase6)
+			assert.False(vm.blockBuilder.Has(tx.ID()))
+
+			vm.ApricotPhase6Time = chainTime
+			assert.NoError(tx.UnsignedTx.SemanticVerify(vm, vm.internalState, tx))
+			assert.NoError(vm.blockBuilder.AddUnverifiedTx(tx))
+			assert.True(vm.blockBuilder.Has(tx.ID()))
+		})
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/lasthyphen/dijetsgo/snow/choices"
 	"github.com/lasthyphen/dijetsgo/snow/consensus/snowman"
 	"github.com/lasthyphen/dijetsgo/vms/proposervm/block"
-	"github.com/lasthyphen/dijetsgo/vms/proposervm/proposer"
 )
 
 const (
@@ -52,6 +51,9 @@ type PostForkBlock interface {
 	setStatus(choices.Status)
 	getStatelessBlk() block.Block
 	setInnerBlk(snowman.Block)
+
+	// builtLocally returns true if this block was proposed by this node.
+	builtLocally() bool
 }
 
 // field of postForkBlock and postForkOption
@@ -129,7 +131,7 @@ func (p *postForkCommonComponents) Verify(parentTimestamp time.Time, parentPChai
 		}
 
 		// Verify the signature of the node
-		shouldHaveProposer := delay < proposer.MaxDelay
+		shouldHaveProposer := delay < p.vm.Windower.MaxDelay()
 		if err := child.SignedBlock.Verify(shouldHaveProposer, p.vm.ctx.ChainID); err != nil {
 			return err
 		}
@@ -161,7 +163,7 @@ func (p *postForkCommonComponents) buildChild(
 	}
 
 	delay := newTimestamp.Sub(parentTimestamp)
-	if delay < proposer.MaxDelay {
+	if delay < p.vm.Windower.MaxDelay() {
 		parentHeight := p.innerBlk.Height()
 		proposerID := p.vm.ctx.NodeID
 		minDelay, err := p.vm.Windower.Delay(parentHeight+1, parentPChainHeight, proposerID)
@@ -192,7 +194,7 @@ func (p *postForkCommonComponents) buildChild(
 
 	// Build the child
 	var statelessChild block.SignedBlock
-	if delay >= proposer.MaxDelay {
+	if delay >= p.vm.Windower.MaxDelay() {
 		statelessChild, err = block.BuildUnsigned(
 			parentID,
 			newTimestamp,
@@ -149,6 +149,10 @@ func (b *postForkBlock) pChainHeight() (uint64, error) {
 	return b.PChainHeight(), nil
 }
 
+func (b *postForkBlock) builtLocally() bool {
+	return b.Proposer() == b.vm.ctx.NodeID
+}
+
 func (b *postForkBlock) setStatus(status choices.Status) {
 	b.status = status
 }
@@ -119,6 +119,12 @@ func (b *postForkOption) pChainHeight() (uint64, error) {
 	return parent.pChainHeight()
 }
 
+// An option is considered to be built by the proposer of its parent block.
+func (b *postForkOption) builtLocally() bool {
+	parent, err := b.vm.getPostForkBlock(b.ParentID())
+	return err == nil && parent.builtLocally()
+}
+
 func (b *postForkOption) setStatus(status choices.Status) {
 	b.status = status
 }
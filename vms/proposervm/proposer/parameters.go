@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposer
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultParameters are the proposer window parameters used by the primary
+// network and by any subnet that doesn't override them.
+var DefaultParameters = Parameters{
+	WindowDuration: WindowDuration,
+	MaxWindows:     MaxWindows,
+}
+
+// Parameters configure the proposer windows of a chain.
+//
+// Every validator of a subnet must use the same parameters. Otherwise,
+// validators will disagree on which blocks were issued inside of their
+// proposer's window and will fail to verify each other's blocks.
+type Parameters struct {
+	// WindowDuration is the length of each proposer's submission window.
+	WindowDuration time.Duration `json:"windowDuration,omitempty"`
+	// MaxWindows is the number of proposers sampled from the validator set
+	// for each block height.
+	MaxWindows int `json:"maxWindows,omitempty"`
+}
+
+// MaxDelay returns the delay after which any node is allowed to issue a block.
+func (p Parameters) MaxDelay() time.Duration {
+	return time.Duration(p.MaxWindows) * p.WindowDuration
+}
+
+// Valid returns nil if the parameters describe a valid configuration.
+func (p Parameters) Valid() error {
+	switch {
+	case p.WindowDuration < time.Second:
+		return fmt.Errorf("windowDuration = %s: fails the condition that: 1s <= windowDuration", p.WindowDuration)
+	case p.WindowDuration%time.Second != 0:
+		// Block timestamps are only specific to the second.
+		return fmt.Errorf("windowDuration = %s: fails the condition that: windowDuration is a whole number of seconds", p.WindowDuration)
+	case p.MaxWindows <= 0:
+		return fmt.Errorf("maxWindows = %d: fails the condition that: 0 < maxWindows", p.MaxWindows)
+	default:
+		return nil
+	}
+}
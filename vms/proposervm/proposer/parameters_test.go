@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParametersValid(t *testing.T) {
+	tests := map[string]struct {
+		params      Parameters
+		shouldError bool
+	}{
+		"default": {
+			params: DefaultParameters,
+		},
+		"single proposer": {
+			params: Parameters{
+				WindowDuration: time.Second,
+				MaxWindows:     1,
+			},
+		},
+		"zero window duration": {
+			params: Parameters{
+				MaxWindows: MaxWindows,
+			},
+			shouldError: true,
+		},
+		"sub-second window duration": {
+			params: Parameters{
+				WindowDuration: 1500 * time.Millisecond,
+				MaxWindows:     MaxWindows,
+			},
+			shouldError: true,
+		},
+		"no windows": {
+			params: Parameters{
+				WindowDuration: WindowDuration,
+			},
+			shouldError: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.params.Valid()
+			if test.shouldError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParametersMaxDelay(t *testing.T) {
+	assert.Equal(t, MaxDelay, DefaultParameters.MaxDelay())
+}
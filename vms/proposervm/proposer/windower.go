@@ -14,7 +14,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/utils/wrappers"
 )
 
-// Proposer list constants
+// Default proposer list constants
 const (
 	MaxWindows     = 6
 	WindowDuration = 5 * time.Second
@@ -29,6 +29,10 @@ type Windower interface {
 		pChainHeight uint64,
 		validatorID ids.ShortID,
 	) (time.Duration, error)
+
+	// MaxDelay returns the delay after which any node is allowed to issue a
+	// block.
+	MaxDelay() time.Duration
 }
 
 // windower interfaces with P-Chain and it is responsible for calculating the
@@ -38,21 +42,32 @@ type windower struct {
 	subnetID    ids.ID
 	chainSource uint64
 	sampler     sampler.WeightedWithoutReplacement
+	params      Parameters
 }
 
+// New returns a windower that uses the default proposer window parameters.
 func New(state validators.State, subnetID, chainID ids.ID) Windower {
+	return NewWithParameters(state, subnetID, chainID, DefaultParameters)
+}
+
+// NewWithParameters returns a windower that uses the provided proposer window
+// parameters. The parameters are assumed to be valid.
+func NewWithParameters(state validators.State, subnetID, chainID ids.ID, params Parameters) Windower {
 	w := wrappers.Packer{Bytes: chainID[:]}
 	return &windower{
 		state:       state,
 		subnetID:    subnetID,
 		chainSource: w.UnpackLong(),
 		sampler:     sampler.NewDeterministicWeightedWithoutReplacement(),
+		params:      params,
 	}
 }
 
+func (w *windower) MaxDelay() time.Duration { return w.params.MaxDelay() }
+
 func (w *windower) Delay(chainHeight, pChainHeight uint64, validatorID ids.ShortID) (time.Duration, error) {
 	if validatorID == ids.ShortEmpty {
-		return MaxDelay, nil
+		return w.params.MaxDelay(), nil
 	}
 
 	// get the validator set by the p-chain height
@@ -91,7 +106,7 @@ func (w *windower) Delay(chainHeight, pChainHeight uint64, validatorID ids.Short
 		return 0, err
 	}
 
-	numToSample := MaxWindows
+	numToSample := w.params.MaxWindows
 	if weight < uint64(numToSample) {
 		numToSample = int(weight)
 	}
@@ -110,7 +125,7 @@ func (w *windower) Delay(chainHeight, pChainHeight uint64, validatorID ids.Short
 		if nodeID == validatorID {
 			return delay, nil
 		}
-		delay += WindowDuration
+		delay += w.params.WindowDuration
 	}
 	return delay, nil
 }
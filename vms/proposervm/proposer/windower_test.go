@@ -175,3 +175,48 @@ func TestWindowerChangeByChain(t *testing.T) {
 		assert.EqualValues(expectedDelay, validatorDelay)
 	}
 }
+
+func TestWindowerSingleProposer(t *testing.T) {
+	assert := assert.New(t)
+
+	subnetID := ids.ID{0, 1}
+	chainID := ids.ID{0, 2}
+	validatorIDs := make([]ids.ShortID, MaxWindows)
+	for i := range validatorIDs {
+		validatorIDs[i] = ids.ShortID{byte(i + 1)}
+	}
+	vdrState := &validators.TestState{
+		T: t,
+		GetValidatorSetF: func(height uint64, subnetID ids.ID) (map[ids.ShortID]uint64, error) {
+			validators := make(map[ids.ShortID]uint64, MaxWindows)
+			for _, id := range validatorIDs {
+				validators[id] = 1
+			}
+			return validators, nil
+		},
+	}
+
+	params := Parameters{
+		WindowDuration: 2 * time.Second,
+		MaxWindows:     1,
+	}
+	w := NewWithParameters(vdrState, subnetID, chainID, params)
+	assert.Equal(2*time.Second, w.MaxDelay())
+
+	numProposers := 0
+	for _, vdrID := range validatorIDs {
+		validatorDelay, err := w.Delay(1, 0, vdrID)
+		assert.NoError(err)
+		switch validatorDelay {
+		case 0:
+			numProposers++
+		default:
+			assert.Equal(w.MaxDelay(), validatorDelay)
+		}
+	}
+	assert.Equal(1, numProposers)
+
+	nonValidatorDelay, err := w.Delay(1, 0, ids.ShortEmpty)
+	assert.NoError(err)
+	assert.Equal(w.MaxDelay(), nonValidatorDelay)
+}
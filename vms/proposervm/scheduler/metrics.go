@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2021, Dijets, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scheduler
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/dijetsgo/utils/wrappers"
+)
+
+type metrics struct {
+	slotsProposed, slotsMissed, slotsIdle, urgentNotifications prometheus.Counter
+}
+
+// Initialize the metrics
+func (m *metrics) Initialize(reg prometheus.Registerer) error {
+	m.slotsProposed = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "slots_proposed",
+		Help: "Number of slots where this node was eligible to propose and the next preferred block was proposed by this node",
+	})
+	m.slotsMissed = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "slots_missed",
+		Help: "Number of slots where this node was eligible to propose and had a block ready, but the next preferred block was proposed by another node",
+	})
+	m.slotsIdle = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "slots_idle",
+		Help: "Number of slots where this node was eligible to propose but the VM had no block ready",
+	})
+	m.urgentNotifications = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "urgent_notifications",
+		Help: "Number of urgent build block notifications received from the VM",
+	})
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.slotsProposed),
+		reg.Register(m.slotsMissed),
+		reg.Register(m.slotsIdle),
+		reg.Register(m.urgentNotifications),
+	)
+	return errs.Err
+}
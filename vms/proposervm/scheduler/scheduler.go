@@ -6,22 +6,46 @@ package scheduler
 import (
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/lasthyphen/dijetsgo/snow/engine/common"
 	"github.com/lasthyphen/dijetsgo/utils/logging"
 )
 
 type Scheduler interface {
 	Dispatch(startTime time.Time)
-	SetBuildBlockTime(t time.Time)
+	SetBuildBlockTime(slot Slot)
 	Close()
 }
 
+// Slot describes the period between two preference changes, during which this
+// node may be allowed to propose a block on top of the preferred block.
+type Slot struct {
+	// WindowStartTime is the time at which this node's proposer window opens.
+	// Urgent notifications from the VM are delivered to the engine as soon as
+	// this time has passed.
+	WindowStartTime time.Time
+	// BuildBlockTime is the time at which all other notifications from the VM
+	// are delivered to the engine. It must not be before [WindowStartTime].
+	BuildBlockTime time.Time
+	// ParentBuiltLocally is true if the preferred block that this slot builds
+	// on top of was proposed by this node.
+	ParentBuiltLocally bool
+}
+
 // Scheduler receives notifications from a VM that it wants its engine to call
 // the VM's BuildBlock method, and delivers the notification to the engine only
 // when the engine should call BuildBlock. Namely, when this node is allowed to
 // propose a block under the congestion control mechanism.
+//
+// The VM may send:
+// - [common.PendingTxs] to report that it has a block ready.
+// - [common.UrgentTxs] to report that it has a block ready that should be
+//   built as soon as this node's proposer window opens.
+// - [common.NoPendingTxs] to report that it no longer has anything to build.
 type scheduler struct {
-	log logging.Logger
+	log     logging.Logger
+	metrics metrics
 	// The VM sends a message on this channel when it wants to tell the engine
 	// that the engine should call the VM's BuildBlock method
 	fromVM <-chan common.Message
@@ -30,32 +54,58 @@ type scheduler struct {
 	toEngine chan<- common.Message
 	// When we receive a message on this channel, it means that we must refrain
 	// from telling the engine to call its VM's BuildBlock method until the
-	// given time
-	newBuildBlockTime chan time.Time
+	// given slot allows it
+	newBuildBlockTime chan Slot
+
+	// The following fields are only accessed by the Dispatch goroutine.
+
+	// slot is the slot currently being scheduled
+	slot Slot
+	// windowOpened is true if this node's proposer window opened during the
+	// current slot
+	windowOpened bool
+	// delivered is true if a notification was delivered to the engine during
+	// the current slot
+	delivered bool
+	// pending is the number of notifications from the VM that haven't been
+	// delivered to the engine yet
+	pending int
+	// urgent is true if any of the pending notifications is urgent
+	urgent bool
 }
 
-func New(log logging.Logger, toEngine chan<- common.Message) (Scheduler, chan<- common.Message) {
+func New(
+	log logging.Logger,
+	toEngine chan<- common.Message,
+	registerer prometheus.Registerer,
+) (Scheduler, chan<- common.Message, error) {
 	vmToEngine := make(chan common.Message, cap(toEngine))
-	return &scheduler{
+	s := &scheduler{
 		log:               log,
 		fromVM:            vmToEngine,
 		toEngine:          toEngine,
-		newBuildBlockTime: make(chan time.Time),
-	}, vmToEngine
+		newBuildBlockTime: make(chan Slot),
+	}
+	return s, vmToEngine, s.metrics.Initialize(registerer)
 }
 
 func (s *scheduler) Dispatch(buildBlockTime time.Time) {
-	timer := time.NewTimer(time.Until(buildBlockTime))
-waitloop:
+	s.slot = Slot{
+		WindowStartTime: buildBlockTime,
+		BuildBlockTime:  buildBlockTime,
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
 	for {
-		select {
-		case <-timer.C: // It's time to tell the engine to try to build a block
-		case buildBlockTime, ok := <-s.newBuildBlockTime:
-			// Stop the timer and clear [timer.C] if needed
-			if !timer.Stop() {
-				<-timer.C
-			}
+		s.deliver(time.Now())
+		s.resetTimer(timer, time.Now())
 
+		select {
+		case <-timer.C: // It may be time to tell the engine to try to build a block
+		case msg := <-s.fromVM:
+			s.handleVMMessage(msg)
+		case slot, ok := <-s.newBuildBlockTime:
 			if !ok {
 				// s.Close() was called
 				return
@@ -63,42 +113,98 @@ waitloop:
 
 			// The time at which we should notify the engine that it should try
 			// to build a block has changed
-			timer.Reset(time.Until(buildBlockTime))
-			continue waitloop
-		}
-
-		for {
-			select {
-			case msg := <-s.fromVM:
-				// Give the engine the message from the VM asking the engine to
-				// build a block
-				select {
-				case s.toEngine <- msg:
-				default:
-					// If the channel to the engine is full, drop the message
-					// from the VM to avoid deadlock
-					s.log.Debug("dropping message %s from VM because channel to engine is full", msg)
-				}
-			case buildBlockTime, ok := <-s.newBuildBlockTime:
-				// The time at which we should notify the engine that it should
-				// try to build a block has changed
-				if !ok {
-					// s.Close() was called
-					return
-				}
-				// We know [timer.C] was drained in the first select statement
-				// so its safe to call [timer.Reset]
-				timer.Reset(time.Until(buildBlockTime))
-				continue waitloop
-			}
+			s.endSlot(slot)
+			s.slot = slot
 		}
 	}
 }
 
-func (s *scheduler) SetBuildBlockTime(t time.Time) {
-	s.newBuildBlockTime <- t
+func (s *scheduler) SetBuildBlockTime(slot Slot) {
+	s.newBuildBlockTime <- slot
 }
 
 func (s *scheduler) Close() {
 	close(s.newBuildBlockTime)
 }
+
+func (s *scheduler) handleVMMessage(msg common.Message) {
+	switch msg {
+	case common.PendingTxs:
+		s.pending++
+	case common.UrgentTxs:
+		s.pending++
+		s.urgent = true
+		s.metrics.urgentNotifications.Inc()
+	case common.NoPendingTxs:
+		// The VM no longer has anything to build, so any notifications that
+		// haven't been delivered yet are stale.
+		s.pending = 0
+		s.urgent = false
+	default:
+		s.log.Debug("dropping unexpected message %s from VM", msg)
+	}
+}
+
+// deliver passes the pending notifications to the engine if the current slot
+// allows it at [now].
+func (s *scheduler) deliver(now time.Time) {
+	if now.Before(s.slot.WindowStartTime) {
+		return
+	}
+	s.windowOpened = true
+
+	if s.pending == 0 || (!s.urgent && now.Before(s.slot.BuildBlockTime)) {
+		return
+	}
+
+	for ; s.pending > 0; s.pending-- {
+		// Give the engine the message from the VM asking the engine to build a
+		// block
+		select {
+		case s.toEngine <- common.PendingTxs:
+			s.delivered = true
+		default:
+			// If the channel to the engine is full, drop the message from the
+			// VM to avoid deadlock
+			s.log.Debug("dropping message %s from VM because channel to engine is full", common.PendingTxs)
+		}
+	}
+	s.urgent = false
+}
+
+// resetTimer sets [timer] to fire at the next time the current slot may allow
+// notifications to be delivered. If no such time exists, the timer is stopped.
+func (s *scheduler) resetTimer(timer *time.Timer, now time.Time) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+
+	switch {
+	case now.Before(s.slot.WindowStartTime):
+		timer.Reset(s.slot.WindowStartTime.Sub(now))
+	case now.Before(s.slot.BuildBlockTime):
+		timer.Reset(s.slot.BuildBlockTime.Sub(now))
+	}
+}
+
+// endSlot records the outcome of the current slot, which is being replaced by
+// [next].
+func (s *scheduler) endSlot(next Slot) {
+	if s.windowOpened {
+		switch {
+		case next.ParentBuiltLocally:
+			s.metrics.slotsProposed.Inc()
+		case s.delivered || s.pending > 0:
+			// This node had a block ready, but another block became preferred.
+			s.metrics.slotsMissed.Inc()
+		default:
+			s.metrics.slotsIdle.Inc()
+		}
+	}
+
+	s.windowOpened = false
+	s.delivered = false
+}
@@ -7,6 +7,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
 	"github.com/lasthyphen/dijetsgo/snow/engine/common"
 	"github.com/lasthyphen/dijetsgo/utils/logging"
 )
@@ -15,7 +18,10 @@ func TestDelayFromNew(t *testing.T) {
 	toEngine := make(chan common.Message, 10)
 	startTime := time.Now().Add(50 * time.Millisecond)
 
-	s, fromVM := New(logging.NoLog{}, toEngine)
+	s, fromVM, err := New(logging.NoLog{}, toEngine, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
 	defer s.Close()
 	go s.Dispatch(startTime)
 
@@ -32,11 +38,17 @@ func TestDelayFromSetTime(t *testing.T) {
 	now := time.Now()
 	startTime := now.Add(50 * time.Millisecond)
 
-	s, fromVM := New(logging.NoLog{}, toEngine)
+	s, fromVM, err := New(logging.NoLog{}, toEngine, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
 	defer s.Close()
 	go s.Dispatch(now)
 
-	s.SetBuildBlockTime(startTime)
+	s.SetBuildBlockTime(Slot{
+		WindowStartTime: startTime,
+		BuildBlockTime:  startTime,
+	})
 
 	fromVM <- common.PendingTxs
 
@@ -51,13 +63,123 @@ func TestReceipt(t *testing.T) {
 	now := time.Now()
 	startTime := now.Add(50 * time.Millisecond)
 
-	s, fromVM := New(logging.NoLog{}, toEngine)
+	s, fromVM, err := New(logging.NoLog{}, toEngine, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
 	defer s.Close()
 	go s.Dispatch(now)
 
 	fromVM <- common.PendingTxs
 
-	s.SetBuildBlockTime(startTime)
+	s.SetBuildBlockTime(Slot{
+		WindowStartTime: startTime,
+		BuildBlockTime:  startTime,
+	})
+
+	<-toEngine
+}
+
+func TestUrgentSkipsBuildBlockTime(t *testing.T) {
+	toEngine := make(chan common.Message, 10)
+	now := time.Now()
+	windowStartTime := now.Add(50 * time.Millisecond)
+	buildBlockTime := now.Add(time.Hour)
+
+	s, fromVM, err := New(logging.NoLog{}, toEngine, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	go s.Dispatch(now)
+
+	s.SetBuildBlockTime(Slot{
+		WindowStartTime: windowStartTime,
+		BuildBlockTime:  buildBlockTime,
+	})
+
+	fromVM <- common.UrgentTxs
+
+	<-toEngine
+	if time.Until(windowStartTime) > 0 {
+		t.Fatalf("passed message too soon")
+	}
+}
+
+func TestNoPendingTxsClearsNotifications(t *testing.T) {
+	toEngine := make(chan common.Message, 10)
+	now := time.Now()
+	startTime := now.Add(50 * time.Millisecond)
+
+	s, fromVM, err := New(logging.NoLog{}, toEngine, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	go s.Dispatch(startTime)
+
+	fromVM <- common.PendingTxs
+	fromVM <- common.NoPendingTxs
+
+	time.Sleep(100 * time.Millisecond)
+	select {
+	case msg := <-toEngine:
+		t.Fatalf("unexpectedly passed %s", msg)
+	default:
+	}
+}
+
+func TestSlotMetrics(t *testing.T) {
+	toEngine := make(chan common.Message, 10)
+	now := time.Now()
+
+	s, fromVM, err := New(logging.NoLog{}, toEngine, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	go s.Dispatch(now)
 
+	openSlot := Slot{
+		WindowStartTime: now,
+		BuildBlockTime:  now,
+	}
+
+	// The window opened, but the VM never had a block ready.
+	s.SetBuildBlockTime(openSlot)
+
+	// The VM had a block ready, but another node's block became preferred.
+	fromVM <- common.PendingTxs
+	<-toEngine
+	s.SetBuildBlockTime(openSlot)
+
+	// This node's block became preferred.
+	fromVM <- common.PendingTxs
 	<-toEngine
+	openSlot.ParentBuiltLocally = true
+	s.SetBuildBlockTime(openSlot)
+
+	// This node's block became preferred again, and the next window is far in
+	// the future.
+	closedSlot := Slot{
+		WindowStartTime:    now.Add(time.Hour),
+		BuildBlockTime:     now.Add(time.Hour),
+		ParentBuiltLocally: true,
+	}
+	s.SetBuildBlockTime(closedSlot)
+
+	// Once this is received, the previous slot has been recorded. Because the
+	// window of the closed slot never opened, it isn't recorded.
+	s.SetBuildBlockTime(closedSlot)
+
+	sched := s.(*scheduler)
+	if idle := testutil.ToFloat64(sched.metrics.slotsIdle); idle != 1 {
+		t.Fatalf("expected 1 idle slot but got %v", idle)
+	}
+	if missed := testutil.ToFloat64(sched.metrics.slotsMissed); missed != 1 {
+		t.Fatalf("expected 1 missed slot but got %v", missed)
+	}
+	if proposed := testutil.ToFloat64(sched.metrics.slotsProposed); proposed != 2 {
+		t.Fatalf("expected 2 proposed slots but got %v", proposed)
+	}
 }
@@ -7,6 +7,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/dijetsgo/api/metrics"
 	"github.com/lasthyphen/dijetsgo/database"
 	"github.com/lasthyphen/dijetsgo/database/manager"
 	"github.com/lasthyphen/dijetsgo/database/prefixdb"
@@ -49,6 +52,7 @@ type VM struct {
 	block.ChainVM
 	activationTime      time.Time
 	minimumPChainHeight uint64
+	windowParams        proposer.Parameters
 
 	state.State
 	resetHeightIndexOngoing utils.AtomicBool
@@ -83,11 +87,13 @@ func New(
 	activationTime time.Time,
 	minimumPChainHeight uint64,
 	resetHeightIndex bool,
+	windowParams proposer.Parameters,
 ) *VM {
 	proVM := &VM{
 		ChainVM:             vm,
 		activationTime:      activationTime,
 		minimumPChainHeight: minimumPChainHeight,
+		windowParams:        windowParams,
 	}
 
 	proVM.resetHeightIndexOngoing.SetValue(resetHeightIndex)
@@ -109,14 +115,31 @@ func (vm *VM) Initialize(
 	prefixDB := prefixdb.New(dbPrefix, rawDB)
 	vm.db = versiondb.New(prefixDB)
 	vm.State = state.New(vm.db)
-	vm.Windower = proposer.New(ctx.ValidatorState, ctx.SubnetID, ctx.ChainID)
+	vm.Windower = proposer.NewWithParameters(ctx.ValidatorState, ctx.SubnetID, ctx.ChainID, vm.windowParams)
 	vm.Tree = tree.New()
 
 	indexerDB := versiondb.New(vm.db)
 	indexerState := state.New(indexerDB)
 	vm.hIndexer = indexer.NewHeightIndexer(vm, vm.ctx.Log, indexerState)
 
-	scheduler, vmToEngine := scheduler.New(vm.ctx.Log, toEngine)
+	registerer := prometheus.NewRegistry()
+	optionalGatherer := metrics.NewOptionalGatherer()
+	multiGatherer := metrics.NewMultiGatherer()
+	if err := multiGatherer.Register("proposervm", registerer); err != nil {
+		return err
+	}
+	if err := multiGatherer.Register("", optionalGatherer); err != nil {
+		return err
+	}
+	if err := ctx.Metrics.Register(multiGatherer); err != nil {
+		return err
+	}
+	ctx.Metrics = optionalGatherer
+
+	scheduler, vmToEngine, err := scheduler.New(vm.ctx.Log, toEngine, registerer)
+	if err != nil {
+		return err
+	}
 	vm.Scheduler = scheduler
 	vm.toScheduler = vmToEngine
 
@@ -129,7 +152,7 @@ func (vm *VM) Initialize(
 	vm.context = context
 	vm.onShutdown = cancel
 
-	err := vm.ChainVM.Initialize(
+	err = vm.ChainVM.Initialize(
 		ctx,
 		dbManager,
 		genesisBytes,
@@ -299,13 +322,20 @@ func (vm *VM) SetPreference(preferred ids.ID) error {
 		// until the P-chain's height has advanced.
 		return nil
 	}
-	if minDelay < minBlockDelay {
-		minDelay = minBlockDelay
+	buildDelay := minDelay
+	if buildDelay < minBlockDelay {
+		buildDelay = minBlockDelay
 	}
 
 	preferredTime := blk.Timestamp()
-	nextStartTime := preferredTime.Add(minDelay)
-	vm.Scheduler.SetBuildBlockTime(nextStartTime)
+	nextStartTime := preferredTime.Add(buildDelay)
+	vm.Scheduler.SetBuildBlockTime(scheduler.Slot{
+		// Urgent notifications skip the minimum block delay, but must still
+		// respect this node's proposer window.
+		WindowStartTime:    preferredTime.Add(minDelay),
+		BuildBlockTime:     nextStartTime,
+		ParentBuiltLocally: blk.builtLocally(),
+	})
 
 	vm.ctx.Log.Debug("set preference to %s with timestamp %v; build time scheduled at %v",
 		blk.ID(), preferredTime, nextStartTime)
@@ -26,6 +26,7 @@ import (
 	"github.com/lasthyphen/dijetsgo/utils/timer/mockable"
 	"github.com/lasthyphen/dijetsgo/version"
 	"github.com/lasthyphen/dijetsgo/vms/proposervm/proposer"
+	"github.com/lasthyphen/dijetsgo/vms/proposervm/scheduler"
 
 	statelessblock "github.com/lasthyphen/dijetsgo/vms/proposervm/block"
 )
@@ -102,7 +103,7 @@ func initTestProposerVM(
 		}
 	}
 
-	proVM := New(coreVM, proBlkStartTime, minPChainHeight, false)
+	proVM := New(coreVM, proBlkStartTime, minPChainHeight, false, proposer.DefaultParameters)
 
 	valState := &validators.TestState{
 		T: t,
@@ -837,7 +838,7 @@ func TestExpiredBuildBlock(t *testing.T) {
 		}
 	}
 
-	proVM := New(coreVM, time.Time{}, 0, false)
+	proVM := New(coreVM, time.Time{}, 0, false, proposer.DefaultParameters)
 
 	valState := &validators.TestState{
 		T: t,
@@ -965,7 +966,10 @@ func TestExpiredBuildBlock(t *testing.T) {
 	}
 
 	proVM.Set(statelessBlock.Timestamp().Add(proposer.MaxDelay))
-	proVM.Scheduler.SetBuildBlockTime(time.Now())
+	proVM.Scheduler.SetBuildBlockTime(scheduler.Slot{
+		WindowStartTime: time.Now(),
+		BuildBlockTime:  time.Now(),
+	})
 
 	// The engine should have been notified to attempt to build a block now that
 	// the window has started again
@@ -1166,7 +1170,7 @@ func TestInnerVMRollback(t *testing.T) {
 
 	dbManager := manager.NewMemDB(version.DefaultVersion1_0_0)
 
-	proVM := New(coreVM, time.Time{}, 0, false)
+	proVM := New(coreVM, time.Time{}, 0, false, proposer.DefaultParameters)
 
 	if err := proVM.Initialize(ctx, dbManager, nil, nil, nil, nil, nil, nil); err != nil {
 		t.Fatalf("failed to initialize proposerVM with %s", err)
@@ -1248,7 +1252,7 @@ func TestInnerVMRollback(t *testing.T) {
 
 	coreBlk.StatusV = choices.Processing
 
-	proVM = New(coreVM, time.Time{}, 0, false)
+	proVM = New(coreVM, time.Time{}, 0, false, proposer.DefaultParameters)
 
 	if err := proVM.Initialize(ctx, dbManager, nil, nil, nil, nil, nil, nil); err != nil {
 		t.Fatalf("failed to initialize proposerVM with %s", err)
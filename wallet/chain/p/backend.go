@@ -40,13 +40,16 @@ type backend struct {
 
 	// txID -> tx
 	txs map[ids.ID]*platformvm.Tx
+	// subnetID -> owner the subnet was transferred to
+	subnetOwners map[ids.ID]platformvm.Owner
 }
 
 func NewBackend(ctx Context, utxos ChainUTXOs, txs map[ids.ID]*platformvm.Tx) Backend {
 	return &backend{
-		Context:    ctx,
-		ChainUTXOs: utxos,
-		txs:        txs,
+		Context:      ctx,
+		ChainUTXOs:   utxos,
+		txs:          txs,
+		subnetOwners: make(map[ids.ID]platformvm.Owner),
 	}
 }
 
@@ -92,6 +95,13 @@ func (b *backend) AcceptTx(ctx stdcontext.Context, tx *platformvm.Tx) error {
 		baseTx = &utx.BaseTx
 	case *platformvm.UnsignedCreateSubnetTx:
 		baseTx = &utx.BaseTx
+	case *platformvm.UnsignedTransferSubnetOwnershipTx:
+		baseTx = &utx.BaseTx
+		b.subnetOwners[utx.Subnet] = utx.Owner
+	case *platformvm.UnsignedRemoveSubnetValidatorTx:
+		baseTx = &utx.BaseTx
+	case *platformvm.UnsignedSetDelegationPolicyTx:
+		baseTx = &utx.BaseTx
 	default:
 		return fmt.Errorf("%w: %T", errUnknownTxType, tx.UnsignedTx)
 	}
@@ -137,3 +147,18 @@ func (b *backend) GetTx(_ stdcontext.Context, txID ids.ID) (*platformvm.Tx, erro
 	}
 	return tx, nil
 }
+
+func (b *backend) GetSubnetOwner(ctx stdcontext.Context, subnetID ids.ID) (platformvm.Owner, error) {
+	if owner, exists := b.subnetOwners[subnetID]; exists {
+		return owner, nil
+	}
+	subnetTx, err := b.GetTx(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	subnet, ok := subnetTx.UnsignedTx.(*platformvm.UnsignedCreateSubnetTx)
+	if !ok {
+		return nil, errWrongTxType
+	}
+	return subnet.Owner, nil
+}
@@ -95,6 +95,41 @@ type Builder interface {
 		options ...common.Option,
 	) (*platformvm.UnsignedCreateSubnetTx, error)
 
+	// NewTransferSubnetOwnershipTx transfers the ownership of a subnet to a
+	// new owner.
+	//
+	// - [subnetID] specifies the subnet to transfer.
+	// - [owner] specifies who will have the ability to create new chains and
+	//   add or remove validators of the subnet.
+	NewTransferSubnetOwnershipTx(
+		subnetID ids.ID,
+		owner *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*platformvm.UnsignedTransferSubnetOwnershipTx, error)
+
+	// NewRemoveSubnetValidatorTx removes a current or pending validator from a
+	// subnet.
+	//
+	// - [nodeID] specifies the validator to remove.
+	// - [subnetID] specifies the subnet to remove the validator from.
+	NewRemoveSubnetValidatorTx(
+		nodeID ids.ShortID,
+		subnetID ids.ID,
+		options ...common.Option,
+	) (*platformvm.UnsignedRemoveSubnetValidatorTx, error)
+
+	// NewSetDelegationPolicyTx restricts who can delegate to a current or
+	// pending primary network validator.
+	//
+	// - [validatorTxID] specifies the tx that added the validator. The
+	//   validator's rewards owner must authorize the tx.
+	// - [policy] specifies who can delegate to the validator, and how much.
+	NewSetDelegationPolicyTx(
+		validatorTxID ids.ID,
+		policy *platformvm.DelegationPolicy,
+		options ...common.Option,
+	) (*platformvm.UnsignedSetDelegationPolicyTx, error)
+
 	// NewImportTx creates an import transaction that attempts to consume all
 	// the available UTXOs and import the funds to [to].
 	//
@@ -124,6 +159,7 @@ type BuilderBackend interface {
 	Context
 	UTXOs(ctx stdcontext.Context, sourceChainID ids.ID) ([]*djtx.UTXO, error)
 	GetTx(ctx stdcontext.Context, txID ids.ID) (*platformvm.Tx, error)
+	GetSubnetOwner(ctx stdcontext.Context, subnetID ids.ID) (platformvm.Owner, error)
 }
 
 type builder struct {
@@ -306,6 +342,110 @@ func (b *builder) NewCreateSubnetTx(
 	}, nil
 }
 
+func (b *builder) NewTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*platformvm.UnsignedTransferSubnetOwnershipTx, error) {
+	toBurn := map[ids.ID]uint64{
+		b.backend.DJTXAssetID(): b.backend.BaseTxFee(),
+	}
+	toStake := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	ids.SortShortIDs(owner.Addrs)
+	return &platformvm.UnsignedTransferSubnetOwnershipTx{
+		BaseTx: platformvm.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         ops.Memo(),
+		}},
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+		Owner:      owner,
+	}, nil
+}
+
+func (b *builder) NewRemoveSubnetValidatorTx(
+	nodeID ids.ShortID,
+	subnetID ids.ID,
+	options ...common.Option,
+) (*platformvm.UnsignedRemoveSubnetValidatorTx, error) {
+	toBurn := map[ids.ID]uint64{
+		b.backend.DJTXAssetID(): b.backend.BaseTxFee(),
+	}
+	toStake := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	return &platformvm.UnsignedRemoveSubnetValidatorTx{
+		BaseTx: platformvm.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         ops.Memo(),
+		}},
+		NodeID:     nodeID,
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+	}, nil
+}
+
+func (b *builder) NewSetDelegationPolicyTx(
+	validatorTxID ids.ID,
+	policy *platformvm.DelegationPolicy,
+	options ...common.Option,
+) (*platformvm.UnsignedSetDelegationPolicyTx, error) {
+	toBurn := map[ids.ID]uint64{
+		b.backend.DJTXAssetID(): b.backend.BaseTxFee(),
+	}
+	toStake := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	validatorAuth, err := b.authorizeValidator(validatorTxID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	ids.SortShortIDs(policy.AllowedAddrs)
+	return &platformvm.UnsignedSetDelegationPolicyTx{
+		BaseTx: platformvm.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         ops.Memo(),
+		}},
+		ValidatorTxID: validatorTxID,
+		ValidatorAuth: validatorAuth,
+		Policy:        *policy,
+	}, nil
+}
+
 func (b *builder) NewImportTx(
 	sourceChainID ids.ID,
 	to *secp256k1fx.OutputOwners,
@@ -679,7 +819,7 @@ func (b *builder) spend(
 }
 
 func (b *builder) authorizeSubnet(subnetID ids.ID, options *common.Options) (*secp256k1fx.Input, error) {
-	subnetTx, err := b.backend.GetTx(options.Context(), subnetID)
+	subnetOwner, err := b.backend.GetSubnetOwner(options.Context(), subnetID)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to fetch subnet %q: %w",
@@ -687,12 +827,38 @@ func (b *builder) authorizeSubnet(subnetID ids.ID, options *common.Options) (*se
 			err,
 		)
 	}
-	subnet, ok := subnetTx.UnsignedTx.(*platformvm.UnsignedCreateSubnetTx)
+
+	owner, ok := subnetOwner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil, errUnknownOwnerType
+	}
+
+	minIssuanceTime := options.MinIssuanceTime()
+	inputSigIndices, ok := b.match(owner, minIssuanceTime)
+	if !ok {
+		// We can't authorize the subnet
+		return nil, errInsufficientAuthorization
+	}
+	return &secp256k1fx.Input{
+		SigIndices: inputSigIndices,
+	}, nil
+}
+
+func (b *builder) authorizeValidator(validatorTxID ids.ID, options *common.Options) (*secp256k1fx.Input, error) {
+	validatorTx, err := b.backend.GetTx(options.Context(), validatorTxID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to fetch validator %q: %w",
+			validatorTxID,
+			err,
+		)
+	}
+	validator, ok := validatorTx.UnsignedTx.(*platformvm.UnsignedAddValidatorTx)
 	if !ok {
 		return nil, errWrongTxType
 	}
 
-	owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+	owner, ok := validator.RewardsOwner.(*secp256k1fx.OutputOwners)
 	if !ok {
 		return nil, errUnknownOwnerType
 	}
@@ -700,7 +866,7 @@ func (b *builder) authorizeSubnet(subnetID ids.ID, options *common.Options) (*se
 	minIssuanceTime := options.MinIssuanceTime()
 	inputSigIndices, ok := b.match(owner, minIssuanceTime)
 	if !ok {
-		// We can't authorize the subnet
+		// We can't authorize the validator
 		return nil, errInsufficientAuthorization
 	}
 	return &secp256k1fx.Input{
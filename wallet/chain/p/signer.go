@@ -21,12 +21,13 @@ import (
 )
 
 var (
-	errUnknownTxType         = errors.New("unknown tx type")
-	errUnknownInputType      = errors.New("unknown input type")
-	errUnknownCredentialType = errors.New("unknown credential type")
-	errUnknownOutputType     = errors.New("unknown output type")
-	errUnknownSubnetAuthType = errors.New("unknown subnet auth type")
-	errInvalidUTXOSigIndex   = errors.New("invalid UTXO signature index")
+	errUnknownTxType            = errors.New("unknown tx type")
+	errUnknownInputType         = errors.New("unknown input type")
+	errUnknownCredentialType    = errors.New("unknown credential type")
+	errUnknownOutputType        = errors.New("unknown output type")
+	errUnknownSubnetAuthType    = errors.New("unknown subnet auth type")
+	errUnknownValidatorAuthType = errors.New("unknown validator auth type")
+	errInvalidUTXOSigIndex      = errors.New("invalid UTXO signature index")
 
 	emptySig [crypto.SECP256K1RSigLen]byte
 
@@ -41,6 +42,7 @@ type Signer interface {
 type SignerBackend interface {
 	GetUTXO(ctx stdcontext.Context, chainID, utxoID ids.ID) (*djtx.UTXO, error)
 	GetTx(ctx stdcontext.Context, txID ids.ID) (*platformvm.Tx, error)
+	GetSubnetOwner(ctx stdcontext.Context, subnetID ids.ID) (platformvm.Owner, error)
 }
 
 type signer struct {
@@ -74,6 +76,12 @@ func (s *signer) Sign(ctx stdcontext.Context, tx *platformvm.Tx) error {
 		return s.signCreateChainTx(ctx, tx, utx)
 	case *platformvm.UnsignedCreateSubnetTx:
 		return s.signCreateSubnetTx(ctx, tx, utx)
+	case *platformvm.UnsignedTransferSubnetOwnershipTx:
+		return s.signTransferSubnetOwnershipTx(ctx, tx, utx)
+	case *platformvm.UnsignedRemoveSubnetValidatorTx:
+		return s.signRemoveSubnetValidatorTx(ctx, tx, utx)
+	case *platformvm.UnsignedSetDelegationPolicyTx:
+		return s.signSetDelegationPolicyTx(ctx, tx, utx)
 	case *platformvm.UnsignedImportTx:
 		return s.signImportTx(ctx, tx, utx)
 	case *platformvm.UnsignedExportTx:
@@ -133,6 +141,45 @@ func (s *signer) signCreateSubnetTx(ctx stdcontext.Context, tx *platformvm.Tx, u
 	return s.sign(tx, txSigners)
 }
 
+func (s *signer) signTransferSubnetOwnershipTx(ctx stdcontext.Context, tx *platformvm.Tx, utx *platformvm.UnsignedTransferSubnetOwnershipTx) error {
+	txSigners, err := s.getSigners(ctx, constants.PlatformChainID, utx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthSigners, err := s.getSubnetSigners(ctx, utx.Subnet, utx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, subnetAuthSigners)
+	return s.sign(tx, txSigners)
+}
+
+func (s *signer) signRemoveSubnetValidatorTx(ctx stdcontext.Context, tx *platformvm.Tx, utx *platformvm.UnsignedRemoveSubnetValidatorTx) error {
+	txSigners, err := s.getSigners(ctx, constants.PlatformChainID, utx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthSigners, err := s.getSubnetSigners(ctx, utx.Subnet, utx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, subnetAuthSigners)
+	return s.sign(tx, txSigners)
+}
+
+func (s *signer) signSetDelegationPolicyTx(ctx stdcontext.Context, tx *platformvm.Tx, utx *platformvm.UnsignedSetDelegationPolicyTx) error {
+	txSigners, err := s.getSigners(ctx, constants.PlatformChainID, utx.Ins)
+	if err != nil {
+		return err
+	}
+	validatorAuthSigners, err := s.getValidatorSigners(ctx, utx.ValidatorTxID, utx.ValidatorAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, validatorAuthSigners)
+	return s.sign(tx, txSigners)
+}
+
 func (s *signer) signImportTx(ctx stdcontext.Context, tx *platformvm.Tx, utx *platformvm.UnsignedImportTx) error {
 	txSigners, err := s.getSigners(ctx, constants.PlatformChainID, utx.Ins)
 	if err != nil {
@@ -210,7 +257,7 @@ func (s *signer) getSubnetSigners(ctx stdcontext.Context, subnetID ids.ID, subne
 		return nil, errUnknownSubnetAuthType
 	}
 
-	subnetTx, err := s.backend.GetTx(ctx, subnetID)
+	subnetOwner, err := s.backend.GetSubnetOwner(ctx, subnetID)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to fetch subnet %q: %w",
@@ -218,18 +265,40 @@ func (s *signer) getSubnetSigners(ctx stdcontext.Context, subnetID ids.ID, subne
 			err,
 		)
 	}
-	subnet, ok := subnetTx.UnsignedTx.(*platformvm.UnsignedCreateSubnetTx)
+
+	return s.getOwnerSigners(subnetOwner, subnetInput)
+}
+
+func (s *signer) getValidatorSigners(ctx stdcontext.Context, validatorTxID ids.ID, validatorAuth verify.Verifiable) ([]*crypto.PrivateKeySECP256K1R, error) {
+	validatorInput, ok := validatorAuth.(*secp256k1fx.Input)
+	if !ok {
+		return nil, errUnknownValidatorAuthType
+	}
+
+	validatorTx, err := s.backend.GetTx(ctx, validatorTxID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to fetch validator %q: %w",
+			validatorTxID,
+			err,
+		)
+	}
+	validator, ok := validatorTx.UnsignedTx.(*platformvm.UnsignedAddValidatorTx)
 	if !ok {
 		return nil, errWrongTxType
 	}
+	return s.getOwnerSigners(validator.RewardsOwner, validatorInput)
+}
 
-	owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+// getOwnerSigners returns the keys that sign [input] on behalf of [ownerIntf]
+func (s *signer) getOwnerSigners(ownerIntf platformvm.Owner, input *secp256k1fx.Input) ([]*crypto.PrivateKeySECP256K1R, error) {
+	owner, ok := ownerIntf.(*secp256k1fx.OutputOwners)
 	if !ok {
 		return nil, errUnknownOwnerType
 	}
 
-	authSigners := make([]*crypto.PrivateKeySECP256K1R, len(subnetInput.SigIndices))
-	for sigIndex, addrIndex := range subnetInput.SigIndices {
+	authSigners := make([]*crypto.PrivateKeySECP256K1R, len(input.SigIndices))
+	for sigIndex, addrIndex := range input.SigIndices {
 		if addrIndex >= uint32(len(owner.Addrs)) {
 			return nil, errInvalidUTXOSigIndex
 		}
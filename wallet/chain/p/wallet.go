@@ -91,6 +91,41 @@ type Wallet interface {
 		options ...common.Option,
 	) (ids.ID, error)
 
+	// IssueTransferSubnetOwnershipTx creates, signs, and issues a transfer of
+	// the ownership of a subnet to a new owner.
+	//
+	// - [subnetID] specifies the subnet to transfer.
+	// - [owner] specifies who will have the ability to create new chains and
+	//   add or remove validators of the subnet.
+	IssueTransferSubnetOwnershipTx(
+		subnetID ids.ID,
+		owner *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (ids.ID, error)
+
+	// IssueRemoveSubnetValidatorTx creates, signs, and issues a removal of a
+	// current or pending validator from a subnet.
+	//
+	// - [nodeID] specifies the validator to remove.
+	// - [subnetID] specifies the subnet to remove the validator from.
+	IssueRemoveSubnetValidatorTx(
+		nodeID ids.ShortID,
+		subnetID ids.ID,
+		options ...common.Option,
+	) (ids.ID, error)
+
+	// IssueSetDelegationPolicyTx creates, signs, and issues a restriction on
+	// who can delegate to a current or pending primary network validator.
+	//
+	// - [validatorTxID] specifies the tx that added the validator. The
+	//   validator's rewards owner must authorize the tx.
+	// - [policy] specifies who can delegate to the validator, and how much.
+	IssueSetDelegationPolicyTx(
+		validatorTxID ids.ID,
+		policy *platformvm.DelegationPolicy,
+		options ...common.Option,
+	) (ids.ID, error)
+
 	// IssueImportTx creates, signs, and issues an import transaction that
 	// attempts to consume all the available UTXOs and import the funds to [to].
 	//
@@ -187,6 +222,30 @@ func (w *wallet) IssueCreateSubnetTx(owner *secp256k1fx.OutputOwners, options ..
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueTransferSubnetOwnershipTx(subnetID ids.ID, owner *secp256k1fx.OutputOwners, options ...common.Option) (ids.ID, error) {
+	utx, err := w.builder.NewTransferSubnetOwnershipTx(subnetID, owner, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
+func (w *wallet) IssueRemoveSubnetValidatorTx(nodeID ids.ShortID, subnetID ids.ID, options ...common.Option) (ids.ID, error) {
+	utx, err := w.builder.NewRemoveSubnetValidatorTx(nodeID, subnetID, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
+func (w *wallet) IssueSetDelegationPolicyTx(validatorTxID ids.ID, policy *platformvm.DelegationPolicy, options ...common.Option) (ids.ID, error) {
+	utx, err := w.builder.NewSetDelegationPolicyTx(validatorTxID, policy, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueImportTx(sourceChainID ids.ID, to *secp256k1fx.OutputOwners, options ...common.Option) (ids.ID, error) {
 	utx, err := w.builder.NewImportTx(sourceChainID, to, options...)
 	if err != nil {
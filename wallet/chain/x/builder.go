@@ -40,6 +40,23 @@ type Builder interface {
 		options ...common.Option,
 	) (*avm.BaseTx, error)
 
+	// NewVestingTx creates a new value transfer of funds that unlock over time.
+	//
+	// - [assetID] specifies the asset to send.
+	// - [amount] specifies the amount of the asset to send. It is split evenly
+	//   between the unlock times, with any remainder unlocking last.
+	// - [owner] specifies the owner of the sent funds. Its locktime is
+	//   ignored.
+	// - [unlockTimes] specifies the strictly increasing unix times at which
+	//   the funds unlock.
+	NewVestingTx(
+		assetID ids.ID,
+		amount uint64,
+		owner *secp256k1fx.OutputOwners,
+		unlockTimes []uint64,
+		options ...common.Option,
+	) (*avm.BaseTx, error)
+
 	// NewCreateAssetTx creates a new asset.
 	//
 	// - [name] specifies a human readable name for this asset.
@@ -191,6 +208,20 @@ func (b *builder) NewBaseTx(
 	}}, nil
 }
 
+func (b *builder) NewVestingTx(
+	assetID ids.ID,
+	amount uint64,
+	owner *secp256k1fx.OutputOwners,
+	unlockTimes []uint64,
+	options ...common.Option,
+) (*avm.BaseTx, error) {
+	outputs, err := avm.NewVestingOutputs(assetID, amount, *owner, unlockTimes)
+	if err != nil {
+		return nil, err
+	}
+	return b.NewBaseTx(outputs, options...)
+}
+
 func (b *builder) NewCreateAssetTx(
 	name string,
 	symbol string,
@@ -33,6 +33,24 @@ type Wallet interface {
 		options ...common.Option,
 	) (ids.ID, error)
 
+	// IssueVestingTx creates, signs, and issues a new value transfer of funds
+	// that unlock over time.
+	//
+	// - [assetID] specifies the asset to send.
+	// - [amount] specifies the amount of the asset to send. It is split evenly
+	//   between the unlock times, with any remainder unlocking last.
+	// - [owner] specifies the owner of the sent funds. Its locktime is
+	//   ignored.
+	// - [unlockTimes] specifies the strictly increasing unix times at which
+	//   the funds unlock.
+	IssueVestingTx(
+		assetID ids.ID,
+		amount uint64,
+		owner *secp256k1fx.OutputOwners,
+		unlockTimes []uint64,
+		options ...common.Option,
+	) (ids.ID, error)
+
 	// IssueCreateAssetTx creates, signs, and issues a new asset.
 	//
 	// - [name] specifies a human readable name for this asset.
@@ -169,6 +187,20 @@ func (w *wallet) IssueBaseTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueVestingTx(
+	assetID ids.ID,
+	amount uint64,
+	owner *secp256k1fx.OutputOwners,
+	unlockTimes []uint64,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewVestingTx(assetID, amount, owner, unlockTimes, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueCreateAssetTx(
 	name string,
 	symbol string,